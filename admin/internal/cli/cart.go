@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"admin/internal/models"
+	cartapi "admin/pkg/api/cart"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newCartCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cart",
+		Short: "Inspect and clean up carts",
+	}
+
+	cmd.AddCommand(newCartInspectCommand(a), newCartPurgeCommand(a))
+
+	return cmd
+}
+
+type cartLine struct {
+	SKU      uint32 `json:"sku"`
+	Name     string `json:"name"`
+	SellerID int64  `json:"seller_id"`
+	Count    uint32 `json:"count"`
+	Price    uint32 `json:"price"`
+	Status   string `json:"status,omitempty"`
+}
+
+type cartInspection struct {
+	UserID     int64      `json:"user_id"`
+	Version    uint64     `json:"version"`
+	TotalPrice uint32     `json:"total_price"`
+	Degraded   bool       `json:"degraded"`
+	Items      []cartLine `json:"items"`
+	Saved      []cartLine `json:"saved"`
+}
+
+func (c cartInspection) Header() []string {
+	return []string{"LIST", "SKU", "NAME", "SELLER", "COUNT", "PRICE", "STATUS"}
+}
+
+func (c cartInspection) Rows() [][]string {
+	rows := make([][]string, 0, len(c.Items)+len(c.Saved))
+
+	lists := []struct {
+		name  string
+		lines []cartLine
+	}{
+		{name: "cart", lines: c.Items},
+		{name: "saved", lines: c.Saved},
+	}
+
+	for _, list := range lists {
+		for _, line := range list.lines {
+			rows = append(rows, []string{
+				list.name, fmt.Sprint(line.SKU), line.Name, fmt.Sprint(line.SellerID),
+				fmt.Sprint(line.Count), fmt.Sprint(line.Price), line.Status,
+			})
+		}
+	}
+
+	return rows
+}
+
+func (c cartInspection) Summary() string {
+	summary := fmt.Sprintf("user %d: version %d, total price %d", c.UserID, c.Version, c.TotalPrice)
+	if c.Degraded {
+		summary += " (degraded, lines with unknown prices are not counted)"
+	}
+
+	return summary
+}
+
+func newCartInspectCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "inspect <user_id>",
+		Short: "Show the cart and saved for later items of a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil || userID <= 0 {
+				return fmt.Errorf("invalid user id %q", args[0])
+			}
+
+			ctx, cancel := a.context(cmd)
+			defer cancel()
+
+			client, closeConn, err := a.cartClient()
+			if err != nil {
+				return err
+			}
+			defer closeConn()
+
+			cart, err := client.CartList(ctx, &cartapi.CartListRequest{UserId: userID})
+			if err != nil {
+				return fmt.Errorf("failed to list cart: %w", err)
+			}
+
+			saved, err := client.ListSaved(ctx, &cartapi.ListSavedRequest{UserId: userID})
+			if err != nil {
+				return fmt.Errorf("failed to list saved items: %w", err)
+			}
+
+			result := cartInspection{
+				UserID:     userID,
+				Version:    cart.Version,
+				TotalPrice: cart.TotalPrice,
+				Degraded:   cart.Degraded,
+				Items:      make([]cartLine, 0, len(cart.Items)),
+				Saved:      make([]cartLine, 0, len(saved.Items)),
+			}
+
+			for _, item := range cart.Items {
+				line := cartLine{SKU: item.Sku, Name: item.Name, SellerID: item.SellerId, Count: item.Count, Price: item.Price}
+
+				switch {
+				case item.Unavailable:
+					line.Status = "unavailable"
+				case item.PriceUnknown:
+					line.Status = "price unknown"
+				}
+
+				result.Items = append(result.Items, line)
+			}
+
+			for _, item := range saved.Items {
+				line := cartLine{SKU: item.Sku, Name: item.Name, SellerID: item.SellerId, Count: item.Count, Price: item.Price}
+
+				switch {
+				case item.BackInStock:
+					line.Status = "back in stock"
+				case item.InStock:
+					line.Status = "in stock"
+				default:
+					line.Status = "out of stock"
+				}
+
+				result.Saved = append(result.Saved, line)
+			}
+
+			return a.print(cmd, result)
+		},
+	}
+}
+
+type purgedCarts struct {
+	DryRun bool                 `json:"dry_run"`
+	Carts  []models.ExpiredCart `json:"carts"`
+}
+
+func (p purgedCarts) Header() []string {
+	return []string{"USER_ID", "LINES", "UNITS", "LAST_ACTIVITY"}
+}
+
+func (p purgedCarts) Rows() [][]string {
+	rows := make([][]string, 0, len(p.Carts))
+	for _, cart := range p.Carts {
+		rows = append(rows, []string{
+			fmt.Sprint(cart.UserID), fmt.Sprint(cart.Lines), fmt.Sprint(cart.Units),
+			cart.LastActivity.Format(time.RFC3339),
+		})
+	}
+
+	return rows
+}
+
+func (p purgedCarts) Summary() string {
+	if p.DryRun {
+		return fmt.Sprintf("would purge %d carts (dry run)", len(p.Carts))
+	}
+
+	return fmt.Sprintf("purged %d carts", len(p.Carts))
+}
+
+func newCartPurgeCommand(a *app) *cobra.Command {
+	var (
+		olderThan time.Duration
+		limit     int32
+		userID    int64
+		dryRun    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "purge-expired",
+		Short: "Empty carts nobody changed for a while",
+		Long: "purge-expired empties carts nobody changed for --older-than with the PurgeExpiredCarts\n" +
+			"admin RPC of the cart service. Carts being checked out are skipped and saved for later\n" +
+			"items are kept. Each purged cart gets a new version, its watchers are notified, and\n" +
+			"the service publishes and audits the removed lines.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if olderThan < time.Second || limit <= 0 {
+				return fmt.Errorf("--older-than must be at least a second and --limit positive")
+			}
+
+			ctx, cancel := a.context(cmd)
+			defer cancel()
+
+			client, closeConn, err := a.cartClient()
+			if err != nil {
+				return err
+			}
+			defer closeConn()
+
+			resp, err := client.PurgeExpiredCarts(ctx, &cartapi.PurgeExpiredCartsRequest{
+				UserId:           userID,
+				OlderThanSeconds: int64(olderThan / time.Second),
+				Limit:            limit,
+				DryRun:           dryRun,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to purge carts: %w", err)
+			}
+
+			result := purgedCarts{DryRun: dryRun, Carts: make([]models.ExpiredCart, 0, len(resp.Carts))}
+			for _, cart := range resp.Carts {
+				result.Carts = append(result.Carts, models.ExpiredCart{
+					UserID:       cart.UserId,
+					Lines:        cart.Lines,
+					Units:        cart.Units,
+					LastActivity: cart.LastActivity.AsTime(),
+				})
+			}
+
+			return a.print(cmd, result)
+		},
+	}
+
+	cmd.Flags().DurationVar(&olderThan, "older-than", 30*24*time.Hour, "purge carts unchanged for this long")
+	cmd.Flags().Int32Var(&limit, "limit", 1000, "purge at most this many carts, at most 10000")
+	cmd.Flags().Int64Var(&userID, "user-id", 0, "admin user the purge is recorded for in the audit log")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only list the carts that would be purged")
+	_ = cmd.MarkFlagRequired("user-id")
+
+	return cmd
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// csvRow is a row of a CSV file with the values of the requested columns.
+type csvRow struct {
+	line   int
+	values []string
+}
+
+// readCSV reads a CSV file whose first row names its columns, "-" reads stdin. Rows
+// hold the values of columns in the given order, other columns are ignored.
+func readCSV(stdin io.Reader, path string, columns ...string) ([]csvRow, error) {
+	in := stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		in = file
+	}
+
+	reader := csv.NewReader(in)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s is empty", path)
+		}
+
+		return nil, err
+	}
+
+	indexes := make([]int, len(columns))
+	for i, column := range columns {
+		indexes[i] = -1
+
+		for j, name := range header {
+			if strings.EqualFold(strings.TrimSpace(name), column) {
+				indexes[i] = j
+			}
+		}
+
+		if indexes[i] < 0 {
+			return nil, fmt.Errorf("%s has no %s column, want columns %s", path, column, strings.Join(columns, ","))
+		}
+	}
+
+	var rows []csvRow
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := csvRow{line: line, values: make([]string, len(columns))}
+
+		for i, index := range indexes {
+			row.values[i] = strings.TrimSpace(record[index])
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
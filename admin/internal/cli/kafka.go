@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"admin/internal/kafka"
+	"admin/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newKafkaCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kafka",
+		Short: "Work with the Kafka events of the services",
+	}
+
+	cmd.AddCommand(newKafkaReplayCommand(a))
+
+	return cmd
+}
+
+type replayedEvents struct {
+	Topic       string                  `json:"topic"`
+	TargetTopic string                  `json:"target_topic"`
+	From        time.Time               `json:"from"`
+	Until       time.Time               `json:"until"`
+	DryRun      bool                    `json:"dry_run"`
+	Events      []models.ReplayedEvents `json:"events"`
+}
+
+func (r replayedEvents) Header() []string {
+	return []string{"TYPE", "COUNT"}
+}
+
+func (r replayedEvents) Rows() [][]string {
+	rows := make([][]string, 0, len(r.Events))
+	for _, events := range r.Events {
+		rows = append(rows, []string{events.Type, fmt.Sprint(events.Count)})
+	}
+
+	return rows
+}
+
+func (r replayedEvents) Summary() string {
+	total := 0
+	for _, events := range r.Events {
+		total += events.Count
+	}
+
+	summary := fmt.Sprintf("replayed %d events of %s from %s to %s onto %s", total, r.Topic,
+		r.From.Format(time.RFC3339), r.Until.Format(time.RFC3339), r.TargetTopic)
+	if r.DryRun {
+		summary = "would have " + summary + " (dry run)"
+	}
+
+	return summary
+}
+
+func newKafkaReplayCommand(a *app) *cobra.Command {
+	var (
+		service string
+		params  models.ReplayParams
+		from    string
+		until   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Publish past events of a topic again",
+		Long: "replay publishes the events of a topic between --from and --until again, to the same\n" +
+			"topic or to --to-topic, keeping their keys, values and headers. Replayed events carry\n" +
+			"a " + kafka.ReplayedFromHeader + " header with their original position. Brokers and the default\n" +
+			"topic come from the config of --service.\n\n" +
+			"--from and --until take RFC 3339 times or durations before now, such as 2h.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if service != serviceCart && service != serviceStocks {
+				return fmt.Errorf("unknown service %q, want %s or %s", service, serviceCart, serviceStocks)
+			}
+
+			cfg, err := a.config(service)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+
+			if params.From, err = parseTime(from, now); err != nil {
+				return fmt.Errorf("invalid --from: %w", err)
+			}
+
+			params.Until = now
+			if until != "" {
+				if params.Until, err = parseTime(until, now); err != nil {
+					return fmt.Errorf("invalid --until: %w", err)
+				}
+			}
+
+			if !params.From.Before(params.Until) {
+				return fmt.Errorf("--from must be before --until")
+			}
+
+			if params.Topic == "" {
+				params.Topic = cfg.Kafka.Topic
+			}
+
+			if params.TargetTopic == "" {
+				params.TargetTopic = params.Topic
+			}
+
+			ctx, cancel := a.context(cmd)
+			defer cancel()
+
+			events, err := kafka.Replay(ctx, cfg.Kafka.Brokers, params)
+			if err != nil {
+				return fmt.Errorf("failed to replay events: %w", err)
+			}
+
+			return a.print(cmd, replayedEvents{
+				Topic:       params.Topic,
+				TargetTopic: params.TargetTopic,
+				From:        params.From,
+				Until:       params.Until,
+				DryRun:      params.DryRun,
+				Events:      events,
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&service, "service", serviceCart, "service whose Kafka config is used: cart or stocks")
+	cmd.Flags().StringVar(&params.Topic, "topic", "", "topic to read (default kafka.topic of the service)")
+	cmd.Flags().StringVar(&params.TargetTopic, "to-topic", "", "topic to publish to (default --topic)")
+	cmd.Flags().StringVar(&from, "from", "", "replay events published at or after this time")
+	cmd.Flags().StringVar(&until, "until", "", "replay events published up to this time (default now)")
+	cmd.Flags().StringSliceVar(&params.Types, "type", nil, "only replay events of these types")
+	cmd.Flags().BoolVar(&params.DryRun, "dry-run", false, "only count the events that would be replayed")
+	_ = cmd.MarkFlagRequired("from")
+
+	return cmd
+}
+
+// parseTime reads an RFC 3339 time or a duration before now.
+func parseTime(value string, now time.Time) (time.Time, error) {
+	if ago, err := time.ParseDuration(value); err == nil {
+		return now.Add(-ago), nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"admin/internal/postgres"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newMigrationsCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrations",
+		Short: "Inspect the database migrations of the services",
+	}
+
+	cmd.AddCommand(newMigrationsStatusCommand(a))
+
+	return cmd
+}
+
+type migration struct {
+	Service string `json:"service"`
+	Version int64  `json:"version"`
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+}
+
+type migrationStatus []migration
+
+func (m migrationStatus) Header() []string {
+	return []string{"SERVICE", "VERSION", "NAME", "STATUS"}
+}
+
+func (m migrationStatus) Rows() [][]string {
+	rows := make([][]string, 0, len(m))
+	for _, migration := range m {
+		rows = append(rows, []string{migration.Service, fmt.Sprint(migration.Version), migration.Name, migration.Status})
+	}
+
+	return rows
+}
+
+func newMigrationsStatusCommand(a *app) *cobra.Command {
+	dirs := map[string]*string{serviceCart: new(string), serviceStocks: new(string)}
+
+	cmd := &cobra.Command{
+		Use:   "status [cart|stocks]",
+		Short: "Show which migrations were applied",
+		Long: "status compares the migration files of the services with the schema version recorded\n" +
+			"in their databases. A migration is applied, pending, or dirty when it failed halfway.",
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{serviceCart, serviceStocks},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			services := []string{serviceCart, serviceStocks}
+			if len(args) == 1 {
+				services = args
+			}
+
+			ctx, cancel := a.context(cmd)
+			defer cancel()
+
+			var result migrationStatus
+
+			for _, service := range services {
+				dir := *dirs[service]
+				if dir == "" {
+					dir = filepath.Join(filepath.Dir(*a.configPaths[service]), "internal", "migrations")
+				}
+
+				migrations, err := migrationFiles(service, dir)
+				if err != nil {
+					return err
+				}
+
+				db, err := a.db(ctx, service)
+				if err != nil {
+					return err
+				}
+
+				version, err := postgres.MigrationVersion(ctx, db)
+				db.Close()
+				if err != nil {
+					return fmt.Errorf("failed to read %s schema version: %w", service, err)
+				}
+
+				for i := range migrations {
+					switch {
+					case version == nil || migrations[i].Version > version.Version:
+						migrations[i].Status = "pending"
+					case migrations[i].Version == version.Version && version.Dirty:
+						migrations[i].Status = "dirty"
+					default:
+						migrations[i].Status = "applied"
+					}
+				}
+
+				result = append(result, migrations...)
+			}
+
+			return a.print(cmd, result)
+		},
+	}
+
+	for service, dir := range dirs {
+		cmd.Flags().StringVar(dir, service+"-dir", "", "migrations of the "+service+" service (default internal/migrations next to its config)")
+	}
+
+	return cmd
+}
+
+// migrationFiles lists the up migrations in dir, named like 000001_init_cart.up.sql.
+func migrationFiles(service, dir string) ([]migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s migrations: %w", service, err)
+	}
+
+	var migrations []migration
+
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".up.sql")
+		if entry.IsDir() || !ok {
+			continue
+		}
+
+		prefix, _, _ := strings.Cut(name, "_")
+
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has no version: %w", entry.Name(), err)
+		}
+
+		migrations = append(migrations, migration{Service: service, Version: version, Name: name})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
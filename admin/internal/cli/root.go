@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"admin/internal/config"
+	"admin/internal/output"
+	"admin/internal/postgres"
+	cartapi "admin/pkg/api/cart"
+	stocksapi "admin/pkg/api/stocks"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	serviceCart   = "cart"
+	serviceStocks = "stocks"
+	apiKeyHeader  = "x-api-key"
+)
+
+// app holds the global flags and the connections commands open from them.
+type app struct {
+	configPaths map[string]*string
+	addrs       map[string]*string
+	apiKey      string
+	output      string
+	timeout     time.Duration
+}
+
+func NewRootCommand() *cobra.Command {
+	a := &app{
+		configPaths: map[string]*string{serviceCart: new(string), serviceStocks: new(string)},
+		addrs:       map[string]*string{serviceCart: new(string), serviceStocks: new(string)},
+	}
+
+	root := &cobra.Command{
+		Use:   "admin",
+		Short: "Operate the cart and stocks services",
+		Long: "admin operates the cart and stocks services through their gRPC APIs and databases.\n" +
+			"Connection settings come from the services' config.yml files.",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return output.Validate(a.output)
+		},
+	}
+
+	flags := root.PersistentFlags()
+	for _, service := range []string{serviceCart, serviceStocks} {
+		flags.StringVar(a.configPaths[service], service+"-config", service+"/config.yml", "config.yml of the "+service+" service")
+		flags.StringVar(a.addrs[service], service+"-addr", "", "gRPC address of the "+service+" service (default localhost:<listen.grpc_port>)")
+	}
+	flags.StringVar(&a.apiKey, "api-key", "", "x-api-key sent to the services (default the first audit.admin_api_keys entry)")
+	flags.StringVarP(&a.output, "output", "o", output.FormatTable, "output format: table or json")
+	flags.DurationVar(&a.timeout, "timeout", time.Minute, "time limit of the command")
+
+	root.AddCommand(
+		newCartCommand(a),
+		newStockCommand(a),
+		newSKUCommand(a),
+		newKafkaCommand(a),
+		newMigrationsCommand(a),
+	)
+
+	return root
+}
+
+// context bounds a command by the --timeout flag.
+func (a *app) context(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(cmd.Context(), a.timeout)
+}
+
+func (a *app) config(service string) (*config.Service, error) {
+	return config.Load(*a.configPaths[service], strings.ToUpper(service))
+}
+
+func (a *app) db(ctx context.Context, service string) (*pgxpool.Pool, error) {
+	cfg, err := a.config(service)
+	if err != nil {
+		return nil, err
+	}
+
+	return postgres.NewPostgres(ctx, cfg.Postgres)
+}
+
+// conn connects to the gRPC API of service. Calls carry an admin API key, so admin
+// RPCs and admin-only flags can be used.
+func (a *app) conn(service string) (*grpc.ClientConn, error) {
+	cfg, err := a.config(service)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := *a.addrs[service]
+	if addr == "" {
+		addr = "localhost:" + cfg.Listen.GRPCPort
+	}
+
+	apiKey := a.apiKey
+	if apiKey == "" && len(cfg.Audit.AdminAPIKeys) > 0 {
+		apiKey = cfg.Audit.AdminAPIKeys[0]
+	}
+
+	conn, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if apiKey != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, apiKeyHeader, apiKey)
+			}
+
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s service at %s: %w", service, addr, err)
+	}
+
+	return conn, nil
+}
+
+func (a *app) cartClient() (cartapi.CartServiceClient, func(), error) {
+	conn, err := a.conn(serviceCart)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cartapi.NewCartServiceClient(conn), func() { conn.Close() }, nil
+}
+
+func (a *app) stocksClient() (stocksapi.StockServiceClient, func(), error) {
+	conn, err := a.conn(serviceStocks)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stocksapi.NewStockServiceClient(conn), func() { conn.Close() }, nil
+}
+
+func (a *app) print(cmd *cobra.Command, result output.Table) error {
+	return output.Print(cmd.OutOrStdout(), a.output, result)
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"admin/internal/models"
+	stocksapi "admin/pkg/api/stocks"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func newSKUCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sku",
+		Short: "Manage the SKU catalog of the stocks service",
+	}
+
+	cmd.AddCommand(newSKUImportCommand(a))
+
+	return cmd
+}
+
+type importedSKUs struct {
+	DryRun bool                 `json:"dry_run"`
+	SKUs   []models.ImportedSKU `json:"skus"`
+}
+
+func (i importedSKUs) Header() []string {
+	return []string{"SKU", "NAME", "TYPE", "RESULT"}
+}
+
+func (i importedSKUs) Rows() [][]string {
+	rows := make([][]string, 0, len(i.SKUs))
+	for _, sku := range i.SKUs {
+		result := "updated"
+		if sku.Created {
+			result = "created"
+		}
+
+		rows = append(rows, []string{fmt.Sprint(sku.SKU.SKU), sku.Name, sku.Type, result})
+	}
+
+	return rows
+}
+
+func (i importedSKUs) Summary() string {
+	created := 0
+	for _, sku := range i.SKUs {
+		if sku.Created {
+			created++
+		}
+	}
+
+	summary := fmt.Sprintf("imported %d skus: %d created, %d updated", len(i.SKUs), created, len(i.SKUs)-created)
+	if i.DryRun {
+		summary = "would have " + summary + " (dry run)"
+	}
+
+	return summary
+}
+
+func newSKUImportCommand(a *app) *cobra.Command {
+	var (
+		file   string
+		userID int64
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create or update SKUs from a CSV file",
+		Long: "import creates the SKUs of a CSV file with the columns sku, name and type, or renames\n" +
+			"and retypes the existing ones. The file is applied by the ImportSKUs admin RPC of the\n" +
+			"stocks service in one transaction, names must stay unique. The service publishes and\n" +
+			"audits the changes, which also clears the SKUs from the cart stock caches.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rows, err := readCSV(cmd.InOrStdin(), file, "sku", "name", "type")
+			if err != nil {
+				return err
+			}
+
+			skus := make([]*stocksapi.CatalogSKU, 0, len(rows))
+			for _, row := range rows {
+				sku, err := strconv.ParseUint(row.values[0], 10, 32)
+				if err != nil || sku == 0 || row.values[1] == "" {
+					return fmt.Errorf("%s:%d: want a positive sku and a name", file, row.line)
+				}
+
+				skus = append(skus, &stocksapi.CatalogSKU{Sku: uint32(sku), Name: row.values[1], Type: row.values[2]})
+			}
+
+			ctx, cancel := a.context(cmd)
+			defer cancel()
+
+			client, closeConn, err := a.stocksClient()
+			if err != nil {
+				return err
+			}
+			defer closeConn()
+
+			resp, err := client.ImportSKUs(ctx, &stocksapi.ImportSKUsRequest{UserId: userID, Skus: skus, DryRun: dryRun})
+			if err != nil {
+				return fmt.Errorf("failed to import skus: %w", err)
+			}
+
+			result := importedSKUs{DryRun: dryRun, SKUs: make([]models.ImportedSKU, 0, len(resp.Skus))}
+			for _, imported := range resp.Skus {
+				result.SKUs = append(result.SKUs, models.ImportedSKU{
+					SKU:     models.SKU{SKU: imported.Sku.GetSku(), Name: imported.Sku.GetName(), Type: imported.Sku.GetType()},
+					Created: imported.Created,
+				})
+			}
+
+			return a.print(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "CSV file of SKUs, - reads stdin")
+	cmd.Flags().Int64Var(&userID, "user-id", 0, "admin user the import is recorded for in the audit log")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "check the SKUs without importing them")
+	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.MarkFlagRequired("user-id")
+
+	return cmd
+}
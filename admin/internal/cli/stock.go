@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"admin/internal/models"
+	stocksapi "admin/pkg/api/stocks"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newStockCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stock",
+		Short: "Inspect and adjust stock offers",
+	}
+
+	cmd.AddCommand(newStockOffersCommand(a), newStockAdjustCommand(a))
+
+	return cmd
+}
+
+type stockOffer struct {
+	SKU       uint32     `json:"sku"`
+	SellerID  int64      `json:"seller_id"`
+	Count     uint32     `json:"count"`
+	Price     uint32     `json:"price"`
+	Location  string     `json:"location"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type stockOffers []stockOffer
+
+func (o stockOffers) Header() []string {
+	return []string{"SKU", "SELLER", "COUNT", "PRICE", "LOCATION", "DELETED_AT"}
+}
+
+func (o stockOffers) Rows() [][]string {
+	rows := make([][]string, 0, len(o))
+	for _, offer := range o {
+		deletedAt := ""
+		if offer.DeletedAt != nil {
+			deletedAt = offer.DeletedAt.Format(time.RFC3339)
+		}
+
+		rows = append(rows, []string{
+			fmt.Sprint(offer.SKU), fmt.Sprint(offer.SellerID), fmt.Sprint(offer.Count),
+			fmt.Sprint(offer.Price), offer.Location, deletedAt,
+		})
+	}
+
+	return rows
+}
+
+func newStockOffersCommand(a *app) *cobra.Command {
+	var includeDeleted bool
+
+	cmd := &cobra.Command{
+		Use:   "offers <sku>",
+		Short: "List the offers of every seller for a SKU",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sku, err := strconv.ParseUint(args[0], 10, 32)
+			if err != nil || sku == 0 {
+				return fmt.Errorf("invalid sku %q", args[0])
+			}
+
+			ctx, cancel := a.context(cmd)
+			defer cancel()
+
+			client, closeConn, err := a.stocksClient()
+			if err != nil {
+				return err
+			}
+			defer closeConn()
+
+			resp, err := client.ListOffers(ctx, &stocksapi.ListOffersRequest{Sku: uint32(sku), IncludeDeleted: includeDeleted})
+			if err != nil {
+				return fmt.Errorf("failed to list offers: %w", err)
+			}
+
+			result := make(stockOffers, 0, len(resp.Offers))
+			for _, item := range resp.Offers {
+				offer := stockOffer{
+					SKU:      item.Sku,
+					SellerID: item.SellerId,
+					Count:    item.Count,
+					Price:    item.Price,
+					Location: item.Location,
+				}
+
+				if item.DeletedAt != nil {
+					deletedAt := item.DeletedAt.AsTime()
+					offer.DeletedAt = &deletedAt
+				}
+
+				result = append(result, offer)
+			}
+
+			return a.print(cmd, result)
+		},
+	}
+
+	cmd.Flags().BoolVar(&includeDeleted, "include-deleted", false, "also list deleted offers that are not purged yet")
+
+	return cmd
+}
+
+type adjustedStock struct {
+	DryRun bool                   `json:"dry_run"`
+	Offers []models.AdjustedStock `json:"offers"`
+}
+
+func (s adjustedStock) Header() []string {
+	return []string{"SKU", "SELLER", "DELTA", "COUNT", "PRICE"}
+}
+
+func (s adjustedStock) Rows() [][]string {
+	rows := make([][]string, 0, len(s.Offers))
+	for _, offer := range s.Offers {
+		rows = append(rows, []string{
+			fmt.Sprint(offer.SKU), fmt.Sprint(offer.SellerID), fmt.Sprintf("%+d", offer.Delta),
+			fmt.Sprint(offer.Count), fmt.Sprint(offer.Price),
+		})
+	}
+
+	return rows
+}
+
+func (s adjustedStock) Summary() string {
+	if s.DryRun {
+		return fmt.Sprintf("would adjust %d offers (dry run)", len(s.Offers))
+	}
+
+	return fmt.Sprintf("adjusted %d offers", len(s.Offers))
+}
+
+func newStockAdjustCommand(a *app) *cobra.Command {
+	var (
+		file   string
+		userID int64
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "adjust",
+		Short: "Change the counts of offers in bulk",
+		Long: "adjust adds the delta of every row of a CSV file with the columns sku, seller_id and\n" +
+			"delta to the count of that offer. Deltas may be negative. The file is applied by the\n" +
+			"AdjustStock admin RPC of the stocks service in one transaction, an unknown offer or a\n" +
+			"count going below zero rejects all of it. The service publishes and audits the\n" +
+			"changes like its other stock changes.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rows, err := readCSV(cmd.InOrStdin(), file, "sku", "seller_id", "delta")
+			if err != nil {
+				return err
+			}
+
+			adjustments := make([]*stocksapi.StockAdjustment, 0, len(rows))
+			for _, row := range rows {
+				sku, skuErr := strconv.ParseUint(row.values[0], 10, 32)
+				sellerID, sellerErr := strconv.ParseInt(row.values[1], 10, 64)
+				delta, deltaErr := strconv.ParseInt(row.values[2], 10, 32)
+
+				if skuErr != nil || sellerErr != nil || deltaErr != nil || sku == 0 || sellerID <= 0 {
+					return fmt.Errorf("%s:%d: want a positive sku and seller_id and an integer delta", file, row.line)
+				}
+
+				adjustments = append(adjustments, &stocksapi.StockAdjustment{Sku: uint32(sku), SellerId: sellerID, Delta: int32(delta)})
+			}
+
+			ctx, cancel := a.context(cmd)
+			defer cancel()
+
+			client, closeConn, err := a.stocksClient()
+			if err != nil {
+				return err
+			}
+			defer closeConn()
+
+			resp, err := client.AdjustStock(ctx, &stocksapi.AdjustStockRequest{UserId: userID, Adjustments: adjustments, DryRun: dryRun})
+			if err != nil {
+				return fmt.Errorf("failed to adjust stock: %w", err)
+			}
+
+			result := adjustedStock{DryRun: dryRun, Offers: make([]models.AdjustedStock, 0, len(resp.Offers))}
+			for i, item := range resp.Offers {
+				result.Offers = append(result.Offers, models.AdjustedStock{
+					SKU:      item.Sku,
+					SellerID: item.SellerId,
+					Delta:    adjustments[i].Delta,
+					Count:    item.Count,
+					Price:    item.Price,
+				})
+			}
+
+			return a.print(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "CSV file of adjustments, - reads stdin")
+	cmd.Flags().Int64Var(&userID, "user-id", 0, "admin user the adjustments are recorded for in the audit log")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "check the adjustments without applying them")
+	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.MarkFlagRequired("user-id")
+
+	return cmd
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/spf13/viper"
+)
+
+// Service is the part of a service's config.yml the admin tool needs. The services
+// keep their configs in internal packages, so the fields are mirrored here.
+type Service struct {
+	Listen   Listen     `mapstructure:"listen"`
+	Postgres DbPostgres `mapstructure:"postgres"`
+	Kafka    Kafka      `mapstructure:"kafka"`
+	Audit    Audit      `mapstructure:"audit"`
+}
+
+type (
+	Listen struct {
+		ServiceName string `mapstructure:"service_name"`
+		GRPCPort    string `mapstructure:"grpc_port"`
+	}
+
+	DbPostgres struct {
+		Host     string `mapstructure:"host"`
+		Port     string `mapstructure:"port"`
+		DbName   string `mapstructure:"db_name"`
+		UserName string `mapstructure:"username"`
+		Password string `mapstructure:"password"`
+		Sslmode  string `mapstructure:"ssl_mode"`
+	}
+
+	Kafka struct {
+		Brokers []string `mapstructure:"brokers"`
+		Topic   string   `mapstructure:"topic"`
+	}
+
+	Audit struct {
+		AdminAPIKeys []string `mapstructure:"admin_api_keys"`
+	}
+)
+
+// Load reads the config.yml of a service with the environment overrides the service
+// applies, given its environment variable prefix, like CART.
+func Load(path, envPrefix string) (*Service, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetEnvPrefix(envPrefix)
+	v.SetEnvKeyReplacer(envKeyReplacer)
+	v.AutomaticEnv()
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	if err := bindEnv(v, reflect.TypeOf(Service{}), ""); err != nil {
+		return nil, fmt.Errorf("failed to bind environment variables: %w", err)
+	}
+
+	if err := readSecretFiles(v, envPrefix); err != nil {
+		return nil, fmt.Errorf("failed to read secret files: %w", err)
+	}
+
+	cfg := &Service{}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
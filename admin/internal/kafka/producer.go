@@ -0,0 +1,61 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+const metadataTimeout = 10 * time.Second
+
+// Producer publishes messages and waits for each to be delivered, as the admin tool
+// reports what it published.
+type Producer struct {
+	producer *kafka.Producer
+}
+
+func NewProducer(brokers []string) (*Producer, error) {
+	if len(brokers) == 0 {
+		return nil, fmt.Errorf("kafka broker address list is empty")
+	}
+
+	prod, err := kafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers": strings.Join(brokers, ","),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating kafka producer: %w", err)
+	}
+
+	return &Producer{producer: prod}, nil
+}
+
+// Produce sends messages and returns once all of them were delivered or failed.
+func (p *Producer) Produce(ctx context.Context, messages []*kafka.Message) error {
+	deliveries := make(chan kafka.Event, len(messages))
+
+	for _, message := range messages {
+		if err := p.producer.Produce(message, deliveries); err != nil {
+			return fmt.Errorf("error sending message to kafka: %w", err)
+		}
+	}
+
+	for range messages {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e := <-deliveries:
+			if m, ok := e.(*kafka.Message); ok && m.TopicPartition.Error != nil {
+				return fmt.Errorf("delivery to %s failed: %w", *m.TopicPartition.Topic, m.TopicPartition.Error)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *Producer) Close() {
+	p.producer.Close()
+}
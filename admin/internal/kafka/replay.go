@@ -0,0 +1,224 @@
+package kafka
+
+import (
+	"admin/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+const (
+	pollTimeoutMs = 100
+	// replayBatchSize is how many events are published before waiting for them.
+	replayBatchSize = 500
+	// ReplayedFromHeader tells consumers where a replayed event was read from.
+	ReplayedFromHeader = "replayed-from"
+)
+
+// Replay reads the events of params.Topic published between params.From and
+// params.Until and publishes them to params.TargetTopic with their key, value and
+// headers. Events published while it runs are not read, so a topic can be replayed
+// onto itself. Events are counted by their type field; on a dry run they are only
+// counted.
+func Replay(ctx context.Context, brokers []string, params models.ReplayParams) ([]models.ReplayedEvents, error) {
+	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":    strings.Join(brokers, ","),
+		"group.id":             "admin-replay",
+		"enable.auto.commit":   false,
+		"enable.partition.eof": true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating kafka consumer: %w", err)
+	}
+	defer consumer.Close()
+
+	positions, ends, err := replayRange(consumer, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(positions) == 0 {
+		return nil, nil
+	}
+
+	if err := consumer.Assign(positions); err != nil {
+		return nil, fmt.Errorf("error assigning partitions: %w", err)
+	}
+
+	var producer *Producer
+	if !params.DryRun {
+		producer, err = NewProducer(brokers)
+		if err != nil {
+			return nil, err
+		}
+		defer producer.Close()
+	}
+
+	types := make(map[string]struct{}, len(params.Types))
+	for _, t := range params.Types {
+		types[t] = struct{}{}
+	}
+
+	counts := make(map[string]int)
+	batch := make([]*kafka.Message, 0, replayBatchSize)
+
+	for len(ends) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var message *kafka.Message
+
+		switch e := consumer.Poll(pollTimeoutMs).(type) {
+		case *kafka.Message:
+			message = e
+		case kafka.PartitionEOF:
+			// Offsets of a partition can have gaps, so its end may not be a message.
+			delete(ends, e.Partition)
+			continue
+		case kafka.Error:
+			if e.IsFatal() {
+				return nil, fmt.Errorf("error reading %s: %w", params.Topic, e)
+			}
+			continue
+		default:
+			continue
+		}
+
+		partition := message.TopicPartition.Partition
+
+		end, reading := ends[partition]
+		if !reading {
+			continue
+		}
+
+		if message.TopicPartition.Offset+1 >= end || message.Timestamp.After(params.Until) {
+			delete(ends, partition)
+		}
+
+		if message.Timestamp.After(params.Until) {
+			continue
+		}
+
+		eventType := messageType(message.Value)
+		if _, ok := types[eventType]; len(types) > 0 && !ok {
+			continue
+		}
+
+		counts[eventType]++
+
+		if params.DryRun {
+			continue
+		}
+
+		batch = append(batch, replayMessage(params.TargetTopic, message))
+		if len(batch) == replayBatchSize {
+			if err := producer.Produce(ctx, batch); err != nil {
+				return nil, err
+			}
+
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := producer.Produce(ctx, batch); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]models.ReplayedEvents, 0, len(counts))
+	for eventType, count := range counts {
+		result = append(result, models.ReplayedEvents{Type: eventType, Count: count})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Type < result[j].Type })
+
+	return result, nil
+}
+
+// replayRange returns where to start reading each partition and the end offset of
+// the partitions that have events to replay.
+func replayRange(consumer *kafka.Consumer, params models.ReplayParams) ([]kafka.TopicPartition, map[int32]kafka.Offset, error) {
+	timeoutMs := int(metadataTimeout.Milliseconds())
+
+	metadata, err := consumer.GetMetadata(&params.Topic, false, timeoutMs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetching kafka metadata: %w", err)
+	}
+
+	topic, ok := metadata.Topics[params.Topic]
+	if !ok || topic.Error.Code() != kafka.ErrNoError {
+		return nil, nil, fmt.Errorf("topic %s not found: %v", params.Topic, topic.Error)
+	}
+
+	times := make([]kafka.TopicPartition, 0, len(topic.Partitions))
+	for _, partition := range topic.Partitions {
+		times = append(times, kafka.TopicPartition{
+			Topic:     &params.Topic,
+			Partition: partition.ID,
+			Offset:    kafka.Offset(params.From.UnixMilli()),
+		})
+	}
+
+	starts, err := consumer.OffsetsForTimes(times, timeoutMs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error looking up offsets: %w", err)
+	}
+
+	positions := make([]kafka.TopicPartition, 0, len(starts))
+	ends := make(map[int32]kafka.Offset, len(starts))
+
+	for _, start := range starts {
+		// No event of the partition is as new as From.
+		if start.Offset < 0 {
+			continue
+		}
+
+		_, high, err := consumer.QueryWatermarkOffsets(params.Topic, start.Partition, timeoutMs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error querying offsets of partition %d: %w", start.Partition, err)
+		}
+
+		if int64(start.Offset) >= high {
+			continue
+		}
+
+		positions = append(positions, start)
+		ends[start.Partition] = kafka.Offset(high)
+	}
+
+	return positions, ends, nil
+}
+
+func replayMessage(topic string, message *kafka.Message) *kafka.Message {
+	headers := append([]kafka.Header{}, message.Headers...)
+	headers = append(headers, kafka.Header{
+		Key:   ReplayedFromHeader,
+		Value: []byte(message.TopicPartition.String()),
+	})
+
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Key:            message.Key,
+		Value:          message.Value,
+		Headers:        headers,
+	}
+}
+
+// messageType returns the type field of the services' JSON events.
+func messageType(value []byte) string {
+	var event struct {
+		Type string `json:"type"`
+	}
+
+	if err := json.Unmarshal(value, &event); err != nil || event.Type == "" {
+		return "unknown"
+	}
+
+	return event.Type
+}
@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// ExpiredCart is a cart nobody changed since LastActivity.
+type ExpiredCart struct {
+	UserID       int64     `json:"user_id"`
+	Lines        int64     `json:"lines"`
+	Units        int64     `json:"units"`
+	LastActivity time.Time `json:"last_activity"`
+}
+
+// AdjustedStock is an offer after an adjustment.
+type AdjustedStock struct {
+	SKU      uint32 `json:"sku"`
+	SellerID int64  `json:"seller_id"`
+	Delta    int32  `json:"delta"`
+	Count    uint32 `json:"count"`
+	Price    uint32 `json:"price"`
+}
+
+// SKU is an entry of the stocks catalog.
+type SKU struct {
+	SKU  uint32 `json:"sku"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ImportedSKU tells whether an imported SKU was created or updated.
+type ImportedSKU struct {
+	SKU
+	Created bool `json:"created"`
+}
+
+// MigrationVersion is the schema version recorded in schema_migrations. Dirty is set
+// when the migration of that version did not finish.
+type MigrationVersion struct {
+	Version int64
+	Dirty   bool
+}
+
+// ReplayParams selects the events of Topic published between From and Until and, when
+// Types is not empty, of one of Types. They are published to TargetTopic again.
+type ReplayParams struct {
+	Topic       string
+	TargetTopic string
+	From        time.Time
+	Until       time.Time
+	Types       []string
+	DryRun      bool
+}
+
+// ReplayedEvents counts the replayed events of a type.
+type ReplayedEvents struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
@@ -0,0 +1,65 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// Table is a command result. JSON output encodes the result itself, table output
+// prints its header and rows.
+type Table interface {
+	Header() []string
+	Rows() [][]string
+}
+
+// Summarizer is a Table with a line printed below its rows.
+type Summarizer interface {
+	Summary() string
+}
+
+// Validate checks that format is a known output format.
+func Validate(format string) error {
+	switch format {
+	case FormatTable, FormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q, want %s or %s", format, FormatTable, FormatJSON)
+	}
+}
+
+// Print writes result to w in format.
+func Print(w io.Writer, format string, result Table) error {
+	if format == FormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(result)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, strings.Join(result.Header(), "\t"))
+
+	for _, row := range result.Rows() {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if summarizer, ok := result.(Summarizer); ok {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, summarizer.Summary())
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"admin/internal/models"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const undefinedTable = "42P01"
+
+// MigrationVersion returns the schema version the services recorded in
+// schema_migrations, the table golang-migrate uses too. It returns nil when no
+// version was recorded yet.
+func MigrationVersion(ctx context.Context, db *pgxpool.Pool) (*models.MigrationVersion, error) {
+	var version models.MigrationVersion
+
+	query := `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+	err := db.QueryRow(ctx, query).Scan(&version.Version, &version.Dirty)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || errors.As(err, &pgErr) && pgErr.Code == undefinedTable {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &version, nil
+}
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"admin/internal/config"
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewPostgres(ctx context.Context, cfg config.DbPostgres) (*pgxpool.Pool, error) {
+	dbUrl := fmt.Sprintf(
+		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
+		cfg.UserName,
+		url.QueryEscape(cfg.Password),
+		cfg.Host,
+		cfg.Port,
+		cfg.DbName,
+		cfg.Sslmode,
+	)
+
+	dbPool, err := pgxpool.New(ctx, dbUrl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Postgres: %w", err)
+	}
+
+	if err = dbPool.Ping(ctx); err != nil {
+		dbPool.Close()
+		return nil, fmt.Errorf("failed to ping Postgres %s: %w", cfg.Host, err)
+	}
+
+	return dbPool, nil
+}
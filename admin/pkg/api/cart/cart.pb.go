@@ -0,0 +1,2835 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.6.1
+// source: cart/cart.proto
+
+package cartapi
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CartLineStatus int32
+
+const (
+	CartLineStatus_CART_LINE_STATUS_UNSPECIFIED CartLineStatus = 0
+	CartLineStatus_CART_LINE_STATUS_OK          CartLineStatus = 1
+	// The offer has no units left.
+	CartLineStatus_CART_LINE_STATUS_OUT_OF_STOCK CartLineStatus = 2
+	// The offer has fewer units than the line, see available_count.
+	CartLineStatus_CART_LINE_STATUS_INSUFFICIENT CartLineStatus = 3
+	// The offer was deleted.
+	CartLineStatus_CART_LINE_STATUS_SKU_REMOVED CartLineStatus = 4
+	// The price differs from the one when the line was added, see previous_price.
+	CartLineStatus_CART_LINE_STATUS_PRICE_CHANGED CartLineStatus = 5
+)
+
+// Enum value maps for CartLineStatus.
+var (
+	CartLineStatus_name = map[int32]string{
+		0: "CART_LINE_STATUS_UNSPECIFIED",
+		1: "CART_LINE_STATUS_OK",
+		2: "CART_LINE_STATUS_OUT_OF_STOCK",
+		3: "CART_LINE_STATUS_INSUFFICIENT",
+		4: "CART_LINE_STATUS_SKU_REMOVED",
+		5: "CART_LINE_STATUS_PRICE_CHANGED",
+	}
+	CartLineStatus_value = map[string]int32{
+		"CART_LINE_STATUS_UNSPECIFIED":   0,
+		"CART_LINE_STATUS_OK":            1,
+		"CART_LINE_STATUS_OUT_OF_STOCK":  2,
+		"CART_LINE_STATUS_INSUFFICIENT":  3,
+		"CART_LINE_STATUS_SKU_REMOVED":   4,
+		"CART_LINE_STATUS_PRICE_CHANGED": 5,
+	}
+)
+
+func (x CartLineStatus) Enum() *CartLineStatus {
+	p := new(CartLineStatus)
+	*p = x
+	return p
+}
+
+func (x CartLineStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CartLineStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_cart_cart_proto_enumTypes[0].Descriptor()
+}
+
+func (CartLineStatus) Type() protoreflect.EnumType {
+	return &file_cart_cart_proto_enumTypes[0]
+}
+
+func (x CartLineStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CartLineStatus.Descriptor instead.
+func (CartLineStatus) EnumDescriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{0}
+}
+
+type CartLineFix int32
+
+const (
+	CartLineFix_CART_LINE_FIX_UNSPECIFIED CartLineFix = 0
+	CartLineFix_CART_LINE_FIX_REMOVED     CartLineFix = 1
+	CartLineFix_CART_LINE_FIX_CLAMPED     CartLineFix = 2
+	CartLineFix_CART_LINE_FIX_REPRICED    CartLineFix = 3
+)
+
+// Enum value maps for CartLineFix.
+var (
+	CartLineFix_name = map[int32]string{
+		0: "CART_LINE_FIX_UNSPECIFIED",
+		1: "CART_LINE_FIX_REMOVED",
+		2: "CART_LINE_FIX_CLAMPED",
+		3: "CART_LINE_FIX_REPRICED",
+	}
+	CartLineFix_value = map[string]int32{
+		"CART_LINE_FIX_UNSPECIFIED": 0,
+		"CART_LINE_FIX_REMOVED":     1,
+		"CART_LINE_FIX_CLAMPED":     2,
+		"CART_LINE_FIX_REPRICED":    3,
+	}
+)
+
+func (x CartLineFix) Enum() *CartLineFix {
+	p := new(CartLineFix)
+	*p = x
+	return p
+}
+
+func (x CartLineFix) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CartLineFix) Descriptor() protoreflect.EnumDescriptor {
+	return file_cart_cart_proto_enumTypes[1].Descriptor()
+}
+
+func (CartLineFix) Type() protoreflect.EnumType {
+	return &file_cart_cart_proto_enumTypes[1]
+}
+
+func (x CartLineFix) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CartLineFix.Descriptor instead.
+func (CartLineFix) EnumDescriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{1}
+}
+
+type CheckoutStatus int32
+
+const (
+	CheckoutStatus_CHECKOUT_STATUS_UNSPECIFIED CheckoutStatus = 0
+	CheckoutStatus_CHECKOUT_STATUS_RUNNING     CheckoutStatus = 1
+	// A step failed and the completed steps are being undone.
+	CheckoutStatus_CHECKOUT_STATUS_COMPENSATING CheckoutStatus = 2
+	CheckoutStatus_CHECKOUT_STATUS_COMPLETED    CheckoutStatus = 3
+	CheckoutStatus_CHECKOUT_STATUS_FAILED       CheckoutStatus = 4
+	// A step that cannot be undone failed for good or ran out of retries. The saga stopped
+	// at step and an operator has to finish it.
+	CheckoutStatus_CHECKOUT_STATUS_STUCK CheckoutStatus = 5
+)
+
+// Enum value maps for CheckoutStatus.
+var (
+	CheckoutStatus_name = map[int32]string{
+		0: "CHECKOUT_STATUS_UNSPECIFIED",
+		1: "CHECKOUT_STATUS_RUNNING",
+		2: "CHECKOUT_STATUS_COMPENSATING",
+		3: "CHECKOUT_STATUS_COMPLETED",
+		4: "CHECKOUT_STATUS_FAILED",
+		5: "CHECKOUT_STATUS_STUCK",
+	}
+	CheckoutStatus_value = map[string]int32{
+		"CHECKOUT_STATUS_UNSPECIFIED":  0,
+		"CHECKOUT_STATUS_RUNNING":      1,
+		"CHECKOUT_STATUS_COMPENSATING": 2,
+		"CHECKOUT_STATUS_COMPLETED":    3,
+		"CHECKOUT_STATUS_FAILED":       4,
+		"CHECKOUT_STATUS_STUCK":        5,
+	}
+)
+
+func (x CheckoutStatus) Enum() *CheckoutStatus {
+	p := new(CheckoutStatus)
+	*p = x
+	return p
+}
+
+func (x CheckoutStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CheckoutStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_cart_cart_proto_enumTypes[2].Descriptor()
+}
+
+func (CheckoutStatus) Type() protoreflect.EnumType {
+	return &file_cart_cart_proto_enumTypes[2]
+}
+
+func (x CheckoutStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CheckoutStatus.Descriptor instead.
+func (CheckoutStatus) EnumDescriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{2}
+}
+
+type CheckoutStep int32
+
+const (
+	// The saga has finished.
+	CheckoutStep_CHECKOUT_STEP_UNSPECIFIED       CheckoutStep = 0
+	CheckoutStep_CHECKOUT_STEP_RESERVE_STOCK     CheckoutStep = 1
+	CheckoutStep_CHECKOUT_STEP_AUTHORIZE_PAYMENT CheckoutStep = 2
+	CheckoutStep_CHECKOUT_STEP_COMMIT_STOCK      CheckoutStep = 3
+	CheckoutStep_CHECKOUT_STEP_CLEAR_CART        CheckoutStep = 4
+	CheckoutStep_CHECKOUT_STEP_VOID_PAYMENT      CheckoutStep = 5
+	CheckoutStep_CHECKOUT_STEP_RELEASE_STOCK     CheckoutStep = 6
+	CheckoutStep_CHECKOUT_STEP_CAPTURE_PAYMENT   CheckoutStep = 7
+)
+
+// Enum value maps for CheckoutStep.
+var (
+	CheckoutStep_name = map[int32]string{
+		0: "CHECKOUT_STEP_UNSPECIFIED",
+		1: "CHECKOUT_STEP_RESERVE_STOCK",
+		2: "CHECKOUT_STEP_AUTHORIZE_PAYMENT",
+		3: "CHECKOUT_STEP_COMMIT_STOCK",
+		4: "CHECKOUT_STEP_CLEAR_CART",
+		5: "CHECKOUT_STEP_VOID_PAYMENT",
+		6: "CHECKOUT_STEP_RELEASE_STOCK",
+		7: "CHECKOUT_STEP_CAPTURE_PAYMENT",
+	}
+	CheckoutStep_value = map[string]int32{
+		"CHECKOUT_STEP_UNSPECIFIED":       0,
+		"CHECKOUT_STEP_RESERVE_STOCK":     1,
+		"CHECKOUT_STEP_AUTHORIZE_PAYMENT": 2,
+		"CHECKOUT_STEP_COMMIT_STOCK":      3,
+		"CHECKOUT_STEP_CLEAR_CART":        4,
+		"CHECKOUT_STEP_VOID_PAYMENT":      5,
+		"CHECKOUT_STEP_RELEASE_STOCK":     6,
+		"CHECKOUT_STEP_CAPTURE_PAYMENT":   7,
+	}
+)
+
+func (x CheckoutStep) Enum() *CheckoutStep {
+	p := new(CheckoutStep)
+	*p = x
+	return p
+}
+
+func (x CheckoutStep) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CheckoutStep) Descriptor() protoreflect.EnumDescriptor {
+	return file_cart_cart_proto_enumTypes[3].Descriptor()
+}
+
+func (CheckoutStep) Type() protoreflect.EnumType {
+	return &file_cart_cart_proto_enumTypes[3]
+}
+
+func (x CheckoutStep) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CheckoutStep.Descriptor instead.
+func (CheckoutStep) EnumDescriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{3}
+}
+
+type AddItemToCartRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Sku             uint32                 `protobuf:"varint,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Count           uint32                 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	ExpectedVersion *uint64                `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	// The seller whose offer the line references. Unset keeps the seller of the line
+	// already in the cart, else picks the default offer of the SKU.
+	SellerId      int64 `protobuf:"varint,5,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddItemToCartRequest) Reset() {
+	*x = AddItemToCartRequest{}
+	mi := &file_cart_cart_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddItemToCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddItemToCartRequest) ProtoMessage() {}
+
+func (x *AddItemToCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddItemToCartRequest.ProtoReflect.Descriptor instead.
+func (*AddItemToCartRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AddItemToCartRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AddItemToCartRequest) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *AddItemToCartRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AddItemToCartRequest) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *AddItemToCartRequest) GetSellerId() int64 {
+	if x != nil {
+		return x.SellerId
+	}
+	return 0
+}
+
+type AddItemToCartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Version       uint64                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddItemToCartResponse) Reset() {
+	*x = AddItemToCartResponse{}
+	mi := &file_cart_cart_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddItemToCartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddItemToCartResponse) ProtoMessage() {}
+
+func (x *AddItemToCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddItemToCartResponse.ProtoReflect.Descriptor instead.
+func (*AddItemToCartResponse) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddItemToCartResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AddItemToCartResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteItemFromCartRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Sku             uint32                 `protobuf:"varint,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	ExpectedVersion *uint64                `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteItemFromCartRequest) Reset() {
+	*x = DeleteItemFromCartRequest{}
+	mi := &file_cart_cart_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteItemFromCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteItemFromCartRequest) ProtoMessage() {}
+
+func (x *DeleteItemFromCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteItemFromCartRequest.ProtoReflect.Descriptor instead.
+func (*DeleteItemFromCartRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DeleteItemFromCartRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DeleteItemFromCartRequest) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *DeleteItemFromCartRequest) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteItemFromCartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Version       uint64                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteItemFromCartResponse) Reset() {
+	*x = DeleteItemFromCartResponse{}
+	mi := &file_cart_cart_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteItemFromCartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteItemFromCartResponse) ProtoMessage() {}
+
+func (x *DeleteItemFromCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteItemFromCartResponse.ProtoReflect.Descriptor instead.
+func (*DeleteItemFromCartResponse) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeleteItemFromCartResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeleteItemFromCartResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type StockItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Sku   uint32                 `protobuf:"varint,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Count uint32                 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Price uint32                 `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	// Set in degraded mode when the stocks service could not be reached for this line.
+	PriceUnknown bool  `protobuf:"varint,5,opt,name=price_unknown,json=priceUnknown,proto3" json:"price_unknown,omitempty"`
+	SellerId     int64 `protobuf:"varint,6,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	// Set when the offer was deleted in stocks. The line is not counted in total_price.
+	Unavailable   bool `protobuf:"varint,7,opt,name=unavailable,proto3" json:"unavailable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockItem) Reset() {
+	*x = StockItem{}
+	mi := &file_cart_cart_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockItem) ProtoMessage() {}
+
+func (x *StockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockItem.ProtoReflect.Descriptor instead.
+func (*StockItem) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StockItem) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *StockItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StockItem) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *StockItem) GetPrice() uint32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *StockItem) GetPriceUnknown() bool {
+	if x != nil {
+		return x.PriceUnknown
+	}
+	return false
+}
+
+func (x *StockItem) GetSellerId() int64 {
+	if x != nil {
+		return x.SellerId
+	}
+	return 0
+}
+
+func (x *StockItem) GetUnavailable() bool {
+	if x != nil {
+		return x.Unavailable
+	}
+	return false
+}
+
+type CartListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartListRequest) Reset() {
+	*x = CartListRequest{}
+	mi := &file_cart_cart_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartListRequest) ProtoMessage() {}
+
+func (x *CartListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartListRequest.ProtoReflect.Descriptor instead.
+func (*CartListRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CartListRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type CartListResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Items      []*StockItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	TotalPrice uint32                 `protobuf:"varint,2,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	Version    uint64                 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// True when some lines have unknown name and price; total_price excludes them.
+	Degraded      bool `protobuf:"varint,4,opt,name=degraded,proto3" json:"degraded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartListResponse) Reset() {
+	*x = CartListResponse{}
+	mi := &file_cart_cart_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartListResponse) ProtoMessage() {}
+
+func (x *CartListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartListResponse.ProtoReflect.Descriptor instead.
+func (*CartListResponse) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CartListResponse) GetItems() []*StockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CartListResponse) GetTotalPrice() uint32 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *CartListResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CartListResponse) GetDegraded() bool {
+	if x != nil {
+		return x.Degraded
+	}
+	return false
+}
+
+type ClearCartRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExpectedVersion *uint64                `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ClearCartRequest) Reset() {
+	*x = ClearCartRequest{}
+	mi := &file_cart_cart_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearCartRequest) ProtoMessage() {}
+
+func (x *ClearCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearCartRequest.ProtoReflect.Descriptor instead.
+func (*ClearCartRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ClearCartRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ClearCartRequest) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type ClearCartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Version       uint64                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearCartResponse) Reset() {
+	*x = ClearCartResponse{}
+	mi := &file_cart_cart_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearCartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearCartResponse) ProtoMessage() {}
+
+func (x *ClearCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearCartResponse.ProtoReflect.Descriptor instead.
+func (*ClearCartResponse) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ClearCartResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ClearCartResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ValidateCartRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	UserId  int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AutoFix bool                   `protobuf:"varint,2,opt,name=auto_fix,json=autoFix,proto3" json:"auto_fix,omitempty"`
+	// Only checked when auto_fix changes the cart.
+	ExpectedVersion *uint64 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ValidateCartRequest) Reset() {
+	*x = ValidateCartRequest{}
+	mi := &file_cart_cart_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateCartRequest) ProtoMessage() {}
+
+func (x *ValidateCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateCartRequest.ProtoReflect.Descriptor instead.
+func (*ValidateCartRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ValidateCartRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ValidateCartRequest) GetAutoFix() bool {
+	if x != nil {
+		return x.AutoFix
+	}
+	return false
+}
+
+func (x *ValidateCartRequest) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type CartLineValidation struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sku      uint32                 `protobuf:"varint,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	SellerId int64                  `protobuf:"varint,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	// Quantity in the cart, after the fix when one was made.
+	Count          uint32         `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Status         CartLineStatus `protobuf:"varint,4,opt,name=status,proto3,enum=cart.CartLineStatus" json:"status,omitempty"`
+	AvailableCount uint32         `protobuf:"varint,5,opt,name=available_count,json=availableCount,proto3" json:"available_count,omitempty"`
+	Price          uint32         `protobuf:"varint,6,opt,name=price,proto3" json:"price,omitempty"`
+	PreviousPrice  *uint32        `protobuf:"varint,7,opt,name=previous_price,json=previousPrice,proto3,oneof" json:"previous_price,omitempty"`
+	Fix            CartLineFix    `protobuf:"varint,8,opt,name=fix,proto3,enum=cart.CartLineFix" json:"fix,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CartLineValidation) Reset() {
+	*x = CartLineValidation{}
+	mi := &file_cart_cart_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartLineValidation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartLineValidation) ProtoMessage() {}
+
+func (x *CartLineValidation) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartLineValidation.ProtoReflect.Descriptor instead.
+func (*CartLineValidation) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CartLineValidation) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *CartLineValidation) GetSellerId() int64 {
+	if x != nil {
+		return x.SellerId
+	}
+	return 0
+}
+
+func (x *CartLineValidation) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CartLineValidation) GetStatus() CartLineStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CartLineStatus_CART_LINE_STATUS_UNSPECIFIED
+}
+
+func (x *CartLineValidation) GetAvailableCount() uint32 {
+	if x != nil {
+		return x.AvailableCount
+	}
+	return 0
+}
+
+func (x *CartLineValidation) GetPrice() uint32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CartLineValidation) GetPreviousPrice() uint32 {
+	if x != nil && x.PreviousPrice != nil {
+		return *x.PreviousPrice
+	}
+	return 0
+}
+
+func (x *CartLineValidation) GetFix() CartLineFix {
+	if x != nil {
+		return x.Fix
+	}
+	return CartLineFix_CART_LINE_FIX_UNSPECIFIED
+}
+
+type ValidateCartResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Lines []*CartLineValidation  `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	// True when every line was ok before any fix.
+	Valid         bool   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	Version       uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateCartResponse) Reset() {
+	*x = ValidateCartResponse{}
+	mi := &file_cart_cart_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateCartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateCartResponse) ProtoMessage() {}
+
+func (x *ValidateCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateCartResponse.ProtoReflect.Descriptor instead.
+func (*ValidateCartResponse) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ValidateCartResponse) GetLines() []*CartLineValidation {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *ValidateCartResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateCartResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type MoveToSavedForLaterRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Sku             uint32                 `protobuf:"varint,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	ExpectedVersion *uint64                `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MoveToSavedForLaterRequest) Reset() {
+	*x = MoveToSavedForLaterRequest{}
+	mi := &file_cart_cart_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveToSavedForLaterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveToSavedForLaterRequest) ProtoMessage() {}
+
+func (x *MoveToSavedForLaterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveToSavedForLaterRequest.ProtoReflect.Descriptor instead.
+func (*MoveToSavedForLaterRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MoveToSavedForLaterRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *MoveToSavedForLaterRequest) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *MoveToSavedForLaterRequest) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type MoveToSavedForLaterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Version       uint64                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveToSavedForLaterResponse) Reset() {
+	*x = MoveToSavedForLaterResponse{}
+	mi := &file_cart_cart_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveToSavedForLaterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveToSavedForLaterResponse) ProtoMessage() {}
+
+func (x *MoveToSavedForLaterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveToSavedForLaterResponse.ProtoReflect.Descriptor instead.
+func (*MoveToSavedForLaterResponse) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MoveToSavedForLaterResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MoveToSavedForLaterResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type MoveToCartRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Sku             uint32                 `protobuf:"varint,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	ExpectedVersion *uint64                `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MoveToCartRequest) Reset() {
+	*x = MoveToCartRequest{}
+	mi := &file_cart_cart_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveToCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveToCartRequest) ProtoMessage() {}
+
+func (x *MoveToCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveToCartRequest.ProtoReflect.Descriptor instead.
+func (*MoveToCartRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MoveToCartRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *MoveToCartRequest) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *MoveToCartRequest) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type MoveToCartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Version       uint64                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveToCartResponse) Reset() {
+	*x = MoveToCartResponse{}
+	mi := &file_cart_cart_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveToCartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveToCartResponse) ProtoMessage() {}
+
+func (x *MoveToCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveToCartResponse.ProtoReflect.Descriptor instead.
+func (*MoveToCartResponse) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MoveToCartResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MoveToCartResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type SavedItem struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Sku            uint32                 `protobuf:"varint,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Count          uint32                 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Price          uint32                 `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	AvailableCount uint32                 `protobuf:"varint,5,opt,name=available_count,json=availableCount,proto3" json:"available_count,omitempty"`
+	InStock        bool                   `protobuf:"varint,6,opt,name=in_stock,json=inStock,proto3" json:"in_stock,omitempty"`
+	BackInStock    bool                   `protobuf:"varint,7,opt,name=back_in_stock,json=backInStock,proto3" json:"back_in_stock,omitempty"`
+	SellerId       int64                  `protobuf:"varint,8,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SavedItem) Reset() {
+	*x = SavedItem{}
+	mi := &file_cart_cart_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedItem) ProtoMessage() {}
+
+func (x *SavedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedItem.ProtoReflect.Descriptor instead.
+func (*SavedItem) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SavedItem) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *SavedItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SavedItem) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SavedItem) GetPrice() uint32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *SavedItem) GetAvailableCount() uint32 {
+	if x != nil {
+		return x.AvailableCount
+	}
+	return 0
+}
+
+func (x *SavedItem) GetInStock() bool {
+	if x != nil {
+		return x.InStock
+	}
+	return false
+}
+
+func (x *SavedItem) GetBackInStock() bool {
+	if x != nil {
+		return x.BackInStock
+	}
+	return false
+}
+
+func (x *SavedItem) GetSellerId() int64 {
+	if x != nil {
+		return x.SellerId
+	}
+	return 0
+}
+
+type ListSavedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedRequest) Reset() {
+	*x = ListSavedRequest{}
+	mi := &file_cart_cart_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedRequest) ProtoMessage() {}
+
+func (x *ListSavedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedRequest.ProtoReflect.Descriptor instead.
+func (*ListSavedRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListSavedRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ListSavedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*SavedItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedResponse) Reset() {
+	*x = ListSavedResponse{}
+	mi := &file_cart_cart_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedResponse) ProtoMessage() {}
+
+func (x *ListSavedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedResponse.ProtoReflect.Descriptor instead.
+func (*ListSavedResponse) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListSavedResponse) GetItems() []*SavedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type WatchCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCartRequest) Reset() {
+	*x = WatchCartRequest{}
+	mi := &file_cart_cart_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCartRequest) ProtoMessage() {}
+
+func (x *WatchCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCartRequest.ProtoReflect.Descriptor instead.
+func (*WatchCartRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WatchCartRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type CheckoutRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Payment method of the customer, tokenized by the payment provider before the checkout
+	// is stored. The simulator takes test card numbers, which are never stored.
+	PaymentMethod string `protobuf:"bytes,2,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutRequest) Reset() {
+	*x = CheckoutRequest{}
+	mi := &file_cart_cart_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutRequest) ProtoMessage() {}
+
+func (x *CheckoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutRequest.ProtoReflect.Descriptor instead.
+func (*CheckoutRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CheckoutRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CheckoutRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+type CheckoutLine struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sku      uint32                 `protobuf:"varint,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	SellerId int64                  `protobuf:"varint,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	Count    uint32                 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// Offer price when the checkout started.
+	Price         uint32 `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutLine) Reset() {
+	*x = CheckoutLine{}
+	mi := &file_cart_cart_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutLine) ProtoMessage() {}
+
+func (x *CheckoutLine) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutLine.ProtoReflect.Descriptor instead.
+func (*CheckoutLine) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CheckoutLine) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *CheckoutLine) GetSellerId() int64 {
+	if x != nil {
+		return x.SellerId
+	}
+	return 0
+}
+
+func (x *CheckoutLine) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CheckoutLine) GetPrice() uint32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type Checkout struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status CheckoutStatus         `protobuf:"varint,3,opt,name=status,proto3,enum=cart.CheckoutStatus" json:"status,omitempty"`
+	// The step running or waiting for a retry.
+	Step       CheckoutStep    `protobuf:"varint,4,opt,name=step,proto3,enum=cart.CheckoutStep" json:"step,omitempty"`
+	Lines      []*CheckoutLine `protobuf:"bytes,5,rep,name=lines,proto3" json:"lines,omitempty"`
+	TotalPrice uint64          `protobuf:"varint,6,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	PaymentId  string          `protobuf:"bytes,7,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	// Why the last attempt of a step failed, or why the saga is compensating.
+	LastError string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Set while the payment waits for the customer to pass 3-D Secure at this URL.
+	PaymentActionUrl string `protobuf:"bytes,11,opt,name=payment_action_url,json=paymentActionUrl,proto3" json:"payment_action_url,omitempty"`
+	// Brand and last four digits of the card paying.
+	CardBrand     string `protobuf:"bytes,12,opt,name=card_brand,json=cardBrand,proto3" json:"card_brand,omitempty"`
+	CardLast4     string `protobuf:"bytes,13,opt,name=card_last4,json=cardLast4,proto3" json:"card_last4,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Checkout) Reset() {
+	*x = Checkout{}
+	mi := &file_cart_cart_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Checkout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Checkout) ProtoMessage() {}
+
+func (x *Checkout) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Checkout.ProtoReflect.Descriptor instead.
+func (*Checkout) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Checkout) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Checkout) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Checkout) GetStatus() CheckoutStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CheckoutStatus_CHECKOUT_STATUS_UNSPECIFIED
+}
+
+func (x *Checkout) GetStep() CheckoutStep {
+	if x != nil {
+		return x.Step
+	}
+	return CheckoutStep_CHECKOUT_STEP_UNSPECIFIED
+}
+
+func (x *Checkout) GetLines() []*CheckoutLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Checkout) GetTotalPrice() uint64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *Checkout) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Checkout) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *Checkout) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Checkout) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Checkout) GetPaymentActionUrl() string {
+	if x != nil {
+		return x.PaymentActionUrl
+	}
+	return ""
+}
+
+func (x *Checkout) GetCardBrand() string {
+	if x != nil {
+		return x.CardBrand
+	}
+	return ""
+}
+
+func (x *Checkout) GetCardLast4() string {
+	if x != nil {
+		return x.CardLast4
+	}
+	return ""
+}
+
+type CheckoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checkout      *Checkout              `protobuf:"bytes,1,opt,name=checkout,proto3" json:"checkout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutResponse) Reset() {
+	*x = CheckoutResponse{}
+	mi := &file_cart_cart_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutResponse) ProtoMessage() {}
+
+func (x *CheckoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutResponse.ProtoReflect.Descriptor instead.
+func (*CheckoutResponse) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CheckoutResponse) GetCheckout() *Checkout {
+	if x != nil {
+		return x.Checkout
+	}
+	return nil
+}
+
+type GetCheckoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CheckoutId    int64                  `protobuf:"varint,2,opt,name=checkout_id,json=checkoutId,proto3" json:"checkout_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCheckoutRequest) Reset() {
+	*x = GetCheckoutRequest{}
+	mi := &file_cart_cart_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCheckoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCheckoutRequest) ProtoMessage() {}
+
+func (x *GetCheckoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCheckoutRequest.ProtoReflect.Descriptor instead.
+func (*GetCheckoutRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetCheckoutRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetCheckoutRequest) GetCheckoutId() int64 {
+	if x != nil {
+		return x.CheckoutId
+	}
+	return 0
+}
+
+type GetCheckoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checkout      *Checkout              `protobuf:"bytes,1,opt,name=checkout,proto3" json:"checkout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCheckoutResponse) Reset() {
+	*x = GetCheckoutResponse{}
+	mi := &file_cart_cart_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCheckoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCheckoutResponse) ProtoMessage() {}
+
+func (x *GetCheckoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCheckoutResponse.ProtoReflect.Descriptor instead.
+func (*GetCheckoutResponse) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetCheckoutResponse) GetCheckout() *Checkout {
+	if x != nil {
+		return x.Checkout
+	}
+	return nil
+}
+
+type HandlePaymentWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payload       []byte                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature     string                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HandlePaymentWebhookRequest) Reset() {
+	*x = HandlePaymentWebhookRequest{}
+	mi := &file_cart_cart_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HandlePaymentWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandlePaymentWebhookRequest) ProtoMessage() {}
+
+func (x *HandlePaymentWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandlePaymentWebhookRequest.ProtoReflect.Descriptor instead.
+func (*HandlePaymentWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *HandlePaymentWebhookRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *HandlePaymentWebhookRequest) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type HandlePaymentWebhookResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The checkout the webhook woke up, or 0 when it concerns no waiting checkout.
+	CheckoutId    int64 `protobuf:"varint,1,opt,name=checkout_id,json=checkoutId,proto3" json:"checkout_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HandlePaymentWebhookResponse) Reset() {
+	*x = HandlePaymentWebhookResponse{}
+	mi := &file_cart_cart_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HandlePaymentWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandlePaymentWebhookResponse) ProtoMessage() {}
+
+func (x *HandlePaymentWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandlePaymentWebhookResponse.ProtoReflect.Descriptor instead.
+func (*HandlePaymentWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *HandlePaymentWebhookResponse) GetCheckoutId() int64 {
+	if x != nil {
+		return x.CheckoutId
+	}
+	return 0
+}
+
+type ListAuditEventsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ActorId    *int64                 `protobuf:"varint,1,opt,name=actor_id,json=actorId,proto3,oneof" json:"actor_id,omitempty"`
+	EntityType string                 `protobuf:"bytes,2,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityId   string                 `protobuf:"bytes,3,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	From       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	// Defaults to 50, at most 500.
+	PageSize int32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous response.
+	PageToken     string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_cart_cart_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListAuditEventsRequest) GetActorId() int64 {
+	if x != nil && x.ActorId != nil {
+		return *x.ActorId
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type AuditEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActorId    int64                  `protobuf:"varint,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	Method     string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	EntityType string                 `protobuf:"bytes,4,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityId   string                 `protobuf:"bytes,5,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// SHA-256 of the deterministic protobuf encoding of the request.
+	RequestHash string `protobuf:"bytes,6,opt,name=request_hash,json=requestHash,proto3" json:"request_hash,omitempty"`
+	// Entity state before and after the call; after is unset for failed calls.
+	Before *structpb.Struct `protobuf:"bytes,7,opt,name=before,proto3" json:"before,omitempty"`
+	After  *structpb.Struct `protobuf:"bytes,8,opt,name=after,proto3" json:"after,omitempty"`
+	// gRPC status code of the call, e.g. OK or FAILED_PRECONDITION.
+	Status        string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	TraceId       string                 `protobuf:"bytes,10,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_cart_cart_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AuditEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetActorId() int64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetRequestHash() string {
+	if x != nil {
+		return x.RequestHash
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetBefore() *structpb.Struct {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetAfter() *structpb.Struct {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListAuditEventsResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Events []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_cart_cart_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type PurgeExpiredCartsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Admin the purge is recorded for in the audit log.
+	UserId           int64 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OlderThanSeconds int64 `protobuf:"varint,2,opt,name=older_than_seconds,json=olderThanSeconds,proto3" json:"older_than_seconds,omitempty"`
+	Limit            int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	DryRun           bool  `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PurgeExpiredCartsRequest) Reset() {
+	*x = PurgeExpiredCartsRequest{}
+	mi := &file_cart_cart_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeExpiredCartsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeExpiredCartsRequest) ProtoMessage() {}
+
+func (x *PurgeExpiredCartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeExpiredCartsRequest.ProtoReflect.Descriptor instead.
+func (*PurgeExpiredCartsRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PurgeExpiredCartsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PurgeExpiredCartsRequest) GetOlderThanSeconds() int64 {
+	if x != nil {
+		return x.OlderThanSeconds
+	}
+	return 0
+}
+
+func (x *PurgeExpiredCartsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PurgeExpiredCartsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ExpiredCart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Lines         int64                  `protobuf:"varint,2,opt,name=lines,proto3" json:"lines,omitempty"`
+	Units         int64                  `protobuf:"varint,3,opt,name=units,proto3" json:"units,omitempty"`
+	LastActivity  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpiredCart) Reset() {
+	*x = ExpiredCart{}
+	mi := &file_cart_cart_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpiredCart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpiredCart) ProtoMessage() {}
+
+func (x *ExpiredCart) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpiredCart.ProtoReflect.Descriptor instead.
+func (*ExpiredCart) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ExpiredCart) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ExpiredCart) GetLines() int64 {
+	if x != nil {
+		return x.Lines
+	}
+	return 0
+}
+
+func (x *ExpiredCart) GetUnits() int64 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *ExpiredCart) GetLastActivity() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivity
+	}
+	return nil
+}
+
+type PurgeExpiredCartsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first.
+	Carts         []*ExpiredCart `protobuf:"bytes,1,rep,name=carts,proto3" json:"carts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeExpiredCartsResponse) Reset() {
+	*x = PurgeExpiredCartsResponse{}
+	mi := &file_cart_cart_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeExpiredCartsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeExpiredCartsResponse) ProtoMessage() {}
+
+func (x *PurgeExpiredCartsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeExpiredCartsResponse.ProtoReflect.Descriptor instead.
+func (*PurgeExpiredCartsResponse) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PurgeExpiredCartsResponse) GetCarts() []*ExpiredCart {
+	if x != nil {
+		return x.Carts
+	}
+	return nil
+}
+
+var File_cart_cart_proto protoreflect.FileDescriptor
+
+const file_cart_cart_proto_rawDesc = "" +
+	"\n" +
+	"\x0fcart/cart.proto\x12\x04cart\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe0\x01\n" +
+	"\x14AddItemToCartRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12\x19\n" +
+	"\x03sku\x18\x02 \x01(\rB\a\xbaH\x04*\x02 \x00R\x03sku\x12 \n" +
+	"\x05count\x18\x03 \x01(\rB\n" +
+	"\xbaH\a*\x05\x18\xe8\a \x00R\x05count\x12.\n" +
+	"\x10expected_version\x18\x04 \x01(\x04H\x00R\x0fexpectedVersion\x88\x01\x01\x12$\n" +
+	"\tseller_id\x18\x05 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\bsellerIdB\x13\n" +
+	"\x11_expected_version\"K\n" +
+	"\x15AddItemToCartResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x04R\aversion\"\x9d\x01\n" +
+	"\x19DeleteItemFromCartRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12\x19\n" +
+	"\x03sku\x18\x02 \x01(\rB\a\xbaH\x04*\x02 \x00R\x03sku\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x04H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"P\n" +
+	"\x1aDeleteItemFromCartResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x04R\aversion\"\xc1\x01\n" +
+	"\tStockItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\rR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\rR\x05count\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\rR\x05price\x12#\n" +
+	"\rprice_unknown\x18\x05 \x01(\bR\fpriceUnknown\x12\x1b\n" +
+	"\tseller_id\x18\x06 \x01(\x03R\bsellerId\x12 \n" +
+	"\vunavailable\x18\a \x01(\bR\vunavailable\"3\n" +
+	"\x0fCartListRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\"\x90\x01\n" +
+	"\x10CartListResponse\x12%\n" +
+	"\x05items\x18\x01 \x03(\v2\x0f.cart.StockItemR\x05items\x12\x1f\n" +
+	"\vtotal_price\x18\x02 \x01(\rR\n" +
+	"totalPrice\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x04R\aversion\x12\x1a\n" +
+	"\bdegraded\x18\x04 \x01(\bR\bdegraded\"y\n" +
+	"\x10ClearCartRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x04H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"G\n" +
+	"\x11ClearCartResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x04R\aversion\"\x97\x01\n" +
+	"\x13ValidateCartRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12\x19\n" +
+	"\bauto_fix\x18\x02 \x01(\bR\aautoFix\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x04H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\xaa\x02\n" +
+	"\x12CartLineValidation\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\rR\x03sku\x12\x1b\n" +
+	"\tseller_id\x18\x02 \x01(\x03R\bsellerId\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\rR\x05count\x12,\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x14.cart.CartLineStatusR\x06status\x12'\n" +
+	"\x0favailable_count\x18\x05 \x01(\rR\x0eavailableCount\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\rR\x05price\x12*\n" +
+	"\x0eprevious_price\x18\a \x01(\rH\x00R\rpreviousPrice\x88\x01\x01\x12#\n" +
+	"\x03fix\x18\b \x01(\x0e2\x11.cart.CartLineFixR\x03fixB\x11\n" +
+	"\x0f_previous_price\"v\n" +
+	"\x14ValidateCartResponse\x12.\n" +
+	"\x05lines\x18\x01 \x03(\v2\x18.cart.CartLineValidationR\x05lines\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x04R\aversion\"\x9e\x01\n" +
+	"\x1aMoveToSavedForLaterRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12\x19\n" +
+	"\x03sku\x18\x02 \x01(\rB\a\xbaH\x04*\x02 \x00R\x03sku\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x04H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"Q\n" +
+	"\x1bMoveToSavedForLaterResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x04R\aversion\"\x95\x01\n" +
+	"\x11MoveToCartRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12\x19\n" +
+	"\x03sku\x18\x02 \x01(\rB\a\xbaH\x04*\x02 \x00R\x03sku\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x04H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"H\n" +
+	"\x12MoveToCartResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x04R\aversion\"\xe2\x01\n" +
+	"\tSavedItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\rR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\rR\x05count\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\rR\x05price\x12'\n" +
+	"\x0favailable_count\x18\x05 \x01(\rR\x0eavailableCount\x12\x19\n" +
+	"\bin_stock\x18\x06 \x01(\bR\ainStock\x12\"\n" +
+	"\rback_in_stock\x18\a \x01(\bR\vbackInStock\x12\x1b\n" +
+	"\tseller_id\x18\b \x01(\x03R\bsellerId\"4\n" +
+	"\x10ListSavedRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\":\n" +
+	"\x11ListSavedResponse\x12%\n" +
+	"\x05items\x18\x01 \x03(\v2\x0f.cart.SavedItemR\x05items\"4\n" +
+	"\x10WatchCartRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\"e\n" +
+	"\x0fCheckoutRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x120\n" +
+	"\x0epayment_method\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\rpaymentMethod\"i\n" +
+	"\fCheckoutLine\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\rR\x03sku\x12\x1b\n" +
+	"\tseller_id\x18\x02 \x01(\x03R\bsellerId\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\rR\x05count\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\rR\x05price\"\xf4\x03\n" +
+	"\bCheckout\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12,\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x14.cart.CheckoutStatusR\x06status\x12&\n" +
+	"\x04step\x18\x04 \x01(\x0e2\x12.cart.CheckoutStepR\x04step\x12(\n" +
+	"\x05lines\x18\x05 \x03(\v2\x12.cart.CheckoutLineR\x05lines\x12\x1f\n" +
+	"\vtotal_price\x18\x06 \x01(\x04R\n" +
+	"totalPrice\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\a \x01(\tR\tpaymentId\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12,\n" +
+	"\x12payment_action_url\x18\v \x01(\tR\x10paymentActionUrl\x12\x1d\n" +
+	"\n" +
+	"card_brand\x18\f \x01(\tR\tcardBrand\x12\x1d\n" +
+	"\n" +
+	"card_last4\x18\r \x01(\tR\tcardLast4\">\n" +
+	"\x10CheckoutResponse\x12*\n" +
+	"\bcheckout\x18\x01 \x01(\v2\x0e.cart.CheckoutR\bcheckout\"`\n" +
+	"\x12GetCheckoutRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12(\n" +
+	"\vcheckout_id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\n" +
+	"checkoutId\"A\n" +
+	"\x13GetCheckoutResponse\x12*\n" +
+	"\bcheckout\x18\x01 \x01(\v2\x0e.cart.CheckoutR\bcheckout\"g\n" +
+	"\x1bHandlePaymentWebhookRequest\x12!\n" +
+	"\apayload\x18\x01 \x01(\fB\a\xbaH\x04z\x02\x10\x01R\apayload\x12%\n" +
+	"\tsignature\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\tsignature\"?\n" +
+	"\x1cHandlePaymentWebhookResponse\x12\x1f\n" +
+	"\vcheckout_id\x18\x01 \x01(\x03R\n" +
+	"checkoutId\"\xb9\x02\n" +
+	"\x16ListAuditEventsRequest\x12\x1e\n" +
+	"\bactor_id\x18\x01 \x01(\x03H\x00R\aactorId\x88\x01\x01\x12(\n" +
+	"\ventity_type\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x18 R\n" +
+	"entityType\x12$\n" +
+	"\tentity_id\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x18@R\bentityId\x12.\n" +
+	"\x04from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12'\n" +
+	"\tpage_size\x18\x06 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xf4\x03(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageTokenB\v\n" +
+	"\t_actor_id\"\xfe\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\x03R\aactorId\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x1f\n" +
+	"\ventity_type\x18\x04 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x05 \x01(\tR\bentityId\x12!\n" +
+	"\frequest_hash\x18\x06 \x01(\tR\vrequestHash\x12/\n" +
+	"\x06before\x18\a \x01(\v2\x17.google.protobuf.StructR\x06before\x12-\n" +
+	"\x05after\x18\b \x01(\v2\x17.google.protobuf.StructR\x05after\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x19\n" +
+	"\btrace_id\x18\n" +
+	" \x01(\tR\atraceId\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"k\n" +
+	"\x17ListAuditEventsResponse\x12(\n" +
+	"\x06events\x18\x01 \x03(\v2\x10.cart.AuditEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xae\x01\n" +
+	"\x18PurgeExpiredCartsRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x125\n" +
+	"\x12older_than_seconds\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x10olderThanSeconds\x12 \n" +
+	"\x05limit\x18\x03 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\x90N \x00R\x05limit\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"\x93\x01\n" +
+	"\vExpiredCart\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05lines\x18\x02 \x01(\x03R\x05lines\x12\x14\n" +
+	"\x05units\x18\x03 \x01(\x03R\x05units\x12?\n" +
+	"\rlast_activity\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\flastActivity\"D\n" +
+	"\x19PurgeExpiredCartsResponse\x12'\n" +
+	"\x05carts\x18\x01 \x03(\v2\x11.cart.ExpiredCartR\x05carts*\xd7\x01\n" +
+	"\x0eCartLineStatus\x12 \n" +
+	"\x1cCART_LINE_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13CART_LINE_STATUS_OK\x10\x01\x12!\n" +
+	"\x1dCART_LINE_STATUS_OUT_OF_STOCK\x10\x02\x12!\n" +
+	"\x1dCART_LINE_STATUS_INSUFFICIENT\x10\x03\x12 \n" +
+	"\x1cCART_LINE_STATUS_SKU_REMOVED\x10\x04\x12\"\n" +
+	"\x1eCART_LINE_STATUS_PRICE_CHANGED\x10\x05*~\n" +
+	"\vCartLineFix\x12\x1d\n" +
+	"\x19CART_LINE_FIX_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15CART_LINE_FIX_REMOVED\x10\x01\x12\x19\n" +
+	"\x15CART_LINE_FIX_CLAMPED\x10\x02\x12\x1a\n" +
+	"\x16CART_LINE_FIX_REPRICED\x10\x03*\xc6\x01\n" +
+	"\x0eCheckoutStatus\x12\x1f\n" +
+	"\x1bCHECKOUT_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17CHECKOUT_STATUS_RUNNING\x10\x01\x12 \n" +
+	"\x1cCHECKOUT_STATUS_COMPENSATING\x10\x02\x12\x1d\n" +
+	"\x19CHECKOUT_STATUS_COMPLETED\x10\x03\x12\x1a\n" +
+	"\x16CHECKOUT_STATUS_FAILED\x10\x04\x12\x19\n" +
+	"\x15CHECKOUT_STATUS_STUCK\x10\x05*\x95\x02\n" +
+	"\fCheckoutStep\x12\x1d\n" +
+	"\x19CHECKOUT_STEP_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bCHECKOUT_STEP_RESERVE_STOCK\x10\x01\x12#\n" +
+	"\x1fCHECKOUT_STEP_AUTHORIZE_PAYMENT\x10\x02\x12\x1e\n" +
+	"\x1aCHECKOUT_STEP_COMMIT_STOCK\x10\x03\x12\x1c\n" +
+	"\x18CHECKOUT_STEP_CLEAR_CART\x10\x04\x12\x1e\n" +
+	"\x1aCHECKOUT_STEP_VOID_PAYMENT\x10\x05\x12\x1f\n" +
+	"\x1bCHECKOUT_STEP_RELEASE_STOCK\x10\x06\x12!\n" +
+	"\x1dCHECKOUT_STEP_CAPTURE_PAYMENT\x10\a2\xea\n" +
+	"\n" +
+	"\vCartService\x12c\n" +
+	"\rAddItemToCart\x12\x1a.cart.AddItemToCartRequest\x1a\x1b.cart.AddItemToCartResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/cart/item/add\x12u\n" +
+	"\x12DeleteItemFromCart\x12\x1f.cart.DeleteItemFromCartRequest\x1a .cart.DeleteItemFromCartResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/cart/item/delete\x12P\n" +
+	"\bCartList\x12\x15.cart.CartListRequest\x1a\x16.cart.CartListResponse\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/cart/list\x12T\n" +
+	"\tClearCart\x12\x16.cart.ClearCartRequest\x1a\x17.cart.ClearCartResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/cart/clear\x12v\n" +
+	"\x13MoveToSavedForLater\x12 .cart.MoveToSavedForLaterRequest\x1a!.cart.MoveToSavedForLaterResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/cart/item/save\x12\\\n" +
+	"\n" +
+	"MoveToCart\x12\x17.cart.MoveToCartRequest\x1a\x18.cart.MoveToCartResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/cart/saved/move\x12Y\n" +
+	"\tListSaved\x12\x16.cart.ListSavedRequest\x1a\x17.cart.ListSavedResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/cart/saved/list\x12`\n" +
+	"\fValidateCart\x12\x19.cart.ValidateCartRequest\x1a\x1a.cart.ValidateCartResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/cart/validate\x12=\n" +
+	"\tWatchCart\x12\x16.cart.WatchCartRequest\x1a\x16.cart.CartListResponse0\x01\x12T\n" +
+	"\bCheckout\x12\x15.cart.CheckoutRequest\x1a\x16.cart.CheckoutResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/cart/checkout\x12a\n" +
+	"\vGetCheckout\x12\x18.cart.GetCheckoutRequest\x1a\x19.cart.GetCheckoutResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/cart/checkout/get\x12]\n" +
+	"\x14HandlePaymentWebhook\x12!.cart.HandlePaymentWebhookRequest\x1a\".cart.HandlePaymentWebhookResponse\x12q\n" +
+	"\x0fListAuditEvents\x12\x1c.cart.ListAuditEventsRequest\x1a\x1d.cart.ListAuditEventsResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/cart/admin/audit/list\x12z\n" +
+	"\x11PurgeExpiredCarts\x12\x1e.cart.PurgeExpiredCartsRequest\x1a\x1f.cart.PurgeExpiredCartsResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/cart/admin/purge-expiredB\x1bZ\x19cart/pkg/api/cart;cartapib\x06proto3"
+
+var (
+	file_cart_cart_proto_rawDescOnce sync.Once
+	file_cart_cart_proto_rawDescData []byte
+)
+
+func file_cart_cart_proto_rawDescGZIP() []byte {
+	file_cart_cart_proto_rawDescOnce.Do(func() {
+		file_cart_cart_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cart_cart_proto_rawDesc), len(file_cart_cart_proto_rawDesc)))
+	})
+	return file_cart_cart_proto_rawDescData
+}
+
+var file_cart_cart_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_cart_cart_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_cart_cart_proto_goTypes = []any{
+	(CartLineStatus)(0),                  // 0: cart.CartLineStatus
+	(CartLineFix)(0),                     // 1: cart.CartLineFix
+	(CheckoutStatus)(0),                  // 2: cart.CheckoutStatus
+	(CheckoutStep)(0),                    // 3: cart.CheckoutStep
+	(*AddItemToCartRequest)(nil),         // 4: cart.AddItemToCartRequest
+	(*AddItemToCartResponse)(nil),        // 5: cart.AddItemToCartResponse
+	(*DeleteItemFromCartRequest)(nil),    // 6: cart.DeleteItemFromCartRequest
+	(*DeleteItemFromCartResponse)(nil),   // 7: cart.DeleteItemFromCartResponse
+	(*StockItem)(nil),                    // 8: cart.StockItem
+	(*CartListRequest)(nil),              // 9: cart.CartListRequest
+	(*CartListResponse)(nil),             // 10: cart.CartListResponse
+	(*ClearCartRequest)(nil),             // 11: cart.ClearCartRequest
+	(*ClearCartResponse)(nil),            // 12: cart.ClearCartResponse
+	(*ValidateCartRequest)(nil),          // 13: cart.ValidateCartRequest
+	(*CartLineValidation)(nil),           // 14: cart.CartLineValidation
+	(*ValidateCartResponse)(nil),         // 15: cart.ValidateCartResponse
+	(*MoveToSavedForLaterRequest)(nil),   // 16: cart.MoveToSavedForLaterRequest
+	(*MoveToSavedForLaterResponse)(nil),  // 17: cart.MoveToSavedForLaterResponse
+	(*MoveToCartRequest)(nil),            // 18: cart.MoveToCartRequest
+	(*MoveToCartResponse)(nil),           // 19: cart.MoveToCartResponse
+	(*SavedItem)(nil),                    // 20: cart.SavedItem
+	(*ListSavedRequest)(nil),             // 21: cart.ListSavedRequest
+	(*ListSavedResponse)(nil),            // 22: cart.ListSavedResponse
+	(*WatchCartRequest)(nil),             // 23: cart.WatchCartRequest
+	(*CheckoutRequest)(nil),              // 24: cart.CheckoutRequest
+	(*CheckoutLine)(nil),                 // 25: cart.CheckoutLine
+	(*Checkout)(nil),                     // 26: cart.Checkout
+	(*CheckoutResponse)(nil),             // 27: cart.CheckoutResponse
+	(*GetCheckoutRequest)(nil),           // 28: cart.GetCheckoutRequest
+	(*GetCheckoutResponse)(nil),          // 29: cart.GetCheckoutResponse
+	(*HandlePaymentWebhookRequest)(nil),  // 30: cart.HandlePaymentWebhookRequest
+	(*HandlePaymentWebhookResponse)(nil), // 31: cart.HandlePaymentWebhookResponse
+	(*ListAuditEventsRequest)(nil),       // 32: cart.ListAuditEventsRequest
+	(*AuditEvent)(nil),                   // 33: cart.AuditEvent
+	(*ListAuditEventsResponse)(nil),      // 34: cart.ListAuditEventsResponse
+	(*PurgeExpiredCartsRequest)(nil),     // 35: cart.PurgeExpiredCartsRequest
+	(*ExpiredCart)(nil),                  // 36: cart.ExpiredCart
+	(*PurgeExpiredCartsResponse)(nil),    // 37: cart.PurgeExpiredCartsResponse
+	(*timestamppb.Timestamp)(nil),        // 38: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),              // 39: google.protobuf.Struct
+}
+var file_cart_cart_proto_depIdxs = []int32{
+	8,  // 0: cart.CartListResponse.items:type_name -> cart.StockItem
+	0,  // 1: cart.CartLineValidation.status:type_name -> cart.CartLineStatus
+	1,  // 2: cart.CartLineValidation.fix:type_name -> cart.CartLineFix
+	14, // 3: cart.ValidateCartResponse.lines:type_name -> cart.CartLineValidation
+	20, // 4: cart.ListSavedResponse.items:type_name -> cart.SavedItem
+	2,  // 5: cart.Checkout.status:type_name -> cart.CheckoutStatus
+	3,  // 6: cart.Checkout.step:type_name -> cart.CheckoutStep
+	25, // 7: cart.Checkout.lines:type_name -> cart.CheckoutLine
+	38, // 8: cart.Checkout.created_at:type_name -> google.protobuf.Timestamp
+	38, // 9: cart.Checkout.updated_at:type_name -> google.protobuf.Timestamp
+	26, // 10: cart.CheckoutResponse.checkout:type_name -> cart.Checkout
+	26, // 11: cart.GetCheckoutResponse.checkout:type_name -> cart.Checkout
+	38, // 12: cart.ListAuditEventsRequest.from:type_name -> google.protobuf.Timestamp
+	38, // 13: cart.ListAuditEventsRequest.to:type_name -> google.protobuf.Timestamp
+	39, // 14: cart.AuditEvent.before:type_name -> google.protobuf.Struct
+	39, // 15: cart.AuditEvent.after:type_name -> google.protobuf.Struct
+	38, // 16: cart.AuditEvent.created_at:type_name -> google.protobuf.Timestamp
+	33, // 17: cart.ListAuditEventsResponse.events:type_name -> cart.AuditEvent
+	38, // 18: cart.ExpiredCart.last_activity:type_name -> google.protobuf.Timestamp
+	36, // 19: cart.PurgeExpiredCartsResponse.carts:type_name -> cart.ExpiredCart
+	4,  // 20: cart.CartService.AddItemToCart:input_type -> cart.AddItemToCartRequest
+	6,  // 21: cart.CartService.DeleteItemFromCart:input_type -> cart.DeleteItemFromCartRequest
+	9,  // 22: cart.CartService.CartList:input_type -> cart.CartListRequest
+	11, // 23: cart.CartService.ClearCart:input_type -> cart.ClearCartRequest
+	16, // 24: cart.CartService.MoveToSavedForLater:input_type -> cart.MoveToSavedForLaterRequest
+	18, // 25: cart.CartService.MoveToCart:input_type -> cart.MoveToCartRequest
+	21, // 26: cart.CartService.ListSaved:input_type -> cart.ListSavedRequest
+	13, // 27: cart.CartService.ValidateCart:input_type -> cart.ValidateCartRequest
+	23, // 28: cart.CartService.WatchCart:input_type -> cart.WatchCartRequest
+	24, // 29: cart.CartService.Checkout:input_type -> cart.CheckoutRequest
+	28, // 30: cart.CartService.GetCheckout:input_type -> cart.GetCheckoutRequest
+	30, // 31: cart.CartService.HandlePaymentWebhook:input_type -> cart.HandlePaymentWebhookRequest
+	32, // 32: cart.CartService.ListAuditEvents:input_type -> cart.ListAuditEventsRequest
+	35, // 33: cart.CartService.PurgeExpiredCarts:input_type -> cart.PurgeExpiredCartsRequest
+	5,  // 34: cart.CartService.AddItemToCart:output_type -> cart.AddItemToCartResponse
+	7,  // 35: cart.CartService.DeleteItemFromCart:output_type -> cart.DeleteItemFromCartResponse
+	10, // 36: cart.CartService.CartList:output_type -> cart.CartListResponse
+	12, // 37: cart.CartService.ClearCart:output_type -> cart.ClearCartResponse
+	17, // 38: cart.CartService.MoveToSavedForLater:output_type -> cart.MoveToSavedForLaterResponse
+	19, // 39: cart.CartService.MoveToCart:output_type -> cart.MoveToCartResponse
+	22, // 40: cart.CartService.ListSaved:output_type -> cart.ListSavedResponse
+	15, // 41: cart.CartService.ValidateCart:output_type -> cart.ValidateCartResponse
+	10, // 42: cart.CartService.WatchCart:output_type -> cart.CartListResponse
+	27, // 43: cart.CartService.Checkout:output_type -> cart.CheckoutResponse
+	29, // 44: cart.CartService.GetCheckout:output_type -> cart.GetCheckoutResponse
+	31, // 45: cart.CartService.HandlePaymentWebhook:output_type -> cart.HandlePaymentWebhookResponse
+	34, // 46: cart.CartService.ListAuditEvents:output_type -> cart.ListAuditEventsResponse
+	37, // 47: cart.CartService.PurgeExpiredCarts:output_type -> cart.PurgeExpiredCartsResponse
+	34, // [34:48] is the sub-list for method output_type
+	20, // [20:34] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_cart_cart_proto_init() }
+func file_cart_cart_proto_init() {
+	if File_cart_cart_proto != nil {
+		return
+	}
+	file_cart_cart_proto_msgTypes[0].OneofWrappers = []any{}
+	file_cart_cart_proto_msgTypes[2].OneofWrappers = []any{}
+	file_cart_cart_proto_msgTypes[7].OneofWrappers = []any{}
+	file_cart_cart_proto_msgTypes[9].OneofWrappers = []any{}
+	file_cart_cart_proto_msgTypes[10].OneofWrappers = []any{}
+	file_cart_cart_proto_msgTypes[12].OneofWrappers = []any{}
+	file_cart_cart_proto_msgTypes[14].OneofWrappers = []any{}
+	file_cart_cart_proto_msgTypes[28].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cart_cart_proto_rawDesc), len(file_cart_cart_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   34,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cart_cart_proto_goTypes,
+		DependencyIndexes: file_cart_cart_proto_depIdxs,
+		EnumInfos:         file_cart_cart_proto_enumTypes,
+		MessageInfos:      file_cart_cart_proto_msgTypes,
+	}.Build()
+	File_cart_cart_proto = out.File
+	file_cart_cart_proto_goTypes = nil
+	file_cart_cart_proto_depIdxs = nil
+}
@@ -0,0 +1,2477 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.6.1
+// source: stocks/stocks.proto
+
+package stocksapi
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AddStockRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Sku      uint32                 `protobuf:"varint,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Count    uint32                 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Price    uint32                 `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	Location string                 `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	// Seller whose offer is changed; it must belong to user_id. Defaults to the user's
+	// own seller, created on first use.
+	SellerId      int64 `protobuf:"varint,6,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddStockRequest) Reset() {
+	*x = AddStockRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddStockRequest) ProtoMessage() {}
+
+func (x *AddStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddStockRequest.ProtoReflect.Descriptor instead.
+func (*AddStockRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AddStockRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AddStockRequest) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *AddStockRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AddStockRequest) GetPrice() uint32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *AddStockRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *AddStockRequest) GetSellerId() int64 {
+	if x != nil {
+		return x.SellerId
+	}
+	return 0
+}
+
+type AddStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddStockResponse) Reset() {
+	*x = AddStockResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddStockResponse) ProtoMessage() {}
+
+func (x *AddStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddStockResponse.ProtoReflect.Descriptor instead.
+func (*AddStockResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddStockResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DeleteStockRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Sku    uint32                 `protobuf:"varint,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	// Seller whose offer is deleted; it must belong to user_id. Defaults to the user's seller.
+	SellerId      int64 `protobuf:"varint,3,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteStockRequest) Reset() {
+	*x = DeleteStockRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStockRequest) ProtoMessage() {}
+
+func (x *DeleteStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStockRequest.ProtoReflect.Descriptor instead.
+func (*DeleteStockRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DeleteStockRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DeleteStockRequest) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *DeleteStockRequest) GetSellerId() int64 {
+	if x != nil {
+		return x.SellerId
+	}
+	return 0
+}
+
+type DeleteStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteStockResponse) Reset() {
+	*x = DeleteStockResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStockResponse) ProtoMessage() {}
+
+func (x *DeleteStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStockResponse.ProtoReflect.Descriptor instead.
+func (*DeleteStockResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeleteStockResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RestoreStockRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Sku    uint32                 `protobuf:"varint,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	// Seller whose offer is restored; it must belong to user_id. Defaults to the user's seller.
+	SellerId      int64 `protobuf:"varint,3,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreStockRequest) Reset() {
+	*x = RestoreStockRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreStockRequest) ProtoMessage() {}
+
+func (x *RestoreStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreStockRequest.ProtoReflect.Descriptor instead.
+func (*RestoreStockRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RestoreStockRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RestoreStockRequest) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *RestoreStockRequest) GetSellerId() int64 {
+	if x != nil {
+		return x.SellerId
+	}
+	return 0
+}
+
+type RestoreStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreStockResponse) Reset() {
+	*x = RestoreStockResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreStockResponse) ProtoMessage() {}
+
+func (x *RestoreStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreStockResponse.ProtoReflect.Descriptor instead.
+func (*RestoreStockResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RestoreStockResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StockItem struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sku      uint32                 `protobuf:"varint,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type     string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Count    uint32                 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Price    uint32                 `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`
+	Location string                 `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	SellerId int64                  `protobuf:"varint,7,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	// Set when the offer is deleted; only listed with include_deleted.
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockItem) Reset() {
+	*x = StockItem{}
+	mi := &file_stocks_stocks_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockItem) ProtoMessage() {}
+
+func (x *StockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockItem.ProtoReflect.Descriptor instead.
+func (*StockItem) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StockItem) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *StockItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StockItem) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StockItem) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *StockItem) GetPrice() uint32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *StockItem) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *StockItem) GetSellerId() int64 {
+	if x != nil {
+		return x.SellerId
+	}
+	return 0
+}
+
+func (x *StockItem) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+type ListStocksByLocationRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	UserId      int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Location    string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	PageSize    int64                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	CurrentPage int64                  `protobuf:"varint,4,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	// Also lists deleted offers. Admin only.
+	IncludeDeleted bool `protobuf:"varint,5,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListStocksByLocationRequest) Reset() {
+	*x = ListStocksByLocationRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStocksByLocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStocksByLocationRequest) ProtoMessage() {}
+
+func (x *ListStocksByLocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStocksByLocationRequest.ProtoReflect.Descriptor instead.
+func (*ListStocksByLocationRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListStocksByLocationRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListStocksByLocationRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *ListStocksByLocationRequest) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListStocksByLocationRequest) GetCurrentPage() int64 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *ListStocksByLocationRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type ListStocksByLocationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*StockItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	PageNumber    int64                  `protobuf:"varint,3,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"`
+	TotalPages    int64                  `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStocksByLocationResponse) Reset() {
+	*x = ListStocksByLocationResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStocksByLocationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStocksByLocationResponse) ProtoMessage() {}
+
+func (x *ListStocksByLocationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStocksByLocationResponse.ProtoReflect.Descriptor instead.
+func (*ListStocksByLocationResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListStocksByLocationResponse) GetItems() []*StockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListStocksByLocationResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListStocksByLocationResponse) GetPageNumber() int64 {
+	if x != nil {
+		return x.PageNumber
+	}
+	return 0
+}
+
+func (x *ListStocksByLocationResponse) GetTotalPages() int64 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type GetStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Sku   uint32                 `protobuf:"varint,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	// Returns the offer of this seller; by default the cheapest offer in stock.
+	SellerId int64 `protobuf:"varint,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	// Also returns a deleted offer. Admin only.
+	IncludeDeleted bool `protobuf:"varint,3,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetStockRequest) Reset() {
+	*x = GetStockRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockRequest) ProtoMessage() {}
+
+func (x *GetStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockRequest.ProtoReflect.Descriptor instead.
+func (*GetStockRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetStockRequest) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *GetStockRequest) GetSellerId() int64 {
+	if x != nil {
+		return x.SellerId
+	}
+	return 0
+}
+
+func (x *GetStockRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type GetStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stock         *StockItem             `protobuf:"bytes,1,opt,name=stock,proto3" json:"stock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockResponse) Reset() {
+	*x = GetStockResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockResponse) ProtoMessage() {}
+
+func (x *GetStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockResponse.ProtoReflect.Descriptor instead.
+func (*GetStockResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetStockResponse) GetStock() *StockItem {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+type WatchStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skus          []uint32               `protobuf:"varint,1,rep,packed,name=skus,proto3" json:"skus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchStockRequest) Reset() {
+	*x = WatchStockRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchStockRequest) ProtoMessage() {}
+
+func (x *WatchStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchStockRequest.ProtoReflect.Descriptor instead.
+func (*WatchStockRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WatchStockRequest) GetSkus() []uint32 {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+type ListOffersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Sku   uint32                 `protobuf:"varint,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	// Also lists deleted offers. Admin only.
+	IncludeDeleted bool `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListOffersRequest) Reset() {
+	*x = ListOffersRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOffersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOffersRequest) ProtoMessage() {}
+
+func (x *ListOffersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOffersRequest.ProtoReflect.Descriptor instead.
+func (*ListOffersRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListOffersRequest) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *ListOffersRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type ListOffersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offers        []*StockItem           `protobuf:"bytes,1,rep,name=offers,proto3" json:"offers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOffersResponse) Reset() {
+	*x = ListOffersResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOffersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOffersResponse) ProtoMessage() {}
+
+func (x *ListOffersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOffersResponse.ProtoReflect.Descriptor instead.
+func (*ListOffersResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListOffersResponse) GetOffers() []*StockItem {
+	if x != nil {
+		return x.Offers
+	}
+	return nil
+}
+
+// StockChange is the default offer of a SKU; deleted when no seller offers it anymore.
+type StockChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           uint32                 `protobuf:"varint,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Count         uint32                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Price         uint32                 `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"`
+	Deleted       bool                   `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	SellerId      int64                  `protobuf:"varint,5,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockChange) Reset() {
+	*x = StockChange{}
+	mi := &file_stocks_stocks_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockChange) ProtoMessage() {}
+
+func (x *StockChange) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockChange.ProtoReflect.Descriptor instead.
+func (*StockChange) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StockChange) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *StockChange) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *StockChange) GetPrice() uint32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *StockChange) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *StockChange) GetSellerId() int64 {
+	if x != nil {
+		return x.SellerId
+	}
+	return 0
+}
+
+type Seller struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Seller) Reset() {
+	*x = Seller{}
+	mi := &file_stocks_stocks_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Seller) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Seller) ProtoMessage() {}
+
+func (x *Seller) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Seller.ProtoReflect.Descriptor instead.
+func (*Seller) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Seller) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Seller) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Seller) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateSellerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSellerRequest) Reset() {
+	*x = CreateSellerRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSellerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSellerRequest) ProtoMessage() {}
+
+func (x *CreateSellerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSellerRequest.ProtoReflect.Descriptor instead.
+func (*CreateSellerRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateSellerRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateSellerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateSellerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seller        *Seller                `protobuf:"bytes,1,opt,name=seller,proto3" json:"seller,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSellerResponse) Reset() {
+	*x = CreateSellerResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSellerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSellerResponse) ProtoMessage() {}
+
+func (x *CreateSellerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSellerResponse.ProtoReflect.Descriptor instead.
+func (*CreateSellerResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateSellerResponse) GetSeller() *Seller {
+	if x != nil {
+		return x.Seller
+	}
+	return nil
+}
+
+type TransferSellerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SellerId      int64                  `protobuf:"varint,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	NewUserId     int64                  `protobuf:"varint,3,opt,name=new_user_id,json=newUserId,proto3" json:"new_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferSellerRequest) Reset() {
+	*x = TransferSellerRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferSellerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferSellerRequest) ProtoMessage() {}
+
+func (x *TransferSellerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferSellerRequest.ProtoReflect.Descriptor instead.
+func (*TransferSellerRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TransferSellerRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *TransferSellerRequest) GetSellerId() int64 {
+	if x != nil {
+		return x.SellerId
+	}
+	return 0
+}
+
+func (x *TransferSellerRequest) GetNewUserId() int64 {
+	if x != nil {
+		return x.NewUserId
+	}
+	return 0
+}
+
+type TransferSellerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seller        *Seller                `protobuf:"bytes,1,opt,name=seller,proto3" json:"seller,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferSellerResponse) Reset() {
+	*x = TransferSellerResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferSellerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferSellerResponse) ProtoMessage() {}
+
+func (x *TransferSellerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferSellerResponse.ProtoReflect.Descriptor instead.
+func (*TransferSellerResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TransferSellerResponse) GetSeller() *Seller {
+	if x != nil {
+		return x.Seller
+	}
+	return nil
+}
+
+type ReservationItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           uint32                 `protobuf:"varint,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	SellerId      int64                  `protobuf:"varint,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	Count         uint32                 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationItem) Reset() {
+	*x = ReservationItem{}
+	mi := &file_stocks_stocks_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationItem) ProtoMessage() {}
+
+func (x *ReservationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationItem.ProtoReflect.Descriptor instead.
+func (*ReservationItem) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReservationItem) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *ReservationItem) GetSellerId() int64 {
+	if x != nil {
+		return x.SellerId
+	}
+	return 0
+}
+
+func (x *ReservationItem) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ReserveStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CheckoutId    string                 `protobuf:"bytes,1,opt,name=checkout_id,json=checkoutId,proto3" json:"checkout_id,omitempty"`
+	Items         []*ReservationItem     `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReserveStockRequest) GetCheckoutId() string {
+	if x != nil {
+		return x.CheckoutId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ReserveStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockResponse) Reset() {
+	*x = ReserveStockResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockResponse) ProtoMessage() {}
+
+func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockResponse.ProtoReflect.Descriptor instead.
+func (*ReserveStockResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{22}
+}
+
+type CommitReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CheckoutId    string                 `protobuf:"bytes,1,opt,name=checkout_id,json=checkoutId,proto3" json:"checkout_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationRequest) Reset() {
+	*x = CommitReservationRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationRequest) ProtoMessage() {}
+
+func (x *CommitReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationRequest.ProtoReflect.Descriptor instead.
+func (*CommitReservationRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CommitReservationRequest) GetCheckoutId() string {
+	if x != nil {
+		return x.CheckoutId
+	}
+	return ""
+}
+
+type CommitReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationResponse) Reset() {
+	*x = CommitReservationResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationResponse) ProtoMessage() {}
+
+func (x *CommitReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationResponse.ProtoReflect.Descriptor instead.
+func (*CommitReservationResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{24}
+}
+
+type ReleaseReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CheckoutId    string                 `protobuf:"bytes,1,opt,name=checkout_id,json=checkoutId,proto3" json:"checkout_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationRequest) Reset() {
+	*x = ReleaseReservationRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationRequest) ProtoMessage() {}
+
+func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReleaseReservationRequest) GetCheckoutId() string {
+	if x != nil {
+		return x.CheckoutId
+	}
+	return ""
+}
+
+type ReleaseReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationResponse) Reset() {
+	*x = ReleaseReservationResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationResponse) ProtoMessage() {}
+
+func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{26}
+}
+
+type StockAdjustment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           uint32                 `protobuf:"varint,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	SellerId      int64                  `protobuf:"varint,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	Delta         int32                  `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockAdjustment) Reset() {
+	*x = StockAdjustment{}
+	mi := &file_stocks_stocks_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockAdjustment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockAdjustment) ProtoMessage() {}
+
+func (x *StockAdjustment) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockAdjustment.ProtoReflect.Descriptor instead.
+func (*StockAdjustment) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *StockAdjustment) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *StockAdjustment) GetSellerId() int64 {
+	if x != nil {
+		return x.SellerId
+	}
+	return 0
+}
+
+func (x *StockAdjustment) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type AdjustStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Admin the adjustments are recorded for in the audit log.
+	UserId        int64              `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Adjustments   []*StockAdjustment `protobuf:"bytes,2,rep,name=adjustments,proto3" json:"adjustments,omitempty"`
+	DryRun        bool               `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockRequest) ProtoMessage() {}
+
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AdjustStockRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AdjustStockRequest) GetAdjustments() []*StockAdjustment {
+	if x != nil {
+		return x.Adjustments
+	}
+	return nil
+}
+
+func (x *AdjustStockRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type AdjustStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offers        []*StockItem           `protobuf:"bytes,1,rep,name=offers,proto3" json:"offers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockResponse) Reset() {
+	*x = AdjustStockResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockResponse) ProtoMessage() {}
+
+func (x *AdjustStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockResponse.ProtoReflect.Descriptor instead.
+func (*AdjustStockResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AdjustStockResponse) GetOffers() []*StockItem {
+	if x != nil {
+		return x.Offers
+	}
+	return nil
+}
+
+type CatalogSKU struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           uint32                 `protobuf:"varint,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatalogSKU) Reset() {
+	*x = CatalogSKU{}
+	mi := &file_stocks_stocks_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatalogSKU) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogSKU) ProtoMessage() {}
+
+func (x *CatalogSKU) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogSKU.ProtoReflect.Descriptor instead.
+func (*CatalogSKU) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CatalogSKU) GetSku() uint32 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *CatalogSKU) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CatalogSKU) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type ImportSKUsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Admin the import is recorded for in the audit log.
+	UserId        int64         `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Skus          []*CatalogSKU `protobuf:"bytes,2,rep,name=skus,proto3" json:"skus,omitempty"`
+	DryRun        bool          `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportSKUsRequest) Reset() {
+	*x = ImportSKUsRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportSKUsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSKUsRequest) ProtoMessage() {}
+
+func (x *ImportSKUsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportSKUsRequest.ProtoReflect.Descriptor instead.
+func (*ImportSKUsRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ImportSKUsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ImportSKUsRequest) GetSkus() []*CatalogSKU {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+func (x *ImportSKUsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ImportedSKU struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Sku   *CatalogSKU            `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	// False when an existing SKU was updated.
+	Created       bool `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportedSKU) Reset() {
+	*x = ImportedSKU{}
+	mi := &file_stocks_stocks_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportedSKU) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportedSKU) ProtoMessage() {}
+
+func (x *ImportedSKU) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportedSKU.ProtoReflect.Descriptor instead.
+func (*ImportedSKU) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ImportedSKU) GetSku() *CatalogSKU {
+	if x != nil {
+		return x.Sku
+	}
+	return nil
+}
+
+func (x *ImportedSKU) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+type ImportSKUsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// In the order of the request.
+	Skus          []*ImportedSKU `protobuf:"bytes,1,rep,name=skus,proto3" json:"skus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportSKUsResponse) Reset() {
+	*x = ImportSKUsResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportSKUsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSKUsResponse) ProtoMessage() {}
+
+func (x *ImportSKUsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportSKUsResponse.ProtoReflect.Descriptor instead.
+func (*ImportSKUsResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ImportSKUsResponse) GetSkus() []*ImportedSKU {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+type ListAuditEventsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ActorId    *int64                 `protobuf:"varint,1,opt,name=actor_id,json=actorId,proto3,oneof" json:"actor_id,omitempty"`
+	EntityType string                 `protobuf:"bytes,2,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityId   string                 `protobuf:"bytes,3,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	From       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	// Defaults to 50, at most 500.
+	PageSize int32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous response.
+	PageToken     string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_stocks_stocks_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListAuditEventsRequest) GetActorId() int64 {
+	if x != nil && x.ActorId != nil {
+		return *x.ActorId
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type AuditEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActorId    int64                  `protobuf:"varint,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	Method     string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	EntityType string                 `protobuf:"bytes,4,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityId   string                 `protobuf:"bytes,5,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// SHA-256 of the deterministic protobuf encoding of the request.
+	RequestHash string `protobuf:"bytes,6,opt,name=request_hash,json=requestHash,proto3" json:"request_hash,omitempty"`
+	// Entity state before and after the call; after is unset for failed calls.
+	Before *structpb.Struct `protobuf:"bytes,7,opt,name=before,proto3" json:"before,omitempty"`
+	After  *structpb.Struct `protobuf:"bytes,8,opt,name=after,proto3" json:"after,omitempty"`
+	// gRPC status code of the call, e.g. OK or FAILED_PRECONDITION.
+	Status        string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	TraceId       string                 `protobuf:"bytes,10,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_stocks_stocks_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AuditEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetActorId() int64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetRequestHash() string {
+	if x != nil {
+		return x.RequestHash
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetBefore() *structpb.Struct {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetAfter() *structpb.Struct {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListAuditEventsResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Events []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_stocks_stocks_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stocks_stocks_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_stocks_stocks_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_stocks_stocks_proto protoreflect.FileDescriptor
+
+const file_stocks_stocks_proto_rawDesc = "" +
+	"\n" +
+	"\x13stocks/stocks.proto\x12\x06stocks\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd4\x01\n" +
+	"\x0fAddStockRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12\x19\n" +
+	"\x03sku\x18\x02 \x01(\rB\a\xbaH\x04*\x02 \x00R\x03sku\x12!\n" +
+	"\x05count\x18\x03 \x01(\rB\v\xbaH\b*\x06\x18\xff\xff\x03 \x00R\x05count\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\rR\x05price\x12%\n" +
+	"\blocation\x18\x05 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\blocation\x12$\n" +
+	"\tseller_id\x18\x06 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\bsellerId\",\n" +
+	"\x10AddStockResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"w\n" +
+	"\x12DeleteStockRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12\x19\n" +
+	"\x03sku\x18\x02 \x01(\rB\a\xbaH\x04*\x02 \x00R\x03sku\x12$\n" +
+	"\tseller_id\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\bsellerId\"/\n" +
+	"\x13DeleteStockResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"x\n" +
+	"\x13RestoreStockRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12\x19\n" +
+	"\x03sku\x18\x02 \x01(\rB\a\xbaH\x04*\x02 \x00R\x03sku\x12$\n" +
+	"\tseller_id\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\bsellerId\"0\n" +
+	"\x14RestoreStockResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xe5\x01\n" +
+	"\tStockItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\rR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\rR\x05count\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\rR\x05price\x12\x1a\n" +
+	"\blocation\x18\x06 \x01(\tR\blocation\x12\x1b\n" +
+	"\tseller_id\x18\a \x01(\x03R\bsellerId\x129\n" +
+	"\n" +
+	"deleted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"\xe3\x01\n" +
+	"\x1bListStocksByLocationRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12%\n" +
+	"\blocation\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\blocation\x12&\n" +
+	"\tpage_size\x18\x03 \x01(\x03B\t\xbaH\x06\"\x04\x18d \x00R\bpageSize\x12*\n" +
+	"\fcurrent_page\x18\x04 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\vcurrentPage\x12'\n" +
+	"\x0finclude_deleted\x18\x05 \x01(\bR\x0eincludeDeleted\"\xaa\x01\n" +
+	"\x1cListStocksByLocationResponse\x12'\n" +
+	"\x05items\x18\x01 \x03(\v2\x11.stocks.StockItemR\x05items\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\x12\x1f\n" +
+	"\vpage_number\x18\x03 \x01(\x03R\n" +
+	"pageNumber\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\x03R\n" +
+	"totalPages\"{\n" +
+	"\x0fGetStockRequest\x12\x19\n" +
+	"\x03sku\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x03sku\x12$\n" +
+	"\tseller_id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\bsellerId\x12'\n" +
+	"\x0finclude_deleted\x18\x03 \x01(\bR\x0eincludeDeleted\";\n" +
+	"\x10GetStockResponse\x12'\n" +
+	"\x05stock\x18\x01 \x01(\v2\x11.stocks.StockItemR\x05stock\"9\n" +
+	"\x11WatchStockRequest\x12$\n" +
+	"\x04skus\x18\x01 \x03(\rB\x10\xbaH\r\x92\x01\n" +
+	"\b\x01\x10d\"\x04*\x02 \x00R\x04skus\"W\n" +
+	"\x11ListOffersRequest\x12\x19\n" +
+	"\x03sku\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x03sku\x12'\n" +
+	"\x0finclude_deleted\x18\x02 \x01(\bR\x0eincludeDeleted\"?\n" +
+	"\x12ListOffersResponse\x12)\n" +
+	"\x06offers\x18\x01 \x03(\v2\x11.stocks.StockItemR\x06offers\"\x82\x01\n" +
+	"\vStockChange\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\rR\x03sku\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\rR\x05count\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\rR\x05price\x12\x18\n" +
+	"\adeleted\x18\x04 \x01(\bR\adeleted\x12\x1b\n" +
+	"\tseller_id\x18\x05 \x01(\x03R\bsellerId\"E\n" +
+	"\x06Seller\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"W\n" +
+	"\x13CreateSellerRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12\x1e\n" +
+	"\x04name\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x01R\x04name\">\n" +
+	"\x14CreateSellerResponse\x12&\n" +
+	"\x06seller\x18\x01 \x01(\v2\x0e.stocks.SellerR\x06seller\"\x88\x01\n" +
+	"\x15TransferSellerRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12$\n" +
+	"\tseller_id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\bsellerId\x12'\n" +
+	"\vnew_user_id\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\tnewUserId\"@\n" +
+	"\x16TransferSellerResponse\x12&\n" +
+	"\x06seller\x18\x01 \x01(\v2\x0e.stocks.SellerR\x06seller\"u\n" +
+	"\x0fReservationItem\x12\x19\n" +
+	"\x03sku\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x03sku\x12$\n" +
+	"\tseller_id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\bsellerId\x12!\n" +
+	"\x05count\x18\x03 \x01(\rB\v\xbaH\b*\x06\x18\xff\xff\x03 \x00R\x05count\"|\n" +
+	"\x13ReserveStockRequest\x12*\n" +
+	"\vcheckout_id\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"checkoutId\x129\n" +
+	"\x05items\x18\x02 \x03(\v2\x17.stocks.ReservationItemB\n" +
+	"\xbaH\a\x92\x01\x04\b\x01\x10dR\x05items\"\x16\n" +
+	"\x14ReserveStockResponse\"F\n" +
+	"\x18CommitReservationRequest\x12*\n" +
+	"\vcheckout_id\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"checkoutId\"\x1b\n" +
+	"\x19CommitReservationResponse\"G\n" +
+	"\x19ReleaseReservationRequest\x12*\n" +
+	"\vcheckout_id\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\n" +
+	"checkoutId\"\x1c\n" +
+	"\x1aReleaseReservationResponse\"~\n" +
+	"\x0fStockAdjustment\x12\x19\n" +
+	"\x03sku\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x03sku\x12$\n" +
+	"\tseller_id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\bsellerId\x12*\n" +
+	"\x05delta\x18\x03 \x01(\x05B\x14\xbaH\x11\x1a\x0f\x18\xff\xff\x03(\x81\x80\xfc\xff\xff\xff\xff\xff\xff\x01R\x05delta\"\x97\x01\n" +
+	"\x12AdjustStockRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12F\n" +
+	"\vadjustments\x18\x02 \x03(\v2\x17.stocks.StockAdjustmentB\v\xbaH\b\x92\x01\x05\b\x01\x10\xe8\aR\vadjustments\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"@\n" +
+	"\x13AdjustStockResponse\x12)\n" +
+	"\x06offers\x18\x01 \x03(\v2\x11.stocks.StockItemR\x06offers\"e\n" +
+	"\n" +
+	"CatalogSKU\x12\x19\n" +
+	"\x03sku\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\x03sku\x12\x1e\n" +
+	"\x04name\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x04name\x12\x1c\n" +
+	"\x04type\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\x04type\"\x83\x01\n" +
+	"\x11ImportSKUsRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x123\n" +
+	"\x04skus\x18\x02 \x03(\v2\x12.stocks.CatalogSKUB\v\xbaH\b\x92\x01\x05\b\x01\x10\xe8\aR\x04skus\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"M\n" +
+	"\vImportedSKU\x12$\n" +
+	"\x03sku\x18\x01 \x01(\v2\x12.stocks.CatalogSKUR\x03sku\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\"=\n" +
+	"\x12ImportSKUsResponse\x12'\n" +
+	"\x04skus\x18\x01 \x03(\v2\x13.stocks.ImportedSKUR\x04skus\"\xb9\x02\n" +
+	"\x16ListAuditEventsRequest\x12\x1e\n" +
+	"\bactor_id\x18\x01 \x01(\x03H\x00R\aactorId\x88\x01\x01\x12(\n" +
+	"\ventity_type\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x18 R\n" +
+	"entityType\x12$\n" +
+	"\tentity_id\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x18@R\bentityId\x12.\n" +
+	"\x04from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12'\n" +
+	"\tpage_size\x18\x06 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xf4\x03(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageTokenB\v\n" +
+	"\t_actor_id\"\xfe\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\x03R\aactorId\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x1f\n" +
+	"\ventity_type\x18\x04 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x05 \x01(\tR\bentityId\x12!\n" +
+	"\frequest_hash\x18\x06 \x01(\tR\vrequestHash\x12/\n" +
+	"\x06before\x18\a \x01(\v2\x17.google.protobuf.StructR\x06before\x12-\n" +
+	"\x05after\x18\b \x01(\v2\x17.google.protobuf.StructR\x05after\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x19\n" +
+	"\btrace_id\x18\n" +
+	" \x01(\tR\atraceId\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"m\n" +
+	"\x17ListAuditEventsResponse\x12*\n" +
+	"\x06events\x18\x01 \x03(\v2\x12.stocks.AuditEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken2\xfb\v\n" +
+	"\fStockService\x12Z\n" +
+	"\bAddStock\x12\x17.stocks.AddStockRequest\x1a\x18.stocks.AddStockResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/stocks/item/add\x12f\n" +
+	"\vDeleteStock\x12\x1a.stocks.DeleteStockRequest\x1a\x1b.stocks.DeleteStockResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/stocks/item/delete\x12j\n" +
+	"\fRestoreStock\x12\x1b.stocks.RestoreStockRequest\x1a\x1c.stocks.RestoreStockResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/stocks/item/restore\x12\x83\x01\n" +
+	"\x14ListStocksByLocation\x12#.stocks.ListStocksByLocationRequest\x1a$.stocks.ListStocksByLocationResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/stocks/list/location\x12Z\n" +
+	"\bGetStock\x12\x17.stocks.GetStockRequest\x1a\x18.stocks.GetStockResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/stocks/item/get\x12c\n" +
+	"\n" +
+	"ListOffers\x12\x19.stocks.ListOffersRequest\x1a\x1a.stocks.ListOffersResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/stocks/offers/list\x12>\n" +
+	"\n" +
+	"WatchStock\x12\x19.stocks.WatchStockRequest\x1a\x13.stocks.StockChange0\x01\x12k\n" +
+	"\fCreateSeller\x12\x1b.stocks.CreateSellerRequest\x1a\x1c.stocks.CreateSellerResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/stocks/seller/create\x12s\n" +
+	"\x0eTransferSeller\x12\x1d.stocks.TransferSellerRequest\x1a\x1e.stocks.TransferSellerResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/stocks/seller/transfer\x12I\n" +
+	"\fReserveStock\x12\x1b.stocks.ReserveStockRequest\x1a\x1c.stocks.ReserveStockResponse\x12X\n" +
+	"\x11CommitReservation\x12 .stocks.CommitReservationRequest\x1a!.stocks.CommitReservationResponse\x12[\n" +
+	"\x12ReleaseReservation\x12!.stocks.ReleaseReservationRequest\x1a\".stocks.ReleaseReservationResponse\x12m\n" +
+	"\vAdjustStock\x12\x1a.stocks.AdjustStockRequest\x1a\x1b.stocks.AdjustStockResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/stocks/admin/stock/adjust\x12h\n" +
+	"\n" +
+	"ImportSKUs\x12\x19.stocks.ImportSKUsRequest\x1a\x1a.stocks.ImportSKUsResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/stocks/admin/sku/import\x12w\n" +
+	"\x0fListAuditEvents\x12\x1e.stocks.ListAuditEventsRequest\x1a\x1f.stocks.ListAuditEventsResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/stocks/admin/audit/listB!Z\x1fstocks/pkg/api/stocks;stocksapib\x06proto3"
+
+var (
+	file_stocks_stocks_proto_rawDescOnce sync.Once
+	file_stocks_stocks_proto_rawDescData []byte
+)
+
+func file_stocks_stocks_proto_rawDescGZIP() []byte {
+	file_stocks_stocks_proto_rawDescOnce.Do(func() {
+		file_stocks_stocks_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_stocks_stocks_proto_rawDesc), len(file_stocks_stocks_proto_rawDesc)))
+	})
+	return file_stocks_stocks_proto_rawDescData
+}
+
+var file_stocks_stocks_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_stocks_stocks_proto_goTypes = []any{
+	(*AddStockRequest)(nil),              // 0: stocks.AddStockRequest
+	(*AddStockResponse)(nil),             // 1: stocks.AddStockResponse
+	(*DeleteStockRequest)(nil),           // 2: stocks.DeleteStockRequest
+	(*DeleteStockResponse)(nil),          // 3: stocks.DeleteStockResponse
+	(*RestoreStockRequest)(nil),          // 4: stocks.RestoreStockRequest
+	(*RestoreStockResponse)(nil),         // 5: stocks.RestoreStockResponse
+	(*StockItem)(nil),                    // 6: stocks.StockItem
+	(*ListStocksByLocationRequest)(nil),  // 7: stocks.ListStocksByLocationRequest
+	(*ListStocksByLocationResponse)(nil), // 8: stocks.ListStocksByLocationResponse
+	(*GetStockRequest)(nil),              // 9: stocks.GetStockRequest
+	(*GetStockResponse)(nil),             // 10: stocks.GetStockResponse
+	(*WatchStockRequest)(nil),            // 11: stocks.WatchStockRequest
+	(*ListOffersRequest)(nil),            // 12: stocks.ListOffersRequest
+	(*ListOffersResponse)(nil),           // 13: stocks.ListOffersResponse
+	(*StockChange)(nil),                  // 14: stocks.StockChange
+	(*Seller)(nil),                       // 15: stocks.Seller
+	(*CreateSellerRequest)(nil),          // 16: stocks.CreateSellerRequest
+	(*CreateSellerResponse)(nil),         // 17: stocks.CreateSellerResponse
+	(*TransferSellerRequest)(nil),        // 18: stocks.TransferSellerRequest
+	(*TransferSellerResponse)(nil),       // 19: stocks.TransferSellerResponse
+	(*ReservationItem)(nil),              // 20: stocks.ReservationItem
+	(*ReserveStockRequest)(nil),          // 21: stocks.ReserveStockRequest
+	(*ReserveStockResponse)(nil),         // 22: stocks.ReserveStockResponse
+	(*CommitReservationRequest)(nil),     // 23: stocks.CommitReservationRequest
+	(*CommitReservationResponse)(nil),    // 24: stocks.CommitReservationResponse
+	(*ReleaseReservationRequest)(nil),    // 25: stocks.ReleaseReservationRequest
+	(*ReleaseReservationResponse)(nil),   // 26: stocks.ReleaseReservationResponse
+	(*StockAdjustment)(nil),              // 27: stocks.StockAdjustment
+	(*AdjustStockRequest)(nil),           // 28: stocks.AdjustStockRequest
+	(*AdjustStockResponse)(nil),          // 29: stocks.AdjustStockResponse
+	(*CatalogSKU)(nil),                   // 30: stocks.CatalogSKU
+	(*ImportSKUsRequest)(nil),            // 31: stocks.ImportSKUsRequest
+	(*ImportedSKU)(nil),                  // 32: stocks.ImportedSKU
+	(*ImportSKUsResponse)(nil),           // 33: stocks.ImportSKUsResponse
+	(*ListAuditEventsRequest)(nil),       // 34: stocks.ListAuditEventsRequest
+	(*AuditEvent)(nil),                   // 35: stocks.AuditEvent
+	(*ListAuditEventsResponse)(nil),      // 36: stocks.ListAuditEventsResponse
+	(*timestamppb.Timestamp)(nil),        // 37: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),              // 38: google.protobuf.Struct
+}
+var file_stocks_stocks_proto_depIdxs = []int32{
+	37, // 0: stocks.StockItem.deleted_at:type_name -> google.protobuf.Timestamp
+	6,  // 1: stocks.ListStocksByLocationResponse.items:type_name -> stocks.StockItem
+	6,  // 2: stocks.GetStockResponse.stock:type_name -> stocks.StockItem
+	6,  // 3: stocks.ListOffersResponse.offers:type_name -> stocks.StockItem
+	15, // 4: stocks.CreateSellerResponse.seller:type_name -> stocks.Seller
+	15, // 5: stocks.TransferSellerResponse.seller:type_name -> stocks.Seller
+	20, // 6: stocks.ReserveStockRequest.items:type_name -> stocks.ReservationItem
+	27, // 7: stocks.AdjustStockRequest.adjustments:type_name -> stocks.StockAdjustment
+	6,  // 8: stocks.AdjustStockResponse.offers:type_name -> stocks.StockItem
+	30, // 9: stocks.ImportSKUsRequest.skus:type_name -> stocks.CatalogSKU
+	30, // 10: stocks.ImportedSKU.sku:type_name -> stocks.CatalogSKU
+	32, // 11: stocks.ImportSKUsResponse.skus:type_name -> stocks.ImportedSKU
+	37, // 12: stocks.ListAuditEventsRequest.from:type_name -> google.protobuf.Timestamp
+	37, // 13: stocks.ListAuditEventsRequest.to:type_name -> google.protobuf.Timestamp
+	38, // 14: stocks.AuditEvent.before:type_name -> google.protobuf.Struct
+	38, // 15: stocks.AuditEvent.after:type_name -> google.protobuf.Struct
+	37, // 16: stocks.AuditEvent.created_at:type_name -> google.protobuf.Timestamp
+	35, // 17: stocks.ListAuditEventsResponse.events:type_name -> stocks.AuditEvent
+	0,  // 18: stocks.StockService.AddStock:input_type -> stocks.AddStockRequest
+	2,  // 19: stocks.StockService.DeleteStock:input_type -> stocks.DeleteStockRequest
+	4,  // 20: stocks.StockService.RestoreStock:input_type -> stocks.RestoreStockRequest
+	7,  // 21: stocks.StockService.ListStocksByLocation:input_type -> stocks.ListStocksByLocationRequest
+	9,  // 22: stocks.StockService.GetStock:input_type -> stocks.GetStockRequest
+	12, // 23: stocks.StockService.ListOffers:input_type -> stocks.ListOffersRequest
+	11, // 24: stocks.StockService.WatchStock:input_type -> stocks.WatchStockRequest
+	16, // 25: stocks.StockService.CreateSeller:input_type -> stocks.CreateSellerRequest
+	18, // 26: stocks.StockService.TransferSeller:input_type -> stocks.TransferSellerRequest
+	21, // 27: stocks.StockService.ReserveStock:input_type -> stocks.ReserveStockRequest
+	23, // 28: stocks.StockService.CommitReservation:input_type -> stocks.CommitReservationRequest
+	25, // 29: stocks.StockService.ReleaseReservation:input_type -> stocks.ReleaseReservationRequest
+	28, // 30: stocks.StockService.AdjustStock:input_type -> stocks.AdjustStockRequest
+	31, // 31: stocks.StockService.ImportSKUs:input_type -> stocks.ImportSKUsRequest
+	34, // 32: stocks.StockService.ListAuditEvents:input_type -> stocks.ListAuditEventsRequest
+	1,  // 33: stocks.StockService.AddStock:output_type -> stocks.AddStockResponse
+	3,  // 34: stocks.StockService.DeleteStock:output_type -> stocks.DeleteStockResponse
+	5,  // 35: stocks.StockService.RestoreStock:output_type -> stocks.RestoreStockResponse
+	8,  // 36: stocks.StockService.ListStocksByLocation:output_type -> stocks.ListStocksByLocationResponse
+	10, // 37: stocks.StockService.GetStock:output_type -> stocks.GetStockResponse
+	13, // 38: stocks.StockService.ListOffers:output_type -> stocks.ListOffersResponse
+	14, // 39: stocks.StockService.WatchStock:output_type -> stocks.StockChange
+	17, // 40: stocks.StockService.CreateSeller:output_type -> stocks.CreateSellerResponse
+	19, // 41: stocks.StockService.TransferSeller:output_type -> stocks.TransferSellerResponse
+	22, // 42: stocks.StockService.ReserveStock:output_type -> stocks.ReserveStockResponse
+	24, // 43: stocks.StockService.CommitReservation:output_type -> stocks.CommitReservationResponse
+	26, // 44: stocks.StockService.ReleaseReservation:output_type -> stocks.ReleaseReservationResponse
+	29, // 45: stocks.StockService.AdjustStock:output_type -> stocks.AdjustStockResponse
+	33, // 46: stocks.StockService.ImportSKUs:output_type -> stocks.ImportSKUsResponse
+	36, // 47: stocks.StockService.ListAuditEvents:output_type -> stocks.ListAuditEventsResponse
+	33, // [33:48] is the sub-list for method output_type
+	18, // [18:33] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_stocks_stocks_proto_init() }
+func file_stocks_stocks_proto_init() {
+	if File_stocks_stocks_proto != nil {
+		return
+	}
+	file_stocks_stocks_proto_msgTypes[34].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stocks_stocks_proto_rawDesc), len(file_stocks_stocks_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   37,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_stocks_stocks_proto_goTypes,
+		DependencyIndexes: file_stocks_stocks_proto_depIdxs,
+		MessageInfos:      file_stocks_stocks_proto_msgTypes,
+	}.Build()
+	File_stocks_stocks_proto = out.File
+	file_stocks_stocks_proto_goTypes = nil
+	file_stocks_stocks_proto_depIdxs = nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"cart/internal/bootstrap"
 	"context"
+	"flag"
 	"fmt"
 	"os"
 	"os/signal"
@@ -10,12 +11,15 @@ import (
 )
 
 func main() {
+	configPath := flag.String("config", "config.yml", "path of the config file")
+	flag.Parse()
+
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	app, err := bootstrap.NewApp(ctx)
+	app, err := bootstrap.NewApp(ctx, *configPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "❌ Failed to initialize application \n")
+		fmt.Fprintf(os.Stderr, "❌ Failed to initialize application: %v\n", err)
 		os.Exit(1)
 	}
 
@@ -7,13 +7,17 @@ import (
 	"cart/internal/migrations"
 	"cart/internal/repository/interfaces"
 	kconstructor "cart/internal/repository/kafka"
+	"cart/internal/repository/payment"
 	"cart/internal/repository/postgres"
 	"cart/internal/repository/stocks"
 	"cart/internal/service"
+	cartapi "cart/pkg/api/cart"
+	"cart/pkg/health"
 	"cart/pkg/log"
 	"cart/pkg/log/zap"
 	"cart/pkg/metrics"
 	"cart/pkg/postgresql"
+	"cart/pkg/ratelimit"
 	"cart/pkg/tracing"
 	"context"
 	"errors"
@@ -22,31 +26,42 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
 )
 
 type App struct {
-	db             postgresql.Client
-	cfg            *config.Configs
-	grpcServer     *grpc.Server
-	gateway        grpcserver.Gateway
-	stockSvc       interfaces.StockService
-	logger         log.Logger
-	shutdownTracer func(context.Context) error
-	metricsServer  metrics.MetricsServer
+	db              postgresql.Client
+	idempotencyRepo interfaces.IdempotencyRepository
+	cartWatcher     interfaces.CartWatcher
+	cfg             *config.Configs
+	grpcServer      *grpc.Server
+	gateway         grpcserver.Gateway
+	stockSvc        interfaces.StockService
+	stockEvents     interfaces.KafkaConsumer
+	checkoutSaga    *service.CheckoutSaga
+	checkoutSteps   interfaces.KafkaConsumer
+	logger          log.Logger
+	shutdownTracer  func(context.Context) error
+	metricsServer   metrics.MetricsServer
+	probes          *health.Health
+	reloader        *reloader
 }
 
-func NewApp(ctx context.Context) (*App, error) {
-	cfg := config.GetConfig()
+func NewApp(ctx context.Context, configPath string) (*App, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
 
-	logger, err := zap.NewLogger(cfg.Listen.ServiceName, cfg.Listen.Env)
+	logger, err := zap.NewLogger(loggerOptions(cfg))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing logger: %v\n", err)
 		return nil, err
 	}
 
-	shutdownTracer, err := tracing.InitTracer(cfg.Listen.ServiceName, cfg.Tracing.JaegerEndpoint)
+	shutdownTracer, err := tracing.InitTracer(ctx, cfg)
 	if err != nil {
 		logger.Errorf("failed to init tracer: %v", err)
 		return nil, err
@@ -58,7 +73,9 @@ func NewApp(ctx context.Context) (*App, error) {
 		return nil, err
 	}
 
-	metricsServer := metrics.NewServer(cartMetrics, cfg.Metrics.Port, logger)
+	probes := health.New(cfg.Health.CheckTimeout, logger, cartapi.CartService_ServiceDesc.ServiceName)
+
+	metricsServer := metrics.NewServer(cartMetrics, probes, cfg.Metrics.Port, logger)
 
 	db, err := postgresql.NewPostgres(ctx, cfg)
 	if err != nil {
@@ -78,34 +95,117 @@ func NewApp(ctx context.Context) (*App, error) {
 		return nil, err
 	}
 
-	stockSvc, err := stocks.NewGRPCStockService(cfg.Listen.StocksServiceURL)
+	stockClientCfg := cfg.StockClient
+	if len(stockClientCfg.Addresses) == 0 {
+		stockClientCfg.Addresses = []string{cfg.Listen.StocksServiceURL}
+	}
+
+	stockSvc, err := stocks.NewGRPCStockService(stockClientCfg)
 	if err != nil {
 		logger.Errorf("failed to create stock client: %v", err)
 		return nil, err
 	}
 
+	var stockEvents interfaces.KafkaConsumer
+
+	if cfg.StockCache.Size > 0 {
+		stockCache := stocks.NewCachedStockService(stockSvc, cfg.StockCache, cartMetrics)
+		stockSvc = stockCache
+
+		stockEvents, err = kconstructor.NewConsumer(
+			stocks.NewCacheInvalidator(stockCache),
+			cfg.Kafka.Brokers,
+			cfg.StockCache.Topic,
+			stockCacheGroupID(cfg.StockCache.GroupID),
+			logger,
+		)
+		if err != nil {
+			logger.Errorf("failed to create stock events consumer: %v", err)
+			return nil, err
+		}
+	}
+
 	repo := postgres.NewRepository(db)
-	svc := service.NewService(repo, stockSvc, kafkaProd, logger)
+	savedRepo := postgres.NewSavedRepository(db)
+	idempotencyRepo := postgres.NewIdempotencyRepository(db)
+	cartWatcher := postgres.NewCartWatcher(db, logger)
+	svc := service.NewService(repo, savedRepo, stockSvc, cartWatcher, kafkaProd, cartMetrics, cfg.StockClient.DegradedMode, logger)
+
+	var auditProd interfaces.KafkaProd
+	if cfg.Audit.Topic != "" {
+		auditProd, err = kconstructor.NewProducer(cfg.Kafka.Brokers, cfg.Audit.Topic)
+		if err != nil {
+			logger.Errorf("failed to create audit producer: %v", err)
+			return nil, err
+		}
+	}
+
+	checkoutProd, err := kconstructor.NewProducer(cfg.Kafka.Brokers, cfg.Checkout.Topic)
+	if err != nil {
+		logger.Errorf("failed to create checkout producer: %v", err)
+		return nil, err
+	}
+
+	paymentProvider, err := newPaymentProvider(cfg.Payment, db)
+	if err != nil {
+		logger.Errorf("failed to create payment provider: %v", err)
+		return nil, err
+	}
+
+	checkoutSaga := service.NewCheckoutSaga(postgres.NewCheckoutRepository(db), repo, stockSvc, paymentProvider, checkoutProd, cartMetrics, cfg.Checkout.StepTimeout, cfg.Checkout.MaxAttempts, cfg.Checkout.MaxRetries, cfg.Payment.ActionTimeout, logger)
+
+	checkoutSteps, err := kconstructor.NewConsumer(checkoutSaga, cfg.Kafka.Brokers, cfg.Checkout.Topic, cfg.Checkout.GroupID, logger)
+	if err != nil {
+		logger.Errorf("failed to create checkout consumer: %v", err)
+		return nil, err
+	}
+
+	auditSvc := service.NewAuditService(postgres.NewAuditRepository(db), repo, savedRepo, auditProd, logger)
+
+	probes.AddCheck("postgres", db.Ping)
+	probes.AddCheck("kafka", kafkaProd.Ping)
+	probes.AddCheck("stocks", stockSvc.Ping)
+
+	// The limiters exist even when rate limiting is disabled, so a reload can enable it.
+	grpcLimiter := newRateLimiter(cfg.RateLimit, cfg.RateLimit.GRPC)
+	gatewayLimiter := newRateLimiter(cfg.RateLimit, cfg.RateLimit.Gateway)
+
+	reloader := newReloader(configPath, cfg, logger, stockSvc, grpcLimiter, gatewayLimiter)
+	metricsServer.Handle("GET /config", grpcserver.AdminOnly(reloader, cfg.Audit.AdminAPIKeys, logger))
+	metricsServer.Handle("/log/level", grpcserver.AdminOnly(logger.LevelHandler(), cfg.Audit.AdminAPIKeys, logger))
+
+	callers, err := grpcserver.NewCallers(cfg.Audit.AdminAPIKeys, cfg.RateLimit.TrustedProxies)
+	if err != nil {
+		logger.Errorf("failed to create rate limit callers: %v", err)
+		return nil, err
+	}
 
 	// gRPC Server Setup
-	grpcServer := grpcserver.NewGRPCServer(svc, logger)
+	grpcServer := grpcserver.NewGRPCServer(svc, checkoutSaga, auditSvc, cfg.Audit.AdminAPIKeys, idempotencyRepo, cfg.Idempotency.TTL, cartMetrics, grpcLimiter, callers, probes.GRPCServer(), logger)
 
 	// gRPC-Gateway Setup
-	gateway, err := grpcserver.NewGateway(ctx, cfg.Listen.GRPCPort, cfg.Listen.GatewayPort, logger, cartMetrics)
+	gateway, err := grpcserver.NewGateway(ctx, cfg.Listen.GRPCPort, cfg.Listen.GatewayPort, gatewayLimiter, callers, logger, cartMetrics)
 	if err != nil {
 		logger.Errorf("failed to create gateway: %v", err)
 		return nil, err
 	}
 
 	return &App{
-		db:             db,
-		cfg:            cfg,
-		grpcServer:     grpcServer,
-		gateway:        gateway,
-		stockSvc:       stockSvc,
-		logger:         logger,
-		shutdownTracer: shutdownTracer,
-		metricsServer:  metricsServer,
+		db:              db,
+		idempotencyRepo: idempotencyRepo,
+		cartWatcher:     cartWatcher,
+		cfg:             cfg,
+		grpcServer:      grpcServer,
+		gateway:         gateway,
+		stockSvc:        stockSvc,
+		stockEvents:     stockEvents,
+		checkoutSaga:    checkoutSaga,
+		checkoutSteps:   checkoutSteps,
+		logger:          logger,
+		shutdownTracer:  shutdownTracer,
+		metricsServer:   metricsServer,
+		probes:          probes,
+		reloader:        reloader,
 	}, nil
 }
 
@@ -114,6 +214,43 @@ func (a *App) Run() error {
 
 	serverErrors := make(chan error, 3)
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	// Start expired idempotency keys cleanup
+	go a.cleanupIdempotencyKeys(jobsCtx)
+
+	// Start cart changes listener
+	go func() {
+		if err := a.cartWatcher.Run(jobsCtx); err != nil {
+			a.logger.Errorf("cart watcher stopped: %v", err)
+		}
+	}()
+
+	// Start stock cache invalidation
+	if a.stockEvents != nil {
+		go func() {
+			if err := a.stockEvents.Start(jobsCtx); err != nil {
+				a.logger.Errorf("stock events consumer stopped: %v", err)
+			}
+		}()
+	}
+
+	// Start checkout saga steps and recovery of stalled sagas
+	go func() {
+		if err := a.checkoutSteps.Start(jobsCtx); err != nil {
+			a.logger.Errorf("checkout consumer stopped: %v", err)
+		}
+	}()
+
+	go a.recoverCheckouts(jobsCtx)
+
+	// Start applying config changes
+	go a.reloader.run(jobsCtx)
+
+	// Start gRPC health status updates
+	go a.probes.Run(jobsCtx, a.cfg.Health.CheckInterval)
+
 	// Start metrics server
 	go func() {
 		if err := a.metricsServer.Run(); err != nil {
@@ -153,6 +290,13 @@ func (a *App) Run() error {
 		return errors.New("server failed to start or stopped unexpectedly: " + err.Error())
 	}
 
+	// Fail readiness first so load balancers drain the instance before it stops serving
+	a.probes.Shutdown()
+	a.logger.Infof("⏳ Readiness set to not serving, draining for %s", a.cfg.Health.DrainDelay)
+	time.Sleep(a.cfg.Health.DrainDelay)
+
+	stopJobs()
+
 	ctx, cancel := context.WithTimeout(context.Background(), constants.ServerTimeout)
 	defer cancel()
 
@@ -164,8 +308,18 @@ func (a *App) Run() error {
 
 	var shutdownErrors []error
 
-	// Shutdown gRPC server
-	a.grpcServer.GracefulStop()
+	// Shutdown gRPC server; watch streams never finish on their own, so they are cut off at the deadline
+	stopped := make(chan struct{})
+	go func() {
+		a.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		a.grpcServer.Stop()
+	}
 	a.logger.Info("✅ gRPC server shutdown complete")
 
 	// Shutdown Gateway
@@ -175,6 +329,22 @@ func (a *App) Run() error {
 		a.logger.Info("✅ Gateway shutdown complete")
 	}
 
+	// Shutdown stock events consumer
+	if a.stockEvents != nil {
+		if err := a.stockEvents.Close(); err != nil {
+			shutdownErrors = append(shutdownErrors, fmt.Errorf("stock events consumer shutdown failed: %w", err))
+		} else {
+			a.logger.Info("✅ Stock events consumer closed")
+		}
+	}
+
+	// Shutdown checkout consumer
+	if err := a.checkoutSteps.Close(); err != nil {
+		shutdownErrors = append(shutdownErrors, fmt.Errorf("checkout consumer shutdown failed: %w", err))
+	} else {
+		a.logger.Info("✅ Checkout consumer closed")
+	}
+
 	// Shutdown Stock client
 	if err := a.stockSvc.Close(); err != nil {
 		shutdownErrors = append(shutdownErrors, fmt.Errorf("stock client shutdown failed: %w", err))
@@ -203,6 +373,127 @@ func (a *App) Run() error {
 	return nil
 }
 
+func (a *App) cleanupIdempotencyKeys(ctx context.Context) {
+	if a.cfg.Idempotency.CleanupInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(a.cfg.Idempotency.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := a.idempotencyRepo.DeleteExpired(ctx)
+			if err != nil {
+				a.logger.Errorf("failed to delete expired idempotency keys: %v", err)
+				continue
+			}
+
+			if deleted > 0 {
+				a.logger.Infof("🧹 Deleted %d expired idempotency keys", deleted)
+			}
+		}
+	}
+}
+
+// recoverCheckouts picks up the sagas left behind by a previous run right away, then
+// keeps re-driving stalled sagas every sweep interval.
+func (a *App) recoverCheckouts(ctx context.Context) {
+	interval := a.cfg.Checkout.SweepInterval
+	if interval <= 0 {
+		interval = constants.CheckoutSweepInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		recovered, err := a.checkoutSaga.RecoverStalled(ctx)
+		if err != nil {
+			a.logger.Errorf("failed to recover stalled checkouts: %v", err)
+		} else if recovered > 0 {
+			a.logger.Infof("🔁 Re-driving %d stalled checkouts", recovered)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (a *App) Logger() log.Logger {
 	return a.logger
 }
+
+// stockCacheGroupID makes the consumer group unique per instance, since every replica
+// has to see all invalidations for its own cache.
+func stockCacheGroupID(prefix string) string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return fmt.Sprintf("%s-%d", prefix, os.Getpid())
+	}
+
+	return prefix + "-" + hostname
+}
+
+// newPaymentProvider returns the configured payment provider.
+func newPaymentProvider(cfg config.Payment, db postgresql.Client) (interfaces.PaymentProvider, error) {
+	switch cfg.Provider {
+	case "", "simulator":
+		return payment.NewSimulator(cfg, postgres.NewSimulatedPaymentRepository(db))
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.Provider)
+	}
+}
+
+func newRateLimiter(cfg config.RateLimit, rules config.RateLimitRules) *ratelimit.Limiter {
+	fallback, methods := rateLimitRules(cfg, rules)
+
+	return ratelimit.New(fallback, methods, cfg.IdleTTL, rateLimitMaxBuckets(cfg))
+}
+
+func updateRateLimiter(limiter *ratelimit.Limiter, cfg config.RateLimit, rules config.RateLimitRules) {
+	limiter.Update(rateLimitRules(cfg, rules))
+}
+
+func rateLimitMaxBuckets(cfg config.RateLimit) int {
+	if cfg.MaxBuckets > 0 {
+		return cfg.MaxBuckets
+	}
+
+	return constants.RateLimitMaxBuckets
+}
+
+// rateLimitRules converts rules to limiter rules. With rate limiting disabled nothing
+// is limited.
+func rateLimitRules(cfg config.RateLimit, rules config.RateLimitRules) (ratelimit.Rule, map[string]ratelimit.Rule) {
+	if !cfg.Enabled {
+		return ratelimit.Rule{}, nil
+	}
+
+	methods := make(map[string]ratelimit.Rule, len(rules.Methods))
+	for _, method := range rules.Methods {
+		methods[method.Name] = ratelimit.Rule{RPS: method.RPS, Burst: method.Burst}
+	}
+
+	return ratelimit.Rule{RPS: rules.RPS, Burst: rules.Burst}, methods
+}
+
+func loggerOptions(cfg *config.Configs) zap.Options {
+	return zap.Options{
+		Service:  cfg.Listen.ServiceName,
+		Env:      cfg.Listen.Env,
+		Level:    cfg.Log.Level,
+		Encoding: cfg.Log.Encoding,
+		Sampling: zap.Sampling{
+			Initial:    cfg.Log.Sampling.Initial,
+			Thereafter: cfg.Log.Sampling.Thereafter,
+			Tick:       cfg.Log.Sampling.Tick,
+		},
+	}
+}
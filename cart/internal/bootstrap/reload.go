@@ -0,0 +1,121 @@
+package bootstrap
+
+import (
+	"cart/internal/config"
+	"cart/internal/constants"
+	"cart/internal/repository/interfaces"
+	"cart/pkg/log/zap"
+	"cart/pkg/ratelimit"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// reloader applies the dynamic settings of the config file while the service runs,
+// whenever the file changes or the process gets SIGHUP. The settings in effect are the
+// static ones read at startup and the dynamic ones of the last valid config.
+type reloader struct {
+	path           string
+	mu             sync.Mutex
+	current        atomic.Pointer[config.Configs]
+	logger         *zap.Logger
+	stockSvc       interfaces.StockService
+	grpcLimiter    *ratelimit.Limiter
+	gatewayLimiter *ratelimit.Limiter
+}
+
+type effectiveConfig struct {
+	Dynamic  []string       `json:"dynamic"`
+	Settings map[string]any `json:"settings"`
+}
+
+func newReloader(path string, cfg *config.Configs, logger *zap.Logger, stockSvc interfaces.StockService, grpcLimiter, gatewayLimiter *ratelimit.Limiter) *reloader {
+	r := &reloader{
+		path:           path,
+		logger:         logger,
+		stockSvc:       stockSvc,
+		grpcLimiter:    grpcLimiter,
+		gatewayLimiter: gatewayLimiter,
+	}
+	r.current.Store(cfg)
+
+	return r
+}
+
+func (r *reloader) run(ctx context.Context) {
+	config.Watch(r.path, constants.ConfigReloadDelay, func() {
+		if ctx.Err() == nil {
+			r.logger.Infof("🔄 Config file %s changed, reloading", r.path)
+			r.reload()
+		}
+	})
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.logger.Infof("🔄 Received SIGHUP, reloading config %s", r.path)
+			r.reload()
+		}
+	}
+}
+
+// reload reads and validates the whole config file again. An invalid file is not
+// applied at all, the settings in effect stay as they are.
+func (r *reloader) reload() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next, err := config.Load(r.path)
+	if err != nil {
+		r.logger.Errorf("failed to reload config, keeping the current one: %v", err)
+		return
+	}
+
+	current := r.current.Load()
+
+	if changed := current.StaticChanges(next); len(changed) > 0 {
+		r.logger.Warnf("⚠️ Changes of %s only apply after a restart", strings.Join(changed, ", "))
+	}
+
+	// A level set through /log/level is kept until the level in the file changes.
+	if next.Log.Level != current.Log.Level {
+		if err := r.logger.SetLevel(next.Log.Level); err != nil {
+			r.logger.Errorf("failed to reload config, keeping the current one: %v", err)
+			return
+		}
+	}
+
+	r.stockSvc.SetTimeout(next.StockClient.Timeout)
+	updateRateLimiter(r.grpcLimiter, next.RateLimit, next.RateLimit.GRPC)
+	updateRateLimiter(r.gatewayLimiter, next.RateLimit, next.RateLimit.Gateway)
+
+	r.current.Store(current.WithDynamic(next))
+
+	r.logger.Info("✅ Config reloaded")
+}
+
+// ServeHTTP shows the settings in effect and which of them are dynamic, with secrets
+// redacted.
+func (r *reloader) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(effectiveConfig{
+		Dynamic:  config.DynamicKeys(),
+		Settings: r.current.Load().Settings(),
+	})
+	if err != nil {
+		r.logger.Errorf("err in encode config response: %v", err)
+	}
+}
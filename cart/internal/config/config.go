@@ -1,19 +1,30 @@
 package config
 
 import (
-	"log"
-	"os"
-	"sync"
+	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+// Configs is the whole service config. Settings tagged dynamic are applied without a
+// restart when the config file changes, settings tagged secret are never shown.
 type Configs struct {
-	Listen   Listen     `mapstructure:"listen"`
-	Postgres DbPostgres `mapstructure:"postgres"`
-	Kafka    Kafka      `mapstructure:"kafka"`
-	Tracing  Tracing    `mapstructure:"tracing"`
-	Metrics  Metrics    `mapstructure:"metrics"`
+	Listen      Listen      `mapstructure:"listen"`
+	Log         Log         `mapstructure:"log"`
+	Postgres    DbPostgres  `mapstructure:"postgres"`
+	Kafka       Kafka       `mapstructure:"kafka"`
+	Tracing     Tracing     `mapstructure:"tracing"`
+	Metrics     Metrics     `mapstructure:"metrics"`
+	Idempotency Idempotency `mapstructure:"idempotency"`
+	Health      Health      `mapstructure:"health"`
+	StockCache  StockCache  `mapstructure:"stock_cache"`
+	StockClient StockClient `mapstructure:"stock_client"`
+	RateLimit   RateLimit   `mapstructure:"rate_limit"`
+	Audit       Audit       `mapstructure:"audit"`
+	Checkout    Checkout    `mapstructure:"checkout"`
+	Payment     Payment     `mapstructure:"payment"`
 }
 
 type (
@@ -26,12 +37,30 @@ type (
 		Env              string `mapstructure:"env"`
 	}
 
+	Log struct {
+		// Level is debug, info, warn or error.
+		Level string `mapstructure:"level" dynamic:"true"`
+		// Encoding is json or console. Empty means console in development and json
+		// otherwise.
+		Encoding string      `mapstructure:"encoding"`
+		Sampling LogSampling `mapstructure:"sampling"`
+	}
+
+	// LogSampling thins out repeated lines: of the lines with the same level and
+	// message, the first Initial per Tick are written and then every Thereafter-th.
+	// Sampling is off while Initial is zero.
+	LogSampling struct {
+		Initial    int           `mapstructure:"initial"`
+		Thereafter int           `mapstructure:"thereafter"`
+		Tick       time.Duration `mapstructure:"tick"`
+	}
+
 	DbPostgres struct {
 		Host     string `mapstructure:"host"`
 		Port     string `mapstructure:"port"`
 		DbName   string `mapstructure:"db_name"`
 		UserName string `mapstructure:"username"`
-		Password string `mapstructure:"password"`
+		Password string `mapstructure:"password" secret:"true"`
 		Sslmode  string `mapstructure:"ssl_mode"`
 	}
 
@@ -42,43 +71,145 @@ type (
 	}
 
 	Tracing struct {
-		JaegerEndpoint string `mapstructure:"jaeger_endpoint"`
+		Exporter    string  `mapstructure:"exporter"`
+		Endpoint    string  `mapstructure:"endpoint"`
+		Insecure    bool    `mapstructure:"insecure"`
+		SampleRatio float64 `mapstructure:"sample_ratio"`
+		Version     string  `mapstructure:"version"`
 	}
 
 	Metrics struct {
 		Port int64 `mapstructure:"port"`
 	}
-)
 
-var (
-	once     sync.Once
-	instance *Configs
+	Health struct {
+		CheckInterval time.Duration `mapstructure:"check_interval"`
+		CheckTimeout  time.Duration `mapstructure:"check_timeout"`
+		DrainDelay    time.Duration `mapstructure:"drain_delay"`
+	}
+
+	Idempotency struct {
+		TTL             time.Duration `mapstructure:"ttl"`
+		CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+	}
+
+	StockCache struct {
+		Size        int           `mapstructure:"size"`
+		TTL         time.Duration `mapstructure:"ttl"`
+		NegativeTTL time.Duration `mapstructure:"negative_ttl"`
+		Topic       string        `mapstructure:"topic"`
+		GroupID     string        `mapstructure:"group_id"`
+	}
+
+	StockClient struct {
+		Addresses        []string      `mapstructure:"addresses"`
+		Timeout          time.Duration `mapstructure:"timeout" dynamic:"true"`
+		MaxAttempts      int           `mapstructure:"max_attempts"`
+		InitialBackoff   time.Duration `mapstructure:"initial_backoff"`
+		MaxBackoff       time.Duration `mapstructure:"max_backoff"`
+		FailureThreshold int           `mapstructure:"failure_threshold"`
+		OpenTimeout      time.Duration `mapstructure:"open_timeout"`
+		KeepaliveTime    time.Duration `mapstructure:"keepalive_time"`
+		DegradedMode     bool          `mapstructure:"degraded_mode"`
+		// APIKey is sent in x-api-key on the internal reservation calls. It has to be
+		// one of the internal API keys of stocks.
+		APIKey string `mapstructure:"api_key" secret:"true"`
+	}
+
+	RateLimit struct {
+		Enabled        bool           `mapstructure:"enabled" dynamic:"true"`
+		IdleTTL        time.Duration  `mapstructure:"idle_ttl"`
+		MaxBuckets     int            `mapstructure:"max_buckets"`
+		TrustedProxies []string       `mapstructure:"trusted_proxies"`
+		GRPC           RateLimitRules `mapstructure:"grpc" dynamic:"true"`
+		Gateway        RateLimitRules `mapstructure:"gateway" dynamic:"true"`
+	}
+
+	// RateLimitRules are the default limit and the per-method overrides. Methods are
+	// a list because viper would split gRPC method names used as map keys.
+	RateLimitRules struct {
+		RPS     float64       `mapstructure:"rps"`
+		Burst   int           `mapstructure:"burst"`
+		Methods []MethodLimit `mapstructure:"methods"`
+	}
+
+	MethodLimit struct {
+		Name  string  `mapstructure:"name"`
+		RPS   float64 `mapstructure:"rps"`
+		Burst int     `mapstructure:"burst"`
+	}
+
+	Audit struct {
+		// Topic also publishes audit events to Kafka when set.
+		Topic        string   `mapstructure:"topic"`
+		AdminAPIKeys []string `mapstructure:"admin_api_keys" secret:"true"`
+	}
+
+	Checkout struct {
+		// Topic carries the step events that drive the saga. GroupID is shared by all
+		// replicas, so every step runs on one of them.
+		Topic       string        `mapstructure:"topic"`
+		GroupID     string        `mapstructure:"group_id"`
+		StepTimeout time.Duration `mapstructure:"step_timeout"`
+		MaxAttempts int           `mapstructure:"max_attempts"`
+		// MaxRetries bounds the attempts of the steps from the stock commit on and of the
+		// compensations, which cannot be undone; a saga running out of them is stuck.
+		MaxRetries    int           `mapstructure:"max_retries"`
+		SweepInterval time.Duration `mapstructure:"sweep_interval"`
+	}
+
+	Payment struct {
+		Provider      string `mapstructure:"provider"`
+		WebhookSecret string `mapstructure:"webhook_secret" secret:"true"`
+		// ActionTimeout is how long a checkout waits for the customer to pass 3-D Secure.
+		ActionTimeout time.Duration    `mapstructure:"action_timeout"`
+		Simulator     PaymentSimulator `mapstructure:"simulator"`
+	}
+
+	// PaymentSimulator sets the outcome of authorizations by card number. Cards are a
+	// list because viper lowercases map keys.
+	PaymentSimulator struct {
+		Cards []SimulatedCard `mapstructure:"cards"`
+	}
+
+	SimulatedCard struct {
+		Number string `mapstructure:"number"`
+		// Outcome is succeed, decline, timeout or three_ds.
+		Outcome string `mapstructure:"outcome"`
+	}
 )
 
-func GetConfig() *Configs {
-	once.Do(func() {
-		pwd, err := os.Getwd()
-		if err != nil {
-			log.Println("err in config Getwd func: ", err)
-			os.Exit(1)
-		}
+// Load reads the config file at path. Every setting can be overridden by the
+// environment variable named after its key, like CART_POSTGRES_PASSWORD for
+// postgres.password, or read from the file CART_POSTGRES_PASSWORD_FILE names. Every
+// invalid setting is reported at once.
+func Load(path string) (*Configs, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(envKeyReplacer)
+	v.AutomaticEnv()
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
 
-		pathConfig := pwd + "/config.yml"
+	if err := bindEnv(v, reflect.TypeOf(Configs{}), ""); err != nil {
+		return nil, fmt.Errorf("failed to bind environment variables: %w", err)
+	}
 
-		viper.SetConfigFile(pathConfig)
+	if err := readSecretFiles(v); err != nil {
+		return nil, fmt.Errorf("failed to read secret files: %w", err)
+	}
 
-		err = viper.ReadInConfig()
-		if err != nil {
-			log.Println("err in load config:", err)
-			os.Exit(1)
-		}
+	cfg := &Configs{}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode config %s: %w", path, err)
+	}
 
-		instance = &Configs{}
-		if err = viper.Unmarshal(instance); err != nil {
-			log.Println("err in marshal load config:", err)
-			os.Exit(1)
-		}
-	})
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s:\n%w", path, err)
+	}
 
-	return instance
+	return cfg, nil
 }
@@ -0,0 +1,320 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const validConfig = `
+listen:
+  service_name: cart
+  gateway_port: 8080
+  grpc_port: 7070
+  migrations_path: migrations
+  stocks_service_url: stocks-service:7071
+postgres:
+  host: cart-db
+  port: 5432
+  username: user_cart
+  db_name: cart
+  password: from-file
+kafka:
+  brokers:
+    - kafka1:29091
+  topic: metrics
+metrics:
+  port: 9080
+stock_client:
+  timeout: 3s
+rate_limit:
+  grpc:
+    rps: 20
+    methods:
+      - name: /cart.CartService/AddItemToCart
+        rps: 5
+checkout:
+  topic: checkout
+  group_id: cart-checkout
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func writeSecret(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	cfg, err := Load(writeConfig(t, validConfig))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Listen.GRPCPort != "7070" {
+		t.Errorf("listen.grpc_port = %q, want 7070", cfg.Listen.GRPCPort)
+	}
+	if cfg.Metrics.Port != 9080 {
+		t.Errorf("metrics.port = %d, want 9080", cfg.Metrics.Port)
+	}
+	if cfg.StockClient.Timeout != 3*time.Second {
+		t.Errorf("stock_client.timeout = %v, want 3s", cfg.StockClient.Timeout)
+	}
+	if !slices.Equal(cfg.Kafka.Brokers, []string{"kafka1:29091"}) {
+		t.Errorf("kafka.brokers = %v, want [kafka1:29091]", cfg.Kafka.Brokers)
+	}
+	if len(cfg.RateLimit.GRPC.Methods) != 1 || cfg.RateLimit.GRPC.Methods[0].RPS != 5 {
+		t.Errorf("rate_limit.grpc.methods = %+v, want AddItemToCart at 5 rps", cfg.RateLimit.GRPC.Methods)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    func(t *testing.T) string
+		wantErr string
+	}{
+		{
+			name:    "missing file",
+			path:    func(t *testing.T) string { return filepath.Join(t.TempDir(), "missing.yml") },
+			wantErr: "failed to read config",
+		},
+		{
+			name:    "malformed yaml",
+			path:    func(t *testing.T) string { return writeConfig(t, "listen: [") },
+			wantErr: "failed to read config",
+		},
+		{
+			name:    "wrong type",
+			path:    func(t *testing.T) string { return writeConfig(t, validConfig+"health:\n  drain_delay: soon\n") },
+			wantErr: "failed to decode config",
+		},
+		{
+			name:    "invalid setting",
+			path:    func(t *testing.T) string { return writeConfig(t, validConfig+"tracing:\n  exporter: zipkin\n") },
+			wantErr: "tracing.exporter must be one of",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.path(t))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid, err := Load(writeConfig(t, validConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		change   func(c *Configs)
+		wantErrs []string
+	}{
+		{
+			name:   "valid",
+			change: func(*Configs) {},
+		},
+		{
+			name:     "missing required settings",
+			change:   func(c *Configs) { c.Listen.ServiceName, c.Postgres.Host, c.Checkout.Topic = "", " ", "" },
+			wantErrs: []string{"listen.service_name is required", "postgres.host is required", "checkout.topic is required"},
+		},
+		{
+			name:     "ports",
+			change:   func(c *Configs) { c.Listen.GRPCPort, c.Listen.GatewayPort, c.Metrics.Port = "grpc", "0", 70000 },
+			wantErrs: []string{"listen.grpc_port must be a port number", "listen.gateway_port must be a port between", "metrics.port must be a port between"},
+		},
+		{
+			name: "negative durations and sizes",
+			change: func(c *Configs) {
+				c.StockClient.Timeout, c.StockCache.Size, c.RateLimit.GRPC.Methods[0].RPS = -time.Second, -1, -5
+			},
+			wantErrs: []string{"stock_client.timeout must not be negative", "stock_cache.size must not be negative", "rate_limit.grpc.methods[0].rps must not be negative"},
+		},
+		{
+			name:     "unknown values",
+			change:   func(c *Configs) { c.Log.Level, c.Postgres.Sslmode, c.Payment.Provider = "trace", "sometimes", "stripe" },
+			wantErrs: []string{"log.level must be one of", "postgres.ssl_mode must be one of", "payment.provider must be one of"},
+		},
+		{
+			name:     "tracing endpoint",
+			change:   func(c *Configs) { c.Tracing.Exporter, c.Tracing.SampleRatio = "otlp-http", 2 },
+			wantErrs: []string{"tracing.endpoint is required", "tracing.sample_ratio must be between 0 and 1"},
+		},
+		{
+			name:     "stocks address",
+			change:   func(c *Configs) { c.Listen.StocksServiceURL = "" },
+			wantErrs: []string{"listen.stocks_service_url is required unless stock_client.addresses is set"},
+		},
+		{
+			name:     "trusted proxies",
+			change:   func(c *Configs) { c.RateLimit.TrustedProxies = []string{"10.0.0.1", "10.0.0.0/8", "gateway"} },
+			wantErrs: []string{"rate_limit.trusted_proxies[2] must be an IP address or CIDR range"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := *valid
+			cfg.RateLimit.GRPC.Methods = slices.Clone(valid.RateLimit.GRPC.Methods)
+			tt.change(&cfg)
+
+			err := cfg.Validate()
+
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("Validate() error = nil, want %q", tt.wantErrs)
+			}
+
+			// Every problem is reported, one per line.
+			if got := strings.Count(err.Error(), "\n") + 1; got != len(tt.wantErrs) {
+				t.Errorf("Validate() reported %d problems, want %d: %v", got, len(tt.wantErrs), err)
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() error = %v, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEnvOverrides(t *testing.T) {
+	t.Setenv("CART_POSTGRES_PASSWORD", "from-env")
+	t.Setenv("CART_LISTEN_GRPC_PORT", "7171")
+	t.Setenv("CART_STOCK_CLIENT_TIMEOUT", "250ms")
+	// Not in the file at all.
+	t.Setenv("CART_AUDIT_TOPIC", "audit")
+	t.Setenv("CART_KAFKA_BROKERS", "kafka1:29091,kafka2:29092")
+
+	cfg, err := Load(writeConfig(t, validConfig))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Postgres.Password != "from-env" {
+		t.Errorf("postgres.password = %q, want from-env", cfg.Postgres.Password)
+	}
+	if cfg.Listen.GRPCPort != "7171" {
+		t.Errorf("listen.grpc_port = %q, want 7171", cfg.Listen.GRPCPort)
+	}
+	if cfg.StockClient.Timeout != 250*time.Millisecond {
+		t.Errorf("stock_client.timeout = %v, want 250ms", cfg.StockClient.Timeout)
+	}
+	if cfg.Audit.Topic != "audit" {
+		t.Errorf("audit.topic = %q, want audit", cfg.Audit.Topic)
+	}
+	if !slices.Equal(cfg.Kafka.Brokers, []string{"kafka1:29091", "kafka2:29092"}) {
+		t.Errorf("kafka.brokers = %v, want both brokers", cfg.Kafka.Brokers)
+	}
+}
+
+func TestEnvOverrideIsValidated(t *testing.T) {
+	t.Setenv("CART_LOG_LEVEL", "verbose")
+
+	_, err := Load(writeConfig(t, validConfig))
+	if err == nil || !strings.Contains(err.Error(), "log.level must be one of") {
+		t.Errorf("Load() error = %v, want the invalid log.level", err)
+	}
+}
+
+func TestSecretFiles(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          func(t *testing.T) map[string]string
+		wantPassword string
+		wantWebhook  string
+		wantErr      string
+	}{
+		{
+			name: "trailing newline is dropped",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{"CART_POSTGRES_PASSWORD_FILE": writeSecret(t, "s3cret\n")}
+			},
+			wantPassword: "s3cret",
+		},
+		{
+			name: "setting not in the file",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{"CART_PAYMENT_WEBHOOK_SECRET_FILE": writeSecret(t, "hook\r\n")}
+			},
+			wantPassword: "from-file",
+			wantWebhook:  "hook",
+		},
+		{
+			name: "both variable and file",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{
+					"CART_POSTGRES_PASSWORD":      "from-env",
+					"CART_POSTGRES_PASSWORD_FILE": writeSecret(t, "s3cret"),
+				}
+			},
+			wantErr: "CART_POSTGRES_PASSWORD and CART_POSTGRES_PASSWORD_FILE are both set",
+		},
+		{
+			name: "missing file",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{"CART_POSTGRES_PASSWORD_FILE": filepath.Join(t.TempDir(), "missing")}
+			},
+			wantErr: "CART_POSTGRES_PASSWORD_FILE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env(t) {
+				t.Setenv(name, value)
+			}
+
+			cfg, err := Load(writeConfig(t, validConfig))
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Load() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Postgres.Password != tt.wantPassword {
+				t.Errorf("postgres.password = %q, want %q", cfg.Postgres.Password, tt.wantPassword)
+			}
+			if cfg.Payment.WebhookSecret != tt.wantWebhook {
+				t.Errorf("payment.webhook_secret = %q, want %q", cfg.Payment.WebhookSecret, tt.wantWebhook)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+const redacted = "[REDACTED]"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Watch calls onChange once the config file at path was written, replaced or
+// recreated, as a mounted ConfigMap is, and then left alone for settle. Waiting keeps
+// a file that is written in several steps from being read half way.
+func Watch(path string, settle time.Duration, onChange func()) {
+	timer := time.AfterFunc(settle, onChange)
+	timer.Stop()
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.OnConfigChange(func(fsnotify.Event) { timer.Reset(settle) })
+	v.WatchConfig()
+}
+
+// WithDynamic returns a copy of c with the dynamic settings of next.
+func (c *Configs) WithDynamic(next *Configs) *Configs {
+	merged := *c
+	mergeDynamic(reflect.ValueOf(&merged).Elem(), reflect.ValueOf(next).Elem())
+
+	return &merged
+}
+
+// StaticChanges returns the keys of the settings other than the dynamic ones whose
+// value in next differs from c. They only apply after a restart.
+func (c *Configs) StaticChanges(next *Configs) []string {
+	var changed []string
+	staticChanges(reflect.ValueOf(c).Elem(), reflect.ValueOf(next).Elem(), "", &changed)
+
+	return changed
+}
+
+// DynamicKeys returns the keys of the dynamic settings.
+func DynamicKeys() []string {
+	var keys []string
+	dynamicKeys(reflect.TypeOf(Configs{}), "", &keys)
+
+	return keys
+}
+
+// Settings returns every setting by its key in the config file, with durations as
+// text and secrets redacted.
+func (c *Configs) Settings() map[string]any {
+	return settings(reflect.ValueOf(c).Elem())
+}
+
+func isDynamic(field reflect.StructField) bool {
+	return field.Tag.Get("dynamic") == "true"
+}
+
+func mergeDynamic(dst, src reflect.Value) {
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+
+		switch {
+		case isDynamic(field):
+			dst.Field(i).Set(src.Field(i))
+		case field.Type.Kind() == reflect.Struct:
+			mergeDynamic(dst.Field(i), src.Field(i))
+		}
+	}
+}
+
+func staticChanges(current, next reflect.Value, prefix string, changed *[]string) {
+	for i := 0; i < current.NumField(); i++ {
+		field := current.Type().Field(i)
+		key := prefix + field.Tag.Get("mapstructure")
+
+		switch {
+		case isDynamic(field):
+			continue
+		case field.Type.Kind() == reflect.Struct:
+			staticChanges(current.Field(i), next.Field(i), key+".", changed)
+		case !reflect.DeepEqual(current.Field(i).Interface(), next.Field(i).Interface()):
+			*changed = append(*changed, key)
+		}
+	}
+}
+
+func dynamicKeys(t reflect.Type, prefix string, keys *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := prefix + field.Tag.Get("mapstructure")
+
+		switch {
+		case isDynamic(field):
+			*keys = append(*keys, key)
+		case field.Type.Kind() == reflect.Struct:
+			dynamicKeys(field.Type, key+".", keys)
+		}
+	}
+}
+
+func settings(v reflect.Value) map[string]any {
+	result := make(map[string]any, v.NumField())
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		key := field.Tag.Get("mapstructure")
+
+		if field.Tag.Get("secret") == "true" {
+			result[key] = redact(v.Field(i))
+			continue
+		}
+
+		result[key] = setting(v.Field(i))
+	}
+
+	return result
+}
+
+func setting(v reflect.Value) any {
+	switch {
+	case v.Type() == durationType:
+		return time.Duration(v.Int()).String()
+	case v.Kind() == reflect.Struct:
+		return settings(v)
+	case v.Kind() == reflect.Slice:
+		values := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, setting(v.Index(i)))
+		}
+
+		return values
+	default:
+		return v.Interface()
+	}
+}
+
+// redact keeps whether a secret is set, and how many for lists, but not its value.
+func redact(v reflect.Value) any {
+	switch {
+	case v.Kind() == reflect.Slice:
+		values := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, redact(v.Index(i)))
+		}
+
+		return values
+	case v.IsZero():
+		return ""
+	default:
+		return redacted
+	}
+}
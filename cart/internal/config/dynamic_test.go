@@ -0,0 +1,75 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDynamicKeys(t *testing.T) {
+	want := []string{"log.level", "stock_client.timeout", "rate_limit.enabled", "rate_limit.grpc", "rate_limit.gateway"}
+
+	if got := DynamicKeys(); !slices.Equal(got, want) {
+		t.Errorf("DynamicKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestWithDynamic(t *testing.T) {
+	current := &Configs{}
+	current.Log.Level = "info"
+	current.Postgres.Host = "cart-db"
+
+	next := &Configs{}
+	next.Log.Level = "debug"
+	next.Postgres.Host = "other-db"
+	next.StockClient.Timeout = time.Second
+	next.StockClient.MaxAttempts = 5
+	next.RateLimit.GRPC.RPS = 10
+
+	merged := current.WithDynamic(next)
+
+	if merged.Log.Level != "debug" || merged.StockClient.Timeout != time.Second || merged.RateLimit.GRPC.RPS != 10 {
+		t.Errorf("dynamic settings were not taken over: %+v", merged)
+	}
+	if merged.Postgres.Host != "cart-db" || merged.StockClient.MaxAttempts != 0 {
+		t.Errorf("static settings were taken over: %+v", merged)
+	}
+	if current.Log.Level != "info" {
+		t.Error("WithDynamic() changed the receiver")
+	}
+
+	if got := current.StaticChanges(next); !slices.Equal(got, []string{"postgres.host", "stock_client.max_attempts"}) {
+		t.Errorf("StaticChanges() = %v, want postgres.host and stock_client.max_attempts", got)
+	}
+}
+
+func TestSettingsRedactSecrets(t *testing.T) {
+	cfg := &Configs{}
+	cfg.Postgres.Host = "cart-db"
+	cfg.Postgres.Password = "cart1234"
+	cfg.Audit.AdminAPIKeys = []string{"admin-dev-key", "other-key"}
+	cfg.StockClient.Timeout = 3 * time.Second
+
+	settings := cfg.Settings()
+
+	postgres := settings["postgres"].(map[string]any)
+	if postgres["host"] != "cart-db" || postgres["password"] != redacted {
+		t.Errorf("postgres = %v, want the host and a redacted password", postgres)
+	}
+
+	audit := settings["audit"].(map[string]any)
+	if want := []any{redacted, redacted}; !reflect.DeepEqual(audit["admin_api_keys"], want) {
+		t.Errorf("audit.admin_api_keys = %v, want %v", audit["admin_api_keys"], want)
+	}
+
+	// An unset secret shows as empty, so it is clear that it is missing.
+	payment := settings["payment"].(map[string]any)
+	if payment["webhook_secret"] != "" {
+		t.Errorf("payment.webhook_secret = %v, want empty", payment["webhook_secret"])
+	}
+
+	if got := settings["stock_client"].(map[string]any)["timeout"]; got != "3s" {
+		t.Errorf("stock_client.timeout = %v, want 3s", got)
+	}
+}
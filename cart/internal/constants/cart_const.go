@@ -6,17 +6,54 @@ import (
 )
 
 var (
-	ErrNotFound           = errors.New("not found")
-	ErrNotRowAffected     = errors.New("not row affected")
-	ErrInvalidSKU         = errors.New("invalid sku")
-	ErrInvalidCount       = errors.New("count must be greater than 0")
-	ErrInvalidUserID      = errors.New("userID must be greater than 0")
-	ErrInsufficientStocks = errors.New("insufficient stocks")
-	ErrUnknownType        = errors.New("unknown event type")
+	ErrNotFound             = errors.New("not found")
+	ErrNotRowAffected       = errors.New("not row affected")
+	ErrInvalidSKU           = errors.New("invalid sku")
+	ErrInvalidUserID        = errors.New("userID must be greater than 0")
+	ErrInsufficientStocks   = errors.New("insufficient stocks")
+	ErrUnknownType          = errors.New("unknown event type")
+	ErrIdempotencyReused    = errors.New("idempotency key was already used with a different payload")
+	ErrIdempotencyPending   = errors.New("request with this idempotency key is still in progress")
+	ErrIdempotencyKeyLong   = errors.New("idempotency key is too long")
+	ErrVersionMismatch      = errors.New("cart version does not match expected version")
+	ErrInvalidVersion       = errors.New("invalid cart version")
+	ErrStockUnavailable     = errors.New("stocks service is unavailable")
+	ErrRateLimited          = errors.New("rate limit exceeded")
+	ErrAdminRequired        = errors.New("admin api key required")
+	ErrSellerMismatch       = errors.New("sku is already in the cart from another seller")
+	ErrInvalidPageToken     = errors.New("invalid page token")
+	ErrCartEmpty            = errors.New("cart is empty")
+	ErrCheckoutInProgress   = errors.New("a checkout of the cart is already in progress")
+	ErrPaymentDeclined      = errors.New("payment declined")
+	ErrPaymentState         = errors.New("payment is not in a state that allows this")
+	ErrInvalidPaymentMethod = errors.New("invalid payment method")
+	ErrWebhookSignature     = errors.New("invalid webhook signature")
 )
 
 const (
 	InternalServerErrMessage = "Something went wrong in server!"
 	ServerTimeout            = 5 * time.Second
 	ReadTimeout              = 3 * time.Second
+	IdempotencyKeyHeader     = "idempotency-key"
+	IdempotencyReplayHeader  = "idempotent-replayed"
+	IdempotencyKeyMaxLength  = 255
+	IfMatchHeader            = "if-match"
+	ETagHeader               = "ETag"
+	VersionConflictRetries   = 3
+	CartChangesChannel       = "cart_changes"
+	ListenRetryInterval      = time.Second
+	SSEKeepAliveInterval     = 15 * time.Second
+	APIKeyHeader             = "x-api-key"
+	ForwardedForHeader       = "x-forwarded-for"
+	RetryAfterHeader         = "Retry-After"
+	RateLimitMaxBuckets      = 100_000
+	CheckoutStepTimeout      = 10 * time.Second
+	CheckoutSweepInterval    = 30 * time.Second
+	CheckoutMaxRetries       = 100
+	PaymentActionTimeout     = 15 * time.Minute
+	PaymentSignatureHeader   = "X-Payment-Signature"
+	PaymentWebhookMaxBytes   = 64 << 10
+	ConfigReloadDelay        = 200 * time.Millisecond
+	RequestIDHeader          = "x-request-id"
+	RequestIDMaxLength       = 128
 )
@@ -0,0 +1,287 @@
+package grpcserver
+
+import (
+	"cart/internal/constants"
+	"cart/internal/domainerr"
+	"cart/internal/models"
+	"cart/internal/service"
+	cartapi "cart/pkg/api/cart"
+	"cart/pkg/log"
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Entity types of audit events. A cart is identified by its user id, a checkout by its
+// checkout id.
+const (
+	auditEntityCart     = "cart"
+	auditEntityCheckout = "checkout"
+)
+
+// auditedMethod tells which entity a mutating RPC changes and how to find its id in the
+// request or, after a successful call, the response.
+type auditedMethod struct {
+	entityType string
+	entityID   func(req, resp interface{}) string
+	// changedCarts returns the users whose carts an admin call changed, from its
+	// response. Such calls are recorded once per changed cart, with the admin as actor.
+	changedCarts func(resp interface{}) []int64
+}
+
+var cartAudit = auditedMethod{entityType: auditEntityCart, entityID: cartEntityID}
+
+// auditedMethods lists the mutating RPCs recorded in the audit log.
+var auditedMethods = map[string]auditedMethod{
+	cartapi.CartService_AddItemToCart_FullMethodName:       cartAudit,
+	cartapi.CartService_DeleteItemFromCart_FullMethodName:  cartAudit,
+	cartapi.CartService_ClearCart_FullMethodName:           cartAudit,
+	cartapi.CartService_MoveToSavedForLater_FullMethodName: cartAudit,
+	cartapi.CartService_MoveToCart_FullMethodName:          cartAudit,
+	cartapi.CartService_ValidateCart_FullMethodName:        cartAudit,
+	cartapi.CartService_Checkout_FullMethodName: {
+		entityType: auditEntityCheckout,
+		entityID: func(_, resp interface{}) string {
+			if r, ok := resp.(*cartapi.CheckoutResponse); ok {
+				return strconv.FormatInt(r.GetCheckout().GetId(), 10)
+			}
+
+			return ""
+		},
+	},
+	cartapi.CartService_PurgeExpiredCarts_FullMethodName: {
+		entityType: auditEntityCart,
+		changedCarts: func(resp interface{}) []int64 {
+			r, ok := resp.(*cartapi.PurgeExpiredCartsResponse)
+			if !ok {
+				return nil
+			}
+
+			userIDs := make([]int64, 0, len(r.GetCarts()))
+			for _, cart := range r.GetCarts() {
+				userIDs = append(userIDs, cart.GetUserId())
+			}
+
+			return userIDs
+		},
+	},
+	cartapi.CartService_HandlePaymentWebhook_FullMethodName: {
+		entityType: auditEntityCheckout,
+		entityID: func(_, resp interface{}) string {
+			if r, ok := resp.(*cartapi.HandlePaymentWebhookResponse); ok && r.GetCheckoutId() != 0 {
+				return strconv.FormatInt(r.GetCheckoutId(), 10)
+			}
+
+			return ""
+		},
+	},
+}
+
+func cartEntityID(req, _ interface{}) string {
+	if r, ok := req.(userIDGetter); ok {
+		return strconv.FormatInt(r.GetUserId(), 10)
+	}
+
+	return ""
+}
+
+type dryRunGetter interface {
+	GetDryRun() bool
+}
+
+// autoFixGetter is implemented by ValidateCartRequest, which only changes the cart
+// with auto_fix.
+type autoFixGetter interface {
+	GetAutoFix() bool
+}
+
+// adminMethods lists the RPCs that require an admin API key.
+var adminMethods = map[string]struct{}{
+	cartapi.CartService_ListAuditEvents_FullMethodName:   {},
+	cartapi.CartService_PurgeExpiredCarts_FullMethodName: {},
+}
+
+// grpcAuditInterceptor records every audited call with the cart of its user before and
+// after it. Calls without a user, like payment webhooks, are recorded with actor 0 and
+// no cart. Admin calls changing the carts of other users are recorded per changed cart,
+// see recordChangedCarts. It runs after the idempotency interceptor, so replayed
+// responses are not recorded twice. Dry runs change nothing and are not recorded.
+// Failing to record is logged and does not fail the call.
+func grpcAuditInterceptor(audit service.AuditService, logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method, ok := auditedMethods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		if fix, ok := req.(autoFixGetter); ok && !fix.GetAutoFix() {
+			return handler(ctx, req)
+		}
+
+		if dryRun, ok := req.(dryRunGetter); ok && dryRun.GetDryRun() {
+			return handler(ctx, req)
+		}
+
+		logger := logger.FromContext(ctx)
+
+		requestHash, err := hashRequest(req)
+		if err != nil {
+			logger.Errorf("err in hash audited request: %v", err)
+		}
+
+		if method.changedCarts != nil {
+			resp, handlerErr := handler(ctx, req)
+			recordChangedCarts(ctx, audit, method, info.FullMethod, req, resp, handlerErr, requestHash, logger)
+
+			return resp, handlerErr
+		}
+
+		user, hasUser := req.(userIDGetter)
+
+		var before []byte
+		if hasUser {
+			before, err = audit.Snapshot(ctx, user.GetUserId())
+			if err != nil {
+				logger.Errorf("err in snapshot cart before audited call: %v", err)
+			}
+		}
+
+		resp, handlerErr := handler(ctx, req)
+
+		recordCtx := context.WithoutCancel(ctx)
+
+		event := models.AuditEvent{
+			Method:      info.FullMethod,
+			EntityType:  method.entityType,
+			RequestHash: requestHash,
+			Before:      before,
+			Status:      codeName(status.Convert(handlerErr)),
+			TraceID:     traceIDFromContext(ctx),
+		}
+
+		if handlerErr == nil {
+			event.EntityID = method.entityID(req, resp)
+		} else {
+			event.EntityID = method.entityID(req, nil)
+		}
+
+		if hasUser {
+			event.ActorID = user.GetUserId()
+
+			if handlerErr == nil {
+				event.After, err = audit.Snapshot(recordCtx, user.GetUserId())
+				if err != nil {
+					logger.Errorf("err in snapshot cart after audited call: %v", err)
+				}
+			}
+		}
+
+		if err := audit.Record(recordCtx, event); err != nil {
+			logger.Errorf("err in record audit event: %v", err)
+		}
+
+		return resp, handlerErr
+	}
+}
+
+// recordChangedCarts records an admin call once per cart it changed, with the cart after
+// the call. Which carts change is only known from the response, so there is no state
+// before the call. A failed call is recorded once, without a cart.
+func recordChangedCarts(ctx context.Context, audit service.AuditService, method auditedMethod, fullMethod string, req, resp interface{}, handlerErr error, requestHash string, logger log.Logger) {
+	recordCtx := context.WithoutCancel(ctx)
+
+	event := models.AuditEvent{
+		Method:      fullMethod,
+		EntityType:  method.entityType,
+		RequestHash: requestHash,
+		Status:      codeName(status.Convert(handlerErr)),
+		TraceID:     traceIDFromContext(ctx),
+	}
+
+	if admin, ok := req.(userIDGetter); ok {
+		event.ActorID = admin.GetUserId()
+	}
+
+	if handlerErr != nil {
+		if err := audit.Record(recordCtx, event); err != nil {
+			logger.Errorf("err in record audit event: %v", err)
+		}
+
+		return
+	}
+
+	for _, userID := range method.changedCarts(resp) {
+		cartEvent := event
+		cartEvent.EntityID = strconv.FormatInt(userID, 10)
+
+		var err error
+
+		cartEvent.After, err = audit.Snapshot(recordCtx, userID)
+		if err != nil {
+			logger.Errorf("err in snapshot cart after audited call: %v", err)
+		}
+
+		if err := audit.Record(recordCtx, cartEvent); err != nil {
+			logger.Errorf("err in record audit event: %v", err)
+		}
+	}
+}
+
+// grpcAdminInterceptor rejects admin RPCs unless x-api-key is one of adminKeys. With no
+// keys configured admin RPCs are disabled.
+func grpcAdminInterceptor(adminKeys []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, ok := adminMethods[info.FullMethod]; !ok {
+			return handler(ctx, req)
+		}
+
+		if !isAdminKey(ctx, adminKeys) {
+			return nil, toStatusError(constants.ErrAdminRequired)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func isAdminKey(ctx context.Context, adminKeys []string) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get(constants.APIKeyHeader)
+	if len(values) == 0 {
+		return false
+	}
+
+	return matchesAdminKey(values[0], adminKeys)
+}
+
+func matchesAdminKey(value string, adminKeys []string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, key := range adminKeys {
+		if subtle.ConstantTimeCompare([]byte(value), []byte(key)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AdminOnly guards an HTTP endpoint like admin RPCs: the X-Api-Key header has to be one
+// of adminKeys, and with no keys configured the endpoint is disabled.
+func AdminOnly(next http.Handler, adminKeys []string, logger log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !matchesAdminKey(r.Header.Get(constants.APIKeyHeader), adminKeys) {
+			writeProblem(w, domainProblem(domainerr.From(constants.ErrAdminRequired), r.URL.Path), logger)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
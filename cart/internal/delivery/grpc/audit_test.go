@@ -0,0 +1,60 @@
+package grpcserver
+
+import (
+	"cart/internal/models"
+	"cart/internal/service"
+	cartapi "cart/pkg/api/cart"
+	"cart/pkg/log/zap"
+	"context"
+	"testing"
+
+	uzap "go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// recordingAudit keeps the recorded events and snapshots every cart as its user id.
+type recordingAudit struct {
+	service.AuditService
+	events []models.AuditEvent
+}
+
+func (a *recordingAudit) Snapshot(_ context.Context, userID int64) ([]byte, error) {
+	return []byte{byte(userID)}, nil
+}
+
+func (a *recordingAudit) Record(_ context.Context, event models.AuditEvent) error {
+	a.events = append(a.events, event)
+	return nil
+}
+
+func TestAuditPurgeExpiredCarts(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: cartapi.CartService_PurgeExpiredCarts_FullMethodName}
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return &cartapi.PurgeExpiredCartsResponse{Carts: []*cartapi.ExpiredCart{{UserId: 7}, {UserId: 9}}}, nil
+	}
+
+	audit := &recordingAudit{}
+	interceptor := grpcAuditInterceptor(audit, &zap.Logger{L: uzap.NewNop()})
+
+	if _, err := interceptor(context.Background(), &cartapi.PurgeExpiredCartsRequest{UserId: 1, DryRun: true}, info, handler); err != nil {
+		t.Fatalf("dry run error = %v", err)
+	}
+	if len(audit.events) != 0 {
+		t.Fatalf("dry run recorded %d events, want none", len(audit.events))
+	}
+
+	if _, err := interceptor(context.Background(), &cartapi.PurgeExpiredCartsRequest{UserId: 1}, info, handler); err != nil {
+		t.Fatalf("purge error = %v", err)
+	}
+
+	if len(audit.events) != 2 {
+		t.Fatalf("recorded %d events, want one per purged cart", len(audit.events))
+	}
+
+	for i, wantCart := range []string{"7", "9"} {
+		event := audit.events[i]
+		if event.ActorID != 1 || event.EntityType != auditEntityCart || event.EntityID != wantCart || event.Before != nil || len(event.After) == 0 {
+			t.Errorf("event %d = %+v, want cart %s purged by admin 1 with its state after", i, event, wantCart)
+		}
+	}
+}
@@ -1,13 +1,18 @@
 package grpcserver
 
 import (
+	"cart/internal/constants"
 	cartapi "cart/pkg/api/cart"
 	"cart/pkg/log"
 	"cart/pkg/metrics"
+	"cart/pkg/ratelimit"
 	"context"
+	"net"
 	"net/http"
+	"net/textproto"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -17,6 +22,8 @@ import (
 
 type Server struct {
 	server  *http.Server
+	conn    *grpc.ClientConn
+	cancel  context.CancelFunc
 	metrics metrics.Metrics
 }
 
@@ -25,23 +32,46 @@ type Gateway interface {
 	Shutdown(ctx context.Context) error
 }
 
-func NewGateway(ctx context.Context, grpcPort, gatewayPort string, logger log.Logger, m metrics.Metrics) (Gateway, error) {
+func NewGateway(ctx context.Context, grpcPort, gatewayPort string, limiter *ratelimit.Limiter, callers *Callers, logger log.Logger, m metrics.Metrics) (Gateway, error) {
 	mux := runtime.NewServeMux(
 		runtime.WithErrorHandler(ErrorMiddleware(logger, m)),
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithForwardResponseOption(forwardETag),
 	)
 
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	}
 
-	err := cartapi.RegisterCartServiceHandlerFromEndpoint(ctx, mux, "localhost:"+grpcPort, opts)
+	conn, err := grpc.NewClient("localhost:"+grpcPort, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	metricsWrapped := MetricsMiddleware(mux, m)
+	err = cartapi.RegisterCartServiceHandler(ctx, mux, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = mux.HandlePath(http.MethodGet, "/cart/watch", watchCartSSE(cartapi.NewCartServiceClient(conn), logger))
+	if err != nil {
+		return nil, err
+	}
+
+	err = mux.HandlePath(http.MethodPost, "/payments/webhook", paymentWebhook(cartapi.NewCartServiceClient(conn), logger))
+	if err != nil {
+		return nil, err
+	}
+
+	var handler http.Handler = mux
+	if limiter != nil {
+		handler = RateLimitMiddleware(handler, limiter, callers, m, logger)
+	}
+
+	metricsWrapped := MetricsMiddleware(handler, m)
 	otelHandler := otelhttp.NewHandler(
-		metricsWrapped,
+		RequestIDMiddleware(metricsWrapped),
 		"cart-grpc-gateway",
 		otelhttp.WithMessageEvents(otelhttp.ReadEvents, otelhttp.WriteEvents),
 		otelhttp.WithSpanOptions(
@@ -49,19 +79,48 @@ func NewGateway(ctx context.Context, grpcPort, gatewayPort string, logger log.Lo
 		),
 	)
 
+	// Long-lived SSE streams are bound to baseCtx so shutdown can end them.
+	baseCtx, cancel := context.WithCancel(context.Background())
+
 	return &Server{
 		server: &http.Server{
-			Addr:    ":" + gatewayPort,
-			Handler: otelHandler,
+			Addr:        ":" + gatewayPort,
+			Handler:     otelHandler,
+			BaseContext: func(net.Listener) context.Context { return baseCtx },
 		},
+		conn:    conn,
+		cancel:  cancel,
 		metrics: m,
 	}, nil
 }
 
+// incomingHeaderMatcher forwards the Idempotency-Key, If-Match, X-Api-Key and X-Request-Id headers to the gRPC
+// server in addition to the headers accepted by runtime.DefaultHeaderMatcher.
+func incomingHeaderMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case textproto.CanonicalMIMEHeaderKey(constants.IdempotencyKeyHeader):
+		return constants.IdempotencyKeyHeader, true
+	case textproto.CanonicalMIMEHeaderKey(constants.IfMatchHeader):
+		return constants.IfMatchHeader, true
+	case textproto.CanonicalMIMEHeaderKey(constants.APIKeyHeader):
+		return constants.APIKeyHeader, true
+	case textproto.CanonicalMIMEHeaderKey(constants.RequestIDHeader):
+		return constants.RequestIDHeader, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
 func (g *Server) Run() error {
 	return g.server.ListenAndServe()
 }
 
 func (g *Server) Shutdown(ctx context.Context) error {
-	return g.server.Shutdown(ctx)
+	g.cancel()
+
+	if err := g.server.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	return g.conn.Close()
 }
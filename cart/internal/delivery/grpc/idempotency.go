@@ -0,0 +1,182 @@
+package grpcserver
+
+import (
+	"cart/internal/constants"
+	"cart/internal/repository/interfaces"
+	cartapi "cart/pkg/api/cart"
+	"cart/pkg/log"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// idempotentMethods lists the mutating RPCs that honour the Idempotency-Key metadata.
+var idempotentMethods = map[string]struct{}{
+	cartapi.CartService_AddItemToCart_FullMethodName:       {},
+	cartapi.CartService_DeleteItemFromCart_FullMethodName:  {},
+	cartapi.CartService_ClearCart_FullMethodName:           {},
+	cartapi.CartService_MoveToSavedForLater_FullMethodName: {},
+	cartapi.CartService_MoveToCart_FullMethodName:          {},
+	cartapi.CartService_ValidateCart_FullMethodName:        {},
+	cartapi.CartService_Checkout_FullMethodName:            {},
+}
+
+func grpcIdempotencyInterceptor(repo interfaces.IdempotencyRepository, ttl time.Duration, logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, ok := idempotentMethods[info.FullMethod]; !ok {
+			return handler(ctx, req)
+		}
+
+		key := idempotencyKeyFromContext(ctx)
+		if key == "" {
+			return handler(ctx, req)
+		}
+
+		if len(key) > constants.IdempotencyKeyMaxLength {
+			return nil, toStatusError(constants.ErrIdempotencyKeyLong)
+		}
+
+		logger := logger.FromContext(ctx)
+
+		requestHash, err := hashRequest(req)
+		if err != nil {
+			logger.Errorf("err in hash idempotent request: %v", err)
+			return nil, toStatusError(err)
+		}
+
+		// Keys are chosen by clients, so they are kept apart per user.
+		var userID int64
+		if r, ok := req.(userIDGetter); ok {
+			userID = r.GetUserId()
+		}
+
+		record, acquired, err := repo.Acquire(ctx, userID, key, info.FullMethod, requestHash, ttl)
+		if err != nil {
+			logger.Errorf("err in acquire idempotency key: %v", err)
+			return nil, toStatusError(err)
+		}
+
+		if !acquired {
+			return replayResponse(ctx, record.RequestHash, requestHash, record.Response, logger)
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			// Failed calls are not cached so the client can retry with the same key.
+			if relErr := repo.Release(context.WithoutCancel(ctx), userID, key, info.FullMethod); relErr != nil {
+				logger.Errorf("err in release idempotency key: %v", relErr)
+			}
+
+			return resp, err
+		}
+
+		data, err := marshalResponse(resp)
+		if err != nil {
+			logger.Errorf("err in marshal idempotent response: %v", err)
+			return resp, nil
+		}
+
+		if err := repo.Complete(context.WithoutCancel(ctx), userID, key, info.FullMethod, data); err != nil {
+			logger.Errorf("err in store idempotent response: %v", err)
+		}
+
+		return resp, nil
+	}
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(constants.IdempotencyKeyHeader)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// hashRequest returns the SHA-256 of the request stored with idempotency keys and audit
+// events. Card details are left out, see redactRequest.
+func hashRequest(req interface{}) (string, error) {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return "", fmt.Errorf("request %T is not a proto message", req)
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(redactRequest(msg))
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// redactRequest returns msg without the fields that must not be derivable from a stored
+// hash. Card numbers have so little entropy that an unsalted hash of them can be brute
+// forced, so the payment method of a checkout is left out. A retry with another card
+// under the same key then counts as the same checkout.
+func redactRequest(msg proto.Message) proto.Message {
+	req, ok := msg.(*cartapi.CheckoutRequest)
+	if !ok {
+		return msg
+	}
+
+	redacted := proto.Clone(req).(*cartapi.CheckoutRequest)
+	redacted.PaymentMethod = ""
+
+	return redacted
+}
+
+func marshalResponse(resp interface{}) ([]byte, error) {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("response %T is not a proto message", resp)
+	}
+
+	wrapped, err := anypb.New(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(wrapped)
+}
+
+func replayResponse(ctx context.Context, storedHash, requestHash string, data []byte, logger log.Logger) (interface{}, error) {
+	if storedHash != requestHash {
+		return nil, toStatusError(constants.ErrIdempotencyReused)
+	}
+
+	if data == nil {
+		return nil, toStatusError(constants.ErrIdempotencyPending)
+	}
+
+	var wrapped anypb.Any
+	if err := proto.Unmarshal(data, &wrapped); err != nil {
+		logger.Errorf("err in unmarshal idempotent response: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	resp, err := wrapped.UnmarshalNew()
+	if err != nil {
+		logger.Errorf("err in unmarshal idempotent response: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(constants.IdempotencyReplayHeader, "true")); err != nil {
+		logger.Errorf("err in set idempotency replay header: %v", err)
+	}
+
+	return resp, nil
+}
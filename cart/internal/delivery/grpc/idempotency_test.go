@@ -0,0 +1,116 @@
+package grpcserver
+
+import (
+	"cart/internal/models"
+	"cart/internal/repository/interfaces"
+	cartapi "cart/pkg/api/cart"
+	"cart/pkg/log/zap"
+	"context"
+	"testing"
+	"time"
+
+	uzap "go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+type idempotencyKey struct {
+	userID int64
+	key    string
+	method string
+}
+
+// memoryIdempotency keeps idempotency records in memory, keyed like the table.
+type memoryIdempotency struct {
+	interfaces.IdempotencyRepository
+	records map[idempotencyKey]models.IdempotencyRecord
+}
+
+func (m *memoryIdempotency) Acquire(_ context.Context, userID int64, key, method, requestHash string, _ time.Duration) (models.IdempotencyRecord, bool, error) {
+	k := idempotencyKey{userID: userID, key: key, method: method}
+	if record, ok := m.records[k]; ok {
+		return record, false, nil
+	}
+
+	m.records[k] = models.IdempotencyRecord{UserID: userID, Key: key, Method: method, RequestHash: requestHash}
+
+	return models.IdempotencyRecord{}, true, nil
+}
+
+func (m *memoryIdempotency) Complete(_ context.Context, userID int64, key, method string, response []byte) error {
+	k := idempotencyKey{userID: userID, key: key, method: method}
+
+	record := m.records[k]
+	record.Response = response
+	m.records[k] = record
+
+	return nil
+}
+
+func (m *memoryIdempotency) Release(_ context.Context, userID int64, key, method string) error {
+	delete(m.records, idempotencyKey{userID: userID, key: key, method: method})
+	return nil
+}
+
+func TestIdempotencyKeysArePerUser(t *testing.T) {
+	repo := &memoryIdempotency{records: make(map[idempotencyKey]models.IdempotencyRecord)}
+	interceptor := grpcIdempotencyInterceptor(repo, time.Hour, &zap.Logger{L: uzap.NewNop()})
+
+	info := &grpc.UnaryServerInfo{FullMethod: cartapi.CartService_ClearCart_FullMethodName}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("idempotency-key", "retry-1"))
+
+	calls := 0
+	handler := func(_ context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return &cartapi.ClearCartResponse{}, nil
+	}
+
+	// Same key, same body shape: each user's call runs once, and user 2 neither gets
+	// "key reused" nor the response of user 1.
+	for _, req := range []*cartapi.ClearCartRequest{{UserId: 1}, {UserId: 2}, {UserId: 1}} {
+		if _, err := interceptor(ctx, req, info, handler); err != nil {
+			t.Fatalf("ClearCart(user %d) error = %v", req.UserId, err)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("handler ran %d times, want once per user", calls)
+	}
+	if len(repo.records) != 2 {
+		t.Errorf("stored %d records, want one per user", len(repo.records))
+	}
+
+	// A different body of user 2 under its own key is rejected, the key of user 1 is
+	// not involved.
+	_, err := interceptor(ctx, &cartapi.ClearCartRequest{UserId: 2, ExpectedVersion: proto.Uint64(3)}, info, handler)
+	if err == nil {
+		t.Error("reused key of user 2 with a new body was accepted")
+	}
+}
+
+func TestHashRequestLeavesOutCardDetails(t *testing.T) {
+	hash := func(req *cartapi.CheckoutRequest) string {
+		t.Helper()
+
+		h, err := hashRequest(req)
+		if err != nil {
+			t.Fatalf("hashRequest() error = %v", err)
+		}
+
+		return h
+	}
+
+	visa := &cartapi.CheckoutRequest{UserId: 1, PaymentMethod: "4242424242424242 12/30 123"}
+	other := &cartapi.CheckoutRequest{UserId: 1, PaymentMethod: "4000000000000002 12/30 123"}
+
+	if hash(visa) != hash(other) {
+		t.Error("hash changes with the card number, the stored hash would reveal it")
+	}
+	if hash(visa) == hash(&cartapi.CheckoutRequest{UserId: 2, PaymentMethod: visa.PaymentMethod}) {
+		t.Error("hash does not change with the user")
+	}
+	if visa.PaymentMethod == "" {
+		t.Error("hashRequest() cleared the payment method of the request itself")
+	}
+}
@@ -1,23 +1,64 @@
 package grpcserver
 
 import (
+	"cart/internal/constants"
 	"cart/internal/models"
 	cartapi "cart/pkg/api/cart"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func ToAddItemCartModel(req *cartapi.AddItemToCartRequest) models.CartItem {
+func ToAddItemCartModel(req *cartapi.AddItemToCartRequest, expectedVersion *uint64) models.CartItem {
 	return models.CartItem{
-		UserID: req.UserId,
-		SKU:    req.Sku,
-		Count:  req.Count,
+		UserID:          req.UserId,
+		SKU:             req.Sku,
+		SellerID:        req.SellerId,
+		Count:           req.Count,
+		ExpectedVersion: expectedVersion,
 	}
 }
 
-func ToDeleteCartItemModel(req *cartapi.DeleteItemFromCartRequest) models.DeleteCartItem {
+func ToDeleteCartItemModel(req *cartapi.DeleteItemFromCartRequest, expectedVersion *uint64) models.DeleteCartItem {
 	return models.DeleteCartItem{
-		UserID: req.UserId,
-		SKU:    req.Sku,
+		UserID:          req.UserId,
+		SKU:             req.Sku,
+		ExpectedVersion: expectedVersion,
+	}
+}
+
+func ToClearCartModel(req *cartapi.ClearCartRequest, expectedVersion *uint64) models.ClearCart {
+	return models.ClearCart{
+		UserID:          req.UserId,
+		ExpectedVersion: expectedVersion,
+	}
+}
+
+func ToPurgeExpiredCartsModel(req *cartapi.PurgeExpiredCartsRequest) models.PurgeExpiredCarts {
+	return models.PurgeExpiredCarts{
+		UserID:    req.UserId,
+		OlderThan: time.Duration(req.OlderThanSeconds) * time.Second,
+		Limit:     int(req.Limit),
+		DryRun:    req.DryRun,
+	}
+}
+
+func ToPurgeExpiredCartsResponse(carts []models.ExpiredCart) *cartapi.PurgeExpiredCartsResponse {
+	resp := &cartapi.PurgeExpiredCartsResponse{Carts: make([]*cartapi.ExpiredCart, 0, len(carts))}
+
+	for _, cart := range carts {
+		resp.Carts = append(resp.Carts, &cartapi.ExpiredCart{
+			UserId:       cart.UserID,
+			Lines:        cart.Lines,
+			Units:        cart.Units,
+			LastActivity: timestamppb.New(cart.LastActivity),
+		})
 	}
+
+	return resp
 }
 
 func ToCartListResponse(domain models.CartItemsList) *cartapi.CartListResponse {
@@ -25,15 +66,220 @@ func ToCartListResponse(domain models.CartItemsList) *cartapi.CartListResponse {
 
 	for _, item := range domain.Items {
 		items = append(items, &cartapi.StockItem{
-			Sku:   item.SKU,
-			Count: item.Count,
-			Name:  item.Name,
-			Price: item.Price,
+			Sku:          item.SKU,
+			SellerId:     item.SellerID,
+			Count:        item.Count,
+			Name:         item.Name,
+			Price:        item.Price,
+			PriceUnknown: item.PriceUnknown,
+			Unavailable:  item.Unavailable,
 		})
 	}
 
 	return &cartapi.CartListResponse{
 		Items:      items,
 		TotalPrice: domain.TotalPrice,
+		Version:    domain.Version,
+		Degraded:   domain.Degraded,
+	}
+}
+
+func ToMoveToSavedModel(req *cartapi.MoveToSavedForLaterRequest, expectedVersion *uint64) models.MoveSavedItem {
+	return models.MoveSavedItem{
+		UserID:          req.UserId,
+		SKU:             req.Sku,
+		ExpectedVersion: expectedVersion,
+	}
+}
+
+func ToMoveToCartModel(req *cartapi.MoveToCartRequest, expectedVersion *uint64) models.MoveSavedItem {
+	return models.MoveSavedItem{
+		UserID:          req.UserId,
+		SKU:             req.Sku,
+		ExpectedVersion: expectedVersion,
+	}
+}
+
+func ToListSavedResponse(domain models.SavedItemsList) *cartapi.ListSavedResponse {
+	items := make([]*cartapi.SavedItem, 0, len(domain.Items))
+
+	for _, item := range domain.Items {
+		items = append(items, &cartapi.SavedItem{
+			Sku:            item.SKU,
+			SellerId:       item.SellerID,
+			Name:           item.Name,
+			Count:          item.Count,
+			Price:          item.Price,
+			AvailableCount: item.AvailableCount,
+			InStock:        item.InStock,
+			BackInStock:    item.BackInStock,
+		})
+	}
+
+	return &cartapi.ListSavedResponse{
+		Items: items,
+	}
+}
+
+var cartLineStatuses = map[models.CartLineStatus]cartapi.CartLineStatus{
+	models.CartLineOK:           cartapi.CartLineStatus_CART_LINE_STATUS_OK,
+	models.CartLineOutOfStock:   cartapi.CartLineStatus_CART_LINE_STATUS_OUT_OF_STOCK,
+	models.CartLineInsufficient: cartapi.CartLineStatus_CART_LINE_STATUS_INSUFFICIENT,
+	models.CartLineSKURemoved:   cartapi.CartLineStatus_CART_LINE_STATUS_SKU_REMOVED,
+	models.CartLinePriceChanged: cartapi.CartLineStatus_CART_LINE_STATUS_PRICE_CHANGED,
+}
+
+var cartLineFixes = map[models.CartLineFix]cartapi.CartLineFix{
+	models.CartLineRemoved:  cartapi.CartLineFix_CART_LINE_FIX_REMOVED,
+	models.CartLineClamped:  cartapi.CartLineFix_CART_LINE_FIX_CLAMPED,
+	models.CartLineRepriced: cartapi.CartLineFix_CART_LINE_FIX_REPRICED,
+}
+
+func ToValidateCartModel(req *cartapi.ValidateCartRequest, expectedVersion *uint64) models.ValidateCart {
+	return models.ValidateCart{
+		UserID:          req.UserId,
+		AutoFix:         req.AutoFix,
+		ExpectedVersion: expectedVersion,
+	}
+}
+
+func ToValidateCartResponse(domain models.CartValidation) *cartapi.ValidateCartResponse {
+	lines := make([]*cartapi.CartLineValidation, 0, len(domain.Lines))
+
+	for _, line := range domain.Lines {
+		lines = append(lines, &cartapi.CartLineValidation{
+			Sku:            line.SKU,
+			SellerId:       line.SellerID,
+			Count:          line.Count,
+			Status:         cartLineStatuses[line.Status],
+			AvailableCount: line.AvailableCount,
+			Price:          line.Price,
+			PreviousPrice:  line.PreviousPrice,
+			Fix:            cartLineFixes[line.Fix],
+		})
 	}
+
+	return &cartapi.ValidateCartResponse{
+		Lines:   lines,
+		Valid:   domain.Valid,
+		Version: domain.Version,
+	}
+}
+
+var checkoutStatuses = map[models.CheckoutStatus]cartapi.CheckoutStatus{
+	models.CheckoutRunning:      cartapi.CheckoutStatus_CHECKOUT_STATUS_RUNNING,
+	models.CheckoutCompensating: cartapi.CheckoutStatus_CHECKOUT_STATUS_COMPENSATING,
+	models.CheckoutCompleted:    cartapi.CheckoutStatus_CHECKOUT_STATUS_COMPLETED,
+	models.CheckoutFailed:       cartapi.CheckoutStatus_CHECKOUT_STATUS_FAILED,
+	models.CheckoutStuck:        cartapi.CheckoutStatus_CHECKOUT_STATUS_STUCK,
+}
+
+var checkoutSteps = map[models.CheckoutStep]cartapi.CheckoutStep{
+	models.CheckoutStepReserveStock:     cartapi.CheckoutStep_CHECKOUT_STEP_RESERVE_STOCK,
+	models.CheckoutStepAuthorizePayment: cartapi.CheckoutStep_CHECKOUT_STEP_AUTHORIZE_PAYMENT,
+	models.CheckoutStepCommitStock:      cartapi.CheckoutStep_CHECKOUT_STEP_COMMIT_STOCK,
+	models.CheckoutStepCapturePayment:   cartapi.CheckoutStep_CHECKOUT_STEP_CAPTURE_PAYMENT,
+	models.CheckoutStepClearCart:        cartapi.CheckoutStep_CHECKOUT_STEP_CLEAR_CART,
+	models.CheckoutStepVoidPayment:      cartapi.CheckoutStep_CHECKOUT_STEP_VOID_PAYMENT,
+	models.CheckoutStepReleaseStock:     cartapi.CheckoutStep_CHECKOUT_STEP_RELEASE_STOCK,
+}
+
+func ToCheckoutResponse(domain models.Checkout) *cartapi.Checkout {
+	lines := make([]*cartapi.CheckoutLine, 0, len(domain.Lines))
+
+	for _, line := range domain.Lines {
+		lines = append(lines, &cartapi.CheckoutLine{
+			Sku:      line.SKU,
+			SellerId: line.SellerID,
+			Count:    line.Count,
+			Price:    line.Price,
+		})
+	}
+
+	return &cartapi.Checkout{
+		Id:               domain.ID,
+		UserId:           domain.UserID,
+		Status:           checkoutStatuses[domain.Status],
+		Step:             checkoutSteps[domain.Step],
+		Lines:            lines,
+		TotalPrice:       domain.TotalPrice,
+		PaymentId:        domain.PaymentID,
+		LastError:        domain.LastError,
+		CreatedAt:        timestamppb.New(domain.CreatedAt),
+		UpdatedAt:        timestamppb.New(domain.UpdatedAt),
+		PaymentActionUrl: domain.PaymentActionURL,
+		CardBrand:        domain.PaymentCard.Brand,
+		CardLast4:        domain.PaymentCard.Last4,
+	}
+}
+
+func ToAuditFilter(req *cartapi.ListAuditEventsRequest) (models.AuditFilter, error) {
+	filter := models.AuditFilter{
+		ActorID:    req.ActorId,
+		EntityType: req.EntityType,
+		EntityID:   req.EntityId,
+		Limit:      int(req.PageSize),
+	}
+
+	if req.From != nil {
+		from := req.From.AsTime()
+		filter.From = &from
+	}
+
+	if req.To != nil {
+		to := req.To.AsTime()
+		filter.To = &to
+	}
+
+	if req.PageToken != "" {
+		beforeID, err := strconv.ParseInt(req.PageToken, 10, 64)
+		if err != nil || beforeID <= 0 {
+			return models.AuditFilter{}, constants.ErrInvalidPageToken
+		}
+
+		filter.BeforeID = beforeID
+	}
+
+	return filter, nil
+}
+
+func ToListAuditEventsResponse(page models.AuditEventsPage) *cartapi.ListAuditEventsResponse {
+	events := make([]*cartapi.AuditEvent, 0, len(page.Events))
+
+	for _, event := range page.Events {
+		events = append(events, &cartapi.AuditEvent{
+			Id:          event.ID,
+			ActorId:     event.ActorID,
+			Method:      event.Method,
+			EntityType:  event.EntityType,
+			EntityId:    event.EntityID,
+			RequestHash: event.RequestHash,
+			Before:      toStruct(event.Before),
+			After:       toStruct(event.After),
+			Status:      event.Status,
+			TraceId:     event.TraceID,
+			CreatedAt:   timestamppb.New(event.CreatedAt),
+		})
+	}
+
+	resp := &cartapi.ListAuditEventsResponse{Events: events}
+	if page.NextCursor > 0 {
+		resp.NextPageToken = strconv.FormatInt(page.NextCursor, 10)
+	}
+
+	return resp
+}
+
+// toStruct decodes a JSON snapshot, returning nil when there is none.
+func toStruct(data []byte) *structpb.Struct {
+	if len(data) == 0 {
+		return nil
+	}
+
+	s := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, s); err != nil {
+		return nil
+	}
+
+	return s
 }
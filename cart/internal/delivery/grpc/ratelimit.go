@@ -0,0 +1,247 @@
+package grpcserver
+
+import (
+	"cart/internal/constants"
+	"cart/internal/domainerr"
+	"cart/pkg/log"
+	"cart/pkg/metrics"
+	"cart/pkg/ratelimit"
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	keyTypeAPIKey = "api_key"
+	keyTypeUser   = "user"
+	keyTypeIP     = "ip"
+)
+
+type userIDGetter interface {
+	GetUserId() int64
+}
+
+// Callers tells who makes a request, for rate limiting. Only an admin API key is taken
+// as the caller, any other key could be made up per request. The client address is the
+// peer, or the address a trusted proxy forwarded. The gateway of the service always
+// counts as a trusted proxy.
+type Callers struct {
+	adminKeys      []string
+	trustedProxies []*net.IPNet
+}
+
+type caller struct {
+	keyType string
+	key     string
+}
+
+// NewCallers creates Callers trusting the proxies given as IP addresses or CIDR ranges.
+func NewCallers(adminKeys, trustedProxies []string) (*Callers, error) {
+	c := &Callers{adminKeys: adminKeys}
+
+	for _, proxy := range trustedProxies {
+		network, err := parseProxy(proxy)
+		if err != nil {
+			return nil, err
+		}
+
+		c.trustedProxies = append(c.trustedProxies, network)
+	}
+
+	return c, nil
+}
+
+// parseProxy parses a trusted proxy given as an IP address or a CIDR range.
+func parseProxy(proxy string) (*net.IPNet, error) {
+	if ip := net.ParseIP(proxy); ip != nil {
+		bits := 8 * len(ip.To16())
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, network, err := net.ParseCIDR(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxy %q: not an IP address or CIDR range", proxy)
+	}
+
+	return network, nil
+}
+
+// grpcRateLimitInterceptor throttles calls per method and caller. A caller with an admin
+// API key has a bucket of its own. Everyone else is limited per client address, and
+// per user id as well when the request has one.
+func grpcRateLimitInterceptor(limiter *ratelimit.Limiter, callers *Callers, m metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if denied, retryAfter, ok := allowCallers(limiter, info.FullMethod, callers.grpcCallers(ctx, req)); !ok {
+			m.IncRateLimited(info.FullMethod, denied.keyType)
+			return nil, rateLimitedError(ctx, retryAfter)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// grpcStreamRateLimitInterceptor throttles opening streams. The request is not read yet,
+// so the caller is the admin API key or the client address.
+func grpcStreamRateLimitInterceptor(limiter *ratelimit.Limiter, callers *Callers, m metrics.Metrics) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if denied, retryAfter, ok := allowCallers(limiter, info.FullMethod, callers.grpcCallers(ss.Context(), nil)); !ok {
+			m.IncRateLimited(info.FullMethod, denied.keyType)
+			return rateLimitedError(ss.Context(), retryAfter)
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// allowCallers takes a token from the bucket of every caller, or from none when one of
+// them is empty, so a throttled user does not drain the bucket of its client address.
+// It returns the caller whose bucket was empty.
+func allowCallers(limiter *ratelimit.Limiter, method string, callers []caller) (caller, time.Duration, bool) {
+	keys := make([]string, 0, len(callers))
+	for _, c := range callers {
+		keys = append(keys, c.keyType+":"+c.key)
+	}
+
+	ok, denied, retryAfter := limiter.AllowAll(method, keys...)
+	if ok {
+		return caller{}, 0, true
+	}
+
+	return callers[denied], retryAfter, false
+}
+
+func (c *Callers) grpcCallers(ctx context.Context, req interface{}) []caller {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if values := md.Get(constants.APIKeyHeader); len(values) > 0 && matchesAdminKey(values[0], c.adminKeys) {
+		return []caller{{keyType: keyTypeAPIKey, key: values[0]}}
+	}
+
+	var remote string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remote = p.Addr.String()
+	}
+
+	// The gateway passes the HTTP client address on in x-forwarded-for.
+	callers := []caller{{keyType: keyTypeIP, key: c.clientIP(remote, md.Get(constants.ForwardedForHeader))}}
+
+	if r, ok := req.(userIDGetter); ok && r.GetUserId() > 0 {
+		callers = append(callers, caller{keyType: keyTypeUser, key: strconv.FormatInt(r.GetUserId(), 10)})
+	}
+
+	return callers
+}
+
+// clientIP walks the forwarded addresses from the nearest hop back and returns the
+// first one not sent by a trusted proxy. Hops further back could be made up by the
+// client.
+func (c *Callers) clientIP(remote string, forwardedFor []string) string {
+	ip := hostOnly(remote)
+	if !c.isTrusted(ip) {
+		return ip
+	}
+
+	var hops []string
+	for _, value := range forwardedFor {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip = hops[i]
+		if !c.isTrusted(ip) {
+			return ip
+		}
+	}
+
+	return ip
+}
+
+func (c *Callers) isTrusted(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	if ip.IsLoopback() {
+		return true
+	}
+
+	for _, network := range c.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rateLimitedError returns RESOURCE_EXHAUSTED with ErrorInfo and RetryInfo details, and sets the
+// retry-after header for plain gRPC clients.
+func rateLimitedError(ctx context.Context, retryAfter time.Duration) error {
+	_ = grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(constants.RetryAfterHeader), retryAfterSeconds(retryAfter)))
+
+	st := domainStatus(domainerr.Wrap(domainerr.KindResourceExhausted, domainerr.ReasonRateLimited, constants.ErrRateLimited))
+	if withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
+
+// RateLimitMiddleware throttles gateway requests per path and client, keyed by an admin
+// X-Api-Key or the client address.
+func RateLimitMiddleware(next http.Handler, limiter *ratelimit.Limiter, callers *Callers, m metrics.Metrics, logger log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if denied, retryAfter, ok := allowCallers(limiter, r.URL.Path, []caller{callers.httpCaller(r)}); !ok {
+			m.IncRateLimited(r.URL.Path, denied.keyType)
+
+			w.Header().Set(constants.RetryAfterHeader, retryAfterSeconds(retryAfter))
+			writeProblem(w, domainProblem(domainerr.Wrap(domainerr.KindResourceExhausted, domainerr.ReasonRateLimited, constants.ErrRateLimited), r.URL.Path), logger)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (c *Callers) httpCaller(r *http.Request) caller {
+	if key := r.Header.Get(constants.APIKeyHeader); matchesAdminKey(key, c.adminKeys) {
+		return caller{keyType: keyTypeAPIKey, key: key}
+	}
+
+	return caller{keyType: keyTypeIP, key: c.clientIP(r.RemoteAddr, r.Header.Values(constants.ForwardedForHeader))}
+}
+
+func hostOnly(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	return host
+}
+
+// retryAfterSeconds rounds up, Retry-After only carries whole seconds.
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Max(1, math.Ceil(d.Seconds()))))
+}
@@ -0,0 +1,218 @@
+package grpcserver
+
+import (
+	cartapi "cart/pkg/api/cart"
+	"cart/pkg/metrics"
+	"cart/pkg/ratelimit"
+	"context"
+	"net"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestNewCallers(t *testing.T) {
+	if _, err := NewCallers(nil, []string{"10.0.0.1", "10.1.0.0/16", "fd00::/8"}); err != nil {
+		t.Errorf("NewCallers() error = %v", err)
+	}
+
+	if _, err := NewCallers(nil, []string{"proxy.local"}); err == nil {
+		t.Error("NewCallers() accepted a host name as trusted proxy")
+	}
+}
+
+func TestHTTPCaller(t *testing.T) {
+	callers, err := NewCallers([]string{"admin-key"}, []string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		apiKey       string
+		forwardedFor []string
+		want         caller
+	}{
+		{
+			name:       "admin key",
+			remoteAddr: "203.0.113.7:5000",
+			apiKey:     "admin-key",
+			want:       caller{keyType: keyTypeAPIKey, key: "admin-key"},
+		},
+		{
+			name:       "unknown key is ignored",
+			remoteAddr: "203.0.113.7:5000",
+			apiKey:     "made-up",
+			want:       caller{keyType: keyTypeIP, key: "203.0.113.7"},
+		},
+		{
+			name:         "forwarded for from an untrusted peer is ignored",
+			remoteAddr:   "203.0.113.7:5000",
+			forwardedFor: []string{"198.51.100.1"},
+			want:         caller{keyType: keyTypeIP, key: "203.0.113.7"},
+		},
+		{
+			name:         "trusted proxy",
+			remoteAddr:   "10.0.0.2:5000",
+			forwardedFor: []string{"198.51.100.1"},
+			want:         caller{keyType: keyTypeIP, key: "198.51.100.1"},
+		},
+		{
+			name:         "client prepends a made up hop",
+			remoteAddr:   "10.0.0.2:5000",
+			forwardedFor: []string{"192.0.2.99, 198.51.100.1"},
+			want:         caller{keyType: keyTypeIP, key: "198.51.100.1"},
+		},
+		{
+			name:         "chain of trusted proxies",
+			remoteAddr:   "10.0.0.2:5000",
+			forwardedFor: []string{"198.51.100.1, 10.0.0.3", "10.0.0.4"},
+			want:         caller{keyType: keyTypeIP, key: "198.51.100.1"},
+		},
+		{
+			name:         "only trusted hops",
+			remoteAddr:   "10.0.0.2:5000",
+			forwardedFor: []string{"10.0.0.3"},
+			want:         caller{keyType: keyTypeIP, key: "10.0.0.3"},
+		},
+		{
+			name:       "trusted proxy without forwarded for",
+			remoteAddr: "10.0.0.2:5000",
+			want:       caller{keyType: keyTypeIP, key: "10.0.0.2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/cart/list", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.apiKey != "" {
+				r.Header.Set("X-Api-Key", tt.apiKey)
+			}
+			for _, value := range tt.forwardedFor {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+
+			if got := callers.httpCaller(r); got != tt.want {
+				t.Errorf("httpCaller() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGRPCCallers(t *testing.T) {
+	callers, err := NewCallers([]string{"admin-key"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gateway := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 40000}
+	client := &net.TCPAddr{IP: net.IPv4(203, 0, 113, 7), Port: 40000}
+
+	tests := []struct {
+		name string
+		peer net.Addr
+		md   metadata.MD
+		req  interface{}
+		want []caller
+	}{
+		{
+			name: "admin key",
+			peer: client,
+			md:   metadata.Pairs("x-api-key", "admin-key"),
+			req:  &cartapi.AddItemToCartRequest{UserId: 1},
+			want: []caller{{keyType: keyTypeAPIKey, key: "admin-key"}},
+		},
+		{
+			name: "unknown key counts as client address and user",
+			peer: client,
+			md:   metadata.Pairs("x-api-key", "made-up"),
+			req:  &cartapi.AddItemToCartRequest{UserId: 1},
+			want: []caller{{keyType: keyTypeIP, key: "203.0.113.7"}, {keyType: keyTypeUser, key: "1"}},
+		},
+		{
+			name: "forwarded for from a direct client is ignored",
+			peer: client,
+			md:   metadata.Pairs("x-forwarded-for", "198.51.100.1"),
+			want: []caller{{keyType: keyTypeIP, key: "203.0.113.7"}},
+		},
+		{
+			name: "payment webhooks have no user",
+			peer: client,
+			req:  &cartapi.HandlePaymentWebhookRequest{Payload: []byte("{}"), Signature: "sig"},
+			want: []caller{{keyType: keyTypeIP, key: "203.0.113.7"}},
+		},
+		{
+			name: "gateway forwards the client address",
+			peer: gateway,
+			md:   metadata.Pairs("x-forwarded-for", "192.0.2.99, 198.51.100.1"),
+			req:  &cartapi.AddItemToCartRequest{UserId: 2},
+			want: []caller{{keyType: keyTypeIP, key: "198.51.100.1"}, {keyType: keyTypeUser, key: "2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			ctx = peer.NewContext(ctx, &peer.Peer{Addr: tt.peer})
+
+			if got := callers.grpcCallers(ctx, tt.req); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("grpcCallers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+type rateLimitedMetrics struct {
+	metrics.Metrics
+	keyTypes []string
+}
+
+func (m *rateLimitedMetrics) IncRateLimited(_, keyType string) {
+	m.keyTypes = append(m.keyTypes, keyType)
+}
+
+func TestRateLimitInterceptorKeepsAddressTokens(t *testing.T) {
+	callers, err := NewCallers(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limiter := ratelimit.New(ratelimit.Rule{RPS: 0.001, Burst: 2}, nil, 0, 0)
+	m := &rateLimitedMetrics{}
+	interceptor := grpcRateLimitInterceptor(limiter, callers, m)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(203, 0, 113, 7), Port: 40000}})
+	info := &grpc.UnaryServerInfo{FullMethod: cartapi.CartService_AddItemToCart_FullMethodName}
+	handler := func(context.Context, interface{}) (interface{}, error) { return nil, nil }
+
+	call := func(userID int64) error {
+		_, err := interceptor(ctx, &cartapi.AddItemToCartRequest{UserId: userID}, info, handler)
+		return err
+	}
+
+	// User 1 takes one token from the address and is throttled once its own bucket is
+	// empty. Its throttled calls must leave the address bucket alone.
+	if err := call(1); err != nil {
+		t.Fatalf("first call of user 1: %v", err)
+	}
+	limiter.Allow(info.FullMethod, keyTypeUser+":1")
+
+	for i := 0; i < 3; i++ {
+		if err := call(1); err == nil {
+			t.Fatal("call of throttled user 1 passed")
+		}
+	}
+
+	if err := call(2); err != nil {
+		t.Errorf("call of user 2 behind the same address: %v", err)
+	}
+	if want := []string{keyTypeUser, keyTypeUser, keyTypeUser}; !reflect.DeepEqual(m.keyTypes, want) {
+		t.Errorf("rate limited key types = %v, want %v", m.keyTypes, want)
+	}
+}
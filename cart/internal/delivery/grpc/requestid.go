@@ -0,0 +1,84 @@
+package grpcserver
+
+import (
+	"cart/internal/constants"
+	"cart/pkg/log"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// requestIDStream replaces the context of a server stream with one carrying the
+// request id.
+type requestIDStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDStream) Context() context.Context {
+	return s.ctx
+}
+
+// grpcRequestIDInterceptor puts the x-request-id of the call, or a new one, into the
+// context for logging and sends it back in the response header.
+func grpcRequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		requestID := incomingRequestID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(constants.RequestIDHeader, requestID))
+
+		return handler(log.WithRequestID(ctx, requestID), req)
+	}
+}
+
+func grpcStreamRequestIDInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		requestID := incomingRequestID(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(constants.RequestIDHeader, requestID))
+
+		return handler(srv, &requestIDStream{
+			ServerStream: ss,
+			ctx:          log.WithRequestID(ss.Context(), requestID),
+		})
+	}
+}
+
+// RequestIDMiddleware gives every gateway request an X-Request-Id, keeping the one the
+// client sent, and passes it on to the gRPC server.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(constants.RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+			r.Header.Set(constants.RequestIDHeader, requestID)
+		}
+
+		w.Header().Set(constants.RequestIDHeader, requestID)
+
+		next.ServeHTTP(w, r.WithContext(log.WithRequestID(r.Context(), requestID)))
+	})
+}
+
+func incomingRequestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if values := md.Get(constants.RequestIDHeader); len(values) > 0 && validRequestID(values[0]) {
+		return values[0]
+	}
+
+	return newRequestID()
+}
+
+func validRequestID(requestID string) bool {
+	return requestID != "" && len(requestID) <= constants.RequestIDMaxLength
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
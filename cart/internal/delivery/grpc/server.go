@@ -1,37 +1,67 @@
 package grpcserver
 
 import (
-	"cart/internal/constants"
+	"cart/internal/models"
+	"cart/internal/repository/interfaces"
 	"cart/internal/service"
 	cartapi "cart/pkg/api/cart"
 	"cart/pkg/log"
+	"cart/pkg/metrics"
+	"cart/pkg/ratelimit"
 	"context"
-	"errors"
+	"time"
 
+	"buf.build/go/protovalidate"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
 type grpcServer struct {
 	cartapi.UnimplementedCartServiceServer
-	service service.CartService
-	logger  log.Logger
+	service  service.CartService
+	checkout service.CheckoutService
+	audit    service.AuditService
+	logger   log.Logger
 }
 
-func NewGRPCServer(svc service.CartService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(svc service.CartService, checkout service.CheckoutService, audit service.AuditService, adminKeys []string, idempotencyRepo interfaces.IdempotencyRepository, idempotencyTTL time.Duration, m metrics.Metrics, limiter *ratelimit.Limiter, callers *Callers, healthSrv healthgrpc.HealthServer, logger log.Logger) *grpc.Server {
 	grpcServer := &grpcServer{
-		service: svc,
-		logger:  logger,
+		service:  svc,
+		checkout: checkout,
+		audit:    audit,
+		logger:   logger,
+	}
+
+	unary := []grpc.UnaryServerInterceptor{
+		grpcRequestIDInterceptor(),
+		grpcMetricsInterceptor(m),
+		grpcLoggingInterceptor(logger),
+		grpcRateLimitInterceptor(limiter, callers, m),
+		grpcAdminInterceptor(adminKeys),
+		grpcValidationInterceptor(protovalidate.GlobalValidator, logger),
+		grpcIdempotencyInterceptor(idempotencyRepo, idempotencyTTL, logger),
+		grpcAuditInterceptor(audit, logger),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		grpcStreamRequestIDInterceptor(),
+		grpcStreamMetricsInterceptor(m),
+		grpcStreamLoggingInterceptor(logger),
+		grpcStreamRateLimitInterceptor(limiter, callers, m),
+		grpcStreamValidationInterceptor(protovalidate.GlobalValidator, logger),
 	}
 
 	srv := grpc.NewServer(
-		grpc.UnaryInterceptor(grpcLoggingInterceptor(logger)),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
 	)
 
 	reflection.Register(srv)
+	healthgrpc.RegisterHealthServer(srv, healthSrv)
 
 	cartapi.RegisterCartServiceServer(srv, grpcServer)
 
@@ -42,53 +72,43 @@ func (s *grpcServer) AddItemToCart(ctx context.Context, req *cartapi.AddItemToCa
 	ctx, span := otel.Tracer("cart-handler").Start(ctx, "grpcServer.AddItemToCart")
 	defer span.End()
 
-	if err := ValidateAddItemToCart(req); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+	expected, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, toStatusError(err)
 	}
 
-	err := s.service.AddItemToCart(ctx, ToAddItemCartModel(req))
+	version, err := s.service.AddItemToCart(ctx, ToAddItemCartModel(req, expected))
 	if err != nil {
-		if errors.Is(err, constants.ErrInsufficientStocks) || errors.Is(err, constants.ErrInvalidSKU) {
-			return nil, status.Error(codes.InvalidArgument, err.Error())
-		}
-
-		return nil, status.Error(codes.Internal, constants.InternalServerErrMessage)
+		return nil, toStatusError(err)
 	}
 
-	return &cartapi.AddItemToCartResponse{Message: "item succesfully added"}, nil
+	return &cartapi.AddItemToCartResponse{Message: "item succesfully added", Version: version}, nil
 }
 
 func (s *grpcServer) DeleteItemFromCart(ctx context.Context, req *cartapi.DeleteItemFromCartRequest) (*cartapi.DeleteItemFromCartResponse, error) {
 	ctx, span := otel.Tracer("cart-handler").Start(ctx, "grpcServer.DeleteItemFromCart")
 	defer span.End()
 
-	if err := ValidateDeleteItemFromCart(req); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+	expected, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, toStatusError(err)
 	}
 
-	err := s.service.DeleteItemFromCart(ctx, ToDeleteCartItemModel(req))
+	version, err := s.service.DeleteItemFromCart(ctx, ToDeleteCartItemModel(req, expected))
 	if err != nil {
-		if errors.Is(err, constants.ErrNotFound) {
-			return nil, status.Error(codes.NotFound, err.Error())
-		}
-
-		return nil, status.Error(codes.Internal, constants.InternalServerErrMessage)
+		return nil, toStatusError(err)
 	}
 
-	return &cartapi.DeleteItemFromCartResponse{Message: "Stock deleted successfully"}, nil
+	return &cartapi.DeleteItemFromCartResponse{Message: "Stock deleted successfully", Version: version}, nil
 }
 
 func (s *grpcServer) CartList(ctx context.Context, req *cartapi.CartListRequest) (*cartapi.CartListResponse, error) {
 	ctx, span := otel.Tracer("cart-handler").Start(ctx, "grpcServer.CartList")
 	defer span.End()
 
-	if err := ValidateCartList(req); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
-	}
-
 	result, err := s.service.ListCartItems(ctx, req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.Internal, constants.InternalServerErrMessage)
+		return nil, toStatusError(err)
 	}
 
 	return ToCartListResponse(result), nil
@@ -98,18 +118,161 @@ func (s *grpcServer) ClearCart(ctx context.Context, req *cartapi.ClearCartReques
 	ctx, span := otel.Tracer("cart-handler").Start(ctx, "grpcServer.ClearCart")
 	defer span.End()
 
-	if err := ValidateClearCart(req); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+	expected, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	version, err := s.service.ClearCart(ctx, ToClearCartModel(req, expected))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &cartapi.ClearCartResponse{Message: "cart succesfully cleared", Version: version}, nil
+}
+
+func (s *grpcServer) MoveToSavedForLater(ctx context.Context, req *cartapi.MoveToSavedForLaterRequest) (*cartapi.MoveToSavedForLaterResponse, error) {
+	ctx, span := otel.Tracer("cart-handler").Start(ctx, "grpcServer.MoveToSavedForLater")
+	defer span.End()
+
+	expected, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	version, err := s.service.MoveToSavedForLater(ctx, ToMoveToSavedModel(req, expected))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &cartapi.MoveToSavedForLaterResponse{Message: "item saved for later", Version: version}, nil
+}
+
+func (s *grpcServer) MoveToCart(ctx context.Context, req *cartapi.MoveToCartRequest) (*cartapi.MoveToCartResponse, error) {
+	ctx, span := otel.Tracer("cart-handler").Start(ctx, "grpcServer.MoveToCart")
+	defer span.End()
+
+	expected, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	version, err := s.service.MoveToCart(ctx, ToMoveToCartModel(req, expected))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &cartapi.MoveToCartResponse{Message: "item moved to cart", Version: version}, nil
+}
+
+func (s *grpcServer) ListSaved(ctx context.Context, req *cartapi.ListSavedRequest) (*cartapi.ListSavedResponse, error) {
+	ctx, span := otel.Tracer("cart-handler").Start(ctx, "grpcServer.ListSaved")
+	defer span.End()
+
+	result, err := s.service.ListSaved(ctx, req.UserId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return ToListSavedResponse(result), nil
+}
+
+func (s *grpcServer) ValidateCart(ctx context.Context, req *cartapi.ValidateCartRequest) (*cartapi.ValidateCartResponse, error) {
+	ctx, span := otel.Tracer("cart-handler").Start(ctx, "grpcServer.ValidateCart")
+	defer span.End()
+
+	expected, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	result, err := s.service.ValidateCart(ctx, ToValidateCartModel(req, expected))
+	if err != nil {
+		return nil, toStatusError(err)
 	}
 
-	err := s.service.ClearCart(ctx, req.UserId)
+	return ToValidateCartResponse(result), nil
+}
+
+func (s *grpcServer) WatchCart(req *cartapi.WatchCartRequest, stream grpc.ServerStreamingServer[cartapi.CartListResponse]) error {
+	ctx, span := otel.Tracer("cart-handler").Start(stream.Context(), "grpcServer.WatchCart")
+	defer span.End()
+
+	err := s.service.WatchCart(ctx, req.UserId, func(cart models.CartItemsList) error {
+		return stream.Send(ToCartListResponse(cart))
+	})
 	if err != nil {
-		if errors.Is(err, constants.ErrNotFound) {
-			return nil, status.Error(codes.NotFound, err.Error())
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
 		}
 
-		return nil, status.Error(codes.Internal, constants.InternalServerErrMessage)
+		return toStatusError(err)
+	}
+
+	return nil
+}
+
+func (s *grpcServer) Checkout(ctx context.Context, req *cartapi.CheckoutRequest) (*cartapi.CheckoutResponse, error) {
+	ctx, span := otel.Tracer("cart-handler").Start(ctx, "grpcServer.Checkout")
+	defer span.End()
+
+	checkout, err := s.checkout.Checkout(ctx, models.CreateCheckout{UserID: req.UserId, PaymentMethod: req.PaymentMethod})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &cartapi.CheckoutResponse{Checkout: ToCheckoutResponse(checkout)}, nil
+}
+
+func (s *grpcServer) GetCheckout(ctx context.Context, req *cartapi.GetCheckoutRequest) (*cartapi.GetCheckoutResponse, error) {
+	ctx, span := otel.Tracer("cart-handler").Start(ctx, "grpcServer.GetCheckout")
+	defer span.End()
+
+	checkout, err := s.checkout.GetCheckout(ctx, models.GetCheckout{UserID: req.UserId, CheckoutID: req.CheckoutId})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &cartapi.GetCheckoutResponse{Checkout: ToCheckoutResponse(checkout)}, nil
+}
+
+func (s *grpcServer) HandlePaymentWebhook(ctx context.Context, req *cartapi.HandlePaymentWebhookRequest) (*cartapi.HandlePaymentWebhookResponse, error) {
+	ctx, span := otel.Tracer("cart-handler").Start(ctx, "grpcServer.HandlePaymentWebhook")
+	defer span.End()
+
+	checkoutID, err := s.checkout.HandlePaymentWebhook(ctx, req.Payload, req.Signature)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &cartapi.HandlePaymentWebhookResponse{CheckoutId: checkoutID}, nil
+}
+
+func (s *grpcServer) PurgeExpiredCarts(ctx context.Context, req *cartapi.PurgeExpiredCartsRequest) (*cartapi.PurgeExpiredCartsResponse, error) {
+	ctx, span := otel.Tracer("cart-handler").Start(ctx, "grpcServer.PurgeExpiredCarts")
+	defer span.End()
+
+	carts, err := s.service.PurgeExpiredCarts(ctx, ToPurgeExpiredCartsModel(req))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return ToPurgeExpiredCartsResponse(carts), nil
+}
+
+func (s *grpcServer) ListAuditEvents(ctx context.Context, req *cartapi.ListAuditEventsRequest) (*cartapi.ListAuditEventsResponse, error) {
+	ctx, span := otel.Tracer("cart-handler").Start(ctx, "grpcServer.ListAuditEvents")
+	defer span.End()
+
+	filter, err := ToAuditFilter(req)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	page, err := s.audit.ListAuditEvents(ctx, filter)
+	if err != nil {
+		return nil, toStatusError(err)
 	}
 
-	return &cartapi.ClearCartResponse{Message: "cart succesfully cleared"}, nil
+	return ToListAuditEventsResponse(page), nil
 }
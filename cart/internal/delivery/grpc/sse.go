@@ -0,0 +1,143 @@
+package grpcserver
+
+import (
+	"cart/internal/constants"
+	"cart/internal/domainerr"
+	cartapi "cart/pkg/api/cart"
+	"cart/pkg/log"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type watchEvent struct {
+	cart *cartapi.CartListResponse
+	err  error
+}
+
+// watchCartSSE bridges the WatchCart stream to Server-Sent Events on
+// GET /cart/watch?user_id=... Every cart snapshot is sent as a "cart" event.
+func watchCartSSE(client cartapi.CartServiceClient, logger log.Logger) runtime.HandlerFunc {
+	marshaler := &runtime.JSONPb{}
+
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		logger := logger.FromContext(r.Context())
+
+		userID, err := strconv.ParseInt(r.URL.Query().Get("user_id"), 10, 64)
+		if err != nil || userID <= 0 {
+			writeProblem(w, domainProblem(domainerr.Wrap(domainerr.KindInvalidArgument, domainerr.ReasonInvalidRequest, constants.ErrInvalidUserID), r.URL.Path), logger)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+			return
+		}
+
+		stream, err := client.WatchCart(r.Context(), &cartapi.WatchCartRequest{UserId: userID})
+		if err != nil {
+			writeSSEError(w, r, err, logger)
+			return
+		}
+
+		events := make(chan watchEvent)
+
+		go func() {
+			defer close(events)
+
+			for {
+				cart, err := stream.Recv()
+
+				select {
+				case events <- watchEvent{cart: cart, err: err}:
+				case <-r.Context().Done():
+					return
+				}
+
+				if err != nil {
+					return
+				}
+			}
+		}()
+
+		// The first snapshot is awaited before the headers are written, so request
+		// errors are still reported with a proper HTTP status.
+		first, ok := <-events
+		if !ok {
+			return
+		}
+
+		if first.err != nil {
+			writeSSEError(w, r, first.err, logger)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		keepAlive := time.NewTicker(constants.SSEKeepAliveInterval)
+		defer keepAlive.Stop()
+
+		event := first
+
+		for {
+			if event.err != nil {
+				if !errors.Is(event.err, io.EOF) && r.Context().Err() == nil {
+					logger.Errorf("err in watch cart stream: %v", event.err)
+					fmt.Fprintf(w, "event: error\ndata: %q\n\n", status.Convert(event.err).Message())
+					flusher.Flush()
+				}
+
+				return
+			}
+
+			data, err := marshaler.Marshal(event.cart)
+			if err != nil {
+				logger.Errorf("err in marshal cart event: %v", err)
+				return
+			}
+
+			fmt.Fprintf(w, "id: %d\nevent: cart\ndata: %s\n\n", event.cart.Version, data)
+			flusher.Flush()
+
+		wait:
+			for {
+				select {
+				case <-r.Context().Done():
+					return
+				case <-keepAlive.C:
+					fmt.Fprint(w, ": keep-alive\n\n")
+					flusher.Flush()
+				case next, ok := <-events:
+					if !ok {
+						return
+					}
+
+					event = next
+
+					break wait
+				}
+			}
+		}
+	}
+}
+
+func writeSSEError(w http.ResponseWriter, r *http.Request, err error, logger log.Logger) {
+	st := status.Convert(err)
+	if st.Code() == codes.Canceled {
+		return
+	}
+
+	writeProblem(w, problemFromStatus(st, r.URL.Path, traceIDFromContext(r.Context())), logger)
+}
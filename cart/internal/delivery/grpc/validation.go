@@ -1,50 +1,87 @@
 package grpcserver
 
 import (
-	"cart/internal/constants"
-	cartapi "cart/pkg/api/cart"
+	"cart/internal/domainerr"
+	"cart/pkg/log"
+	"context"
+	"errors"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
-func ValidateAddItemToCart(req *cartapi.AddItemToCartRequest) error {
-	if req.UserId <= 0 {
-		return constants.ErrInvalidUserID
-	}
+// Request rules are declared in cart.proto with buf.validate annotations.
+
+func grpcValidationInterceptor(validator protovalidate.Validator, logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validateRequest(validator, req, logger.FromContext(ctx)); err != nil {
+			return nil, err
+		}
 
-	if req.Sku == 0 {
-		return constants.ErrInvalidSKU
+		return handler(ctx, req)
 	}
+}
 
-	if req.Count == 0 {
-		return constants.ErrInvalidCount
+func grpcStreamValidationInterceptor(validator protovalidate.Validator, logger log.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss, validator: validator, logger: logger})
 	}
+}
 
-	return nil
+// validatingStream validates every message the client sends on a stream.
+type validatingStream struct {
+	grpc.ServerStream
+	validator protovalidate.Validator
+	logger    log.Logger
 }
 
-func ValidateDeleteItemFromCart(req *cartapi.DeleteItemFromCartRequest) error {
-	if req.UserId <= 0 {
-		return constants.ErrInvalidUserID
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
 	}
 
-	if req.Sku == 0 {
-		return constants.ErrInvalidSKU
+	return validateRequest(s.validator, m, s.logger.FromContext(s.Context()))
+}
+
+// validateRequest answers INVALID_ARGUMENT with an ErrorInfo detail and a BadRequest
+// detail that lists every violated field.
+func validateRequest(validator protovalidate.Validator, req interface{}, logger log.Logger) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
 	}
 
-	return nil
-}
+	err := validator.Validate(msg)
+	if err == nil {
+		return nil
+	}
 
-func ValidateCartList(req *cartapi.CartListRequest) error {
-	if req.UserId <= 0 {
-		return constants.ErrInvalidUserID
+	var valErr *protovalidate.ValidationError
+	if !errors.As(err, &valErr) {
+		logger.Errorf("err in validate request: %v", err)
+		return toStatusError(err)
 	}
 
-	return nil
-}
+	badRequest := &errdetails.BadRequest{}
+	messages := make([]string, 0, len(valErr.Violations))
+
+	for _, violation := range valErr.Violations {
+		field := protovalidate.FieldPathString(violation.Proto.GetField())
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: violation.Proto.GetMessage(),
+			Reason:      violation.Proto.GetRuleId(),
+		})
+		messages = append(messages, field+": "+violation.Proto.GetMessage())
+	}
 
-func ValidateClearCart(req *cartapi.ClearCartRequest) error {
-	if req.UserId <= 0 {
-		return constants.ErrInvalidUserID
+	st := domainStatus(domainerr.Wrap(domainerr.KindInvalidArgument, domainerr.ReasonInvalidRequest, errors.New("invalid request: "+strings.Join(messages, "; "))))
+	if withDetails, err := st.WithDetails(badRequest); err == nil {
+		st = withDetails
 	}
 
-	return nil
+	return st.Err()
 }
@@ -0,0 +1,75 @@
+package grpcserver
+
+import (
+	"cart/internal/constants"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+type versioned interface {
+	GetVersion() uint64
+}
+
+// expectedVersion returns the version the client expects the cart to be at. The
+// expected_version field takes precedence over the If-Match header of the gateway.
+func expectedVersion(ctx context.Context, field *uint64) (*uint64, error) {
+	if field != nil {
+		return field, nil
+	}
+
+	version, ok, err := parseETag(ifMatchFromContext(ctx))
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+func ifMatchFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(constants.IfMatchHeader)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func parseETag(tag string) (uint64, bool, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" || tag == "*" {
+		return 0, false, nil
+	}
+
+	tag = strings.TrimPrefix(tag, "W/")
+	tag = strings.Trim(tag, `"`)
+
+	version, err := strconv.ParseUint(tag, 10, 64)
+	if err != nil {
+		return 0, false, constants.ErrInvalidVersion
+	}
+
+	return version, true, nil
+}
+
+func formatETag(version uint64) string {
+	return `"` + strconv.FormatUint(version, 10) + `"`
+}
+
+// forwardETag exposes the cart version of gateway responses as the ETag header.
+func forwardETag(_ context.Context, w http.ResponseWriter, msg proto.Message) error {
+	if v, ok := msg.(versioned); ok {
+		w.Header().Set(constants.ETagHeader, formatETag(v.GetVersion()))
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package grpcserver
+
+import (
+	"cart/internal/constants"
+	"cart/internal/domainerr"
+	cartapi "cart/pkg/api/cart"
+	"cart/pkg/log"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// paymentWebhook passes the raw body and signature of POST /payments/webhook on to
+// HandlePaymentWebhook, since the signature covers the body byte for byte.
+func paymentWebhook(client cartapi.CartServiceClient, logger log.Logger) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, constants.PaymentWebhookMaxBytes))
+		if err != nil {
+			writeProblem(w, domainProblem(domainerr.Wrap(domainerr.KindInvalidArgument, domainerr.ReasonInvalidRequest, errors.New("failed to read webhook body")), r.URL.Path), logger)
+			return
+		}
+
+		_, err = client.HandlePaymentWebhook(r.Context(), &cartapi.HandlePaymentWebhookRequest{
+			Payload:   payload,
+			Signature: r.Header.Get(constants.PaymentSignatureHeader),
+		})
+		if err != nil {
+			writeSSEError(w, r, err, logger)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
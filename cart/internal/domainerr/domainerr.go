@@ -0,0 +1,210 @@
+package domainerr
+
+import (
+	"cart/internal/constants"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Kind is the class of a failure. The delivery layer maps it to a gRPC code.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindInvalidArgument
+	KindNotFound
+	KindAlreadyExists
+	KindFailedPrecondition
+	KindAborted
+	KindResourceExhausted
+	KindUnavailable
+	KindPermissionDenied
+)
+
+// Reason is a machine-readable cause, stable across releases.
+type Reason string
+
+const (
+	ReasonInternal             Reason = "INTERNAL"
+	ReasonInvalidRequest       Reason = "INVALID_REQUEST"
+	ReasonNotFound             Reason = "NOT_FOUND"
+	ReasonInvalidSKU           Reason = "INVALID_SKU"
+	ReasonInsufficientStock    Reason = "INSUFFICIENT_STOCK"
+	ReasonVersionMismatch      Reason = "VERSION_MISMATCH"
+	ReasonInvalidVersion       Reason = "INVALID_VERSION"
+	ReasonStockUnavailable     Reason = "STOCK_UNAVAILABLE"
+	ReasonIdempotencyReused    Reason = "IDEMPOTENCY_KEY_REUSED"
+	ReasonIdempotencyPending   Reason = "IDEMPOTENCY_KEY_IN_PROGRESS"
+	ReasonIdempotencyKeyLong   Reason = "IDEMPOTENCY_KEY_TOO_LONG"
+	ReasonRateLimited          Reason = "RATE_LIMITED"
+	ReasonAdminRequired        Reason = "ADMIN_REQUIRED"
+	ReasonInvalidPageToken     Reason = "INVALID_PAGE_TOKEN"
+	ReasonSellerMismatch       Reason = "SELLER_MISMATCH"
+	ReasonCartEmpty            Reason = "CART_EMPTY"
+	ReasonCheckoutInProgress   Reason = "CHECKOUT_IN_PROGRESS"
+	ReasonWebhookSignature     Reason = "INVALID_WEBHOOK_SIGNATURE"
+	ReasonInvalidPaymentMethod Reason = "INVALID_PAYMENT_METHOD"
+)
+
+// Violation is a precondition that did not hold, such as the stock of a SKU.
+type Violation struct {
+	Type        string
+	Subject     string
+	Description string
+}
+
+// Error is a domain failure with its reason and details. It wraps the matching
+// sentinel from constants, so errors.Is keeps working on it.
+type Error struct {
+	Kind       Kind
+	Reason     Reason
+	Message    string
+	Metadata   map[string]string
+	Violations []Violation
+	err        error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Wrap creates an error of kind and reason with the message of err.
+func Wrap(kind Kind, reason Reason, err error) *Error {
+	return &Error{
+		Kind:    kind,
+		Reason:  reason,
+		Message: err.Error(),
+		err:     err,
+	}
+}
+
+// WithMetadata adds a key to the ErrorInfo metadata.
+func (e *Error) WithMetadata(key, value string) *Error {
+	if e.Metadata == nil {
+		e.Metadata = make(map[string]string)
+	}
+
+	e.Metadata[key] = value
+
+	return e
+}
+
+// WithViolation adds a failed precondition.
+func (e *Error) WithViolation(typ, subject, description string) *Error {
+	e.Violations = append(e.Violations, Violation{Type: typ, Subject: subject, Description: description})
+	return e
+}
+
+func InvalidSKU(sku uint32) *Error {
+	return Wrap(KindInvalidArgument, ReasonInvalidSKU, constants.ErrInvalidSKU).
+		WithMetadata("sku", strconv.FormatUint(uint64(sku), 10))
+}
+
+// InvalidOffer reports that the seller does not offer sku. A zero sellerID means no
+// seller offers it.
+func InvalidOffer(sku uint32, sellerID int64) *Error {
+	if sellerID == 0 {
+		return InvalidSKU(sku)
+	}
+
+	return InvalidSKU(sku).WithMetadata("seller_id", strconv.FormatInt(sellerID, 10))
+}
+
+// SellerMismatch reports that a line of sku from another seller is already in the way.
+func SellerMismatch(sku uint32, lineSellerID, sellerID int64) *Error {
+	skuStr := strconv.FormatUint(uint64(sku), 10)
+
+	return Wrap(KindFailedPrecondition, ReasonSellerMismatch, constants.ErrSellerMismatch).
+		WithMetadata("sku", skuStr).
+		WithMetadata("line_seller_id", strconv.FormatInt(lineSellerID, 10)).
+		WithMetadata("seller_id", strconv.FormatInt(sellerID, 10)).
+		WithViolation("SELLER", "sku/"+skuStr, fmt.Sprintf("line references seller %d, not %d", lineSellerID, sellerID))
+}
+
+// InsufficientStock reports that requested units of sku, counting those already in
+// the cart, exceed the available stock.
+func InsufficientStock(sku, requested, available uint32) *Error {
+	skuStr := strconv.FormatUint(uint64(sku), 10)
+
+	return Wrap(KindFailedPrecondition, ReasonInsufficientStock, constants.ErrInsufficientStocks).
+		WithMetadata("sku", skuStr).
+		WithMetadata("requested", strconv.FormatUint(uint64(requested), 10)).
+		WithMetadata("available", strconv.FormatUint(uint64(available), 10)).
+		WithViolation("STOCK", "sku/"+skuStr, fmt.Sprintf("requested %d, available %d", requested, available))
+}
+
+func VersionMismatch(expected, actual uint64) *Error {
+	return Wrap(KindFailedPrecondition, ReasonVersionMismatch, constants.ErrVersionMismatch).
+		WithMetadata("expected_version", strconv.FormatUint(expected, 10)).
+		WithMetadata("actual_version", strconv.FormatUint(actual, 10)).
+		WithViolation("VERSION", "cart", fmt.Sprintf("expected version %d, cart is at %d", expected, actual))
+}
+
+func CartEmpty(userID int64) *Error {
+	return Wrap(KindFailedPrecondition, ReasonCartEmpty, constants.ErrCartEmpty).
+		WithMetadata("user_id", strconv.FormatInt(userID, 10)).
+		WithViolation("CART", "cart", "cart has no lines to check out")
+}
+
+// CheckoutInProgress reports that the user already has a running checkout.
+func CheckoutInProgress(userID int64) *Error {
+	return Wrap(KindFailedPrecondition, ReasonCheckoutInProgress, constants.ErrCheckoutInProgress).
+		WithMetadata("user_id", strconv.FormatInt(userID, 10)).
+		WithViolation("CHECKOUT", "cart", "wait for the running checkout to finish")
+}
+
+func CheckoutNotFound(checkoutID int64) *Error {
+	return Wrap(KindNotFound, ReasonNotFound, constants.ErrNotFound).
+		WithMetadata("checkout_id", strconv.FormatInt(checkoutID, 10))
+}
+
+func InvalidWebhookSignature() *Error {
+	return Wrap(KindPermissionDenied, ReasonWebhookSignature, constants.ErrWebhookSignature)
+}
+
+var sentinels = []struct {
+	err    error
+	kind   Kind
+	reason Reason
+}{
+	{constants.ErrNotFound, KindNotFound, ReasonNotFound},
+	{constants.ErrInvalidSKU, KindInvalidArgument, ReasonInvalidSKU},
+	{constants.ErrInvalidUserID, KindInvalidArgument, ReasonInvalidRequest},
+	{constants.ErrInsufficientStocks, KindFailedPrecondition, ReasonInsufficientStock},
+	{constants.ErrVersionMismatch, KindFailedPrecondition, ReasonVersionMismatch},
+	{constants.ErrInvalidVersion, KindInvalidArgument, ReasonInvalidVersion},
+	{constants.ErrStockUnavailable, KindUnavailable, ReasonStockUnavailable},
+	{constants.ErrIdempotencyReused, KindInvalidArgument, ReasonIdempotencyReused},
+	{constants.ErrIdempotencyPending, KindAborted, ReasonIdempotencyPending},
+	{constants.ErrIdempotencyKeyLong, KindInvalidArgument, ReasonIdempotencyKeyLong},
+	{constants.ErrRateLimited, KindResourceExhausted, ReasonRateLimited},
+	{constants.ErrAdminRequired, KindPermissionDenied, ReasonAdminRequired},
+	{constants.ErrInvalidPageToken, KindInvalidArgument, ReasonInvalidPageToken},
+	{constants.ErrSellerMismatch, KindFailedPrecondition, ReasonSellerMismatch},
+	{constants.ErrCartEmpty, KindFailedPrecondition, ReasonCartEmpty},
+	{constants.ErrCheckoutInProgress, KindFailedPrecondition, ReasonCheckoutInProgress},
+	{constants.ErrWebhookSignature, KindPermissionDenied, ReasonWebhookSignature},
+	{constants.ErrInvalidPaymentMethod, KindInvalidArgument, ReasonInvalidPaymentMethod},
+}
+
+// From returns the domain error in err's chain, or one built from a known sentinel.
+// It returns nil for unexpected errors.
+func From(err error) *Error {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+
+	for _, s := range sentinels {
+		if errors.Is(err, s.err) {
+			return Wrap(s.kind, s.reason, s.err)
+		}
+	}
+
+	return nil
+}
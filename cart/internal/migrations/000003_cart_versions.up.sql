@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS cart_versions (
+	"user_id" INT PRIMARY KEY,
+	"version" BIGINT NOT NULL DEFAULT 0,
+	"updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
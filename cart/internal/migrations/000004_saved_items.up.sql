@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS saved_items (
+	"id" SERIAL PRIMARY KEY,
+	"user_id" INT NOT NULL,
+	"sku" BIGINT NOT NULL,
+	"count" INT NOT NULL DEFAULT 0,
+	"last_seen_count" INT,
+	"created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	"updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE("user_id","sku")
+);
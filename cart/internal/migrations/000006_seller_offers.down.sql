@@ -0,0 +1,2 @@
+ALTER TABLE saved_items DROP COLUMN IF EXISTS "seller_id";
+ALTER TABLE cart DROP COLUMN IF EXISTS "seller_id";
@@ -0,0 +1,4 @@
+-- Cart and saved lines reference the offer of one seller. Lines from before sellers
+-- existed keep seller 0 and follow the default offer of their SKU.
+ALTER TABLE cart ADD COLUMN IF NOT EXISTS "seller_id" BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE saved_items ADD COLUMN IF NOT EXISTS "seller_id" BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- The offer price when the line was last added, to tell the user about price changes.
+-- Lines added before it was recorded have none.
+ALTER TABLE cart ADD COLUMN IF NOT EXISTS "price" BIGINT;
@@ -0,0 +1,23 @@
+-- Saga state of checkouts. step_deadline is the lease of the running step: a step is
+-- only claimed once it has passed, and sagas left past it are picked up again.
+CREATE TABLE IF NOT EXISTS checkouts (
+	"id" BIGSERIAL PRIMARY KEY,
+	"user_id" BIGINT NOT NULL,
+	"status" TEXT NOT NULL DEFAULT 'running',
+	"step" TEXT NOT NULL DEFAULT 'reserve_stock',
+	"lines" JSONB NOT NULL,
+	"total_price" BIGINT NOT NULL,
+	"payment_id" TEXT NOT NULL DEFAULT '',
+	"attempts" INT NOT NULL DEFAULT 0,
+	"last_error" TEXT NOT NULL DEFAULT '',
+	"step_deadline" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	"created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	"updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One active checkout per user.
+CREATE UNIQUE INDEX IF NOT EXISTS checkouts_active_user_idx ON checkouts ("user_id")
+	WHERE status IN ('running', 'compensating');
+CREATE INDEX IF NOT EXISTS checkouts_step_deadline_idx ON checkouts ("step_deadline")
+	WHERE status IN ('running', 'compensating');
+CREATE INDEX IF NOT EXISTS checkouts_user_idx ON checkouts ("user_id", "id");
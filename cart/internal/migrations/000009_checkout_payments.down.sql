@@ -0,0 +1,2 @@
+ALTER TABLE checkouts DROP COLUMN IF EXISTS "payment_action_url";
+ALTER TABLE checkouts DROP COLUMN IF EXISTS "payment_method";
@@ -0,0 +1,4 @@
+-- The payment method token the checkout pays with, and the 3-D Secure page the customer
+-- has to pass while the authorization waits for it.
+ALTER TABLE checkouts ADD COLUMN IF NOT EXISTS "payment_method" TEXT NOT NULL DEFAULT '';
+ALTER TABLE checkouts ADD COLUMN IF NOT EXISTS "payment_action_url" TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS simulated_payments;
+ALTER TABLE checkouts ADD COLUMN IF NOT EXISTS "payment_method" TEXT NOT NULL DEFAULT '';
+ALTER TABLE checkouts DROP COLUMN IF EXISTS "card_last4";
+ALTER TABLE checkouts DROP COLUMN IF EXISTS "card_brand";
+ALTER TABLE checkouts DROP COLUMN IF EXISTS "payment_token";
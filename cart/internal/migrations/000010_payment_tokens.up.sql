@@ -0,0 +1,22 @@
+-- Checkouts keep the provider token of their payment method and the card brand and
+-- last four digits to show, never the card number. Numbers stored before are cut down
+-- to their last four digits; checkouts still to authorize with them fail as declined.
+ALTER TABLE checkouts ADD COLUMN IF NOT EXISTS "payment_token" TEXT NOT NULL DEFAULT '';
+ALTER TABLE checkouts ADD COLUMN IF NOT EXISTS "card_brand" TEXT NOT NULL DEFAULT '';
+ALTER TABLE checkouts ADD COLUMN IF NOT EXISTS "card_last4" TEXT NOT NULL DEFAULT '';
+UPDATE checkouts SET card_last4 = RIGHT(payment_method, 4) WHERE payment_method <> '';
+ALTER TABLE checkouts DROP COLUMN IF EXISTS "payment_method";
+
+-- Payments of the simulator provider. The id is derived from the reference, so a
+-- retried authorization finds the first payment.
+CREATE TABLE IF NOT EXISTS simulated_payments (
+	"id" TEXT PRIMARY KEY,
+	"reference" TEXT NOT NULL,
+	"status" TEXT NOT NULL,
+	"amount" BIGINT NOT NULL,
+	"captured_amount" BIGINT NOT NULL DEFAULT 0,
+	"refunded_amount" BIGINT NOT NULL DEFAULT 0,
+	"action_url" TEXT NOT NULL DEFAULT '',
+	"created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	"updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,7 @@
+-- Idempotency keys are chosen by clients, so they are only unique per user. Requests
+-- without a user are stored with user_id 0.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS "user_id" BIGINT NOT NULL DEFAULT 0;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idempotency_keys_user_key_method_idx ON idempotency_keys ("user_id", "key", "method");
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
@@ -7,11 +7,23 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"cart/pkg/postgresql"
+
+	"github.com/jackc/pgx/v5"
 )
 
+// versionTableQuery creates the version table of golang-migrate, so the version is
+// recorded the same way whether the service or the migrate CLI applied the migrations.
+const versionTableQuery = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		dirty BOOLEAN NOT NULL
+	)
+`
+
 func RunManualMigrations(ctx context.Context, db postgresql.Client, migrationsDir string) error {
 	files := []string{}
 	err := filepath.WalkDir(migrationsDir, func(path string, d fs.DirEntry, err error) error {
@@ -32,19 +44,75 @@ func RunManualMigrations(ctx context.Context, db postgresql.Client, migrationsDi
 
 	sort.Strings(files)
 
+	if _, err := db.Exec(ctx, versionTableQuery); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
 	for _, file := range files {
 		sqlBytes, err := os.ReadFile(file)
 		if err != nil {
 			return fmt.Errorf("failed to read migration file %s: %w", file, err)
 		}
 
+		version, err := migrationVersion(file)
+		if err != nil {
+			return err
+		}
+
+		// Left dirty when the migration fails.
+		if err := setVersion(ctx, db, version, true); err != nil {
+			return err
+		}
+
 		_, err = db.Exec(ctx, string(sqlBytes))
 		if err != nil {
 			return fmt.Errorf("failed to execute migration %s: %w", file, err)
 		}
 
+		if err := setVersion(ctx, db, version, false); err != nil {
+			return err
+		}
+
 		fmt.Printf("✅ Applied migration %s\n", filepath.Base(file))
 	}
 
 	return nil
 }
+
+// migrationVersion reads the version of a migration file named like 000001_init.up.sql.
+func migrationVersion(file string) (int64, error) {
+	prefix, _, _ := strings.Cut(filepath.Base(file), "_")
+
+	version, err := strconv.ParseInt(prefix, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("migration %s has no version: %w", file, err)
+	}
+
+	return version, nil
+}
+
+// setVersion records version as the current one, schema_migrations holds a single row.
+func setVersion(ctx context.Context, db postgresql.Client, version int64, dirty bool) error {
+	tx, err := db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `TRUNCATE schema_migrations`); err != nil {
+		return fmt.Errorf("failed to record migration version %d: %w", version, err)
+	}
+
+	query := `INSERT INTO schema_migrations (version, dirty) VALUES (@version, @dirty)`
+
+	args := pgx.NamedArgs{
+		"version": version,
+		"dirty":   dirty,
+	}
+
+	if _, err := tx.Exec(ctx, query, args); err != nil {
+		return fmt.Errorf("failed to record migration version %d: %w", version, err)
+	}
+
+	return tx.Commit(ctx)
+}
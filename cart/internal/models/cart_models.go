@@ -1,33 +1,118 @@
 package models
 
+import "time"
+
+// CartItem is a cart line. It references the offer of SellerID for the SKU; zero means
+// the default offer. Price is the offer price when the line was last added, nil for
+// lines added before it was recorded.
 type CartItem struct {
-	UserID int64
-	SKU    uint32
-	Count  uint32
+	UserID          int64
+	SKU             uint32
+	SellerID        int64
+	Count           uint32
+	Price           *uint32
+	ExpectedVersion *uint64
 }
 
 type DeleteCartItem struct {
-	UserID int64
-	SKU    uint32
+	UserID          int64
+	SKU             uint32
+	ExpectedVersion *uint64
+}
+
+type ClearCart struct {
+	UserID          int64
+	ExpectedVersion *uint64
 }
 
 type CartItemModel struct {
-	SKU   uint32
-	Count uint32
-	Name  string
-	Price uint32
+	SKU          uint32
+	SellerID     int64
+	Count        uint32
+	Name         string
+	Price        uint32
+	PriceUnknown bool
+	// Unavailable is set when the offer of the line was deleted in stocks.
+	Unavailable bool
+}
+
+// CartLineStatus is the result of checking a cart line against its offer.
+type CartLineStatus string
+
+const (
+	CartLineOK           CartLineStatus = "ok"
+	CartLineOutOfStock   CartLineStatus = "out_of_stock"
+	CartLineInsufficient CartLineStatus = "insufficient"
+	CartLineSKURemoved   CartLineStatus = "sku_removed"
+	CartLinePriceChanged CartLineStatus = "price_changed"
+)
+
+// CartLineFix is the correction auto fix made to a cart line.
+type CartLineFix string
+
+const (
+	CartLineNotFixed CartLineFix = ""
+	CartLineRemoved  CartLineFix = "removed"
+	CartLineClamped  CartLineFix = "clamped"
+	CartLineRepriced CartLineFix = "repriced"
+)
+
+type ValidateCart struct {
+	UserID          int64
+	AutoFix         bool
+	ExpectedVersion *uint64
+}
+
+// CartLineValidation is the status of one line. Count is the quantity in the cart after
+// the fix, PreviousPrice the recorded price when it differs from Price.
+type CartLineValidation struct {
+	SKU            uint32
+	SellerID       int64
+	Count          uint32
+	Status         CartLineStatus
+	AvailableCount uint32
+	Price          uint32
+	PreviousPrice  *uint32
+	Fix            CartLineFix
+}
+
+type CartValidation struct {
+	Lines   []CartLineValidation
+	Valid   bool
+	Version uint64
 }
 
 type CartItemsList struct {
 	Items      []CartItemModel
 	TotalPrice uint32
+	Version    uint64
+	Degraded   bool
 }
 
 type StockItem struct {
 	SKU      uint32
+	SellerID int64
 	Name     string
 	Type     string
 	Count    uint32
 	Price    uint32
 	Location string
 }
+
+// PurgeExpiredCarts empties up to Limit carts nobody changed for OlderThan, as the admin
+// UserID. With DryRun the carts are only listed.
+type PurgeExpiredCarts struct {
+	UserID    int64
+	OlderThan time.Duration
+	Limit     int
+	DryRun    bool
+}
+
+// ExpiredCart is a cart nobody changed since LastActivity, with the lines it had.
+type ExpiredCart struct {
+	UserID       int64
+	Lines        int64
+	Units        int64
+	LastActivity time.Time
+	Items        []CartItem
+}
@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+type CheckoutStatus string
+
+const (
+	CheckoutRunning      CheckoutStatus = "running"
+	CheckoutCompensating CheckoutStatus = "compensating"
+	CheckoutCompleted    CheckoutStatus = "completed"
+	CheckoutFailed       CheckoutStatus = "failed"
+	// CheckoutStuck is a saga given up at a step that cannot be undone, past the stock
+	// commit or in a compensation. Its step and last error tell an operator where.
+	CheckoutStuck CheckoutStatus = "stuck"
+)
+
+// CheckoutStep is a step of the checkout saga. The forward steps run in order; once
+// one of them fails for good the compensations undo the steps done so far.
+type CheckoutStep string
+
+const (
+	CheckoutStepNone             CheckoutStep = ""
+	CheckoutStepReserveStock     CheckoutStep = "reserve_stock"
+	CheckoutStepAuthorizePayment CheckoutStep = "authorize_payment"
+	CheckoutStepCommitStock      CheckoutStep = "commit_stock"
+	CheckoutStepCapturePayment   CheckoutStep = "capture_payment"
+	CheckoutStepClearCart        CheckoutStep = "clear_cart"
+	CheckoutStepVoidPayment      CheckoutStep = "void_payment"
+	CheckoutStepReleaseStock     CheckoutStep = "release_stock"
+)
+
+// CheckoutLine is a cart line as checked out, with the offer price at that time.
+type CheckoutLine struct {
+	SKU      uint32
+	SellerID int64
+	Count    uint32
+	Price    uint32
+}
+
+type Checkout struct {
+	ID               int64
+	UserID           int64
+	Status           CheckoutStatus
+	Step             CheckoutStep
+	Lines            []CheckoutLine
+	TotalPrice       uint64
+	PaymentCard      PaymentCard
+	PaymentID        string
+	PaymentActionURL string
+	Attempts         int
+	LastError        string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// CreateCheckout starts a checkout paid with PaymentMethod, the card number or
+// provider token the customer entered. It is only passed to the provider to tokenize.
+type CreateCheckout struct {
+	UserID        int64
+	PaymentMethod string
+}
+
+// CheckoutAdvance moves a saga past its current step. PaymentID is kept when empty.
+type CheckoutAdvance struct {
+	Status    CheckoutStatus
+	Step      CheckoutStep
+	PaymentID string
+	LastError string
+}
+
+type GetCheckout struct {
+	UserID     int64
+	CheckoutID int64
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// IdempotencyRecord is the outcome of a request sent by UserID with an idempotency key.
+// Keys of different users do not collide.
+type IdempotencyRecord struct {
+	UserID      int64
+	Key         string
+	Method      string
+	RequestHash string
+	Response    []byte
+	ExpiresAt   time.Time
+}
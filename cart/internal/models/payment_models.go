@@ -0,0 +1,56 @@
+package models
+
+type PaymentStatus string
+
+const (
+	PaymentAuthorized PaymentStatus = "authorized"
+	// PaymentRequiresAction waits for the customer to pass 3-D Secure at ActionURL.
+	PaymentRequiresAction PaymentStatus = "requires_action"
+	PaymentCaptured       PaymentStatus = "captured"
+	PaymentVoided         PaymentStatus = "voided"
+	PaymentRefunded       PaymentStatus = "refunded"
+	PaymentDeclined       PaymentStatus = "declined"
+)
+
+type Payment struct {
+	ID             string
+	Reference      string
+	Status         PaymentStatus
+	Amount         uint64
+	CapturedAmount uint64
+	RefundedAmount uint64
+	ActionURL      string
+}
+
+// PaymentCard is a payment method as the provider tokenized it. Token is all that is
+// needed to pay with it; Brand and Last4 are for showing it. The card number itself is
+// never kept.
+type PaymentCard struct {
+	Token string
+	Brand string
+	Last4 string
+}
+
+// AuthorizePayment asks the provider to hold Amount on the payment method of Token.
+// Reference is unique per purchase, so a retried authorization returns the first
+// payment.
+type AuthorizePayment struct {
+	Reference string
+	UserID    int64
+	Amount    uint64
+	Token     string
+}
+
+type PaymentEventType string
+
+const (
+	PaymentEventAuthorized PaymentEventType = "payment.authorized"
+	PaymentEventDeclined   PaymentEventType = "payment.declined"
+)
+
+// PaymentEvent is a verified webhook notification of the provider.
+type PaymentEvent struct {
+	Type      PaymentEventType
+	PaymentID string
+	Reference string
+}
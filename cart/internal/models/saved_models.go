@@ -0,0 +1,30 @@
+package models
+
+type SavedItem struct {
+	UserID        int64
+	SKU           uint32
+	SellerID      int64
+	Count         uint32
+	LastSeenCount *uint32
+}
+
+type MoveSavedItem struct {
+	UserID          int64
+	SKU             uint32
+	ExpectedVersion *uint64
+}
+
+type SavedItemModel struct {
+	SKU            uint32
+	SellerID       int64
+	Count          uint32
+	Name           string
+	Price          uint32
+	AvailableCount uint32
+	InStock        bool
+	BackInStock    bool
+}
+
+type SavedItemsList struct {
+	Items []SavedItemModel
+}
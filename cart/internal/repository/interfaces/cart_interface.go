@@ -6,9 +6,12 @@ import (
 )
 
 type CartRepository interface {
-	AddItem(ctx context.Context, item models.CartItem) (int64, error)
-	CartItemCount(ctx context.Context, userID int64, sku uint32) (uint32, error)
-	DeleteCartItem(ctx context.Context, userID int64, sku uint32) error
+	AddItem(ctx context.Context, item models.CartItem) (int64, uint64, error)
+	CartItem(ctx context.Context, userID int64, sku uint32) (models.CartItem, error)
+	CartVersion(ctx context.Context, userID int64) (uint64, error)
+	DeleteCartItem(ctx context.Context, item models.DeleteCartItem) (uint64, error)
 	ListItems(ctx context.Context, userID int64) ([]models.CartItem, error)
-	ClearCart(ctx context.Context, userID int64) error
+	ClearCart(ctx context.Context, params models.ClearCart) (uint64, error)
+	PurgeExpiredCarts(ctx context.Context, params models.PurgeExpiredCarts) ([]models.ExpiredCart, error)
+	FixItems(ctx context.Context, userID int64, expected *uint64, lines []models.CartLineValidation) (uint64, error)
 }
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"cart/internal/models"
+	"context"
+	"time"
+)
+
+type CheckoutRepository interface {
+	CreateCheckout(ctx context.Context, userID int64, card models.PaymentCard, lines []models.CheckoutLine, totalPrice uint64) (models.Checkout, error)
+	GetCheckout(ctx context.Context, checkoutID int64) (models.Checkout, error)
+	ClaimStep(ctx context.Context, checkoutID int64, step models.CheckoutStep, lease time.Duration) (models.Checkout, error)
+	AdvanceStep(ctx context.Context, checkoutID int64, from models.CheckoutStep, next models.CheckoutAdvance) (models.Checkout, error)
+	FailAttempt(ctx context.Context, checkoutID int64, step models.CheckoutStep, lastError string) error
+	AwaitAction(ctx context.Context, checkoutID int64, step models.CheckoutStep, payment models.Payment, wait time.Duration) error
+	WakeStep(ctx context.Context, checkoutID int64, step models.CheckoutStep) (models.Checkout, error)
+	CompleteCheckout(ctx context.Context, checkout models.Checkout) (models.Checkout, error)
+	ListStalled(ctx context.Context, olderThan time.Duration, limit int) ([]models.Checkout, error)
+}
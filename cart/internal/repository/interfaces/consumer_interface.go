@@ -0,0 +1,12 @@
+package interfaces
+
+import "context"
+
+type KafkaHandler interface {
+	HandleMessage(message []byte) error
+}
+
+type KafkaConsumer interface {
+	Start(ctx context.Context) error
+	Close() error
+}
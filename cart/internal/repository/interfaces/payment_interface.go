@@ -0,0 +1,35 @@
+package interfaces
+
+import (
+	"cart/internal/models"
+	"context"
+)
+
+type PaymentProvider interface {
+	// Tokenize turns the payment method the customer entered into a token of the
+	// provider, with the brand and last four digits of the card.
+	Tokenize(ctx context.Context, paymentMethod string) (models.PaymentCard, error)
+	// Authorize holds the amount on the payment method. Authorizing a reference again
+	// returns its first payment. A payment that needs 3-D Secure is returned as
+	// requires_action and is decided by a later webhook.
+	Authorize(ctx context.Context, params models.AuthorizePayment) (models.Payment, error)
+	// Capture charges amount of an authorized payment. Capturing it again is a no-op.
+	Capture(ctx context.Context, paymentID string, amount uint64) (models.Payment, error)
+	// Void cancels a payment that is not captured. Voiding it again is a no-op.
+	Void(ctx context.Context, paymentID string) (models.Payment, error)
+	// Refund pays amount of a captured payment back.
+	Refund(ctx context.Context, paymentID string, amount uint64) (models.Payment, error)
+	// VerifyWebhook checks the signature of a webhook payload and returns its event.
+	VerifyWebhook(ctx context.Context, payload []byte, signature string) (models.PaymentEvent, error)
+}
+
+// SimulatedPaymentRepository keeps the payments of the simulator provider, so they
+// outlive restarts and every replica sees the same ones.
+type SimulatedPaymentRepository interface {
+	// CreatePayment stores payment unless one with its id exists, and returns the
+	// stored one.
+	CreatePayment(ctx context.Context, payment models.Payment) (models.Payment, error)
+	// UpdatePayment applies change to a payment under a row lock and stores the result.
+	// It returns ErrNotFound for an unknown payment and stores nothing when change fails.
+	UpdatePayment(ctx context.Context, paymentID string, change func(payment *models.Payment) error) (models.Payment, error)
+}
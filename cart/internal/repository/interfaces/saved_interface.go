@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"cart/internal/models"
+	"context"
+)
+
+type SavedRepository interface {
+	MoveToSaved(ctx context.Context, params models.MoveSavedItem, lastSeenCount *uint32) (uint64, error)
+	MoveToCart(ctx context.Context, params models.MoveSavedItem, offer models.StockItem) (uint64, error)
+	SavedItem(ctx context.Context, userID int64, sku uint32) (models.SavedItem, error)
+	ListSaved(ctx context.Context, userID int64) ([]models.SavedItem, error)
+	UpdateLastSeenCount(ctx context.Context, userID int64, sku uint32, count uint32) error
+}
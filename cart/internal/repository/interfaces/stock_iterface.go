@@ -3,9 +3,25 @@ package interfaces
 import (
 	"cart/internal/models"
 	"context"
+	"time"
 )
 
 type StockService interface {
-	GetSKU(ctx context.Context, sku uint32) (models.StockItem, error)
+	// GetOffer returns the offer of the seller for sku, or its default offer when
+	// sellerID is zero.
+	GetOffer(ctx context.Context, sku uint32, sellerID int64) (models.StockItem, error)
+	// ReserveStock takes the units of all lines out of their offers for the checkout,
+	// all or none. Reserving a checkout again is a no-op.
+	ReserveStock(ctx context.Context, checkoutID string, lines []models.CheckoutLine) error
+	CommitReservation(ctx context.Context, checkoutID string) error
+	ReleaseReservation(ctx context.Context, checkoutID string) error
+	Ping(ctx context.Context) error
+	// SetTimeout changes the deadline of later calls to the stocks service.
+	SetTimeout(timeout time.Duration)
 	Close() error
 }
+
+type StockCache interface {
+	StockService
+	Invalidate(sku uint32, sellerID int64)
+}
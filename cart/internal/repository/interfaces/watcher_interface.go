@@ -0,0 +1,8 @@
+package interfaces
+
+import "context"
+
+type CartWatcher interface {
+	Subscribe(userID int64) (<-chan uint64, func())
+	Run(ctx context.Context) error
+}
@@ -0,0 +1,105 @@
+package kafka
+
+import (
+	"cart/internal/repository/interfaces"
+	"cart/pkg/log"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+const pollTimeoutMs = 100
+
+type Consumer struct {
+	consumer *kafka.Consumer
+	handler  interfaces.KafkaHandler
+	logger   log.Logger
+
+	// mu keeps Close from running in the middle of a Poll.
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewConsumer subscribes to topic starting from the latest offset. Messages are
+// processed at most once, which suits cache invalidation.
+func NewConsumer(handler interfaces.KafkaHandler, address []string, topic, groupID string, logger log.Logger) (interfaces.KafkaConsumer, error) {
+	if len(address) == 0 {
+		return nil, fmt.Errorf("kafka broker address list is empty")
+	}
+
+	conf := &kafka.ConfigMap{
+		"bootstrap.servers":  strings.Join(address, ","),
+		"group.id":           groupID,
+		"enable.auto.commit": true,
+		"auto.offset.reset":  "latest",
+	}
+
+	c, err := kafka.NewConsumer(conf)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kafka consumer: %w", err)
+	}
+
+	if err := c.Subscribe(topic, nil); err != nil {
+		return nil, fmt.Errorf("error subscribing to kafka topic: %w", err)
+	}
+
+	return &Consumer{
+		consumer: c,
+		handler:  handler,
+		logger:   logger,
+	}, nil
+}
+
+func (c *Consumer) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+			ev, ok := c.poll()
+			if !ok {
+				return nil
+			}
+
+			if ev == nil {
+				continue
+			}
+
+			switch msg := ev.(type) {
+			case *kafka.Message:
+				if err := c.handler.HandleMessage(msg.Value); err != nil {
+					c.logger.Errorf("err in handle kafka message: %v", err)
+				}
+			case kafka.Error:
+				c.logger.Errorf("kafka consumer error: %v", msg)
+			}
+		}
+	}
+}
+
+func (c *Consumer) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+
+	c.closed = true
+
+	return c.consumer.Close()
+}
+
+func (c *Consumer) poll() (kafka.Event, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, false
+	}
+
+	return c.consumer.Poll(pollTimeoutMs), true
+}
@@ -2,17 +2,20 @@ package kafka
 
 import (
 	"cart/internal/repository/interfaces"
+	"context"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel"
 )
 
 const (
-	flushTimeout       = 5000
-	partition    int32 = 0
+	flushTimeout          = 5000
+	partition       int32 = 0
+	metadataTimeout       = 2 * time.Second
 )
 
 type Producer struct {
@@ -52,7 +55,11 @@ func NewProducer(address []string, topic string) (interfaces.KafkaProd, error) {
 	return &Producer{producer: prod, topic: topic}, nil
 }
 
-func (p *Producer) Produce(message []byte, key string, t time.Time) error {
+// Produce sends the message with the trace context of ctx in its headers.
+func (p *Producer) Produce(ctx context.Context, message []byte, key string, t time.Time) error {
+	var headers []kafka.Header
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier{headers: &headers})
+
 	kafkaMessage := &kafka.Message{
 		TopicPartition: kafka.TopicPartition{
 			Topic:     &p.topic,
@@ -61,6 +68,7 @@ func (p *Producer) Produce(message []byte, key string, t time.Time) error {
 		Value:     message,
 		Key:       []byte(key),
 		Timestamp: t,
+		Headers:   headers,
 	}
 
 	if err := p.producer.Produce(kafkaMessage, nil); err != nil {
@@ -70,6 +78,20 @@ func (p *Producer) Produce(message []byte, key string, t time.Time) error {
 	return nil
 }
 
+// Ping checks that the brokers are reachable by fetching the topic metadata.
+func (p *Producer) Ping(ctx context.Context) error {
+	timeout := metadataTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	if _, err := p.producer.GetMetadata(&p.topic, false, int(timeout.Milliseconds())); err != nil {
+		return fmt.Errorf("error fetching kafka metadata: %w", err)
+	}
+
+	return nil
+}
+
 func (p *Producer) Close() {
 	p.producer.Flush(flushTimeout)
 	p.producer.Close()
@@ -0,0 +1,291 @@
+package payment
+
+import (
+	"cart/internal/config"
+	"cart/internal/constants"
+	"cart/internal/models"
+	"cart/internal/repository/interfaces"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Outcomes of simulated authorizations.
+const (
+	OutcomeSucceed = "succeed"
+	OutcomeDecline = "decline"
+	OutcomeTimeout = "timeout"
+	OutcomeThreeDS = "three_ds"
+)
+
+const (
+	simulatorActionURL   = "https://payments.simulator.local/3ds/"
+	simulatorTokenPrefix = "sim_tok_"
+	simulatorPaymentID   = "sim_pay_"
+)
+
+var outcomes = map[string]struct{}{
+	OutcomeSucceed: {},
+	OutcomeDecline: {},
+	OutcomeTimeout: {},
+	OutcomeThreeDS: {},
+}
+
+// simulatorWebhook is the body of a simulator webhook call.
+type simulatorWebhook struct {
+	Type      string `json:"type"`
+	PaymentID string `json:"payment_id"`
+}
+
+// simulator is an in-process payment provider for development and end to end tests.
+// The outcome of an authorization depends only on the card number, so runs repeat:
+// configured cards decline, time out or require 3-D Secure, all others succeed.
+// Tokenizing a card puts its outcome into the token, the number is not kept anywhere.
+// Payments are stored in Postgres with an id derived from their reference, so they are
+// shared by all replicas and outlive restarts.
+// Webhooks are signed with the hex HMAC-SHA256 of the body under the webhook secret.
+// A verified webhook also settles the 3-D Secure challenge of its payment, standing in
+// for the customer.
+type simulator struct {
+	outcomes map[string]string
+	secret   []byte
+	payments interfaces.SimulatedPaymentRepository
+}
+
+func NewSimulator(cfg config.Payment, payments interfaces.SimulatedPaymentRepository) (interfaces.PaymentProvider, error) {
+	cards := make(map[string]string, len(cfg.Simulator.Cards))
+	for _, card := range cfg.Simulator.Cards {
+		if _, ok := outcomes[card.Outcome]; !ok {
+			return nil, fmt.Errorf("unknown outcome %q of simulated card ending in %s", card.Outcome, last4(card.Number))
+		}
+
+		cards[card.Number] = card.Outcome
+	}
+
+	return &simulator{
+		outcomes: cards,
+		secret:   []byte(cfg.WebhookSecret),
+		payments: payments,
+	}, nil
+}
+
+// Tokenize takes a card number of 12 to 19 digits and returns a token holding the
+// outcome of the card and its last four digits.
+func (s *simulator) Tokenize(ctx context.Context, paymentMethod string) (models.PaymentCard, error) {
+	if err := ctx.Err(); err != nil {
+		return models.PaymentCard{}, err
+	}
+
+	if len(paymentMethod) < 12 || len(paymentMethod) > 19 || strings.Trim(paymentMethod, "0123456789") != "" {
+		return models.PaymentCard{}, fmt.Errorf("%w: not a card number", constants.ErrInvalidPaymentMethod)
+	}
+
+	outcome, ok := s.outcomes[paymentMethod]
+	if !ok {
+		outcome = OutcomeSucceed
+	}
+
+	return models.PaymentCard{
+		Token: simulatorTokenPrefix + outcome + "_" + last4(paymentMethod),
+		Brand: cardBrand(paymentMethod),
+		Last4: last4(paymentMethod),
+	}, nil
+}
+
+// Authorize decides by the outcome in params.Token. Cards that time out never answer,
+// the call returns once ctx is done. Tokens the simulator did not issue are declined.
+func (s *simulator) Authorize(ctx context.Context, params models.AuthorizePayment) (models.Payment, error) {
+	if err := ctx.Err(); err != nil {
+		return models.Payment{}, err
+	}
+
+	outcome, ok := tokenOutcome(params.Token)
+	if !ok {
+		return models.Payment{}, fmt.Errorf("%w: unknown payment token", constants.ErrPaymentDeclined)
+	}
+
+	if outcome == OutcomeTimeout {
+		<-ctx.Done()
+		return models.Payment{}, ctx.Err()
+	}
+
+	payment := models.Payment{
+		ID:        simulatorPaymentID + params.Reference,
+		Reference: params.Reference,
+		Status:    models.PaymentAuthorized,
+		Amount:    params.Amount,
+	}
+
+	switch outcome {
+	case OutcomeDecline:
+		payment.Status = models.PaymentDeclined
+	case OutcomeThreeDS:
+		payment.Status = models.PaymentRequiresAction
+		payment.ActionURL = simulatorActionURL + payment.ID
+	}
+
+	stored, err := s.payments.CreatePayment(ctx, payment)
+	if err != nil {
+		return models.Payment{}, err
+	}
+
+	return s.result(stored)
+}
+
+func (s *simulator) Capture(ctx context.Context, paymentID string, amount uint64) (models.Payment, error) {
+	return s.update(ctx, paymentID, func(payment *models.Payment) error {
+		switch {
+		case payment.Status == models.PaymentCaptured && payment.CapturedAmount == amount:
+			return nil
+		case payment.Status != models.PaymentAuthorized || amount > payment.Amount:
+			return fmt.Errorf("%w: capture %d of %s payment of %d", constants.ErrPaymentState, amount, payment.Status, payment.Amount)
+		}
+
+		payment.Status = models.PaymentCaptured
+		payment.CapturedAmount = amount
+
+		return nil
+	})
+}
+
+func (s *simulator) Void(ctx context.Context, paymentID string) (models.Payment, error) {
+	return s.update(ctx, paymentID, func(payment *models.Payment) error {
+		switch payment.Status {
+		case models.PaymentVoided, models.PaymentDeclined:
+			return nil
+		case models.PaymentAuthorized, models.PaymentRequiresAction:
+			payment.Status = models.PaymentVoided
+			payment.ActionURL = ""
+
+			return nil
+		default:
+			return fmt.Errorf("%w: void %s payment", constants.ErrPaymentState, payment.Status)
+		}
+	})
+}
+
+func (s *simulator) Refund(ctx context.Context, paymentID string, amount uint64) (models.Payment, error) {
+	return s.update(ctx, paymentID, func(payment *models.Payment) error {
+		refundable := payment.CapturedAmount - payment.RefundedAmount
+
+		if payment.Status != models.PaymentCaptured || amount == 0 || amount > refundable {
+			return fmt.Errorf("%w: refund %d of %s payment with %d refundable", constants.ErrPaymentState, amount, payment.Status, refundable)
+		}
+
+		payment.RefundedAmount += amount
+		if payment.RefundedAmount == payment.CapturedAmount {
+			payment.Status = models.PaymentRefunded
+		}
+
+		return nil
+	})
+}
+
+// VerifyWebhook accepts payment.authorized and payment.declined for payments waiting
+// for 3-D Secure and settles them accordingly. Without a webhook secret every call is
+// rejected.
+func (s *simulator) VerifyWebhook(ctx context.Context, payload []byte, signature string) (models.PaymentEvent, error) {
+	if len(s.secret) == 0 || !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		return models.PaymentEvent{}, constants.ErrWebhookSignature
+	}
+
+	var webhook simulatorWebhook
+	if err := json.Unmarshal(payload, &webhook); err != nil {
+		return models.PaymentEvent{}, fmt.Errorf("failed to decode webhook: %w", err)
+	}
+
+	payment, err := s.payments.UpdatePayment(ctx, webhook.PaymentID, func(payment *models.Payment) error {
+		if payment.Status != models.PaymentRequiresAction {
+			return nil
+		}
+
+		switch models.PaymentEventType(webhook.Type) {
+		case models.PaymentEventAuthorized:
+			payment.Status = models.PaymentAuthorized
+			payment.ActionURL = ""
+		case models.PaymentEventDeclined:
+			payment.Status = models.PaymentDeclined
+			payment.ActionURL = ""
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.PaymentEvent{}, err
+	}
+
+	return models.PaymentEvent{
+		Type:      models.PaymentEventType(webhook.Type),
+		PaymentID: payment.ID,
+		Reference: payment.Reference,
+	}, nil
+}
+
+func (s *simulator) update(ctx context.Context, paymentID string, change func(payment *models.Payment) error) (models.Payment, error) {
+	if err := ctx.Err(); err != nil {
+		return models.Payment{}, err
+	}
+
+	return s.payments.UpdatePayment(ctx, paymentID, change)
+}
+
+func (s *simulator) result(payment models.Payment) (models.Payment, error) {
+	if payment.Status == models.PaymentDeclined {
+		return payment, fmt.Errorf("%w: card of payment %s was declined", constants.ErrPaymentDeclined, payment.ID)
+	}
+
+	return payment, nil
+}
+
+func (s *simulator) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// tokenOutcome reads the outcome out of a token issued by Tokenize.
+func tokenOutcome(token string) (string, bool) {
+	rest, ok := strings.CutPrefix(token, simulatorTokenPrefix)
+	if !ok {
+		return "", false
+	}
+
+	// Outcomes may contain underscores, the last four digits follow the last one.
+	outcome := rest
+	if i := strings.LastIndex(rest, "_"); i >= 0 {
+		outcome = rest[:i]
+	}
+
+	if _, ok := outcomes[outcome]; !ok {
+		return "", false
+	}
+
+	return outcome, true
+}
+
+// cardBrand tells the brand of a card number by its leading digits.
+func cardBrand(number string) string {
+	switch {
+	case strings.HasPrefix(number, "4"):
+		return "visa"
+	case number[:2] >= "51" && number[:2] <= "55", number[:2] >= "22" && number[:2] <= "27":
+		return "mastercard"
+	case strings.HasPrefix(number, "34"), strings.HasPrefix(number, "37"):
+		return "amex"
+	default:
+		return "card"
+	}
+}
+
+func last4(number string) string {
+	if len(number) <= 4 {
+		return number
+	}
+
+	return number[len(number)-4:]
+}
@@ -0,0 +1,188 @@
+package payment
+
+import (
+	"cart/internal/config"
+	"cart/internal/constants"
+	"cart/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// memoryPayments keeps simulated payments like the Postgres repository does.
+type memoryPayments struct {
+	payments map[string]models.Payment
+}
+
+func (m *memoryPayments) CreatePayment(_ context.Context, payment models.Payment) (models.Payment, error) {
+	if stored, ok := m.payments[payment.ID]; ok {
+		return stored, nil
+	}
+
+	m.payments[payment.ID] = payment
+
+	return payment, nil
+}
+
+func (m *memoryPayments) UpdatePayment(_ context.Context, paymentID string, change func(payment *models.Payment) error) (models.Payment, error) {
+	payment, ok := m.payments[paymentID]
+	if !ok {
+		return models.Payment{}, fmt.Errorf("%w: payment %s", constants.ErrNotFound, paymentID)
+	}
+
+	if err := change(&payment); err != nil {
+		return models.Payment{}, err
+	}
+
+	m.payments[paymentID] = payment
+
+	return payment, nil
+}
+
+const (
+	succeedCard = "4242424242424242"
+	declineCard = "4000000000000002"
+	threeDSCard = "5200000000003220"
+)
+
+func newTestSimulator(t *testing.T) (*simulator, *memoryPayments) {
+	t.Helper()
+
+	payments := &memoryPayments{payments: make(map[string]models.Payment)}
+
+	provider, err := NewSimulator(config.Payment{
+		WebhookSecret: "secret",
+		Simulator: config.PaymentSimulator{Cards: []config.SimulatedCard{
+			{Number: declineCard, Outcome: OutcomeDecline},
+			{Number: threeDSCard, Outcome: OutcomeThreeDS},
+		}},
+	}, payments)
+	if err != nil {
+		t.Fatalf("NewSimulator() error = %v", err)
+	}
+
+	return provider.(*simulator), payments
+}
+
+func authorize(t *testing.T, s *simulator, reference, card string) (models.Payment, error) {
+	t.Helper()
+
+	tokenized, err := s.Tokenize(context.Background(), card)
+	if err != nil {
+		t.Fatalf("Tokenize() error = %v", err)
+	}
+
+	return s.Authorize(context.Background(), models.AuthorizePayment{Reference: reference, Amount: 500, Token: tokenized.Token})
+}
+
+func TestTokenize(t *testing.T) {
+	s, _ := newTestSimulator(t)
+
+	card, err := s.Tokenize(context.Background(), threeDSCard)
+	if err != nil {
+		t.Fatalf("Tokenize() error = %v", err)
+	}
+
+	if card.Brand != "mastercard" || card.Last4 != "3220" {
+		t.Errorf("Tokenize() = %+v, want a mastercard ending in 3220", card)
+	}
+
+	if strings.Contains(card.Token, threeDSCard) {
+		t.Errorf("token %q holds the card number", card.Token)
+	}
+
+	for _, method := range []string{"", "1234", "4242-4242-4242-4242", "sim_tok_succeed_4242"} {
+		if _, err := s.Tokenize(context.Background(), method); !errors.Is(err, constants.ErrInvalidPaymentMethod) {
+			t.Errorf("Tokenize(%q) error = %v, want ErrInvalidPaymentMethod", method, err)
+		}
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	s, _ := newTestSimulator(t)
+
+	payment, err := authorize(t, s, "1", succeedCard)
+	if err != nil || payment.Status != models.PaymentAuthorized {
+		t.Fatalf("Authorize() = %+v, %v, want authorized", payment, err)
+	}
+
+	// A retry, from this or another replica, finds the first payment.
+	again, err := authorize(t, s, "1", succeedCard)
+	if err != nil || again.ID != payment.ID {
+		t.Errorf("Authorize() again = %+v, %v, want payment %s", again, err, payment.ID)
+	}
+
+	if _, err := authorize(t, s, "2", declineCard); !errors.Is(err, constants.ErrPaymentDeclined) {
+		t.Errorf("Authorize() of a declined card error = %v, want ErrPaymentDeclined", err)
+	}
+
+	_, err = s.Authorize(context.Background(), models.AuthorizePayment{Reference: "3", Amount: 500, Token: succeedCard})
+	if !errors.Is(err, constants.ErrPaymentDeclined) {
+		t.Errorf("Authorize() with a card number for token error = %v, want ErrPaymentDeclined", err)
+	}
+}
+
+func TestCaptureAndRefund(t *testing.T) {
+	s, _ := newTestSimulator(t)
+	ctx := context.Background()
+
+	payment, err := authorize(t, s, "1", succeedCard)
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		captured, err := s.Capture(ctx, payment.ID, 500)
+		if err != nil || captured.Status != models.PaymentCaptured {
+			t.Fatalf("Capture() #%d = %+v, %v, want captured", i+1, captured, err)
+		}
+	}
+
+	if _, err := s.Void(ctx, payment.ID); !errors.Is(err, constants.ErrPaymentState) {
+		t.Errorf("Void() of a captured payment error = %v, want ErrPaymentState", err)
+	}
+
+	refunded, err := s.Refund(ctx, payment.ID, 500)
+	if err != nil || refunded.Status != models.PaymentRefunded {
+		t.Errorf("Refund() = %+v, %v, want refunded", refunded, err)
+	}
+
+	if _, err := s.Capture(ctx, "sim_pay_unknown", 500); !errors.Is(err, constants.ErrNotFound) {
+		t.Errorf("Capture() of an unknown payment error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestWebhookSettlesThreeDS(t *testing.T) {
+	s, payments := newTestSimulator(t)
+
+	payment, err := authorize(t, s, "7", threeDSCard)
+	if err != nil || payment.Status != models.PaymentRequiresAction || payment.ActionURL == "" {
+		t.Fatalf("Authorize() = %+v, %v, want requires_action with an action URL", payment, err)
+	}
+
+	body := []byte(`{"type": "payment.authorized", "payment_id": "` + payment.ID + `"}`)
+
+	if _, err := s.VerifyWebhook(context.Background(), body, "bad"); !errors.Is(err, constants.ErrWebhookSignature) {
+		t.Fatalf("VerifyWebhook() with a bad signature error = %v, want ErrWebhookSignature", err)
+	}
+
+	event, err := s.VerifyWebhook(context.Background(), body, s.sign(body))
+	if err != nil {
+		t.Fatalf("VerifyWebhook() error = %v", err)
+	}
+
+	if event.Reference != "7" || event.Type != models.PaymentEventAuthorized {
+		t.Errorf("VerifyWebhook() = %+v, want payment.authorized of reference 7", event)
+	}
+
+	if stored := payments.payments[payment.ID]; stored.Status != models.PaymentAuthorized || stored.ActionURL != "" {
+		t.Errorf("stored payment = %+v, want authorized", stored)
+	}
+
+	again, err := authorize(t, s, "7", threeDSCard)
+	if err != nil || again.Status != models.PaymentAuthorized {
+		t.Errorf("Authorize() after the webhook = %+v, %v, want authorized", again, err)
+	}
+}
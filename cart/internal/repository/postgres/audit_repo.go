@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"cart/internal/models"
+	"cart/internal/repository/interfaces"
+	"cart/pkg/postgresql"
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type auditRepo struct {
+	db postgresql.Client
+}
+
+func NewAuditRepository(db postgresql.Client) interfaces.AuditRepository {
+	return &auditRepo{db: db}
+}
+
+func (r *auditRepo) Insert(ctx context.Context, event models.AuditEvent) (models.AuditEvent, error) {
+	query := `
+		INSERT INTO audit_log (actor_id, method, entity_type, entity_id, request_hash, before, after, status, trace_id)
+		VALUES (@actorID, @method, @entityType, @entityID, @requestHash, @before, @after, @status, NULLIF(@traceID, ''))
+		RETURNING id, created_at
+	`
+	args := pgx.NamedArgs{
+		"actorID":     event.ActorID,
+		"method":      event.Method,
+		"entityType":  event.EntityType,
+		"entityID":    event.EntityID,
+		"requestHash": event.RequestHash,
+		"before":      jsonOrNil(event.Before),
+		"after":       jsonOrNil(event.After),
+		"status":      event.Status,
+		"traceID":     event.TraceID,
+	}
+
+	if err := r.db.QueryRow(ctx, query, args).Scan(&event.ID, &event.CreatedAt); err != nil {
+		return models.AuditEvent{}, err
+	}
+
+	return event, nil
+}
+
+// List returns the events matching filter, newest first.
+func (r *auditRepo) List(ctx context.Context, filter models.AuditFilter) ([]models.AuditEvent, error) {
+	var (
+		events     []models.AuditEvent
+		conditions []string
+		args       = pgx.NamedArgs{"limit": filter.Limit}
+	)
+
+	if filter.ActorID != nil {
+		conditions = append(conditions, "actor_id = @actorID")
+		args["actorID"] = *filter.ActorID
+	}
+
+	if filter.EntityType != "" {
+		conditions = append(conditions, "entity_type = @entityType")
+		args["entityType"] = filter.EntityType
+	}
+
+	if filter.EntityID != "" {
+		conditions = append(conditions, "entity_id = @entityID")
+		args["entityID"] = filter.EntityID
+	}
+
+	if filter.From != nil {
+		conditions = append(conditions, "created_at >= @from")
+		args["from"] = *filter.From
+	}
+
+	if filter.To != nil {
+		conditions = append(conditions, "created_at < @to")
+		args["to"] = *filter.To
+	}
+
+	if filter.BeforeID > 0 {
+		conditions = append(conditions, "id < @beforeID")
+		args["beforeID"] = filter.BeforeID
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := `
+		SELECT
+			id, actor_id, method, entity_type, entity_id, request_hash,
+			before, after, status, trace_id, created_at
+		FROM audit_log
+		` + where + `
+		ORDER BY id DESC
+		LIMIT @limit
+	`
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event DbAuditEvent
+
+		err := rows.Scan(
+			&event.ID, &event.ActorID, &event.Method, &event.EntityType, &event.EntityID, &event.RequestHash,
+			&event.Before, &event.After, &event.Status, &event.TraceID, &event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event.ToDomain())
+	}
+
+	return events, rows.Err()
+}
+
+// jsonOrNil stores a missing snapshot as SQL NULL rather than an empty JSONB value.
+func jsonOrNil(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+
+	return string(data)
+}
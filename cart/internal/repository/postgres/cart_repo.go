@@ -19,35 +19,40 @@ func NewRepository(db postgresql.Client) interfaces.CartRepository {
 	return &cartRepo{db: db}
 }
 
-func (r *cartRepo) AddItem(ctx context.Context, item models.CartItem) (int64, error) {
+func (r *cartRepo) AddItem(ctx context.Context, item models.CartItem) (int64, uint64, error) {
 	var cartId int64
 	query := `
-		INSERT INTO cart (user_id, sku, count)
-		VALUES (@userID, @sku, @count)
+		INSERT INTO cart (user_id, sku, seller_id, count, price)
+		VALUES (@userID, @sku, @sellerID, @count, @price)
 		ON CONFLICT (user_id, sku)
-		DO UPDATE SET count = cart.count + EXCLUDED.count
+		DO UPDATE SET count = cart.count + EXCLUDED.count, seller_id = EXCLUDED.seller_id, price = EXCLUDED.price
 		RETURNING cart.id
 	`
 	args := pgx.NamedArgs{
-		"userID": item.UserID,
-		"sku":    item.SKU,
-		"count":  item.Count,
+		"userID":   item.UserID,
+		"sku":      item.SKU,
+		"sellerID": item.SellerID,
+		"count":    item.Count,
+		"price":    item.Price,
 	}
 
-	err := r.db.QueryRow(ctx, query, args).Scan(&cartId)
+	version, err := withVersionCheck(ctx, r.db, item.UserID, item.ExpectedVersion, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, query, args).Scan(&cartId)
+	})
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
-	return cartId, nil
+	return cartId, version, nil
 }
 
-func (r *cartRepo) CartItemCount(ctx context.Context, userID int64, sku uint32) (uint32, error) {
-	var itemCount uint32
+// CartItem returns the cart line of the SKU, a zero line when the SKU is not in the cart.
+func (r *cartRepo) CartItem(ctx context.Context, userID int64, sku uint32) (models.CartItem, error) {
+	item := DbCartItem{UserID: userID, SKU: sku}
 
 	query := `
 		SELECT
-			count
+			seller_id, count
 		FROM cart
 		WHERE user_id = @user_id AND sku = @sku
 	`
@@ -56,22 +61,22 @@ func (r *cartRepo) CartItemCount(ctx context.Context, userID int64, sku uint32)
 		"sku":     sku,
 	}
 
-	err := r.db.QueryRow(ctx, query, args).Scan(&itemCount)
+	err := r.db.QueryRow(ctx, query, args).Scan(&item.SellerID, &item.Count)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return 0, nil
+			return models.CartItem{UserID: userID, SKU: sku}, nil
 		}
 
-		return 0, err
+		return models.CartItem{}, err
 	}
 
-	return itemCount, nil
+	return item.ToDomain(), nil
 }
 
 func (r *cartRepo) ListItems(ctx context.Context, userID int64) ([]models.CartItem, error) {
 	var items []models.CartItem
 
-	query := `SELECT sku, count FROM cart WHERE user_id = @userID`
+	query := `SELECT sku, seller_id, count, price FROM cart WHERE user_id = @userID ORDER BY id`
 
 	args := pgx.NamedArgs{
 		"userID": userID,
@@ -87,7 +92,7 @@ func (r *cartRepo) ListItems(ctx context.Context, userID int64) ([]models.CartIt
 		var item DbCartItem
 		item.UserID = userID
 
-		if err := rows.Scan(&item.SKU, &item.Count); err != nil {
+		if err := rows.Scan(&item.SKU, &item.SellerID, &item.Count, &item.Price); err != nil {
 			return nil, err
 		}
 
@@ -97,41 +102,274 @@ func (r *cartRepo) ListItems(ctx context.Context, userID int64) ([]models.CartIt
 	return items, nil
 }
 
-func (r *cartRepo) DeleteCartItem(ctx context.Context, userID int64, sku uint32) error {
-	query := `DELETE FROM cart WHERE user_id = @userID AND sku = @sku`
+func (r *cartRepo) CartVersion(ctx context.Context, userID int64) (uint64, error) {
+	var version uint64
+
+	query := `SELECT version FROM cart_versions WHERE user_id = @userID`
 
 	args := pgx.NamedArgs{
 		"userID": userID,
-		"sku":    sku,
 	}
 
-	cmdTag, err := r.db.Exec(ctx, query, args)
+	err := r.db.QueryRow(ctx, query, args).Scan(&version)
 	if err != nil {
-		return err
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, err
 	}
 
-	if cmdTag.RowsAffected() == 0 {
-		return constants.ErrNotRowAffected
+	return version, nil
+}
+
+func (r *cartRepo) DeleteCartItem(ctx context.Context, item models.DeleteCartItem) (uint64, error) {
+	query := `DELETE FROM cart WHERE user_id = @userID AND sku = @sku`
+
+	args := pgx.NamedArgs{
+		"userID": item.UserID,
+		"sku":    item.SKU,
 	}
 
-	return nil
+	return withVersionCheck(ctx, r.db, item.UserID, item.ExpectedVersion, func(tx pgx.Tx) error {
+		cmdTag, err := tx.Exec(ctx, query, args)
+		if err != nil {
+			return err
+		}
+
+		if cmdTag.RowsAffected() == 0 {
+			return constants.ErrNotRowAffected
+		}
+
+		return nil
+	})
 }
 
-func (r *cartRepo) ClearCart(ctx context.Context, userID int64) error {
+// FixItems applies the auto fix corrections to the cart in one version bump: removed
+// lines are deleted, the others get the fixed count and the current price.
+func (r *cartRepo) FixItems(ctx context.Context, userID int64, expected *uint64, lines []models.CartLineValidation) (uint64, error) {
+	deleteQuery := `DELETE FROM cart WHERE user_id = @userID AND sku = @sku`
+	updateQuery := `UPDATE cart SET count = @count, price = @price WHERE user_id = @userID AND sku = @sku`
+
+	return withVersionCheck(ctx, r.db, userID, expected, func(tx pgx.Tx) error {
+		for _, line := range lines {
+			args := pgx.NamedArgs{
+				"userID": userID,
+				"sku":    line.SKU,
+				"count":  line.Count,
+				"price":  line.Price,
+			}
+
+			query := updateQuery
+			if line.Fix == models.CartLineRemoved {
+				query = deleteQuery
+			}
+
+			if _, err := tx.Exec(ctx, query, args); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *cartRepo) ClearCart(ctx context.Context, params models.ClearCart) (uint64, error) {
 	query := `DELETE FROM cart WHERE user_id = @userID`
 
 	args := pgx.NamedArgs{
-		"userID": userID,
+		"userID": params.UserID,
 	}
 
-	cmdTag, err := r.db.Exec(ctx, query, args)
+	return withVersionCheck(ctx, r.db, params.UserID, params.ExpectedVersion, func(tx pgx.Tx) error {
+		cmdTag, err := tx.Exec(ctx, query, args)
+		if err != nil {
+			return err
+		}
+
+		if cmdTag.RowsAffected() == 0 {
+			return constants.ErrNotRowAffected
+		}
+
+		return nil
+	})
+}
+
+// expiredCartsQuery selects the carts nobody changed for older_than, limited to ids
+// unless they are NULL. Every change bumps the cart version, carts from before
+// versions existed fall back to their oldest line. Carts being checked out are never
+// expired.
+const expiredCartsQuery = `
+	SELECT c.user_id, COUNT(*), COALESCE(SUM(c.count), 0), COALESCE(v.updated_at, MIN(c.created_at))
+	FROM cart c
+	LEFT JOIN cart_versions v ON v.user_id = c.user_id
+	WHERE (@ids::BIGINT[] IS NULL OR c.user_id = ANY(@ids::BIGINT[]))
+		AND NOT EXISTS (
+			SELECT 1 FROM checkouts ch
+			WHERE ch.user_id = c.user_id AND ch.status IN ('running', 'compensating')
+		)
+	GROUP BY c.user_id, v.updated_at
+	HAVING COALESCE(v.updated_at, MIN(c.created_at)) < NOW() - make_interval(secs => @older_than)
+	ORDER BY 4
+	LIMIT @limit
+`
+
+// PurgeExpiredCarts empties up to params.Limit carts nobody changed for
+// params.OlderThan and returns them, oldest first, with the removed lines. Like any
+// other change it bumps their versions and notifies the cart watchers. Saved for later
+// items are kept. A dry run is rolled back.
+func (r *cartRepo) PurgeExpiredCarts(ctx context.Context, params models.PurgeExpiredCarts) (carts []models.ExpiredCart, err error) {
+	lockQuery := `SELECT user_id FROM cart_versions WHERE user_id = ANY(@ids) ORDER BY user_id FOR UPDATE`
+	deleteQuery := `DELETE FROM cart WHERE user_id = ANY(@ids) RETURNING user_id, sku, seller_id, count, price`
+	bumpQuery := `
+		INSERT INTO cart_versions (user_id, version)
+		SELECT UNNEST(@ids::BIGINT[]), 1
+		ON CONFLICT (user_id) DO UPDATE SET
+			version = cart_versions.version + 1,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING user_id, version
+	`
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil || params.DryRun {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				err = errors.Join(err, rbErr)
+			}
+		}
+	}()
+
+	candidates, err := expiredCarts(ctx, tx, params, nil)
+	if err != nil || len(candidates) == 0 {
+		return nil, err
 	}
 
-	if cmdTag.RowsAffected() == 0 {
-		return constants.ErrNotRowAffected
+	// Takes the locks the cart writers take, then checks again that nobody changed a
+	// cart meanwhile.
+	if _, err = tx.Exec(ctx, lockQuery, pgx.NamedArgs{"ids": expiredUserIDs(candidates)}); err != nil {
+		return nil, err
+	}
+
+	carts, err = expiredCarts(ctx, tx, params, expiredUserIDs(candidates))
+	if err != nil || len(carts) == 0 {
+		return nil, err
+	}
+
+	args := pgx.NamedArgs{"ids": expiredUserIDs(carts)}
+
+	items, err := deletedItems(ctx, tx, deleteQuery, args)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range carts {
+		carts[i].Items = items[carts[i].UserID]
+	}
+
+	versions, err := bumpedVersions(ctx, tx, bumpQuery, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delivered to the listeners of every replica once the transaction commits.
+	for _, change := range versions {
+		if err = notifyCartChange(ctx, tx, change.UserID, change.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	if params.DryRun {
+		return carts, nil
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return carts, nil
+}
+
+func expiredCarts(ctx context.Context, tx pgx.Tx, params models.PurgeExpiredCarts, ids []int64) ([]models.ExpiredCart, error) {
+	var result []models.ExpiredCart
+
+	args := pgx.NamedArgs{
+		"ids":        ids,
+		"older_than": params.OlderThan.Seconds(),
+		"limit":      params.Limit,
+	}
+
+	rows, err := tx.Query(ctx, expiredCartsQuery, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cart models.ExpiredCart
+
+		if err := rows.Scan(&cart.UserID, &cart.Lines, &cart.Units, &cart.LastActivity); err != nil {
+			return nil, err
+		}
+
+		result = append(result, cart)
+	}
+
+	return result, rows.Err()
+}
+
+func deletedItems(ctx context.Context, tx pgx.Tx, query string, args pgx.NamedArgs) (map[int64][]models.CartItem, error) {
+	items := make(map[int64][]models.CartItem)
+
+	rows, err := tx.Query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item DbCartItem
+
+		if err := rows.Scan(&item.UserID, &item.SKU, &item.SellerID, &item.Count, &item.Price); err != nil {
+			return nil, err
+		}
+
+		items[item.UserID] = append(items[item.UserID], item.ToDomain())
+	}
+
+	return items, rows.Err()
+}
+
+func bumpedVersions(ctx context.Context, tx pgx.Tx, query string, args pgx.NamedArgs) ([]DbCartChange, error) {
+	var changes []DbCartChange
+
+	rows, err := tx.Query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change DbCartChange
+
+		if err := rows.Scan(&change.UserID, &change.Version); err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
+
+func expiredUserIDs(carts []models.ExpiredCart) []int64 {
+	ids := make([]int64, 0, len(carts))
+	for _, cart := range carts {
+		ids = append(ids, cart.UserID)
 	}
 
-	return nil
+	return ids
 }
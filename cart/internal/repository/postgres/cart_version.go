@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"cart/internal/constants"
+	"cart/internal/domainerr"
+	"cart/pkg/postgresql"
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// withVersionCheck runs write inside a transaction that holds the lock on the user's
+// cart version. It fails with a version mismatch error when expected is set and
+// differs from the stored version, otherwise it bumps the version after write succeeds
+// and notifies cart watchers.
+func withVersionCheck(ctx context.Context, db postgresql.TxStarter, userID int64, expected *uint64, write func(tx pgx.Tx) error) (version uint64, err error) {
+	tx, err := db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				err = errors.Join(err, rbErr)
+			}
+		}
+	}()
+
+	args := pgx.NamedArgs{
+		"userID": userID,
+	}
+
+	lockQuery := `
+		INSERT INTO cart_versions (user_id, version)
+		VALUES (@userID, 0)
+		ON CONFLICT (user_id) DO NOTHING
+	`
+	if _, err = tx.Exec(ctx, lockQuery, args); err != nil {
+		return 0, err
+	}
+
+	selectQuery := `SELECT version FROM cart_versions WHERE user_id = @userID FOR UPDATE`
+	if err = tx.QueryRow(ctx, selectQuery, args).Scan(&version); err != nil {
+		return 0, err
+	}
+
+	if expected != nil && *expected != version {
+		return 0, domainerr.VersionMismatch(*expected, version)
+	}
+
+	if err = write(tx); err != nil {
+		return 0, err
+	}
+
+	bumpQuery := `
+		UPDATE cart_versions
+		SET version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = @userID
+		RETURNING version
+	`
+	if err = tx.QueryRow(ctx, bumpQuery, args).Scan(&version); err != nil {
+		return 0, err
+	}
+
+	// Delivered to the listeners of every replica once the transaction commits.
+	if err = notifyCartChange(ctx, tx, userID, version); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func notifyCartChange(ctx context.Context, tx pgx.Tx, userID int64, version uint64) error {
+	payload, err := json.Marshal(DbCartChange{UserID: userID, Version: version})
+	if err != nil {
+		return err
+	}
+
+	query := `SELECT pg_notify(@channel, @payload)`
+
+	args := pgx.NamedArgs{
+		"channel": constants.CartChangesChannel,
+		"payload": string(payload),
+	}
+
+	_, err = tx.Exec(ctx, query, args)
+
+	return err
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"cart/internal/constants"
+	"cart/internal/repository/interfaces"
+	"cart/pkg/log"
+	"cart/pkg/postgresql"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// cartWatcher fans out cart change notifications received through Postgres
+// LISTEN/NOTIFY to the in-process subscribers of each user.
+type cartWatcher struct {
+	db     postgresql.Listener
+	logger log.Logger
+
+	mu   sync.Mutex
+	subs map[int64]map[chan uint64]struct{}
+}
+
+func NewCartWatcher(db postgresql.Listener, logger log.Logger) interfaces.CartWatcher {
+	return &cartWatcher{
+		db:     db,
+		logger: logger,
+		subs:   make(map[int64]map[chan uint64]struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving the new cart version after each change of the
+// user's cart and a function to unsubscribe. Signals are coalesced for slow readers.
+func (w *cartWatcher) Subscribe(userID int64) (<-chan uint64, func()) {
+	ch := make(chan uint64, 1)
+
+	w.mu.Lock()
+	if w.subs[userID] == nil {
+		w.subs[userID] = make(map[chan uint64]struct{})
+	}
+	w.subs[userID][ch] = struct{}{}
+	w.mu.Unlock()
+
+	unsubscribe := func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		delete(w.subs[userID], ch)
+		if len(w.subs[userID]) == 0 {
+			delete(w.subs, userID)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+// Run listens for cart changes until ctx is done, reconnecting on failures.
+func (w *cartWatcher) Run(ctx context.Context) error {
+	reconnected := false
+
+	for {
+		err := w.db.Listen(ctx, constants.CartChangesChannel, func() {
+			// Changes made while the listener was down are unknown, so everyone refreshes.
+			if reconnected {
+				w.broadcast()
+			}
+		}, w.dispatch)
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		w.logger.Errorf("cart changes listener stopped: %v", err)
+		reconnected = true
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(constants.ListenRetryInterval):
+		}
+	}
+}
+
+func (w *cartWatcher) dispatch(payload string) {
+	var change DbCartChange
+
+	if err := json.Unmarshal([]byte(payload), &change); err != nil {
+		w.logger.Errorf("err in unmarshal cart change: %v", err)
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for ch := range w.subs[change.UserID] {
+		signal(ch, change.Version)
+	}
+}
+
+func (w *cartWatcher) broadcast() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, subs := range w.subs {
+		for ch := range subs {
+			signal(ch, 0)
+		}
+	}
+}
+
+func signal(ch chan uint64, version uint64) {
+	select {
+	case ch <- version:
+	default:
+		// A refresh is already pending and will include this change.
+	}
+}
@@ -0,0 +1,343 @@
+package postgres
+
+import (
+	"cart/internal/constants"
+	"cart/internal/models"
+	"cart/internal/repository/interfaces"
+	"cart/pkg/postgresql"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type checkoutRepo struct {
+	db postgresql.Client
+}
+
+func NewCheckoutRepository(db postgresql.Client) interfaces.CheckoutRepository {
+	return &checkoutRepo{db: db}
+}
+
+const checkoutColumns = `id, user_id, status, step, lines, total_price, payment_token, card_brand, card_last4, payment_id, payment_action_url, attempts, last_error, created_at, updated_at`
+
+// CreateCheckout starts a saga at its first step, paid with card. It returns
+// ErrCheckoutInProgress when the user already has an active checkout.
+func (r *checkoutRepo) CreateCheckout(ctx context.Context, userID int64, card models.PaymentCard, lines []models.CheckoutLine, totalPrice uint64) (models.Checkout, error) {
+	dbLines := make([]DbCheckoutLine, 0, len(lines))
+	for _, line := range lines {
+		dbLines = append(dbLines, DbCheckoutLine{
+			SKU:      line.SKU,
+			SellerID: line.SellerID,
+			Count:    line.Count,
+			Price:    line.Price,
+		})
+	}
+
+	query := `
+		INSERT INTO checkouts (user_id, status, step, lines, total_price, payment_token, card_brand, card_last4)
+		VALUES (@user_id, @status, @step, @lines, @total_price, @payment_token, @card_brand, @card_last4)
+		ON CONFLICT (user_id) WHERE status IN ('running', 'compensating') DO NOTHING
+		RETURNING ` + checkoutColumns
+
+	args := pgx.NamedArgs{
+		"user_id":       userID,
+		"status":        string(models.CheckoutRunning),
+		"step":          string(models.CheckoutStepReserveStock),
+		"lines":         dbLines,
+		"total_price":   totalPrice,
+		"payment_token": card.Token,
+		"card_brand":    card.Brand,
+		"card_last4":    card.Last4,
+	}
+
+	checkout, err := scanCheckout(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Checkout{}, constants.ErrCheckoutInProgress
+		}
+
+		return models.Checkout{}, err
+	}
+
+	return checkout, nil
+}
+
+func (r *checkoutRepo) GetCheckout(ctx context.Context, checkoutID int64) (models.Checkout, error) {
+	query := `SELECT ` + checkoutColumns + ` FROM checkouts WHERE id = @id`
+
+	args := pgx.NamedArgs{
+		"id": checkoutID,
+	}
+
+	return scanCheckout(r.db.QueryRow(ctx, query, args))
+}
+
+// ClaimStep takes a lease on step of an active saga whose previous lease has run out,
+// counting the attempt. It returns ErrNotRowAffected when the saga is at another step
+// or someone else holds the lease.
+func (r *checkoutRepo) ClaimStep(ctx context.Context, checkoutID int64, step models.CheckoutStep, lease time.Duration) (models.Checkout, error) {
+	query := `
+		UPDATE checkouts SET
+			step_deadline = NOW() + make_interval(secs => @lease),
+			attempts = attempts + 1,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = @id
+			AND step = @step
+			AND status IN ('running', 'compensating')
+			AND step_deadline <= NOW()
+		RETURNING ` + checkoutColumns
+
+	args := pgx.NamedArgs{
+		"id":    checkoutID,
+		"step":  string(step),
+		"lease": lease.Seconds(),
+	}
+
+	checkout, err := scanCheckout(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Checkout{}, constants.ErrNotRowAffected
+		}
+
+		return models.Checkout{}, err
+	}
+
+	return checkout, nil
+}
+
+// AdvanceStep moves an active saga from step from to next and makes the new step
+// claimable at once. It returns ErrNotRowAffected when the saga is no longer at from.
+func (r *checkoutRepo) AdvanceStep(ctx context.Context, checkoutID int64, from models.CheckoutStep, next models.CheckoutAdvance) (models.Checkout, error) {
+	query := `
+		UPDATE checkouts SET
+			status = @status,
+			step = @step,
+			payment_id = COALESCE(NULLIF(@payment_id, ''), payment_id),
+			payment_action_url = '',
+			last_error = @last_error,
+			attempts = 0,
+			step_deadline = NOW(),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = @id
+			AND step = @from
+			AND status IN ('running', 'compensating')
+		RETURNING ` + checkoutColumns
+
+	args := pgx.NamedArgs{
+		"id":         checkoutID,
+		"from":       string(from),
+		"status":     string(next.Status),
+		"step":       string(next.Step),
+		"payment_id": next.PaymentID,
+		"last_error": next.LastError,
+	}
+
+	checkout, err := scanCheckout(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Checkout{}, constants.ErrNotRowAffected
+		}
+
+		return models.Checkout{}, err
+	}
+
+	return checkout, nil
+}
+
+// FailAttempt records why an attempt of step failed. The lease is kept, so the step
+// is retried once it runs out.
+func (r *checkoutRepo) FailAttempt(ctx context.Context, checkoutID int64, step models.CheckoutStep, lastError string) error {
+	query := `
+		UPDATE checkouts SET
+			last_error = @last_error,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = @id AND step = @step
+	`
+	args := pgx.NamedArgs{
+		"id":         checkoutID,
+		"step":       string(step),
+		"last_error": lastError,
+	}
+
+	_, err := r.db.Exec(ctx, query, args)
+
+	return err
+}
+
+// AwaitAction parks step until the customer acted on the payment, or wait passed. The
+// attempts start over, as waiting is not a failed attempt.
+func (r *checkoutRepo) AwaitAction(ctx context.Context, checkoutID int64, step models.CheckoutStep, payment models.Payment, wait time.Duration) error {
+	query := `
+		UPDATE checkouts SET
+			payment_id = @payment_id,
+			payment_action_url = @action_url,
+			attempts = 0,
+			step_deadline = NOW() + make_interval(secs => @wait),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = @id AND step = @step
+	`
+	args := pgx.NamedArgs{
+		"id":         checkoutID,
+		"step":       string(step),
+		"payment_id": payment.ID,
+		"action_url": payment.ActionURL,
+		"wait":       wait.Seconds(),
+	}
+
+	_, err := r.db.Exec(ctx, query, args)
+
+	return err
+}
+
+// WakeStep makes a parked step of an active saga claimable at once. It returns
+// ErrNotRowAffected when the saga is not at step.
+func (r *checkoutRepo) WakeStep(ctx context.Context, checkoutID int64, step models.CheckoutStep) (models.Checkout, error) {
+	query := `
+		UPDATE checkouts SET
+			step_deadline = NOW(),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = @id
+			AND step = @step
+			AND status IN ('running', 'compensating')
+		RETURNING ` + checkoutColumns
+
+	args := pgx.NamedArgs{
+		"id":   checkoutID,
+		"step": string(step),
+	}
+
+	checkout, err := scanCheckout(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Checkout{}, constants.ErrNotRowAffected
+		}
+
+		return models.Checkout{}, err
+	}
+
+	return checkout, nil
+}
+
+// CompleteCheckout finishes a saga at the clear cart step and takes its lines out of
+// the cart in the same transaction, so a retried step cannot remove them twice. Units
+// the user added meanwhile stay in the cart. It returns ErrNotRowAffected when the
+// saga is no longer at the clear cart step.
+func (r *checkoutRepo) CompleteCheckout(ctx context.Context, checkout models.Checkout) (models.Checkout, error) {
+	var completed models.Checkout
+
+	completeQuery := `
+		UPDATE checkouts SET
+			status = @status,
+			step = '',
+			last_error = '',
+			attempts = 0,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = @id AND step = @step
+		RETURNING ` + checkoutColumns
+	deleteQuery := `
+		DELETE FROM cart
+		WHERE user_id = @userID AND sku = @sku AND seller_id = @sellerID AND count <= @count
+	`
+	updateQuery := `
+		UPDATE cart SET count = count - @count
+		WHERE user_id = @userID AND sku = @sku AND seller_id = @sellerID AND count > @count
+	`
+
+	_, err := withVersionCheck(ctx, r.db, checkout.UserID, nil, func(tx pgx.Tx) error {
+		args := pgx.NamedArgs{
+			"id":     checkout.ID,
+			"status": string(models.CheckoutCompleted),
+			"step":   string(models.CheckoutStepClearCart),
+		}
+
+		var err error
+
+		completed, err = scanCheckout(tx.QueryRow(ctx, completeQuery, args))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return constants.ErrNotRowAffected
+			}
+
+			return err
+		}
+
+		for _, line := range checkout.Lines {
+			args := pgx.NamedArgs{
+				"userID":   checkout.UserID,
+				"sku":      line.SKU,
+				"sellerID": line.SellerID,
+				"count":    line.Count,
+			}
+
+			if _, err := tx.Exec(ctx, deleteQuery, args); err != nil {
+				return err
+			}
+
+			if _, err := tx.Exec(ctx, updateQuery, args); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.Checkout{}, err
+	}
+
+	return completed, nil
+}
+
+// ListStalled returns active sagas whose step lease ran out more than olderThan ago,
+// longest waiting first.
+func (r *checkoutRepo) ListStalled(ctx context.Context, olderThan time.Duration, limit int) ([]models.Checkout, error) {
+	var result []models.Checkout
+
+	query := `
+		SELECT ` + checkoutColumns + `
+		FROM checkouts
+		WHERE status IN ('running', 'compensating')
+			AND step_deadline <= NOW() - make_interval(secs => @older_than)
+		ORDER BY step_deadline
+		LIMIT @limit
+	`
+	args := pgx.NamedArgs{
+		"older_than": olderThan.Seconds(),
+		"limit":      limit,
+	}
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		checkout, err := scanCheckout(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, checkout)
+	}
+
+	return result, rows.Err()
+}
+
+func scanCheckout(row pgx.Row) (models.Checkout, error) {
+	var dbCheckout DbCheckout
+
+	err := row.Scan(
+		&dbCheckout.ID, &dbCheckout.UserID, &dbCheckout.Status, &dbCheckout.Step,
+		&dbCheckout.Lines, &dbCheckout.TotalPrice, &dbCheckout.PaymentToken, &dbCheckout.CardBrand,
+		&dbCheckout.CardLast4, &dbCheckout.PaymentID,
+		&dbCheckout.PaymentActionURL, &dbCheckout.Attempts, &dbCheckout.LastError,
+		&dbCheckout.CreatedAt, &dbCheckout.UpdatedAt,
+	)
+	if err != nil {
+		return models.Checkout{}, err
+	}
+
+	return dbCheckout.ToDomain(), nil
+}
@@ -2,18 +2,181 @@ package postgres
 
 import (
 	"cart/internal/models"
+	"time"
 )
 
 type DbCartItem struct {
-	UserID int64  `db:"user_id"`
-	SKU    uint32 `db:"sku"`
-	Count  uint32 `db:"count"`
+	UserID   int64   `db:"user_id"`
+	SKU      uint32  `db:"sku"`
+	SellerID int64   `db:"seller_id"`
+	Count    uint32  `db:"count"`
+	Price    *uint32 `db:"price"`
 }
 
 func (d DbCartItem) ToDomain() models.CartItem {
 	return models.CartItem{
-		UserID: d.UserID,
-		SKU:    d.SKU,
-		Count:  d.Count,
+		UserID:   d.UserID,
+		SKU:      d.SKU,
+		SellerID: d.SellerID,
+		Count:    d.Count,
+		Price:    d.Price,
+	}
+}
+
+type DbIdempotencyRecord struct {
+	UserID      int64     `db:"user_id"`
+	Key         string    `db:"key"`
+	Method      string    `db:"method"`
+	RequestHash string    `db:"request_hash"`
+	Response    []byte    `db:"response"`
+	ExpiresAt   time.Time `db:"expires_at"`
+}
+
+func (d DbIdempotencyRecord) ToDomain() models.IdempotencyRecord {
+	return models.IdempotencyRecord{
+		UserID:      d.UserID,
+		Key:         d.Key,
+		Method:      d.Method,
+		RequestHash: d.RequestHash,
+		Response:    d.Response,
+		ExpiresAt:   d.ExpiresAt,
+	}
+}
+
+type DbSavedItem struct {
+	UserID        int64   `db:"user_id"`
+	SKU           uint32  `db:"sku"`
+	SellerID      int64   `db:"seller_id"`
+	Count         uint32  `db:"count"`
+	LastSeenCount *uint32 `db:"last_seen_count"`
+}
+
+func (d DbSavedItem) ToDomain() models.SavedItem {
+	return models.SavedItem{
+		UserID:        d.UserID,
+		SKU:           d.SKU,
+		SellerID:      d.SellerID,
+		Count:         d.Count,
+		LastSeenCount: d.LastSeenCount,
+	}
+}
+
+type DbCartChange struct {
+	UserID  int64  `json:"user_id"`
+	Version uint64 `json:"version"`
+}
+
+type DbAuditEvent struct {
+	ID          int64     `db:"id"`
+	ActorID     int64     `db:"actor_id"`
+	Method      string    `db:"method"`
+	EntityType  string    `db:"entity_type"`
+	EntityID    string    `db:"entity_id"`
+	RequestHash string    `db:"request_hash"`
+	Before      []byte    `db:"before"`
+	After       []byte    `db:"after"`
+	Status      string    `db:"status"`
+	TraceID     *string   `db:"trace_id"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (d DbAuditEvent) ToDomain() models.AuditEvent {
+	event := models.AuditEvent{
+		ID:          d.ID,
+		ActorID:     d.ActorID,
+		Method:      d.Method,
+		EntityType:  d.EntityType,
+		EntityID:    d.EntityID,
+		RequestHash: d.RequestHash,
+		Before:      d.Before,
+		After:       d.After,
+		Status:      d.Status,
+		CreatedAt:   d.CreatedAt,
+	}
+
+	if d.TraceID != nil {
+		event.TraceID = *d.TraceID
+	}
+
+	return event
+}
+
+// DbCheckoutLine is a line in the lines JSONB column of checkouts.
+type DbCheckoutLine struct {
+	SKU      uint32 `json:"sku"`
+	SellerID int64  `json:"seller_id"`
+	Count    uint32 `json:"count"`
+	Price    uint32 `json:"price"`
+}
+
+type DbCheckout struct {
+	ID               int64            `db:"id"`
+	UserID           int64            `db:"user_id"`
+	Status           string           `db:"status"`
+	Step             string           `db:"step"`
+	Lines            []DbCheckoutLine `db:"lines"`
+	TotalPrice       uint64           `db:"total_price"`
+	PaymentToken     string           `db:"payment_token"`
+	CardBrand        string           `db:"card_brand"`
+	CardLast4        string           `db:"card_last4"`
+	PaymentID        string           `db:"payment_id"`
+	PaymentActionURL string           `db:"payment_action_url"`
+	Attempts         int              `db:"attempts"`
+	LastError        string           `db:"last_error"`
+	CreatedAt        time.Time        `db:"created_at"`
+	UpdatedAt        time.Time        `db:"updated_at"`
+}
+
+func (d DbCheckout) ToDomain() models.Checkout {
+	lines := make([]models.CheckoutLine, 0, len(d.Lines))
+	for _, line := range d.Lines {
+		lines = append(lines, models.CheckoutLine{
+			SKU:      line.SKU,
+			SellerID: line.SellerID,
+			Count:    line.Count,
+			Price:    line.Price,
+		})
+	}
+
+	return models.Checkout{
+		ID:         d.ID,
+		UserID:     d.UserID,
+		Status:     models.CheckoutStatus(d.Status),
+		Step:       models.CheckoutStep(d.Step),
+		Lines:      lines,
+		TotalPrice: d.TotalPrice,
+		PaymentCard: models.PaymentCard{
+			Token: d.PaymentToken,
+			Brand: d.CardBrand,
+			Last4: d.CardLast4,
+		},
+		PaymentID:        d.PaymentID,
+		PaymentActionURL: d.PaymentActionURL,
+		Attempts:         d.Attempts,
+		LastError:        d.LastError,
+		CreatedAt:        d.CreatedAt,
+		UpdatedAt:        d.UpdatedAt,
+	}
+}
+
+type DbSimulatedPayment struct {
+	ID             string `db:"id"`
+	Reference      string `db:"reference"`
+	Status         string `db:"status"`
+	Amount         uint64 `db:"amount"`
+	CapturedAmount uint64 `db:"captured_amount"`
+	RefundedAmount uint64 `db:"refunded_amount"`
+	ActionURL      string `db:"action_url"`
+}
+
+func (d DbSimulatedPayment) ToDomain() models.Payment {
+	return models.Payment{
+		ID:             d.ID,
+		Reference:      d.Reference,
+		Status:         models.PaymentStatus(d.Status),
+		Amount:         d.Amount,
+		CapturedAmount: d.CapturedAmount,
+		RefundedAmount: d.RefundedAmount,
+		ActionURL:      d.ActionURL,
 	}
 }
@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"cart/internal/constants"
+	"cart/internal/models"
+	"cart/internal/repository/interfaces"
+	"cart/pkg/postgresql"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type savedRepo struct {
+	db postgresql.Client
+}
+
+func NewSavedRepository(db postgresql.Client) interfaces.SavedRepository {
+	return &savedRepo{db: db}
+}
+
+// MoveToSaved moves the whole cart line of the SKU into the saved list. The cart
+// version is bumped because the cart content changes.
+func (r *savedRepo) MoveToSaved(ctx context.Context, params models.MoveSavedItem, lastSeenCount *uint32) (uint64, error) {
+	deleteQuery := `
+		DELETE FROM cart
+		WHERE user_id = @userID AND sku = @sku
+		RETURNING count, seller_id
+	`
+	saveQuery := `
+		INSERT INTO saved_items (user_id, sku, seller_id, count, last_seen_count)
+		VALUES (@userID, @sku, @sellerID, @count, @lastSeenCount)
+		ON CONFLICT (user_id, sku)
+		DO UPDATE SET
+			count = saved_items.count + EXCLUDED.count,
+			seller_id = COALESCE(NULLIF(EXCLUDED.seller_id, 0), saved_items.seller_id),
+			last_seen_count = COALESCE(EXCLUDED.last_seen_count, saved_items.last_seen_count),
+			updated_at = CURRENT_TIMESTAMP
+	`
+
+	return withVersionCheck(ctx, r.db, params.UserID, params.ExpectedVersion, func(tx pgx.Tx) error {
+		var (
+			count    uint32
+			sellerID int64
+		)
+
+		args := pgx.NamedArgs{
+			"userID":        params.UserID,
+			"sku":           params.SKU,
+			"lastSeenCount": lastSeenCount,
+		}
+
+		err := tx.QueryRow(ctx, deleteQuery, args).Scan(&count, &sellerID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return constants.ErrNotRowAffected
+			}
+
+			return err
+		}
+
+		args["count"] = count
+		args["sellerID"] = sellerID
+
+		_, err = tx.Exec(ctx, saveQuery, args)
+
+		return err
+	})
+}
+
+// MoveToCart moves the saved item of the SKU back into the cart as a line of the offer.
+func (r *savedRepo) MoveToCart(ctx context.Context, params models.MoveSavedItem, offer models.StockItem) (uint64, error) {
+	deleteQuery := `
+		DELETE FROM saved_items
+		WHERE user_id = @userID AND sku = @sku
+		RETURNING count
+	`
+	addQuery := `
+		INSERT INTO cart (user_id, sku, seller_id, count, price)
+		VALUES (@userID, @sku, @sellerID, @count, @price)
+		ON CONFLICT (user_id, sku)
+		DO UPDATE SET count = cart.count + EXCLUDED.count, seller_id = EXCLUDED.seller_id, price = EXCLUDED.price
+	`
+
+	return withVersionCheck(ctx, r.db, params.UserID, params.ExpectedVersion, func(tx pgx.Tx) error {
+		var count uint32
+
+		args := pgx.NamedArgs{
+			"userID":   params.UserID,
+			"sku":      params.SKU,
+			"sellerID": offer.SellerID,
+			"price":    offer.Price,
+		}
+
+		err := tx.QueryRow(ctx, deleteQuery, args).Scan(&count)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return constants.ErrNotRowAffected
+			}
+
+			return err
+		}
+
+		args["count"] = count
+
+		_, err = tx.Exec(ctx, addQuery, args)
+
+		return err
+	})
+}
+
+func (r *savedRepo) SavedItem(ctx context.Context, userID int64, sku uint32) (models.SavedItem, error) {
+	item := DbSavedItem{UserID: userID, SKU: sku}
+
+	query := `
+		SELECT
+			seller_id, count, last_seen_count
+		FROM saved_items
+		WHERE user_id = @userID AND sku = @sku
+	`
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"sku":    sku,
+	}
+
+	err := r.db.QueryRow(ctx, query, args).Scan(&item.SellerID, &item.Count, &item.LastSeenCount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.SavedItem{}, constants.ErrNotRowAffected
+		}
+
+		return models.SavedItem{}, err
+	}
+
+	return item.ToDomain(), nil
+}
+
+func (r *savedRepo) ListSaved(ctx context.Context, userID int64) ([]models.SavedItem, error) {
+	var items []models.SavedItem
+
+	query := `
+		SELECT
+			sku, seller_id, count, last_seen_count
+		FROM saved_items
+		WHERE user_id = @userID
+		ORDER BY created_at
+	`
+	args := pgx.NamedArgs{
+		"userID": userID,
+	}
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item DbSavedItem
+		item.UserID = userID
+
+		if err := rows.Scan(&item.SKU, &item.SellerID, &item.Count, &item.LastSeenCount); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item.ToDomain())
+	}
+
+	return items, rows.Err()
+}
+
+func (r *savedRepo) UpdateLastSeenCount(ctx context.Context, userID int64, sku uint32, count uint32) error {
+	query := `
+		UPDATE saved_items
+		SET last_seen_count = @count, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = @userID AND sku = @sku
+	`
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"sku":    sku,
+		"count":  count,
+	}
+
+	_, err := r.db.Exec(ctx, query, args)
+
+	return err
+}
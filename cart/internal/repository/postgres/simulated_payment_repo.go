@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"cart/internal/constants"
+	"cart/internal/models"
+	"cart/internal/repository/interfaces"
+	"cart/pkg/postgresql"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type simulatedPaymentRepo struct {
+	db postgresql.Client
+}
+
+func NewSimulatedPaymentRepository(db postgresql.Client) interfaces.SimulatedPaymentRepository {
+	return &simulatedPaymentRepo{db: db}
+}
+
+const simulatedPaymentColumns = `id, reference, status, amount, captured_amount, refunded_amount, action_url`
+
+// CreatePayment stores payment unless a payment with its id exists already, which it
+// returns instead.
+func (r *simulatedPaymentRepo) CreatePayment(ctx context.Context, payment models.Payment) (models.Payment, error) {
+	insertQuery := `
+		INSERT INTO simulated_payments (id, reference, status, amount, action_url)
+		VALUES (@id, @reference, @status, @amount, @action_url)
+		ON CONFLICT (id) DO NOTHING
+	`
+	selectQuery := `SELECT ` + simulatedPaymentColumns + ` FROM simulated_payments WHERE id = @id`
+
+	args := pgx.NamedArgs{
+		"id":         payment.ID,
+		"reference":  payment.Reference,
+		"status":     string(payment.Status),
+		"amount":     payment.Amount,
+		"action_url": payment.ActionURL,
+	}
+
+	if _, err := r.db.Exec(ctx, insertQuery, args); err != nil {
+		return models.Payment{}, err
+	}
+
+	return scanSimulatedPayment(r.db.QueryRow(ctx, selectQuery, args))
+}
+
+// UpdatePayment locks the payment, applies change to it and stores the result in one
+// transaction, so concurrent captures, voids and webhooks of a payment are serialized.
+func (r *simulatedPaymentRepo) UpdatePayment(ctx context.Context, paymentID string, change func(payment *models.Payment) error) (payment models.Payment, err error) {
+	selectQuery := `SELECT ` + simulatedPaymentColumns + ` FROM simulated_payments WHERE id = @id FOR UPDATE`
+	updateQuery := `
+		UPDATE simulated_payments SET
+			status = @status,
+			captured_amount = @captured_amount,
+			refunded_amount = @refunded_amount,
+			action_url = @action_url,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = @id
+	`
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return models.Payment{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				err = errors.Join(err, rbErr)
+			}
+		}
+	}()
+
+	payment, err = scanSimulatedPayment(tx.QueryRow(ctx, selectQuery, pgx.NamedArgs{"id": paymentID}))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Payment{}, fmt.Errorf("%w: payment %s", constants.ErrNotFound, paymentID)
+		}
+
+		return models.Payment{}, err
+	}
+
+	if err = change(&payment); err != nil {
+		return models.Payment{}, err
+	}
+
+	args := pgx.NamedArgs{
+		"id":              payment.ID,
+		"status":          string(payment.Status),
+		"captured_amount": payment.CapturedAmount,
+		"refunded_amount": payment.RefundedAmount,
+		"action_url":      payment.ActionURL,
+	}
+
+	if _, err = tx.Exec(ctx, updateQuery, args); err != nil {
+		return models.Payment{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Payment{}, err
+	}
+
+	return payment, nil
+}
+
+func scanSimulatedPayment(row pgx.Row) (models.Payment, error) {
+	var dbPayment DbSimulatedPayment
+
+	err := row.Scan(
+		&dbPayment.ID, &dbPayment.Reference, &dbPayment.Status, &dbPayment.Amount,
+		&dbPayment.CapturedAmount, &dbPayment.RefundedAmount, &dbPayment.ActionURL,
+	)
+	if err != nil {
+		return models.Payment{}, err
+	}
+
+	return dbPayment.ToDomain(), nil
+}
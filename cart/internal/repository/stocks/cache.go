@@ -0,0 +1,153 @@
+package stocks
+
+import (
+	"cart/internal/config"
+	"cart/internal/constants"
+	"cart/internal/models"
+	"cart/internal/repository/interfaces"
+	"cart/pkg/lru"
+	"cart/pkg/metrics"
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const stockCacheName = "stock"
+
+// offerKey identifies a cached offer; a zero sellerID stands for the default offer.
+type offerKey struct {
+	sku      uint32
+	sellerID int64
+}
+
+type cachedStock struct {
+	item  models.StockItem
+	found bool
+}
+
+// cachedStockService is a read-through cache in front of the stocks service. Concurrent
+// misses of one offer share a single request and unknown offers are cached as well.
+type cachedStockService struct {
+	next    interfaces.StockService
+	cache   *lru.Cache[offerKey, cachedStock]
+	group   singleflight.Group
+	cfg     config.StockCache
+	metrics metrics.Metrics
+
+	// generation changes on every invalidation, so lookups that were in flight
+	// meanwhile do not store a stale result.
+	generation atomic.Uint64
+}
+
+func NewCachedStockService(next interfaces.StockService, cfg config.StockCache, m metrics.Metrics) interfaces.StockCache {
+	return &cachedStockService{
+		next:    next,
+		cache:   lru.New[offerKey, cachedStock](cfg.Size),
+		cfg:     cfg,
+		metrics: m,
+	}
+}
+
+func (s *cachedStockService) GetOffer(ctx context.Context, sku uint32, sellerID int64) (models.StockItem, error) {
+	key := offerKey{sku: sku, sellerID: sellerID}
+
+	if cached, ok := s.cache.Get(key); ok {
+		s.metrics.IncCacheHit(stockCacheName)
+		return cached.result()
+	}
+
+	s.metrics.IncCacheMiss(stockCacheName)
+
+	ch := s.group.DoChan(fmt.Sprintf("%d/%d", sku, sellerID), func() (interface{}, error) {
+		generation := s.generation.Load()
+
+		// The shared lookup must not fail because the first caller went away.
+		item, err := s.next.GetOffer(context.WithoutCancel(ctx), sku, sellerID)
+
+		var cached cachedStock
+
+		switch {
+		case err == nil:
+			cached = cachedStock{item: item, found: true}
+			s.store(generation, key, cached, s.cfg.TTL)
+		case errors.Is(err, constants.ErrNotFound):
+			cached = cachedStock{}
+			s.store(generation, key, cached, s.cfg.NegativeTTL)
+		default:
+			return nil, err
+		}
+
+		return cached, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return models.StockItem{}, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return models.StockItem{}, res.Err
+		}
+
+		return res.Val.(cachedStock).result()
+	}
+}
+
+// Invalidate drops the cached offer of the seller for sku, and the default offer of sku
+// which any offer change can replace, so the next lookup reaches the stocks service.
+func (s *cachedStockService) Invalidate(sku uint32, sellerID int64) {
+	s.generation.Add(1)
+	s.cache.Delete(offerKey{sku: sku, sellerID: sellerID})
+	s.cache.Delete(offerKey{sku: sku})
+}
+
+// ReserveStock drops the reserved offers from the cache right away instead of waiting
+// for their stock events. Released units come back through the stock events.
+func (s *cachedStockService) ReserveStock(ctx context.Context, checkoutID string, lines []models.CheckoutLine) error {
+	err := s.next.ReserveStock(ctx, checkoutID, lines)
+
+	for _, line := range lines {
+		s.Invalidate(line.SKU, line.SellerID)
+	}
+
+	return err
+}
+
+func (s *cachedStockService) CommitReservation(ctx context.Context, checkoutID string) error {
+	return s.next.CommitReservation(ctx, checkoutID)
+}
+
+func (s *cachedStockService) ReleaseReservation(ctx context.Context, checkoutID string) error {
+	return s.next.ReleaseReservation(ctx, checkoutID)
+}
+
+func (s *cachedStockService) Ping(ctx context.Context) error {
+	return s.next.Ping(ctx)
+}
+
+func (s *cachedStockService) SetTimeout(timeout time.Duration) {
+	s.next.SetTimeout(timeout)
+}
+
+func (s *cachedStockService) Close() error {
+	return s.next.Close()
+}
+
+func (s *cachedStockService) store(generation uint64, key offerKey, cached cachedStock, ttl time.Duration) {
+	if ttl <= 0 || s.generation.Load() != generation {
+		return
+	}
+
+	s.cache.Set(key, cached, ttl)
+}
+
+func (c cachedStock) result() (models.StockItem, error) {
+	if !c.found {
+		return models.StockItem{}, constants.ErrNotFound
+	}
+
+	return c.item, nil
+}
@@ -0,0 +1,197 @@
+package stocks
+
+import (
+	"cart/internal/config"
+	"cart/internal/constants"
+	"cart/internal/models"
+	"cart/internal/repository/interfaces"
+	"cart/pkg/metrics"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeStockService struct {
+	interfaces.StockService
+
+	calls   atomic.Int32
+	release chan struct{}
+	items   map[uint32]models.StockItem
+	err     error
+}
+
+func (f *fakeStockService) GetOffer(_ context.Context, sku uint32, _ int64) (models.StockItem, error) {
+	f.calls.Add(1)
+
+	if f.release != nil {
+		<-f.release
+	}
+
+	if f.err != nil {
+		return models.StockItem{}, f.err
+	}
+
+	item, ok := f.items[sku]
+	if !ok {
+		return models.StockItem{}, constants.ErrNotFound
+	}
+
+	return item, nil
+}
+
+type cacheMetrics struct {
+	metrics.Metrics
+
+	hits, misses atomic.Int32
+}
+
+func (m *cacheMetrics) IncCacheHit(string)  { m.hits.Add(1) }
+func (m *cacheMetrics) IncCacheMiss(string) { m.misses.Add(1) }
+
+var testCacheConfig = config.StockCache{Size: 10, TTL: time.Minute, NegativeTTL: time.Minute}
+
+func TestGetOfferCachesHitsAndMisses(t *testing.T) {
+	next := &fakeStockService{items: map[uint32]models.StockItem{1: {SKU: 1, Count: 5}}}
+	m := &cacheMetrics{}
+	cache := NewCachedStockService(next, testCacheConfig, m)
+
+	for i := 0; i < 3; i++ {
+		item, err := cache.GetOffer(context.Background(), 1, 0)
+		if err != nil || item.Count != 5 {
+			t.Fatalf("GetOffer() = %+v, %v, want count 5", item, err)
+		}
+
+		if _, err := cache.GetOffer(context.Background(), 2, 0); !errors.Is(err, constants.ErrNotFound) {
+			t.Fatalf("GetOffer() of an unknown sku error = %v, want ErrNotFound", err)
+		}
+	}
+
+	if got := next.calls.Load(); got != 2 {
+		t.Errorf("stocks service calls = %d, want 2, one per offer", got)
+	}
+	if m.hits.Load() != 4 || m.misses.Load() != 2 {
+		t.Errorf("hits, misses = %d, %d, want 4, 2", m.hits.Load(), m.misses.Load())
+	}
+}
+
+func TestGetOfferDoesNotCacheErrors(t *testing.T) {
+	next := &fakeStockService{err: errors.New("unavailable")}
+	cache := NewCachedStockService(next, testCacheConfig, &cacheMetrics{})
+
+	for i := 0; i < 2; i++ {
+		if _, err := cache.GetOffer(context.Background(), 1, 0); err == nil {
+			t.Fatal("GetOffer() error = nil, want the stocks service error")
+		}
+	}
+
+	if got := next.calls.Load(); got != 2 {
+		t.Errorf("stocks service calls = %d, want 2, errors must not be cached", got)
+	}
+}
+
+func TestGetOfferSharesConcurrentMisses(t *testing.T) {
+	next := &fakeStockService{
+		items:   map[uint32]models.StockItem{1: {SKU: 1, Count: 5}},
+		release: make(chan struct{}),
+	}
+	cache := NewCachedStockService(next, testCacheConfig, &cacheMetrics{})
+
+	const callers = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := cache.GetOffer(context.Background(), 1, 0)
+			errs <- err
+		}()
+	}
+
+	// Let the lookup finish once it started; callers arriving later join it or hit the cache.
+	for next.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	close(next.release)
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetOffer() error = %v", err)
+		}
+	}
+
+	if got := next.calls.Load(); got != 1 {
+		t.Errorf("stocks service calls = %d, want 1", got)
+	}
+}
+
+func TestGetOfferReturnsWhenCallerGivesUp(t *testing.T) {
+	next := &fakeStockService{release: make(chan struct{})}
+	defer close(next.release)
+
+	cache := NewCachedStockService(next, testCacheConfig, &cacheMetrics{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := cache.GetOffer(ctx, 1, 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetOffer() error = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	next := &fakeStockService{items: map[uint32]models.StockItem{1: {SKU: 1, Count: 5}}}
+	cache := NewCachedStockService(next, testCacheConfig, &cacheMetrics{})
+
+	if _, err := cache.GetOffer(context.Background(), 1, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	next.items[1] = models.StockItem{SKU: 1, Count: 2}
+	cache.Invalidate(1, 7)
+
+	item, err := cache.GetOffer(context.Background(), 1, 0)
+	if err != nil || item.Count != 2 {
+		t.Errorf("GetOffer() = %+v, %v, want the default offer reloaded with count 2", item, err)
+	}
+}
+
+func TestInvalidateDuringLookupIsNotOverwritten(t *testing.T) {
+	next := &fakeStockService{
+		items:   map[uint32]models.StockItem{1: {SKU: 1, Count: 5}},
+		release: make(chan struct{}),
+	}
+	cache := NewCachedStockService(next, testCacheConfig, &cacheMetrics{})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = cache.GetOffer(context.Background(), 1, 0)
+	}()
+
+	for next.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The offer changes while the lookup is in flight with the old count.
+	cache.Invalidate(1, 0)
+	close(next.release)
+	<-done
+
+	next.items[1] = models.StockItem{SKU: 1, Count: 2}
+	next.release = nil
+
+	item, err := cache.GetOffer(context.Background(), 1, 0)
+	if err != nil || item.Count != 2 {
+		t.Errorf("GetOffer() = %+v, %v, want count 2, the stale lookup must not be cached", item, err)
+	}
+}
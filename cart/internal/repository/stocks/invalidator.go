@@ -0,0 +1,42 @@
+package stocks
+
+import (
+	"cart/internal/repository/interfaces"
+	"encoding/json"
+)
+
+// stockEventTypes are the stocks service events after which a cached SKU is stale.
+var stockEventTypes = map[string]struct{}{
+	"sku_created":    {},
+	"sku_changed":    {},
+	"stock_deleted":  {},
+	"stock_restored": {},
+	"stock_reserved": {},
+	"stock_released": {},
+}
+
+type cacheInvalidator struct {
+	cache interfaces.StockCache
+}
+
+// NewCacheInvalidator returns a Kafka message handler that evicts the offers changed in
+// the stocks service from the cache.
+func NewCacheInvalidator(cache interfaces.StockCache) interfaces.KafkaHandler {
+	return &cacheInvalidator{cache: cache}
+}
+
+func (h *cacheInvalidator) HandleMessage(message []byte) error {
+	var event StockEvent
+
+	if err := json.Unmarshal(message, &event); err != nil {
+		return err
+	}
+
+	if _, ok := stockEventTypes[event.Type]; !ok || event.Service != "stock" {
+		return nil
+	}
+
+	h.cache.Invalidate(event.Payload.SKU, event.Payload.SellerID)
+
+	return nil
+}
@@ -33,3 +33,12 @@ func (res StockItemResponse) ToModel() models.StockItem {
 		Location: res.Location,
 	}
 }
+
+type StockEvent struct {
+	Type    string `json:"type"`
+	Service string `json:"service"`
+	Payload struct {
+		SKU      uint32 `json:"sku"`
+		SellerID int64  `json:"seller_id"`
+	} `json:"payload"`
+}
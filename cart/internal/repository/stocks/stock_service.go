@@ -1,75 +1,306 @@
 package stocks
 
 import (
+	"cart/internal/config"
 	"cart/internal/constants"
 	"cart/internal/models"
 	"cart/internal/repository/interfaces"
 	stocksapi "cart/pkg/api/stocks"
+	"cart/pkg/breaker"
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	stocksScheme         = "stocks"
+	roundRobinServiceCfg = `{"loadBalancingConfig": [{"round_robin": {}}]}`
+)
+
+// retryableCodes are the failures after which GetStock, being idempotent, is retried.
+var retryableCodes = map[codes.Code]struct{}{
+	codes.Unavailable:       {},
+	codes.DeadlineExceeded:  {},
+	codes.ResourceExhausted: {},
+	codes.Aborted:           {},
+}
+
 type grpcStockService struct {
 	client  stocksapi.StockServiceClient
+	health  healthgrpc.HealthClient
 	conn    *grpc.ClientConn
-	timeout time.Duration
+	breaker *breaker.Breaker
+	cfg     config.StockClient
+
+	// timeout bounds every call and can be changed at runtime by SetTimeout.
+	timeout atomic.Int64
 }
 
-func NewGRPCStockService(serverAddr string) (interfaces.StockService, error) {
-	conn, err := grpc.NewClient(serverAddr,
+// NewGRPCStockService connects to every address in cfg and balances calls between
+// them round robin.
+func NewGRPCStockService(cfg config.StockClient) (interfaces.StockService, error) {
+	if len(cfg.Addresses) == 0 {
+		return nil, fmt.Errorf("stock service address list is empty")
+	}
+
+	addresses := make([]resolver.Address, 0, len(cfg.Addresses))
+	for _, addr := range cfg.Addresses {
+		addresses = append(addresses, resolver.Address{Addr: addr})
+	}
+
+	r := manual.NewBuilderWithScheme(stocksScheme)
+	r.InitialState(resolver.State{Addresses: addresses})
+
+	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+		grpc.WithResolvers(r),
+		grpc.WithDefaultServiceConfig(roundRobinServiceCfg),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
+
+	if cfg.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    cfg.KeepaliveTime,
+			Timeout: cfg.Timeout,
+		}))
+	}
+
+	conn, err := grpc.NewClient(r.Scheme()+":///stocks", opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to stock service: %w", err)
 	}
 
-	client := stocksapi.NewStockServiceClient(conn)
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+
+	var cb *breaker.Breaker
+	if cfg.FailureThreshold > 0 {
+		cb = breaker.New(cfg.FailureThreshold, cfg.OpenTimeout)
+	}
 
-	return &grpcStockService{
-		client:  client,
+	s := &grpcStockService{
+		client:  stocksapi.NewStockServiceClient(conn),
+		health:  healthgrpc.NewHealthClient(conn),
 		conn:    conn,
-		timeout: constants.ReadTimeout,
-	}, nil
+		breaker: cb,
+		cfg:     cfg,
+	}
+	s.SetTimeout(cfg.Timeout)
+
+	return s, nil
 }
 
-func (s *grpcStockService) GetSKU(ctx context.Context, sku uint32) (models.StockItem, error) {
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
-	defer cancel()
+// GetOffer fetches the stock offer, retrying transient failures with jittered backoff.
+// Once the circuit breaker is open it fails fast with ErrStockUnavailable.
+func (s *grpcStockService) GetOffer(ctx context.Context, sku uint32, sellerID int64) (models.StockItem, error) {
+	var err error
 
-	resp, err := s.client.GetStock(ctx, &stocksapi.GetStockRequest{Sku: sku})
+	for attempt := 1; ; attempt++ {
+		var resp *stocksapi.GetStockResponse
 
-	if err != nil {
-		st, ok := status.FromError(err)
-		switch {
-		case ok && st.Code() == codes.NotFound:
+		resp, err = s.getStock(ctx, sku, sellerID)
+		if err == nil {
+			if resp.Stock == nil {
+				return models.StockItem{}, constants.ErrNotFound
+			}
+
+			return models.StockItem{
+				SKU:      resp.Stock.Sku,
+				SellerID: resp.Stock.SellerId,
+				Name:     resp.Stock.Name,
+				Type:     resp.Stock.Type,
+				Count:    resp.Stock.Count,
+				Price:    resp.Stock.Price,
+				Location: resp.Stock.Location,
+			}, nil
+		}
+
+		if status.Code(err) == codes.NotFound {
 			return models.StockItem{}, constants.ErrNotFound
-		case ctx.Err() == context.DeadlineExceeded:
-			return models.StockItem{}, fmt.Errorf("stock service request timed out")
-		default:
-			return models.StockItem{}, fmt.Errorf("gRPC stock service error: %w", err)
+		}
+
+		if errors.Is(err, breaker.ErrOpen) {
+			return models.StockItem{}, fmt.Errorf("%w: %w", constants.ErrStockUnavailable, err)
+		}
+
+		if !isRetryable(err) || attempt >= s.cfg.MaxAttempts || ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return models.StockItem{}, ctx.Err()
+		case <-time.After(s.backoff(attempt)):
 		}
 	}
 
-	if resp.Stock == nil {
-		return models.StockItem{}, constants.ErrNotFound
+	if isRetryable(err) {
+		return models.StockItem{}, fmt.Errorf("%w: %w", constants.ErrStockUnavailable, err)
 	}
 
-	return models.StockItem{
-		SKU:      resp.Stock.Sku,
-		Name:     resp.Stock.Name,
-		Type:     resp.Stock.Type,
-		Count:    resp.Stock.Count,
-		Price:    resp.Stock.Price,
-		Location: resp.Stock.Location,
-	}, nil
+	return models.StockItem{}, fmt.Errorf("gRPC stock service error: %w", err)
+}
+
+func (s *grpcStockService) ReserveStock(ctx context.Context, checkoutID string, lines []models.CheckoutLine) error {
+	items := make([]*stocksapi.ReservationItem, 0, len(lines))
+	for _, line := range lines {
+		items = append(items, &stocksapi.ReservationItem{
+			Sku:      line.SKU,
+			SellerId: line.SellerID,
+			Count:    line.Count,
+		})
+	}
+
+	return s.reservationCall(ctx, func(ctx context.Context) error {
+		_, err := s.client.ReserveStock(ctx, &stocksapi.ReserveStockRequest{CheckoutId: checkoutID, Items: items})
+		return err
+	})
+}
+
+func (s *grpcStockService) CommitReservation(ctx context.Context, checkoutID string) error {
+	return s.reservationCall(ctx, func(ctx context.Context) error {
+		_, err := s.client.CommitReservation(ctx, &stocksapi.CommitReservationRequest{CheckoutId: checkoutID})
+		return err
+	})
+}
+
+func (s *grpcStockService) ReleaseReservation(ctx context.Context, checkoutID string) error {
+	return s.reservationCall(ctx, func(ctx context.Context) error {
+		_, err := s.client.ReleaseReservation(ctx, &stocksapi.ReleaseReservationRequest{CheckoutId: checkoutID})
+		return err
+	})
+}
+
+// Ping asks the stocks service for its grpc.health.v1 status. It bypasses the circuit
+// breaker so readiness reflects the actual state of stocks.
+func (s *grpcStockService) Ping(ctx context.Context) error {
+	resp, err := s.health.Check(ctx, &healthgrpc.HealthCheckRequest{
+		Service: stocksapi.StockService_ServiceDesc.ServiceName,
+	})
+	if err != nil {
+		return fmt.Errorf("gRPC stock service health check: %w", err)
+	}
+
+	if resp.Status != healthgrpc.HealthCheckResponse_SERVING {
+		return fmt.Errorf("stock service is %s", resp.Status)
+	}
+
+	return nil
+}
+
+// SetTimeout changes the deadline of calls made from now on. A zero timeout means
+// constants.ReadTimeout.
+func (s *grpcStockService) SetTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = constants.ReadTimeout
+	}
+
+	s.timeout.Store(int64(timeout))
 }
 
 func (s *grpcStockService) Close() error {
 	return s.conn.Close()
 }
+
+func (s *grpcStockService) getStock(ctx context.Context, sku uint32, sellerID int64) (*stocksapi.GetStockResponse, error) {
+	if s.breaker != nil {
+		if err := s.breaker.Allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(s.timeout.Load()))
+	defer cancel()
+
+	resp, err := s.client.GetStock(ctx, &stocksapi.GetStockRequest{Sku: sku, SellerId: sellerID})
+
+	if s.breaker != nil {
+		// Only an unhealthy stocks service counts against the breaker, not an unknown SKU.
+		if isRetryable(err) {
+			s.breaker.Failure()
+		} else {
+			s.breaker.Success()
+		}
+	}
+
+	return resp, err
+}
+
+// reservationCall makes a reservation RPC once, through the circuit breaker. The
+// checkout saga retries failed steps itself.
+func (s *grpcStockService) reservationCall(ctx context.Context, call func(ctx context.Context) error) error {
+	if s.breaker != nil {
+		if err := s.breaker.Allow(); err != nil {
+			return fmt.Errorf("%w: %w", constants.ErrStockUnavailable, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(s.timeout.Load()))
+	defer cancel()
+
+	if s.cfg.APIKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, constants.APIKeyHeader, s.cfg.APIKey)
+	}
+
+	err := call(ctx)
+
+	if s.breaker != nil {
+		if isRetryable(err) {
+			s.breaker.Failure()
+		} else {
+			s.breaker.Success()
+		}
+	}
+
+	switch {
+	case err == nil:
+		return nil
+	case status.Code(err) == codes.NotFound:
+		return fmt.Errorf("%w: %w", constants.ErrNotFound, err)
+	case status.Code(err) == codes.FailedPrecondition:
+		return fmt.Errorf("%w: %w", constants.ErrInsufficientStocks, err)
+	case isRetryable(err):
+		return fmt.Errorf("%w: %w", constants.ErrStockUnavailable, err)
+	default:
+		return fmt.Errorf("gRPC stock service error: %w", err)
+	}
+}
+
+// backoff returns a random delay up to the exponential backoff of attempt ("full jitter").
+func (s *grpcStockService) backoff(attempt int) time.Duration {
+	delay := s.cfg.InitialBackoff << (attempt - 1)
+	if delay <= 0 || (s.cfg.MaxBackoff > 0 && delay > s.cfg.MaxBackoff) {
+		delay = s.cfg.MaxBackoff
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	return rand.N(delay)
+}
+
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	_, ok := retryableCodes[status.Code(err)]
+
+	return ok
+}
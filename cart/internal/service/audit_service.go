@@ -0,0 +1,175 @@
+package service
+
+import (
+	"cart/internal/models"
+	"cart/internal/repository/interfaces"
+	"cart/pkg/log"
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 500
+)
+
+type Auditor struct {
+	audit     interfaces.AuditRepository
+	repo      interfaces.CartRepository
+	saved     interfaces.SavedRepository
+	kafkaProd interfaces.KafkaProd
+	logger    log.Logger
+}
+
+// NewAuditService records audit events in the audit_log table. When kafkaProd is not
+// nil every recorded event is also published to its topic.
+func NewAuditService(audit interfaces.AuditRepository, repo interfaces.CartRepository, saved interfaces.SavedRepository, kafkaProd interfaces.KafkaProd, logger log.Logger) *Auditor {
+	return &Auditor{
+		audit:     audit,
+		repo:      repo,
+		saved:     saved,
+		kafkaProd: kafkaProd,
+		logger:    logger,
+	}
+}
+
+type cartSnapshot struct {
+	Version uint64         `json:"version"`
+	Items   []snapshotItem `json:"items"`
+	Saved   []snapshotItem `json:"saved"`
+}
+
+type snapshotItem struct {
+	SKU      uint32 `json:"sku"`
+	SellerID int64  `json:"seller_id,omitempty"`
+	Count    uint32 `json:"count"`
+}
+
+// Snapshot returns the cart and saved-for-later lines of the user as JSON.
+func (s *Auditor) Snapshot(ctx context.Context, userID int64) ([]byte, error) {
+	version, err := s.repo.CartVersion(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.ListItems(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := s.saved.ListSaved(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := cartSnapshot{
+		Version: version,
+		Items:   make([]snapshotItem, 0, len(items)),
+		Saved:   make([]snapshotItem, 0, len(saved)),
+	}
+
+	for _, item := range items {
+		snapshot.Items = append(snapshot.Items, snapshotItem{SKU: item.SKU, SellerID: item.SellerID, Count: item.Count})
+	}
+
+	for _, item := range saved {
+		snapshot.Saved = append(snapshot.Saved, snapshotItem{SKU: item.SKU, SellerID: item.SellerID, Count: item.Count})
+	}
+
+	return json.Marshal(snapshot)
+}
+
+type AuditKafkaEvent struct {
+	Type      string            `json:"type"`
+	Service   string            `json:"service"`
+	Timestamp time.Time         `json:"timestamp"`
+	Payload   AuditEventPayload `json:"payload"`
+}
+
+type AuditEventPayload struct {
+	ID          int64           `json:"id"`
+	ActorID     int64           `json:"actor_id"`
+	Method      string          `json:"method"`
+	EntityType  string          `json:"entity_type"`
+	EntityID    string          `json:"entity_id"`
+	RequestHash string          `json:"request_hash"`
+	Before      json.RawMessage `json:"before,omitempty"`
+	After       json.RawMessage `json:"after,omitempty"`
+	Status      string          `json:"status"`
+	TraceID     string          `json:"trace_id,omitempty"`
+}
+
+func (s *Auditor) Record(ctx context.Context, event models.AuditEvent) error {
+	ctx, span := otel.Tracer("cart-service").Start(ctx, "AuditService.Record")
+	defer span.End()
+
+	event, err := s.audit.Insert(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	if s.kafkaProd == nil {
+		return nil
+	}
+
+	msg, err := json.Marshal(AuditKafkaEvent{
+		Type:      "audit_event",
+		Service:   "cart",
+		Timestamp: event.CreatedAt,
+		Payload: AuditEventPayload{
+			ID:          event.ID,
+			ActorID:     event.ActorID,
+			Method:      event.Method,
+			EntityType:  event.EntityType,
+			EntityID:    event.EntityID,
+			RequestHash: event.RequestHash,
+			Before:      event.Before,
+			After:       event.After,
+			Status:      event.Status,
+			TraceID:     event.TraceID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.kafkaProd.Produce(ctx, msg, event.EntityID, event.CreatedAt); err != nil {
+		s.logger.FromContext(ctx).Errorf("err in produce audit event: %v", err)
+	}
+
+	return nil
+}
+
+// ListAuditEvents returns one page of events, newest first. NextCursor is the id to pass
+// as BeforeID for the next page, or zero on the last page.
+func (s *Auditor) ListAuditEvents(ctx context.Context, filter models.AuditFilter) (models.AuditEventsPage, error) {
+	ctx, span := otel.Tracer("cart-service").Start(ctx, "AuditService.ListAuditEvents")
+	defer span.End()
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditPageSize
+	}
+
+	filter.Limit = min(filter.Limit, maxAuditPageSize)
+
+	// One extra row tells whether another page follows.
+	pageSize := filter.Limit
+	filter.Limit++
+
+	events, err := s.audit.List(ctx, filter)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in list audit events: %v", err)
+		return models.AuditEventsPage{}, err
+	}
+
+	page := models.AuditEventsPage{Events: events}
+	if len(events) > pageSize {
+		page.Events = events[:pageSize]
+		page.NextCursor = page.Events[pageSize-1].ID
+	}
+
+	return page, nil
+}
@@ -6,8 +6,26 @@ import (
 )
 
 type CartService interface {
-	AddItemToCart(ctx context.Context, params models.CartItem) error
+	AddItemToCart(ctx context.Context, params models.CartItem) (uint64, error)
 	ListCartItems(ctx context.Context, userID int64) (models.CartItemsList, error)
-	DeleteItemFromCart(ctx context.Context, params models.DeleteCartItem) error
-	ClearCart(ctx context.Context, userID int64) error
+	DeleteItemFromCart(ctx context.Context, params models.DeleteCartItem) (uint64, error)
+	ClearCart(ctx context.Context, params models.ClearCart) (uint64, error)
+	PurgeExpiredCarts(ctx context.Context, params models.PurgeExpiredCarts) ([]models.ExpiredCart, error)
+	MoveToSavedForLater(ctx context.Context, params models.MoveSavedItem) (uint64, error)
+	MoveToCart(ctx context.Context, params models.MoveSavedItem) (uint64, error)
+	ListSaved(ctx context.Context, userID int64) (models.SavedItemsList, error)
+	ValidateCart(ctx context.Context, params models.ValidateCart) (models.CartValidation, error)
+	WatchCart(ctx context.Context, userID int64, send func(models.CartItemsList) error) error
+}
+
+type CheckoutService interface {
+	Checkout(ctx context.Context, params models.CreateCheckout) (models.Checkout, error)
+	GetCheckout(ctx context.Context, params models.GetCheckout) (models.Checkout, error)
+	HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) (int64, error)
+}
+
+type AuditService interface {
+	Snapshot(ctx context.Context, userID int64) ([]byte, error)
+	Record(ctx context.Context, event models.AuditEvent) error
+	ListAuditEvents(ctx context.Context, filter models.AuditFilter) (models.AuditEventsPage, error)
 }
@@ -2,9 +2,11 @@ package service
 
 import (
 	"cart/internal/constants"
+	"cart/internal/domainerr"
 	"cart/internal/models"
 	"cart/internal/repository/interfaces"
 	"cart/pkg/log"
+	"cart/pkg/metrics"
 	"context"
 	"errors"
 	"fmt"
@@ -14,78 +16,139 @@ import (
 
 type Service struct {
 	repo      interfaces.CartRepository
+	saved     interfaces.SavedRepository
 	stock     interfaces.StockService
+	watcher   interfaces.CartWatcher
 	kafkaProd interfaces.KafkaProd
+	metrics   metrics.Metrics
 	logger    log.Logger
+
+	// degradedMode keeps cart lines whose stock info is unavailable in listings
+	// instead of dropping them.
+	degradedMode bool
 }
 
-func NewService(repo interfaces.CartRepository, stock interfaces.StockService, kafkaProd interfaces.KafkaProd, logger log.Logger) *Service {
+func NewService(repo interfaces.CartRepository, saved interfaces.SavedRepository, stock interfaces.StockService, watcher interfaces.CartWatcher, kafkaProd interfaces.KafkaProd, m metrics.Metrics, degradedMode bool, logger log.Logger) *Service {
 	return &Service{
-		repo:      repo,
-		stock:     stock,
-		kafkaProd: kafkaProd,
-		logger:    logger,
+		repo:         repo,
+		saved:        saved,
+		stock:        stock,
+		watcher:      watcher,
+		kafkaProd:    kafkaProd,
+		metrics:      m,
+		logger:       logger,
+		degradedMode: degradedMode,
 	}
 }
 
-func (s *Service) AddItemToCart(ctx context.Context, params models.CartItem) error {
+func (s *Service) AddItemToCart(ctx context.Context, params models.CartItem) (uint64, error) {
 	var (
-		addedType      = "cart_item_added"
-		status         = "success"
-		reason         string
-		isInsufficient bool
-		cartId         int64
+		addedType       = "cart_item_added"
+		status          = "success"
+		reason          string
+		insufficientErr error
+		cartId          int64
+		version         uint64
 	)
 
 	ctx, span := otel.Tracer("cart-service").Start(ctx, "CartService.AddItemToCart")
 	defer span.End()
 
-	skuItem, err := s.stock.GetSKU(ctx, params.SKU)
+	// Without a seller an existing line keeps its offer, a new one takes the default offer.
+	if params.SellerID == 0 {
+		line, err := s.repo.CartItem(ctx, params.UserID, params.SKU)
+		if err != nil {
+			s.logger.FromContext(ctx).Errorf("err in get cart item in AddItemToCart: %v", err)
+			return 0, err
+		}
+
+		params.SellerID = line.SellerID
+	}
+
+	skuItem, err := s.stock.GetOffer(ctx, params.SKU, params.SellerID)
 	if err != nil {
-		s.logger.Errorf("err in get sku in AddItemToCart: %v", err)
+		s.logger.FromContext(ctx).Errorf("err in get sku in AddItemToCart: %v", err)
 
 		if errors.Is(err, constants.ErrNotFound) {
-			return constants.ErrInvalidSKU
+			return 0, domainerr.InvalidOffer(params.SKU, params.SellerID)
 		}
 
-		return fmt.Errorf("failed to validate SKU: %w", err)
+		return 0, fmt.Errorf("failed to validate SKU: %w", err)
 	}
 
-	cartItemCount, err := s.repo.CartItemCount(ctx, params.UserID, params.SKU)
-	if err != nil {
-		s.logger.Errorf("err in CartItemCount: %v", err)
-		return err
+	params.SellerID = skuItem.SellerID
+	params.Price = &skuItem.Price
+
+	for attempt := 1; ; attempt++ {
+		cartId, version, err = s.tryAddItem(ctx, params, skuItem.Count)
+		if errors.Is(err, constants.ErrVersionMismatch) && params.ExpectedVersion == nil && attempt < constants.VersionConflictRetries {
+			continue
+		}
+
+		break
 	}
 
-	if skuItem.Count < params.Count+cartItemCount {
+	if errors.Is(err, constants.ErrInsufficientStocks) {
+		insufficientErr = err
+	} else if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in AddItem: %v", err)
+		return 0, err
+	}
+
+	if insufficientErr != nil {
 		reason = constants.ErrInsufficientStocks.Error()
 		addedType = "cart_item_failed"
-		isInsufficient = true
 	}
 
-	if !isInsufficient {
-		cartId, err = s.repo.AddItem(ctx, params)
+	msg, timestamp, err := BuildKafkaEvent(addedType, cartId, skuItem.Price, reason, status, params)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in build kafka event: %v", err)
+	}
+
+	err = s.kafkaProd.Produce(ctx, msg, fmt.Sprint(params.SKU), timestamp)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in produce kafka msg: %v", err)
+	}
+
+	if insufficientErr != nil {
+		s.metrics.IncInsufficientStock()
+		return 0, insufficientErr
+	}
+
+	s.metrics.AddItemsAdded(params.Count)
+
+	return version, nil
+}
+
+// tryAddItem checks the available stock against the cart at a known version and writes
+// only if the cart is still at that version, so concurrent adds cannot exceed the stock.
+func (s *Service) tryAddItem(ctx context.Context, params models.CartItem, available uint32) (int64, uint64, error) {
+	expected := params.ExpectedVersion
+	if expected == nil {
+		current, err := s.repo.CartVersion(ctx, params.UserID)
 		if err != nil {
-			s.logger.Errorf("err in AddItem: %v", err)
-			return err
+			return 0, 0, err
 		}
+
+		expected = &current
 	}
 
-	msg, timestamp, err := BuildKafkaEvent(addedType, cartId, skuItem.Price, reason, status, params)
+	line, err := s.repo.CartItem(ctx, params.UserID, params.SKU)
 	if err != nil {
-		s.logger.Errorf("err in build kafka event: %v", err)
+		return 0, 0, err
 	}
 
-	err = s.kafkaProd.Produce(msg, fmt.Sprint(params.SKU), timestamp)
-	if err != nil {
-		s.logger.Errorf("err in produce kafka msg: %v", err)
+	if line.SellerID != 0 && line.SellerID != params.SellerID {
+		return 0, 0, domainerr.SellerMismatch(params.SKU, line.SellerID, params.SellerID)
 	}
 
-	if isInsufficient {
-		return constants.ErrInsufficientStocks
+	if requested := params.Count + line.Count; available < requested {
+		return 0, 0, domainerr.InsufficientStock(params.SKU, requested, available)
 	}
 
-	return nil
+	params.ExpectedVersion = expected
+
+	return s.repo.AddItem(ctx, params)
 }
 
 func (s *Service) ListCartItems(ctx context.Context, userID int64) (models.CartItemsList, error) {
@@ -95,53 +158,129 @@ func (s *Service) ListCartItems(ctx context.Context, userID int64) (models.CartI
 	var result models.CartItemsList
 	var total uint32
 
+	// The version is read before the items, so a concurrent change can only make it
+	// older than the listed items and a later expected_version check fails safely.
+	version, err := s.repo.CartVersion(ctx, userID)
+	if err != nil {
+		return result, err
+	}
+
 	items, err := s.repo.ListItems(ctx, userID)
 	if err != nil {
 		return result, err
 	}
 
 	for _, item := range items {
-		stockItem, err := s.stock.GetSKU(ctx, item.SKU)
+		stockItem, err := s.stock.GetOffer(ctx, item.SKU, item.SellerID)
 		if err != nil {
-			s.logger.Errorf("failed to fetch stock info for SKU %d: %v", item.SKU, err)
+			s.logger.FromContext(ctx).Errorf("failed to fetch stock info for SKU %d: %v", item.SKU, err)
+
+			// The line stays listed, without a price, until the user removes it or the
+			// seller restores the offer.
+			if errors.Is(err, constants.ErrNotFound) {
+				result.Items = append(result.Items, models.CartItemModel{
+					SKU:         item.SKU,
+					SellerID:    item.SellerID,
+					Count:       item.Count,
+					Unavailable: true,
+				})
+
+				continue
+			}
+
+			if s.degradedMode && errors.Is(err, constants.ErrStockUnavailable) {
+				result.Items = append(result.Items, models.CartItemModel{
+					SKU:          item.SKU,
+					SellerID:     item.SellerID,
+					Count:        item.Count,
+					PriceUnknown: true,
+				})
+				result.Degraded = true
+			}
+
 			continue
 		}
 
 		result.Items = append(result.Items, models.CartItemModel{
-			SKU:   item.SKU,
-			Count: item.Count,
-			Name:  stockItem.Name,
-			Price: stockItem.Price,
+			SKU:      item.SKU,
+			SellerID: stockItem.SellerID,
+			Count:    item.Count,
+			Name:     stockItem.Name,
+			Price:    stockItem.Price,
 		})
 
 		total += stockItem.Price * uint32(item.Count)
 	}
 
 	result.TotalPrice = total
+	result.Version = version
+
+	s.metrics.ObserveCartSize(len(result.Items))
 
 	return result, nil
 }
 
-func (s *Service) DeleteItemFromCart(ctx context.Context, params models.DeleteCartItem) error {
+func (s *Service) DeleteItemFromCart(ctx context.Context, params models.DeleteCartItem) (uint64, error) {
 	ctx, span := otel.Tracer("cart-service").Start(ctx, "CartService.DeleteItemFromCart")
 	defer span.End()
 
-	err := s.repo.DeleteCartItem(ctx, params.UserID, params.SKU)
+	version, err := s.repo.DeleteCartItem(ctx, params)
 	if err != nil && errors.Is(err, constants.ErrNotRowAffected) {
-		return constants.ErrNotFound
+		return 0, constants.ErrNotFound
+	}
+
+	return version, err
+}
+
+// purgedLineReason is the reason of the cart_item_removed events of purged carts.
+const purgedLineReason = "cart_expired"
+
+// PurgeExpiredCarts empties the carts nobody changed for params.OlderThan. Each removed
+// line is published as a cart_item_removed event with reason cart_expired.
+func (s *Service) PurgeExpiredCarts(ctx context.Context, params models.PurgeExpiredCarts) ([]models.ExpiredCart, error) {
+	ctx, span := otel.Tracer("cart-service").Start(ctx, "CartService.PurgeExpiredCarts")
+	defer span.End()
+
+	carts, err := s.repo.PurgeExpiredCarts(ctx, params)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in PurgeExpiredCarts: %v", err)
+		return nil, err
+	}
+
+	if params.DryRun {
+		return carts, nil
+	}
+
+	for _, cart := range carts {
+		for _, item := range cart.Items {
+			var price uint32
+			if item.Price != nil {
+				price = *item.Price
+			}
+
+			msg, timestamp, err := BuildKafkaEvent("cart_item_removed", 0, price, purgedLineReason, "success", item)
+			if err != nil {
+				s.logger.FromContext(ctx).Errorf("err in build kafka event: %v", err)
+				continue
+			}
+
+			if err := s.kafkaProd.Produce(ctx, msg, fmt.Sprint(item.SKU), timestamp); err != nil {
+				s.logger.FromContext(ctx).Errorf("err in produce kafka msg: %v", err)
+			}
+		}
 	}
 
-	return err
+	return carts, nil
 }
 
-func (s *Service) ClearCart(ctx context.Context, userID int64) error {
+func (s *Service) ClearCart(ctx context.Context, params models.ClearCart) (uint64, error) {
 	ctx, span := otel.Tracer("cart-service").Start(ctx, "CartService.ClearCart")
 	defer span.End()
 
-	err := s.repo.ClearCart(ctx, userID)
+	version, err := s.repo.ClearCart(ctx, params)
 	if err != nil && errors.Is(err, constants.ErrNotRowAffected) {
-		return constants.ErrNotFound
+		return 0, constants.ErrNotFound
 	}
 
-	return err
+	return version, err
 }
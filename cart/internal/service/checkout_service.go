@@ -0,0 +1,425 @@
+package service
+
+import (
+	"cart/internal/constants"
+	"cart/internal/domainerr"
+	"cart/internal/models"
+	"cart/internal/repository/interfaces"
+	"cart/pkg/log"
+	"cart/pkg/metrics"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const stalledCheckoutsBatch = 100
+
+// errAwaitingAction tells that the step is parked until the customer passed 3-D Secure.
+var errAwaitingAction = errors.New("payment awaits customer action")
+
+// CheckoutSaga runs checkouts as sagas persisted in Postgres. Every step change is
+// announced on the checkout topic and the saga consumes those events to run the next
+// step, so any replica can pick it up. Steps are claimed with a lease of stepTimeout;
+// sagas whose lease ran out, because an attempt failed, an event was lost or a replica
+// crashed, are found again by RecoverStalled. Steps that can be undone get maxAttempts,
+// the others maxRetries before the saga gives up. A payment that needs 3-D Secure parks
+// the authorization for actionTimeout, until the provider's webhook wakes it.
+type CheckoutSaga struct {
+	checkouts interfaces.CheckoutRepository
+	carts     interfaces.CartRepository
+	stock     interfaces.StockService
+	payment   interfaces.PaymentProvider
+	steps     interfaces.KafkaProd
+	metrics   metrics.Metrics
+	logger    log.Logger
+
+	stepTimeout   time.Duration
+	maxAttempts   int
+	maxRetries    int
+	actionTimeout time.Duration
+}
+
+func NewCheckoutSaga(checkouts interfaces.CheckoutRepository, carts interfaces.CartRepository, stock interfaces.StockService, payment interfaces.PaymentProvider, steps interfaces.KafkaProd, m metrics.Metrics, stepTimeout time.Duration, maxAttempts, maxRetries int, actionTimeout time.Duration, logger log.Logger) *CheckoutSaga {
+	if stepTimeout <= 0 {
+		stepTimeout = constants.CheckoutStepTimeout
+	}
+
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	if maxRetries <= 0 {
+		maxRetries = constants.CheckoutMaxRetries
+	}
+
+	if actionTimeout <= 0 {
+		actionTimeout = constants.PaymentActionTimeout
+	}
+
+	return &CheckoutSaga{
+		checkouts:     checkouts,
+		carts:         carts,
+		stock:         stock,
+		payment:       payment,
+		steps:         steps,
+		metrics:       m,
+		logger:        logger,
+		stepTimeout:   stepTimeout,
+		maxAttempts:   maxAttempts,
+		maxRetries:    maxRetries,
+		actionTimeout: actionTimeout,
+	}
+}
+
+// Checkout starts a saga for the lines in the user's cart at their current offer
+// prices, paid with params.PaymentMethod. The payment method is tokenized by the
+// provider first and only the token, brand and last four digits are stored. The cart
+// is left as it is until the saga clears it.
+func (s *CheckoutSaga) Checkout(ctx context.Context, params models.CreateCheckout) (models.Checkout, error) {
+	ctx, span := otel.Tracer("cart-service").Start(ctx, "CheckoutSaga.Checkout")
+	defer span.End()
+
+	items, err := s.carts.ListItems(ctx, params.UserID)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in list cart items in Checkout: %v", err)
+		return models.Checkout{}, err
+	}
+
+	if len(items) == 0 {
+		return models.Checkout{}, domainerr.CartEmpty(params.UserID)
+	}
+
+	var (
+		lines      = make([]models.CheckoutLine, 0, len(items))
+		totalPrice uint64
+	)
+
+	for _, item := range items {
+		offer, err := s.stock.GetOffer(ctx, item.SKU, item.SellerID)
+		if err != nil {
+			s.logger.FromContext(ctx).Errorf("err in get sku in Checkout: %v", err)
+
+			if errors.Is(err, constants.ErrNotFound) {
+				return models.Checkout{}, domainerr.InvalidOffer(item.SKU, item.SellerID)
+			}
+
+			return models.Checkout{}, fmt.Errorf("failed to get offer of sku %d: %w", item.SKU, err)
+		}
+
+		lines = append(lines, models.CheckoutLine{
+			SKU:      item.SKU,
+			SellerID: offer.SellerID,
+			Count:    item.Count,
+			Price:    offer.Price,
+		})
+
+		totalPrice += uint64(offer.Price) * uint64(item.Count)
+	}
+
+	card, err := s.payment.Tokenize(ctx, params.PaymentMethod)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in tokenize payment method in Checkout: %v", err)
+		return models.Checkout{}, err
+	}
+
+	checkout, err := s.checkouts.CreateCheckout(ctx, params.UserID, card, lines, totalPrice)
+	if err != nil {
+		if errors.Is(err, constants.ErrCheckoutInProgress) {
+			return models.Checkout{}, domainerr.CheckoutInProgress(params.UserID)
+		}
+
+		s.logger.FromContext(ctx).Errorf("err in create checkout: %v", err)
+		return models.Checkout{}, err
+	}
+
+	s.produceStepEvent(ctx, checkout)
+
+	return checkout, nil
+}
+
+// GetCheckout returns a checkout of the user.
+func (s *CheckoutSaga) GetCheckout(ctx context.Context, params models.GetCheckout) (models.Checkout, error) {
+	ctx, span := otel.Tracer("cart-service").Start(ctx, "CheckoutSaga.GetCheckout")
+	defer span.End()
+
+	checkout, err := s.checkouts.GetCheckout(ctx, params.CheckoutID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Checkout{}, domainerr.CheckoutNotFound(params.CheckoutID)
+		}
+
+		s.logger.FromContext(ctx).Errorf("err in get checkout: %v", err)
+		return models.Checkout{}, err
+	}
+
+	if checkout.UserID != params.UserID {
+		return models.Checkout{}, domainerr.CheckoutNotFound(params.CheckoutID)
+	}
+
+	return checkout, nil
+}
+
+// HandlePaymentWebhook wakes the checkout whose authorization waits for the payment
+// the webhook is about and returns its id. Webhooks of other payments are accepted and
+// ignored, with a zero id.
+func (s *CheckoutSaga) HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) (int64, error) {
+	ctx, span := otel.Tracer("cart-service").Start(ctx, "CheckoutSaga.HandlePaymentWebhook")
+	defer span.End()
+
+	event, err := s.payment.VerifyWebhook(ctx, payload, signature)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in verify payment webhook: %v", err)
+
+		if errors.Is(err, constants.ErrWebhookSignature) {
+			return 0, domainerr.InvalidWebhookSignature()
+		}
+
+		return 0, err
+	}
+
+	checkoutID, err := strconv.ParseInt(event.Reference, 10, 64)
+	if err != nil {
+		return 0, nil
+	}
+
+	checkout, err := s.checkouts.WakeStep(ctx, checkoutID, models.CheckoutStepAuthorizePayment)
+	if err != nil {
+		if errors.Is(err, constants.ErrNotRowAffected) {
+			return 0, nil
+		}
+
+		s.logger.FromContext(ctx).Errorf("err in wake checkout %d: %v", checkoutID, err)
+		return 0, err
+	}
+
+	s.produceStepEvent(ctx, checkout)
+
+	return checkout.ID, nil
+}
+
+// HandleMessage runs the step announced by a checkout step event.
+func (s *CheckoutSaga) HandleMessage(message []byte) error {
+	var event CheckoutStepEvent
+
+	if err := json.Unmarshal(message, &event); err != nil {
+		return err
+	}
+
+	if event.Type != checkoutStepEventType || event.Service != "cart" || event.Payload.Step == "" {
+		return nil
+	}
+
+	return s.RunStep(context.Background(), event.Payload.CheckoutID, models.CheckoutStep(event.Payload.Step))
+}
+
+// RecoverStalled announces the steps of the sagas whose lease ran out more than a
+// step timeout ago again, so they continue after failed attempts, lost events and
+// crashed replicas.
+func (s *CheckoutSaga) RecoverStalled(ctx context.Context) (int, error) {
+	stalled, err := s.checkouts.ListStalled(ctx, s.stepTimeout, stalledCheckoutsBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, checkout := range stalled {
+		s.produceStepEvent(ctx, checkout)
+	}
+
+	return len(stalled), nil
+}
+
+// RunStep runs step of the checkout unless the saga has moved past it or another
+// attempt holds its lease. Steps are idempotent, so running one again after its lease
+// ran out is safe.
+func (s *CheckoutSaga) RunStep(ctx context.Context, checkoutID int64, step models.CheckoutStep) error {
+	ctx, span := otel.Tracer("cart-service").Start(ctx, "CheckoutSaga.RunStep")
+	defer span.End()
+
+	checkout, err := s.checkouts.ClaimStep(ctx, checkoutID, step, s.stepTimeout)
+	if err != nil {
+		if errors.Is(err, constants.ErrNotRowAffected) {
+			return nil
+		}
+
+		return err
+	}
+
+	flow, ok := checkoutFlow[checkout.Step]
+	if !ok {
+		return fmt.Errorf("checkout %d is at unknown step %q", checkout.ID, checkout.Step)
+	}
+
+	stepCtx, cancel := context.WithTimeout(ctx, s.stepTimeout)
+	err = s.runStep(stepCtx, checkout, flow)
+	cancel()
+
+	if err == nil {
+		s.metrics.IncCheckoutStep(string(checkout.Step), "ok")
+		return nil
+	}
+
+	if errors.Is(err, errAwaitingAction) {
+		s.metrics.IncCheckoutStep(string(checkout.Step), "waiting")
+		return nil
+	}
+
+	s.logger.FromContext(ctx).Errorf("err in checkout %d step %s, attempt %d: %v", checkout.ID, checkout.Step, checkout.Attempts, err)
+
+	final := isFinalFailure(err)
+
+	maxAttempts := s.maxAttempts
+	if flow.persistent {
+		maxAttempts = s.maxRetries
+	}
+
+	if !final && checkout.Attempts < maxAttempts {
+		s.metrics.IncCheckoutStep(string(checkout.Step), "retry")
+		return s.checkouts.FailAttempt(ctx, checkout.ID, checkout.Step, err.Error())
+	}
+
+	// A step that cannot be undone, or one that may have taken effect when it ran out
+	// of retries, leaves the saga to an operator.
+	if flow.compensation == models.CheckoutStepNone || (flow.persistent && !final) {
+		s.metrics.IncCheckoutStep(string(checkout.Step), "stuck")
+		s.logger.FromContext(ctx).Errorf("checkout %d is stuck at step %s: %v", checkout.ID, checkout.Step, err)
+
+		return s.advance(ctx, checkout, models.CheckoutAdvance{
+			Status:    models.CheckoutStuck,
+			Step:      checkout.Step,
+			LastError: err.Error(),
+		})
+	}
+
+	s.metrics.IncCheckoutStep(string(checkout.Step), "failed")
+
+	return s.advance(ctx, checkout, models.CheckoutAdvance{
+		Status:    models.CheckoutCompensating,
+		Step:      flow.compensation,
+		LastError: err.Error(),
+	})
+}
+
+// runStep does the work of the step and moves the saga on.
+func (s *CheckoutSaga) runStep(ctx context.Context, checkout models.Checkout, flow checkoutStepFlow) error {
+	var (
+		checkoutRef = strconv.FormatInt(checkout.ID, 10)
+		paymentID   string
+		err         error
+	)
+
+	switch checkout.Step {
+	case models.CheckoutStepReserveStock:
+		err = s.stock.ReserveStock(ctx, checkoutRef, checkout.Lines)
+	case models.CheckoutStepAuthorizePayment:
+		paymentID, err = s.authorize(ctx, checkout, checkoutRef)
+	case models.CheckoutStepCommitStock:
+		err = s.stock.CommitReservation(ctx, checkoutRef)
+	case models.CheckoutStepCapturePayment:
+		_, err = s.payment.Capture(ctx, checkout.PaymentID, checkout.TotalPrice)
+	case models.CheckoutStepClearCart:
+		// The last step finishes the saga in the same transaction as it clears the cart.
+		return s.complete(ctx, checkout)
+	case models.CheckoutStepVoidPayment:
+		// Without a payment id no authorization was made, or the provider lets it expire.
+		if checkout.PaymentID != "" {
+			_, err = s.payment.Void(ctx, checkout.PaymentID)
+		}
+	case models.CheckoutStepReleaseStock:
+		err = s.stock.ReleaseReservation(ctx, checkoutRef)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Compensations keep the error that started them.
+	lastError := ""
+	if checkout.Status == models.CheckoutCompensating {
+		lastError = checkout.LastError
+	}
+
+	return s.advance(ctx, checkout, models.CheckoutAdvance{
+		Status:    flow.nextStatus,
+		Step:      flow.next,
+		PaymentID: paymentID,
+		LastError: lastError,
+	})
+}
+
+// authorize returns the id of the authorized payment. A payment that needs 3-D Secure
+// parks the step the first time; still waiting when it is woken again means the
+// customer did not pass the challenge in time.
+func (s *CheckoutSaga) authorize(ctx context.Context, checkout models.Checkout, checkoutRef string) (string, error) {
+	payment, err := s.payment.Authorize(ctx, models.AuthorizePayment{
+		Reference: checkoutRef,
+		UserID:    checkout.UserID,
+		Amount:    checkout.TotalPrice,
+		Token:     checkout.PaymentCard.Token,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if payment.Status != models.PaymentRequiresAction {
+		return payment.ID, nil
+	}
+
+	if checkout.PaymentActionURL != "" {
+		return "", fmt.Errorf("%w: 3-D Secure of payment %s was not passed in time", constants.ErrPaymentDeclined, payment.ID)
+	}
+
+	if err := s.checkouts.AwaitAction(ctx, checkout.ID, checkout.Step, payment, s.actionTimeout); err != nil {
+		return "", err
+	}
+
+	return "", errAwaitingAction
+}
+
+func (s *CheckoutSaga) advance(ctx context.Context, checkout models.Checkout, next models.CheckoutAdvance) error {
+	advanced, err := s.checkouts.AdvanceStep(ctx, checkout.ID, checkout.Step, next)
+	if err != nil {
+		// Another attempt moved the saga on already.
+		if errors.Is(err, constants.ErrNotRowAffected) {
+			return nil
+		}
+
+		return err
+	}
+
+	s.produceStepEvent(ctx, advanced)
+
+	return nil
+}
+
+func (s *CheckoutSaga) complete(ctx context.Context, checkout models.Checkout) error {
+	completed, err := s.checkouts.CompleteCheckout(ctx, checkout)
+	if err != nil {
+		if errors.Is(err, constants.ErrNotRowAffected) {
+			return nil
+		}
+
+		return err
+	}
+
+	s.produceStepEvent(ctx, completed)
+
+	return nil
+}
+
+func (s *CheckoutSaga) produceStepEvent(ctx context.Context, checkout models.Checkout) {
+	msg, timestamp, err := BuildCheckoutStepEvent(checkout)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in build kafka event: %v", err)
+	}
+
+	err = s.steps.Produce(ctx, msg, strconv.FormatInt(checkout.ID, 10), timestamp)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in produce kafka msg: %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"cart/internal/constants"
+	"cart/internal/models"
+	"cart/internal/repository/interfaces"
+	"cart/pkg/log/zap"
+	"cart/pkg/metrics"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	uzap "go.uber.org/zap"
+)
+
+type fakeCheckouts struct {
+	interfaces.CheckoutRepository
+
+	checkout models.Checkout
+	advanced *models.CheckoutAdvance
+	failed   bool
+}
+
+func (f *fakeCheckouts) ClaimStep(_ context.Context, _ int64, _ models.CheckoutStep, _ time.Duration) (models.Checkout, error) {
+	f.checkout.Attempts++
+	return f.checkout, nil
+}
+
+func (f *fakeCheckouts) AdvanceStep(_ context.Context, _ int64, _ models.CheckoutStep, next models.CheckoutAdvance) (models.Checkout, error) {
+	f.advanced = &next
+	f.checkout.Status = next.Status
+	f.checkout.Step = next.Step
+
+	return f.checkout, nil
+}
+
+func (f *fakeCheckouts) FailAttempt(_ context.Context, _ int64, _ models.CheckoutStep, _ string) error {
+	f.failed = true
+	return nil
+}
+
+type failingStock struct {
+	interfaces.StockService
+
+	err error
+}
+
+func (f failingStock) ReserveStock(context.Context, string, []models.CheckoutLine) error {
+	return f.err
+}
+
+func (f failingStock) CommitReservation(context.Context, string) error {
+	return f.err
+}
+
+func (f failingStock) ReleaseReservation(context.Context, string) error {
+	return f.err
+}
+
+type failingPayment struct {
+	interfaces.PaymentProvider
+
+	err error
+}
+
+func (f failingPayment) Capture(context.Context, string, uint64) (models.Payment, error) {
+	return models.Payment{}, f.err
+}
+
+func (f failingPayment) Void(context.Context, string) (models.Payment, error) {
+	return models.Payment{}, f.err
+}
+
+type discardProducer struct {
+	interfaces.KafkaProd
+}
+
+func (discardProducer) Produce(context.Context, []byte, string, time.Time) error {
+	return nil
+}
+
+type stepMetrics struct {
+	metrics.Metrics
+
+	results []string
+}
+
+func (m *stepMetrics) IncCheckoutStep(_, result string) {
+	m.results = append(m.results, result)
+}
+
+func TestRunStepGivesUp(t *testing.T) {
+	const (
+		maxAttempts = 3
+		maxRetries  = 5
+	)
+
+	errTransient := errors.New("connection reset")
+
+	tests := []struct {
+		name     string
+		status   models.CheckoutStatus
+		step     models.CheckoutStep
+		attempts int
+		err      error
+		// wantStatus and wantStep are where the saga moved to, zero when the attempt is
+		// only failed and retried later.
+		wantStatus models.CheckoutStatus
+		wantStep   models.CheckoutStep
+		wantResult string
+	}{
+		{
+			name:       "reserve is retried",
+			step:       models.CheckoutStepReserveStock,
+			attempts:   1,
+			err:        errTransient,
+			wantResult: "retry",
+		},
+		{
+			name:       "reserve out of attempts compensates",
+			step:       models.CheckoutStepReserveStock,
+			attempts:   maxAttempts,
+			err:        errTransient,
+			wantStatus: models.CheckoutCompensating,
+			wantStep:   models.CheckoutStepReleaseStock,
+			wantResult: "failed",
+		},
+		{
+			name:       "insufficient stock compensates at once",
+			step:       models.CheckoutStepReserveStock,
+			err:        constants.ErrInsufficientStocks,
+			wantStatus: models.CheckoutCompensating,
+			wantStep:   models.CheckoutStepReleaseStock,
+			wantResult: "failed",
+		},
+		{
+			name:       "commit is retried past max attempts",
+			step:       models.CheckoutStepCommitStock,
+			attempts:   maxAttempts,
+			err:        errTransient,
+			wantResult: "retry",
+		},
+		{
+			name:       "commit out of retries is stuck",
+			step:       models.CheckoutStepCommitStock,
+			attempts:   maxRetries,
+			err:        errTransient,
+			wantStatus: models.CheckoutStuck,
+			wantStep:   models.CheckoutStepCommitStock,
+			wantResult: "stuck",
+		},
+		{
+			name:       "commit of an unknown reservation compensates",
+			step:       models.CheckoutStepCommitStock,
+			err:        constants.ErrNotFound,
+			wantStatus: models.CheckoutCompensating,
+			wantStep:   models.CheckoutStepVoidPayment,
+			wantResult: "failed",
+		},
+		{
+			name:       "capture of a voided payment is stuck",
+			step:       models.CheckoutStepCapturePayment,
+			err:        constants.ErrPaymentState,
+			wantStatus: models.CheckoutStuck,
+			wantStep:   models.CheckoutStepCapturePayment,
+			wantResult: "stuck",
+		},
+		{
+			name:       "release out of retries is stuck",
+			status:     models.CheckoutCompensating,
+			step:       models.CheckoutStepReleaseStock,
+			attempts:   maxRetries,
+			err:        errTransient,
+			wantStatus: models.CheckoutStuck,
+			wantStep:   models.CheckoutStepReleaseStock,
+			wantResult: "stuck",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status
+			if status == "" {
+				status = models.CheckoutRunning
+			}
+
+			checkouts := &fakeCheckouts{checkout: models.Checkout{
+				ID:        1,
+				Status:    status,
+				Step:      tt.step,
+				PaymentID: "sim_pay_1",
+				// ClaimStep counts the attempt being made.
+				Attempts: tt.attempts - 1,
+			}}
+			m := &stepMetrics{}
+
+			saga := NewCheckoutSaga(checkouts, nil, failingStock{err: tt.err}, failingPayment{err: tt.err}, discardProducer{}, m,
+				time.Second, maxAttempts, maxRetries, time.Minute, &zap.Logger{L: uzap.NewNop()})
+
+			if err := saga.RunStep(context.Background(), 1, tt.step); err != nil {
+				t.Fatalf("RunStep() error = %v", err)
+			}
+
+			if len(m.results) != 1 || m.results[0] != tt.wantResult {
+				t.Errorf("step results = %v, want [%s]", m.results, tt.wantResult)
+			}
+
+			if tt.wantStatus == "" {
+				if !checkouts.failed || checkouts.advanced != nil {
+					t.Fatalf("failed = %t, advanced = %+v, want the attempt failed only", checkouts.failed, checkouts.advanced)
+				}
+
+				return
+			}
+
+			if checkouts.advanced == nil {
+				t.Fatal("saga did not move on")
+			}
+
+			if checkouts.advanced.Status != tt.wantStatus || checkouts.advanced.Step != tt.wantStep {
+				t.Errorf("saga moved to %s at %q, want %s at %q", checkouts.advanced.Status, checkouts.advanced.Step, tt.wantStatus, tt.wantStep)
+			}
+
+			if checkouts.advanced.LastError != tt.err.Error() {
+				t.Errorf("last error = %q, want %q", checkouts.advanced.LastError, tt.err.Error())
+			}
+		})
+	}
+}
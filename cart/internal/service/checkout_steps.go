@@ -0,0 +1,69 @@
+package service
+
+import (
+	"cart/internal/constants"
+	"cart/internal/models"
+	"errors"
+)
+
+// checkoutStepFlow is where a saga goes from a step. A failed step with a compensation
+// starts it once the failure is final or the step ran out of attempts. Persistent steps
+// get the larger number of retries, and a persistent step running out of them, like a
+// step without compensation failing for good, leaves the saga stuck.
+type checkoutStepFlow struct {
+	next         models.CheckoutStep
+	nextStatus   models.CheckoutStatus
+	compensation models.CheckoutStep
+	persistent   bool
+}
+
+// checkoutFlow chains the forward steps reserve stock, authorize payment, commit stock,
+// capture payment and clear cart. Committing the stock is the pivot: from then on the
+// saga keeps retrying until the cart is cleared rather than giving up a committed
+// order, and only stops when an operator has to step in.
+var checkoutFlow = map[models.CheckoutStep]checkoutStepFlow{
+	models.CheckoutStepReserveStock: {
+		next:         models.CheckoutStepAuthorizePayment,
+		nextStatus:   models.CheckoutRunning,
+		compensation: models.CheckoutStepReleaseStock,
+	},
+	models.CheckoutStepAuthorizePayment: {
+		next:         models.CheckoutStepCommitStock,
+		nextStatus:   models.CheckoutRunning,
+		compensation: models.CheckoutStepVoidPayment,
+	},
+	models.CheckoutStepCommitStock: {
+		next:         models.CheckoutStepCapturePayment,
+		nextStatus:   models.CheckoutRunning,
+		compensation: models.CheckoutStepVoidPayment,
+		persistent:   true,
+	},
+	models.CheckoutStepCapturePayment: {
+		next:       models.CheckoutStepClearCart,
+		nextStatus: models.CheckoutRunning,
+		persistent: true,
+	},
+	models.CheckoutStepClearCart: {
+		next:       models.CheckoutStepNone,
+		nextStatus: models.CheckoutCompleted,
+		persistent: true,
+	},
+	models.CheckoutStepVoidPayment: {
+		next:       models.CheckoutStepReleaseStock,
+		nextStatus: models.CheckoutCompensating,
+		persistent: true,
+	},
+	models.CheckoutStepReleaseStock: {
+		next:       models.CheckoutStepNone,
+		nextStatus: models.CheckoutFailed,
+		persistent: true,
+	},
+}
+
+// isFinalFailure tells whether a step failed in a way retrying cannot change.
+func isFinalFailure(err error) bool {
+	return errors.Is(err, constants.ErrInsufficientStocks) ||
+		errors.Is(err, constants.ErrNotFound) ||
+		errors.Is(err, constants.ErrPaymentDeclined) ||
+		errors.Is(err, constants.ErrPaymentState)
+}
@@ -7,12 +7,13 @@ import (
 )
 
 type Payload struct {
-	CardId int64  `json:"card_id"`
-	SKU    uint32 `json:"sku"`
-	Count  uint32 `json:"count"`
-	Price  uint32 `json:"price"`
-	Status string `json:"status"`
-	Reason string `json:"reason,omitempty"`
+	CardId   int64  `json:"card_id"`
+	SKU      uint32 `json:"sku"`
+	SellerID int64  `json:"seller_id,omitempty"`
+	Count    uint32 `json:"count"`
+	Price    uint32 `json:"price"`
+	Status   string `json:"status"`
+	Reason   string `json:"reason,omitempty"`
 }
 
 type KafkaEvent struct {
@@ -29,12 +30,75 @@ func BuildKafkaEvent(addedType string, cartId int64, price uint32, reason, statu
 		Service:   "cart",
 		Timestamp: timestamp,
 		Payload: Payload{
-			CardId: cartId,
-			SKU:    item.SKU,
-			Count:  item.Count,
-			Price:  price,
-			Reason: reason,
-			Status: status,
+			CardId:   cartId,
+			SKU:      item.SKU,
+			SellerID: item.SellerID,
+			Count:    item.Count,
+			Price:    price,
+			Reason:   reason,
+			Status:   status,
+		},
+	}
+
+	msg, err := json.Marshal(message)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return msg, timestamp, nil
+}
+
+const checkoutStepEventType = "checkout_step"
+
+type CheckoutStepLine struct {
+	SKU      uint32 `json:"sku"`
+	SellerID int64  `json:"seller_id"`
+	Count    uint32 `json:"count"`
+	Price    uint32 `json:"price"`
+}
+
+type CheckoutStepPayload struct {
+	CheckoutID int64              `json:"checkout_id"`
+	UserID     int64              `json:"user_id"`
+	Status     string             `json:"status"`
+	Step       string             `json:"step"`
+	Lines      []CheckoutStepLine `json:"lines"`
+	LastError  string             `json:"last_error,omitempty"`
+}
+
+// CheckoutStepEvent announces the step a checkout saga is at. The saga consumes these
+// events to run the step; a finished saga has an empty step. The orders service places
+// and updates the order of the checkout from them, so they carry its lines.
+type CheckoutStepEvent struct {
+	Type      string              `json:"type"`
+	Service   string              `json:"service"`
+	Timestamp time.Time           `json:"timestamp"`
+	Payload   CheckoutStepPayload `json:"payload"`
+}
+
+func BuildCheckoutStepEvent(checkout models.Checkout) ([]byte, time.Time, error) {
+	lines := make([]CheckoutStepLine, 0, len(checkout.Lines))
+	for _, line := range checkout.Lines {
+		lines = append(lines, CheckoutStepLine{
+			SKU:      line.SKU,
+			SellerID: line.SellerID,
+			Count:    line.Count,
+			Price:    line.Price,
+		})
+	}
+
+	timestamp := time.Now()
+	message := CheckoutStepEvent{
+		Type:      checkoutStepEventType,
+		Service:   "cart",
+		Timestamp: timestamp,
+		Payload: CheckoutStepPayload{
+			CheckoutID: checkout.ID,
+			UserID:     checkout.UserID,
+			Status:     string(checkout.Status),
+			Step:       string(checkout.Step),
+			Lines:      lines,
+			LastError:  checkout.LastError,
 		},
 	}
 
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"cart/internal/constants"
+	"cart/internal/domainerr"
+	"cart/internal/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+)
+
+func (s *Service) MoveToSavedForLater(ctx context.Context, params models.MoveSavedItem) (uint64, error) {
+	ctx, span := otel.Tracer("cart-service").Start(ctx, "CartService.MoveToSavedForLater")
+	defer span.End()
+
+	line, err := s.repo.CartItem(ctx, params.UserID, params.SKU)
+	if err != nil {
+		return 0, err
+	}
+
+	if line.Count == 0 {
+		return 0, constants.ErrNotFound
+	}
+
+	saved, err := s.saved.SavedItem(ctx, params.UserID, params.SKU)
+	if err != nil && !errors.Is(err, constants.ErrNotRowAffected) {
+		return 0, err
+	}
+
+	if saved.SellerID != 0 && line.SellerID != 0 && saved.SellerID != line.SellerID {
+		return 0, domainerr.SellerMismatch(params.SKU, saved.SellerID, line.SellerID)
+	}
+
+	// The stock count seen at save time is the baseline for back-in-stock detection,
+	// it stays unknown when stocks can not be reached.
+	var lastSeenCount *uint32
+
+	stockItem, err := s.stock.GetOffer(ctx, params.SKU, line.SellerID)
+	switch {
+	case err == nil:
+		lastSeenCount = &stockItem.Count
+	case errors.Is(err, constants.ErrNotFound):
+		lastSeenCount = new(uint32)
+	default:
+		s.logger.FromContext(ctx).Errorf("failed to fetch stock info for SKU %d: %v", params.SKU, err)
+	}
+
+	version, err := s.saved.MoveToSaved(ctx, params, lastSeenCount)
+	if err != nil && errors.Is(err, constants.ErrNotRowAffected) {
+		return 0, constants.ErrNotFound
+	}
+
+	return version, err
+}
+
+func (s *Service) MoveToCart(ctx context.Context, params models.MoveSavedItem) (uint64, error) {
+	var version uint64
+
+	ctx, span := otel.Tracer("cart-service").Start(ctx, "CartService.MoveToCart")
+	defer span.End()
+
+	saved, err := s.saved.SavedItem(ctx, params.UserID, params.SKU)
+	if err != nil {
+		if errors.Is(err, constants.ErrNotRowAffected) {
+			return 0, constants.ErrNotFound
+		}
+
+		return 0, err
+	}
+
+	skuItem, err := s.stock.GetOffer(ctx, params.SKU, saved.SellerID)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in get sku in MoveToCart: %v", err)
+
+		if errors.Is(err, constants.ErrNotFound) {
+			return 0, domainerr.InvalidOffer(params.SKU, saved.SellerID)
+		}
+
+		return 0, fmt.Errorf("failed to validate SKU: %w", err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		version, err = s.tryMoveToCart(ctx, params, skuItem)
+		if errors.Is(err, constants.ErrVersionMismatch) && params.ExpectedVersion == nil && attempt < constants.VersionConflictRetries {
+			continue
+		}
+
+		break
+	}
+
+	if err != nil {
+		if errors.Is(err, constants.ErrNotRowAffected) {
+			return 0, constants.ErrNotFound
+		} else if errors.Is(err, constants.ErrInsufficientStocks) {
+			s.metrics.IncInsufficientStock()
+			return 0, err
+		}
+
+		s.logger.FromContext(ctx).Errorf("err in MoveToCart: %v", err)
+
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// tryMoveToCart works like tryAddItem for the whole saved quantity of the SKU, which
+// joins the cart as a line of the offer.
+func (s *Service) tryMoveToCart(ctx context.Context, params models.MoveSavedItem, offer models.StockItem) (uint64, error) {
+	expected := params.ExpectedVersion
+	if expected == nil {
+		current, err := s.repo.CartVersion(ctx, params.UserID)
+		if err != nil {
+			return 0, err
+		}
+
+		expected = &current
+	}
+
+	saved, err := s.saved.SavedItem(ctx, params.UserID, params.SKU)
+	if err != nil {
+		return 0, err
+	}
+
+	line, err := s.repo.CartItem(ctx, params.UserID, params.SKU)
+	if err != nil {
+		return 0, err
+	}
+
+	if line.SellerID != 0 && line.SellerID != offer.SellerID {
+		return 0, domainerr.SellerMismatch(params.SKU, line.SellerID, offer.SellerID)
+	}
+
+	if requested := saved.Count + line.Count; offer.Count < requested {
+		return 0, domainerr.InsufficientStock(params.SKU, requested, offer.Count)
+	}
+
+	params.ExpectedVersion = expected
+
+	return s.saved.MoveToCart(ctx, params, offer)
+}
+
+// ListSaved enriches saved items with the current stock info. BackInStock is reported
+// once, on the first listing after the SKU's count went from 0 to positive.
+func (s *Service) ListSaved(ctx context.Context, userID int64) (models.SavedItemsList, error) {
+	ctx, span := otel.Tracer("cart-service").Start(ctx, "CartService.ListSaved")
+	defer span.End()
+
+	var result models.SavedItemsList
+
+	items, err := s.saved.ListSaved(ctx, userID)
+	if err != nil {
+		return result, err
+	}
+
+	for _, item := range items {
+		stockItem, err := s.stock.GetOffer(ctx, item.SKU, item.SellerID)
+		if err != nil && !errors.Is(err, constants.ErrNotFound) {
+			s.logger.FromContext(ctx).Errorf("failed to fetch stock info for SKU %d: %v", item.SKU, err)
+			continue
+		}
+
+		// A legacy line without seller follows the default offer.
+		sellerID := item.SellerID
+		if stockItem.SellerID != 0 {
+			sellerID = stockItem.SellerID
+		}
+
+		backInStock := item.LastSeenCount != nil && *item.LastSeenCount == 0 && stockItem.Count > 0
+
+		if item.LastSeenCount == nil || *item.LastSeenCount != stockItem.Count {
+			if err := s.saved.UpdateLastSeenCount(ctx, userID, item.SKU, stockItem.Count); err != nil {
+				s.logger.FromContext(ctx).Errorf("err in update last seen count: %v", err)
+			}
+		}
+
+		if backInStock {
+			s.produceBackInStock(ctx, item, stockItem.Price)
+		}
+
+		result.Items = append(result.Items, models.SavedItemModel{
+			SKU:            item.SKU,
+			SellerID:       sellerID,
+			Count:          item.Count,
+			Name:           stockItem.Name,
+			Price:          stockItem.Price,
+			AvailableCount: stockItem.Count,
+			InStock:        stockItem.Count >= item.Count,
+			BackInStock:    backInStock,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *Service) produceBackInStock(ctx context.Context, item models.SavedItem, price uint32) {
+	params := models.CartItem{
+		UserID:   item.UserID,
+		SKU:      item.SKU,
+		SellerID: item.SellerID,
+		Count:    item.Count,
+	}
+
+	msg, timestamp, err := BuildKafkaEvent("saved_item_back_in_stock", 0, price, "", "success", params)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in build kafka event: %v", err)
+		return
+	}
+
+	if err := s.kafkaProd.Produce(ctx, msg, fmt.Sprint(item.SKU), timestamp); err != nil {
+		s.logger.FromContext(ctx).Errorf("err in produce kafka msg: %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"cart/internal/constants"
+	"cart/internal/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+)
+
+// fixEvents are the Kafka event types emitted for auto fix corrections.
+var fixEvents = map[models.CartLineFix]string{
+	models.CartLineRemoved:  "cart_item_removed",
+	models.CartLineClamped:  "cart_item_clamped",
+	models.CartLineRepriced: "cart_item_repriced",
+}
+
+// ValidateCart checks every cart line against its offer in stocks. With AutoFix dead
+// lines are removed, quantities clamped to the available stock and changed prices
+// accepted, all in one cart version, and an event is emitted for every correction.
+func (s *Service) ValidateCart(ctx context.Context, params models.ValidateCart) (models.CartValidation, error) {
+	ctx, span := otel.Tracer("cart-service").Start(ctx, "CartService.ValidateCart")
+	defer span.End()
+
+	var (
+		result models.CartValidation
+		err    error
+	)
+
+	for attempt := 1; ; attempt++ {
+		result, err = s.tryValidateCart(ctx, params)
+		if errors.Is(err, constants.ErrVersionMismatch) && params.ExpectedVersion == nil && attempt < constants.VersionConflictRetries {
+			continue
+		}
+
+		break
+	}
+
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in ValidateCart: %v", err)
+		return models.CartValidation{}, err
+	}
+
+	for _, line := range result.Lines {
+		if line.Fix != models.CartLineNotFixed {
+			s.produceFixEvent(ctx, params.UserID, line)
+		}
+	}
+
+	return result, nil
+}
+
+// tryValidateCart validates the lines at a known version and only writes the fixes if
+// the cart is still at that version.
+func (s *Service) tryValidateCart(ctx context.Context, params models.ValidateCart) (models.CartValidation, error) {
+	result := models.CartValidation{Valid: true}
+
+	version, err := s.repo.CartVersion(ctx, params.UserID)
+	if err != nil {
+		return result, err
+	}
+
+	expected := params.ExpectedVersion
+	if expected == nil {
+		expected = &version
+	}
+
+	items, err := s.repo.ListItems(ctx, params.UserID)
+	if err != nil {
+		return result, err
+	}
+
+	var fixes []models.CartLineValidation
+
+	for _, item := range items {
+		line, err := s.validateLine(ctx, item)
+		if err != nil {
+			return models.CartValidation{}, err
+		}
+
+		if line.Status != models.CartLineOK {
+			result.Valid = false
+		}
+
+		if params.AutoFix {
+			line = fixLine(line)
+			if line.Fix != models.CartLineNotFixed {
+				fixes = append(fixes, line)
+			}
+		}
+
+		result.Lines = append(result.Lines, line)
+	}
+
+	result.Version = version
+
+	if len(fixes) > 0 {
+		result.Version, err = s.repo.FixItems(ctx, params.UserID, expected, fixes)
+		if err != nil {
+			return models.CartValidation{}, err
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Service) validateLine(ctx context.Context, item models.CartItem) (models.CartLineValidation, error) {
+	line := models.CartLineValidation{
+		SKU:      item.SKU,
+		SellerID: item.SellerID,
+		Count:    item.Count,
+		Status:   models.CartLineOK,
+	}
+
+	offer, err := s.stock.GetOffer(ctx, item.SKU, item.SellerID)
+	if err != nil {
+		if errors.Is(err, constants.ErrNotFound) {
+			line.Status = models.CartLineSKURemoved
+			return line, nil
+		}
+
+		return line, fmt.Errorf("failed to validate SKU %d: %w", item.SKU, err)
+	}
+
+	line.SellerID = offer.SellerID
+	line.AvailableCount = offer.Count
+	line.Price = offer.Price
+
+	if item.Price != nil && *item.Price != offer.Price {
+		line.PreviousPrice = item.Price
+	}
+
+	switch {
+	case offer.Count == 0:
+		line.Status = models.CartLineOutOfStock
+	case offer.Count < item.Count:
+		line.Status = models.CartLineInsufficient
+	case line.PreviousPrice != nil:
+		line.Status = models.CartLinePriceChanged
+	}
+
+	return line, nil
+}
+
+// fixLine returns the line after its auto fix correction.
+func fixLine(line models.CartLineValidation) models.CartLineValidation {
+	switch line.Status {
+	case models.CartLineSKURemoved, models.CartLineOutOfStock:
+		line.Fix = models.CartLineRemoved
+		line.Count = 0
+	case models.CartLineInsufficient:
+		line.Fix = models.CartLineClamped
+		line.Count = line.AvailableCount
+	case models.CartLinePriceChanged:
+		line.Fix = models.CartLineRepriced
+	}
+
+	return line
+}
+
+func (s *Service) produceFixEvent(ctx context.Context, userID int64, line models.CartLineValidation) {
+	item := models.CartItem{
+		UserID:   userID,
+		SKU:      line.SKU,
+		SellerID: line.SellerID,
+		Count:    line.Count,
+	}
+
+	msg, timestamp, err := BuildKafkaEvent(fixEvents[line.Fix], 0, line.Price, string(line.Status), "success", item)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in build kafka event: %v", err)
+		return
+	}
+
+	if err := s.kafkaProd.Produce(ctx, msg, fmt.Sprint(line.SKU), timestamp); err != nil {
+		s.logger.FromContext(ctx).Errorf("err in produce kafka msg: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"cart/internal/models"
+	"context"
+
+	"go.opentelemetry.io/otel"
+)
+
+// WatchCart sends the current cart snapshot and then a new one after every change of
+// the user's cart until ctx is done or send fails.
+func (s *Service) WatchCart(ctx context.Context, userID int64, send func(models.CartItemsList) error) error {
+	ctx, span := otel.Tracer("cart-service").Start(ctx, "CartService.WatchCart")
+	defer span.End()
+
+	updates, unsubscribe := s.watcher.Subscribe(userID)
+	defer unsubscribe()
+
+	var (
+		sent        bool
+		lastVersion uint64
+	)
+
+	push := func() error {
+		cart, err := s.ListCartItems(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if sent && cart.Version == lastVersion {
+			return nil
+		}
+
+		sent, lastVersion = true, cart.Version
+
+		return send(cart)
+	}
+
+	if err := push(); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-updates:
+			if err := push(); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -19,10 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CartService_AddItemToCart_FullMethodName      = "/cart.CartService/AddItemToCart"
-	CartService_DeleteItemFromCart_FullMethodName = "/cart.CartService/DeleteItemFromCart"
-	CartService_CartList_FullMethodName           = "/cart.CartService/CartList"
-	CartService_ClearCart_FullMethodName          = "/cart.CartService/ClearCart"
+	CartService_AddItemToCart_FullMethodName        = "/cart.CartService/AddItemToCart"
+	CartService_DeleteItemFromCart_FullMethodName   = "/cart.CartService/DeleteItemFromCart"
+	CartService_CartList_FullMethodName             = "/cart.CartService/CartList"
+	CartService_ClearCart_FullMethodName            = "/cart.CartService/ClearCart"
+	CartService_MoveToSavedForLater_FullMethodName  = "/cart.CartService/MoveToSavedForLater"
+	CartService_MoveToCart_FullMethodName           = "/cart.CartService/MoveToCart"
+	CartService_ListSaved_FullMethodName            = "/cart.CartService/ListSaved"
+	CartService_ValidateCart_FullMethodName         = "/cart.CartService/ValidateCart"
+	CartService_WatchCart_FullMethodName            = "/cart.CartService/WatchCart"
+	CartService_Checkout_FullMethodName             = "/cart.CartService/Checkout"
+	CartService_GetCheckout_FullMethodName          = "/cart.CartService/GetCheckout"
+	CartService_HandlePaymentWebhook_FullMethodName = "/cart.CartService/HandlePaymentWebhook"
+	CartService_ListAuditEvents_FullMethodName      = "/cart.CartService/ListAuditEvents"
+	CartService_PurgeExpiredCarts_FullMethodName    = "/cart.CartService/PurgeExpiredCarts"
 )
 
 // CartServiceClient is the client API for CartService service.
@@ -33,6 +43,33 @@ type CartServiceClient interface {
 	DeleteItemFromCart(ctx context.Context, in *DeleteItemFromCartRequest, opts ...grpc.CallOption) (*DeleteItemFromCartResponse, error)
 	CartList(ctx context.Context, in *CartListRequest, opts ...grpc.CallOption) (*CartListResponse, error)
 	ClearCart(ctx context.Context, in *ClearCartRequest, opts ...grpc.CallOption) (*ClearCartResponse, error)
+	MoveToSavedForLater(ctx context.Context, in *MoveToSavedForLaterRequest, opts ...grpc.CallOption) (*MoveToSavedForLaterResponse, error)
+	MoveToCart(ctx context.Context, in *MoveToCartRequest, opts ...grpc.CallOption) (*MoveToCartResponse, error)
+	ListSaved(ctx context.Context, in *ListSavedRequest, opts ...grpc.CallOption) (*ListSavedResponse, error)
+	// ValidateCart checks every line against its offer in stocks. With auto_fix it also
+	// removes dead lines, clamps quantities to the available stock and accepts changed
+	// prices, emitting an event for every correction.
+	ValidateCart(ctx context.Context, in *ValidateCartRequest, opts ...grpc.CallOption) (*ValidateCartResponse, error)
+	// WatchCart streams the full cart snapshot on every change. The gateway exposes it
+	// as Server-Sent Events on GET /cart/watch?user_id=...
+	WatchCart(ctx context.Context, in *WatchCartRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CartListResponse], error)
+	// Checkout starts the checkout saga for the whole cart: reserve stock, authorize
+	// payment, commit stock, capture payment, clear cart. It returns once the saga is persisted; poll
+	// GetCheckout for the outcome.
+	Checkout(ctx context.Context, in *CheckoutRequest, opts ...grpc.CallOption) (*CheckoutResponse, error)
+	GetCheckout(ctx context.Context, in *GetCheckoutRequest, opts ...grpc.CallOption) (*GetCheckoutResponse, error)
+	// HandlePaymentWebhook takes a notification of the payment provider, such as the
+	// outcome of a 3-D Secure challenge. The gateway exposes it as POST /payments/webhook
+	// with the raw body and the X-Payment-Signature header.
+	HandlePaymentWebhook(ctx context.Context, in *HandlePaymentWebhookRequest, opts ...grpc.CallOption) (*HandlePaymentWebhookResponse, error)
+	// ListAuditEvents lists recorded mutations, newest first. Admin only: callers must
+	// send one of the configured admin API keys in x-api-key.
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	// PurgeExpiredCarts empties up to limit carts nobody changed for older_than_seconds,
+	// oldest first. Carts being checked out are skipped and saved for later items are
+	// kept. Each purged cart gets a new version. With dry_run nothing is changed. Admin
+	// only, like ListAuditEvents.
+	PurgeExpiredCarts(ctx context.Context, in *PurgeExpiredCartsRequest, opts ...grpc.CallOption) (*PurgeExpiredCartsResponse, error)
 }
 
 type cartServiceClient struct {
@@ -83,6 +120,115 @@ func (c *cartServiceClient) ClearCart(ctx context.Context, in *ClearCartRequest,
 	return out, nil
 }
 
+func (c *cartServiceClient) MoveToSavedForLater(ctx context.Context, in *MoveToSavedForLaterRequest, opts ...grpc.CallOption) (*MoveToSavedForLaterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveToSavedForLaterResponse)
+	err := c.cc.Invoke(ctx, CartService_MoveToSavedForLater_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) MoveToCart(ctx context.Context, in *MoveToCartRequest, opts ...grpc.CallOption) (*MoveToCartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveToCartResponse)
+	err := c.cc.Invoke(ctx, CartService_MoveToCart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) ListSaved(ctx context.Context, in *ListSavedRequest, opts ...grpc.CallOption) (*ListSavedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSavedResponse)
+	err := c.cc.Invoke(ctx, CartService_ListSaved_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) ValidateCart(ctx context.Context, in *ValidateCartRequest, opts ...grpc.CallOption) (*ValidateCartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateCartResponse)
+	err := c.cc.Invoke(ctx, CartService_ValidateCart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) WatchCart(ctx context.Context, in *WatchCartRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CartListResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CartService_ServiceDesc.Streams[0], CartService_WatchCart_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchCartRequest, CartListResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CartService_WatchCartClient = grpc.ServerStreamingClient[CartListResponse]
+
+func (c *cartServiceClient) Checkout(ctx context.Context, in *CheckoutRequest, opts ...grpc.CallOption) (*CheckoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckoutResponse)
+	err := c.cc.Invoke(ctx, CartService_Checkout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) GetCheckout(ctx context.Context, in *GetCheckoutRequest, opts ...grpc.CallOption) (*GetCheckoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCheckoutResponse)
+	err := c.cc.Invoke(ctx, CartService_GetCheckout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) HandlePaymentWebhook(ctx context.Context, in *HandlePaymentWebhookRequest, opts ...grpc.CallOption) (*HandlePaymentWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HandlePaymentWebhookResponse)
+	err := c.cc.Invoke(ctx, CartService_HandlePaymentWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, CartService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartServiceClient) PurgeExpiredCarts(ctx context.Context, in *PurgeExpiredCartsRequest, opts ...grpc.CallOption) (*PurgeExpiredCartsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeExpiredCartsResponse)
+	err := c.cc.Invoke(ctx, CartService_PurgeExpiredCarts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CartServiceServer is the server API for CartService service.
 // All implementations must embed UnimplementedCartServiceServer
 // for forward compatibility.
@@ -91,6 +237,33 @@ type CartServiceServer interface {
 	DeleteItemFromCart(context.Context, *DeleteItemFromCartRequest) (*DeleteItemFromCartResponse, error)
 	CartList(context.Context, *CartListRequest) (*CartListResponse, error)
 	ClearCart(context.Context, *ClearCartRequest) (*ClearCartResponse, error)
+	MoveToSavedForLater(context.Context, *MoveToSavedForLaterRequest) (*MoveToSavedForLaterResponse, error)
+	MoveToCart(context.Context, *MoveToCartRequest) (*MoveToCartResponse, error)
+	ListSaved(context.Context, *ListSavedRequest) (*ListSavedResponse, error)
+	// ValidateCart checks every line against its offer in stocks. With auto_fix it also
+	// removes dead lines, clamps quantities to the available stock and accepts changed
+	// prices, emitting an event for every correction.
+	ValidateCart(context.Context, *ValidateCartRequest) (*ValidateCartResponse, error)
+	// WatchCart streams the full cart snapshot on every change. The gateway exposes it
+	// as Server-Sent Events on GET /cart/watch?user_id=...
+	WatchCart(*WatchCartRequest, grpc.ServerStreamingServer[CartListResponse]) error
+	// Checkout starts the checkout saga for the whole cart: reserve stock, authorize
+	// payment, commit stock, capture payment, clear cart. It returns once the saga is persisted; poll
+	// GetCheckout for the outcome.
+	Checkout(context.Context, *CheckoutRequest) (*CheckoutResponse, error)
+	GetCheckout(context.Context, *GetCheckoutRequest) (*GetCheckoutResponse, error)
+	// HandlePaymentWebhook takes a notification of the payment provider, such as the
+	// outcome of a 3-D Secure challenge. The gateway exposes it as POST /payments/webhook
+	// with the raw body and the X-Payment-Signature header.
+	HandlePaymentWebhook(context.Context, *HandlePaymentWebhookRequest) (*HandlePaymentWebhookResponse, error)
+	// ListAuditEvents lists recorded mutations, newest first. Admin only: callers must
+	// send one of the configured admin API keys in x-api-key.
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	// PurgeExpiredCarts empties up to limit carts nobody changed for older_than_seconds,
+	// oldest first. Carts being checked out are skipped and saved for later items are
+	// kept. Each purged cart gets a new version. With dry_run nothing is changed. Admin
+	// only, like ListAuditEvents.
+	PurgeExpiredCarts(context.Context, *PurgeExpiredCartsRequest) (*PurgeExpiredCartsResponse, error)
 	mustEmbedUnimplementedCartServiceServer()
 }
 
@@ -113,6 +286,36 @@ func (UnimplementedCartServiceServer) CartList(context.Context, *CartListRequest
 func (UnimplementedCartServiceServer) ClearCart(context.Context, *ClearCartRequest) (*ClearCartResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClearCart not implemented")
 }
+func (UnimplementedCartServiceServer) MoveToSavedForLater(context.Context, *MoveToSavedForLaterRequest) (*MoveToSavedForLaterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveToSavedForLater not implemented")
+}
+func (UnimplementedCartServiceServer) MoveToCart(context.Context, *MoveToCartRequest) (*MoveToCartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveToCart not implemented")
+}
+func (UnimplementedCartServiceServer) ListSaved(context.Context, *ListSavedRequest) (*ListSavedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSaved not implemented")
+}
+func (UnimplementedCartServiceServer) ValidateCart(context.Context, *ValidateCartRequest) (*ValidateCartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateCart not implemented")
+}
+func (UnimplementedCartServiceServer) WatchCart(*WatchCartRequest, grpc.ServerStreamingServer[CartListResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCart not implemented")
+}
+func (UnimplementedCartServiceServer) Checkout(context.Context, *CheckoutRequest) (*CheckoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Checkout not implemented")
+}
+func (UnimplementedCartServiceServer) GetCheckout(context.Context, *GetCheckoutRequest) (*GetCheckoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCheckout not implemented")
+}
+func (UnimplementedCartServiceServer) HandlePaymentWebhook(context.Context, *HandlePaymentWebhookRequest) (*HandlePaymentWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandlePaymentWebhook not implemented")
+}
+func (UnimplementedCartServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedCartServiceServer) PurgeExpiredCarts(context.Context, *PurgeExpiredCartsRequest) (*PurgeExpiredCartsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeExpiredCarts not implemented")
+}
 func (UnimplementedCartServiceServer) mustEmbedUnimplementedCartServiceServer() {}
 func (UnimplementedCartServiceServer) testEmbeddedByValue()                     {}
 
@@ -206,6 +409,179 @@ func _CartService_ClearCart_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CartService_MoveToSavedForLater_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveToSavedForLaterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).MoveToSavedForLater(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_MoveToSavedForLater_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).MoveToSavedForLater(ctx, req.(*MoveToSavedForLaterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_MoveToCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveToCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).MoveToCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_MoveToCart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).MoveToCart(ctx, req.(*MoveToCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_ListSaved_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSavedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).ListSaved(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_ListSaved_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).ListSaved(ctx, req.(*ListSavedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_ValidateCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).ValidateCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_ValidateCart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).ValidateCart(ctx, req.(*ValidateCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_WatchCart_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCartRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CartServiceServer).WatchCart(m, &grpc.GenericServerStream[WatchCartRequest, CartListResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CartService_WatchCartServer = grpc.ServerStreamingServer[CartListResponse]
+
+func _CartService_Checkout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).Checkout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_Checkout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).Checkout(ctx, req.(*CheckoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_GetCheckout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCheckoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).GetCheckout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_GetCheckout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).GetCheckout(ctx, req.(*GetCheckoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_HandlePaymentWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlePaymentWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).HandlePaymentWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_HandlePaymentWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).HandlePaymentWebhook(ctx, req.(*HandlePaymentWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartService_PurgeExpiredCarts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeExpiredCartsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartServiceServer).PurgeExpiredCarts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CartService_PurgeExpiredCarts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartServiceServer).PurgeExpiredCarts(ctx, req.(*PurgeExpiredCartsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CartService_ServiceDesc is the grpc.ServiceDesc for CartService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,7 +605,49 @@ var CartService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ClearCart",
 			Handler:    _CartService_ClearCart_Handler,
 		},
+		{
+			MethodName: "MoveToSavedForLater",
+			Handler:    _CartService_MoveToSavedForLater_Handler,
+		},
+		{
+			MethodName: "MoveToCart",
+			Handler:    _CartService_MoveToCart_Handler,
+		},
+		{
+			MethodName: "ListSaved",
+			Handler:    _CartService_ListSaved_Handler,
+		},
+		{
+			MethodName: "ValidateCart",
+			Handler:    _CartService_ValidateCart_Handler,
+		},
+		{
+			MethodName: "Checkout",
+			Handler:    _CartService_Checkout_Handler,
+		},
+		{
+			MethodName: "GetCheckout",
+			Handler:    _CartService_GetCheckout_Handler,
+		},
+		{
+			MethodName: "HandlePaymentWebhook",
+			Handler:    _CartService_HandlePaymentWebhook_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _CartService_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "PurgeExpiredCarts",
+			Handler:    _CartService_PurgeExpiredCarts_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCart",
+			Handler:       _CartService_WatchCart_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "cart/cart.proto",
 }
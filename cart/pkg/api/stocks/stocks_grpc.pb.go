@@ -21,8 +21,19 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	StockService_AddStock_FullMethodName             = "/stocks.StockService/AddStock"
 	StockService_DeleteStock_FullMethodName          = "/stocks.StockService/DeleteStock"
+	StockService_RestoreStock_FullMethodName         = "/stocks.StockService/RestoreStock"
 	StockService_ListStocksByLocation_FullMethodName = "/stocks.StockService/ListStocksByLocation"
 	StockService_GetStock_FullMethodName             = "/stocks.StockService/GetStock"
+	StockService_ListOffers_FullMethodName           = "/stocks.StockService/ListOffers"
+	StockService_WatchStock_FullMethodName           = "/stocks.StockService/WatchStock"
+	StockService_CreateSeller_FullMethodName         = "/stocks.StockService/CreateSeller"
+	StockService_TransferSeller_FullMethodName       = "/stocks.StockService/TransferSeller"
+	StockService_ReserveStock_FullMethodName         = "/stocks.StockService/ReserveStock"
+	StockService_CommitReservation_FullMethodName    = "/stocks.StockService/CommitReservation"
+	StockService_ReleaseReservation_FullMethodName   = "/stocks.StockService/ReleaseReservation"
+	StockService_AdjustStock_FullMethodName          = "/stocks.StockService/AdjustStock"
+	StockService_ImportSKUs_FullMethodName           = "/stocks.StockService/ImportSKUs"
+	StockService_ListAuditEvents_FullMethodName      = "/stocks.StockService/ListAuditEvents"
 )
 
 // StockServiceClient is the client API for StockService service.
@@ -30,9 +41,46 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type StockServiceClient interface {
 	AddStock(ctx context.Context, in *AddStockRequest, opts ...grpc.CallOption) (*AddStockResponse, error)
+	// DeleteStock soft deletes an offer. It is hidden from listings and GetStock, and
+	// purged after the configured retention period unless restored.
 	DeleteStock(ctx context.Context, in *DeleteStockRequest, opts ...grpc.CallOption) (*DeleteStockResponse, error)
+	// RestoreStock undoes the deletion of an offer that is not purged yet.
+	RestoreStock(ctx context.Context, in *RestoreStockRequest, opts ...grpc.CallOption) (*RestoreStockResponse, error)
 	ListStocksByLocation(ctx context.Context, in *ListStocksByLocationRequest, opts ...grpc.CallOption) (*ListStocksByLocationResponse, error)
 	GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*GetStockResponse, error)
+	// ListOffers lists the offers of every seller for a SKU, the default offer first.
+	ListOffers(ctx context.Context, in *ListOffersRequest, opts ...grpc.CallOption) (*ListOffersResponse, error)
+	// WatchStock streams the current default offer of the requested SKUs and then every
+	// change of it. Slow readers only get the latest change per SKU.
+	WatchStock(ctx context.Context, in *WatchStockRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StockChange], error)
+	CreateSeller(ctx context.Context, in *CreateSellerRequest, opts ...grpc.CallOption) (*CreateSellerResponse, error)
+	// TransferSeller hands a seller and all its offers over to another user. Only the
+	// current owner may call it.
+	TransferSeller(ctx context.Context, in *TransferSellerRequest, opts ...grpc.CallOption) (*TransferSellerResponse, error)
+	// ReserveStock takes the units of a checkout out of the offers' counts, all or none.
+	// Repeated calls with the same checkout_id reserve once. A released checkout_id
+	// fails with FAILED_PRECONDITION. Internal: callers must send one of the configured
+	// internal API keys in x-api-key, and it is not on the gateway.
+	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error)
+	// CommitReservation makes the reservation of a checkout final. Internal, like
+	// ReserveStock.
+	CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*CommitReservationResponse, error)
+	// ReleaseReservation gives the reserved units of a checkout back to the offers. It
+	// does nothing for committed reservations, and marks an unknown checkout_id released
+	// so it cannot be reserved later. Internal, like ReserveStock.
+	ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error)
+	// AdjustStock adds a delta to the counts of offers in bulk, in one transaction: an
+	// unknown or deleted offer or a count going below zero rejects all of it. The changed
+	// offers are returned in the order of the adjustments. With dry_run nothing is
+	// changed. Admin only, like ListAuditEvents.
+	AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error)
+	// ImportSKUs creates the SKUs of the catalog or renames and retypes existing ones, all
+	// of them or none. A name taken by another SKU fails with SKU_NAME_TAKEN. With
+	// dry_run nothing is changed. Admin only, like ListAuditEvents.
+	ImportSKUs(ctx context.Context, in *ImportSKUsRequest, opts ...grpc.CallOption) (*ImportSKUsResponse, error)
+	// ListAuditEvents lists recorded mutations, newest first. Admin only: callers must
+	// send one of the configured admin API keys in x-api-key.
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
 }
 
 type stockServiceClient struct {
@@ -63,6 +111,16 @@ func (c *stockServiceClient) DeleteStock(ctx context.Context, in *DeleteStockReq
 	return out, nil
 }
 
+func (c *stockServiceClient) RestoreStock(ctx context.Context, in *RestoreStockRequest, opts ...grpc.CallOption) (*RestoreStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreStockResponse)
+	err := c.cc.Invoke(ctx, StockService_RestoreStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *stockServiceClient) ListStocksByLocation(ctx context.Context, in *ListStocksByLocationRequest, opts ...grpc.CallOption) (*ListStocksByLocationResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListStocksByLocationResponse)
@@ -83,14 +141,160 @@ func (c *stockServiceClient) GetStock(ctx context.Context, in *GetStockRequest,
 	return out, nil
 }
 
+func (c *stockServiceClient) ListOffers(ctx context.Context, in *ListOffersRequest, opts ...grpc.CallOption) (*ListOffersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOffersResponse)
+	err := c.cc.Invoke(ctx, StockService_ListOffers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) WatchStock(ctx context.Context, in *WatchStockRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StockChange], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StockService_ServiceDesc.Streams[0], StockService_WatchStock_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchStockRequest, StockChange]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_WatchStockClient = grpc.ServerStreamingClient[StockChange]
+
+func (c *stockServiceClient) CreateSeller(ctx context.Context, in *CreateSellerRequest, opts ...grpc.CallOption) (*CreateSellerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSellerResponse)
+	err := c.cc.Invoke(ctx, StockService_CreateSeller_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) TransferSeller(ctx context.Context, in *TransferSellerRequest, opts ...grpc.CallOption) (*TransferSellerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferSellerResponse)
+	err := c.cc.Invoke(ctx, StockService_TransferSeller_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveStockResponse)
+	err := c.cc.Invoke(ctx, StockService_ReserveStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*CommitReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommitReservationResponse)
+	err := c.cc.Invoke(ctx, StockService_CommitReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseReservationResponse)
+	err := c.cc.Invoke(ctx, StockService_ReleaseReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdjustStockResponse)
+	err := c.cc.Invoke(ctx, StockService_AdjustStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) ImportSKUs(ctx context.Context, in *ImportSKUsRequest, opts ...grpc.CallOption) (*ImportSKUsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportSKUsResponse)
+	err := c.cc.Invoke(ctx, StockService_ImportSKUs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, StockService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StockServiceServer is the server API for StockService service.
 // All implementations must embed UnimplementedStockServiceServer
 // for forward compatibility.
 type StockServiceServer interface {
 	AddStock(context.Context, *AddStockRequest) (*AddStockResponse, error)
+	// DeleteStock soft deletes an offer. It is hidden from listings and GetStock, and
+	// purged after the configured retention period unless restored.
 	DeleteStock(context.Context, *DeleteStockRequest) (*DeleteStockResponse, error)
+	// RestoreStock undoes the deletion of an offer that is not purged yet.
+	RestoreStock(context.Context, *RestoreStockRequest) (*RestoreStockResponse, error)
 	ListStocksByLocation(context.Context, *ListStocksByLocationRequest) (*ListStocksByLocationResponse, error)
 	GetStock(context.Context, *GetStockRequest) (*GetStockResponse, error)
+	// ListOffers lists the offers of every seller for a SKU, the default offer first.
+	ListOffers(context.Context, *ListOffersRequest) (*ListOffersResponse, error)
+	// WatchStock streams the current default offer of the requested SKUs and then every
+	// change of it. Slow readers only get the latest change per SKU.
+	WatchStock(*WatchStockRequest, grpc.ServerStreamingServer[StockChange]) error
+	CreateSeller(context.Context, *CreateSellerRequest) (*CreateSellerResponse, error)
+	// TransferSeller hands a seller and all its offers over to another user. Only the
+	// current owner may call it.
+	TransferSeller(context.Context, *TransferSellerRequest) (*TransferSellerResponse, error)
+	// ReserveStock takes the units of a checkout out of the offers' counts, all or none.
+	// Repeated calls with the same checkout_id reserve once. A released checkout_id
+	// fails with FAILED_PRECONDITION. Internal: callers must send one of the configured
+	// internal API keys in x-api-key, and it is not on the gateway.
+	ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error)
+	// CommitReservation makes the reservation of a checkout final. Internal, like
+	// ReserveStock.
+	CommitReservation(context.Context, *CommitReservationRequest) (*CommitReservationResponse, error)
+	// ReleaseReservation gives the reserved units of a checkout back to the offers. It
+	// does nothing for committed reservations, and marks an unknown checkout_id released
+	// so it cannot be reserved later. Internal, like ReserveStock.
+	ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error)
+	// AdjustStock adds a delta to the counts of offers in bulk, in one transaction: an
+	// unknown or deleted offer or a count going below zero rejects all of it. The changed
+	// offers are returned in the order of the adjustments. With dry_run nothing is
+	// changed. Admin only, like ListAuditEvents.
+	AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error)
+	// ImportSKUs creates the SKUs of the catalog or renames and retypes existing ones, all
+	// of them or none. A name taken by another SKU fails with SKU_NAME_TAKEN. With
+	// dry_run nothing is changed. Admin only, like ListAuditEvents.
+	ImportSKUs(context.Context, *ImportSKUsRequest) (*ImportSKUsResponse, error)
+	// ListAuditEvents lists recorded mutations, newest first. Admin only: callers must
+	// send one of the configured admin API keys in x-api-key.
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
 	mustEmbedUnimplementedStockServiceServer()
 }
 
@@ -107,12 +311,45 @@ func (UnimplementedStockServiceServer) AddStock(context.Context, *AddStockReques
 func (UnimplementedStockServiceServer) DeleteStock(context.Context, *DeleteStockRequest) (*DeleteStockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteStock not implemented")
 }
+func (UnimplementedStockServiceServer) RestoreStock(context.Context, *RestoreStockRequest) (*RestoreStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreStock not implemented")
+}
 func (UnimplementedStockServiceServer) ListStocksByLocation(context.Context, *ListStocksByLocationRequest) (*ListStocksByLocationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListStocksByLocation not implemented")
 }
 func (UnimplementedStockServiceServer) GetStock(context.Context, *GetStockRequest) (*GetStockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStock not implemented")
 }
+func (UnimplementedStockServiceServer) ListOffers(context.Context, *ListOffersRequest) (*ListOffersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOffers not implemented")
+}
+func (UnimplementedStockServiceServer) WatchStock(*WatchStockRequest, grpc.ServerStreamingServer[StockChange]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchStock not implemented")
+}
+func (UnimplementedStockServiceServer) CreateSeller(context.Context, *CreateSellerRequest) (*CreateSellerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSeller not implemented")
+}
+func (UnimplementedStockServiceServer) TransferSeller(context.Context, *TransferSellerRequest) (*TransferSellerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferSeller not implemented")
+}
+func (UnimplementedStockServiceServer) ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveStock not implemented")
+}
+func (UnimplementedStockServiceServer) CommitReservation(context.Context, *CommitReservationRequest) (*CommitReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitReservation not implemented")
+}
+func (UnimplementedStockServiceServer) ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseReservation not implemented")
+}
+func (UnimplementedStockServiceServer) AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustStock not implemented")
+}
+func (UnimplementedStockServiceServer) ImportSKUs(context.Context, *ImportSKUsRequest) (*ImportSKUsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportSKUs not implemented")
+}
+func (UnimplementedStockServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
 func (UnimplementedStockServiceServer) mustEmbedUnimplementedStockServiceServer() {}
 func (UnimplementedStockServiceServer) testEmbeddedByValue()                      {}
 
@@ -170,6 +407,24 @@ func _StockService_DeleteStock_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StockService_RestoreStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).RestoreStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_RestoreStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).RestoreStock(ctx, req.(*RestoreStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StockService_ListStocksByLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListStocksByLocationRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +461,179 @@ func _StockService_GetStock_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StockService_ListOffers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOffersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ListOffers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ListOffers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ListOffers(ctx, req.(*ListOffersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_WatchStock_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStockRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockServiceServer).WatchStock(m, &grpc.GenericServerStream[WatchStockRequest, StockChange]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_WatchStockServer = grpc.ServerStreamingServer[StockChange]
+
+func _StockService_CreateSeller_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSellerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).CreateSeller(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_CreateSeller_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).CreateSeller(ctx, req.(*CreateSellerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_TransferSeller_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferSellerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).TransferSeller(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_TransferSeller_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).TransferSeller(ctx, req.(*TransferSellerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ReserveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ReserveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_CommitReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).CommitReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_CommitReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).CommitReservation(ctx, req.(*CommitReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_ReleaseReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ReleaseReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ReleaseReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ReleaseReservation(ctx, req.(*ReleaseReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_AdjustStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).AdjustStock(ctx, req.(*AdjustStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_ImportSKUs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportSKUsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ImportSKUs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ImportSKUs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ImportSKUs(ctx, req.(*ImportSKUsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StockService_ServiceDesc is the grpc.ServiceDesc for StockService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -221,6 +649,10 @@ var StockService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteStock",
 			Handler:    _StockService_DeleteStock_Handler,
 		},
+		{
+			MethodName: "RestoreStock",
+			Handler:    _StockService_RestoreStock_Handler,
+		},
 		{
 			MethodName: "ListStocksByLocation",
 			Handler:    _StockService_ListStocksByLocation_Handler,
@@ -229,7 +661,49 @@ var StockService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStock",
 			Handler:    _StockService_GetStock_Handler,
 		},
+		{
+			MethodName: "ListOffers",
+			Handler:    _StockService_ListOffers_Handler,
+		},
+		{
+			MethodName: "CreateSeller",
+			Handler:    _StockService_CreateSeller_Handler,
+		},
+		{
+			MethodName: "TransferSeller",
+			Handler:    _StockService_TransferSeller_Handler,
+		},
+		{
+			MethodName: "ReserveStock",
+			Handler:    _StockService_ReserveStock_Handler,
+		},
+		{
+			MethodName: "CommitReservation",
+			Handler:    _StockService_CommitReservation_Handler,
+		},
+		{
+			MethodName: "ReleaseReservation",
+			Handler:    _StockService_ReleaseReservation_Handler,
+		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _StockService_AdjustStock_Handler,
+		},
+		{
+			MethodName: "ImportSKUs",
+			Handler:    _StockService_ImportSKUs_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _StockService_ListAuditEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchStock",
+			Handler:       _StockService_WatchStock_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "stocks/stocks.proto",
 }
@@ -0,0 +1,99 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestBreaker(failureThreshold int, openTimeout time.Duration) (*Breaker, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	b := New(failureThreshold, openTimeout)
+	b.now = func() time.Time { return now }
+
+	return b, &now
+}
+
+func TestOpensAfterConsecutiveFailures(t *testing.T) {
+	b, _ := newTestBreaker(3, time.Second)
+
+	b.Failure()
+	b.Failure()
+	b.Success()
+	b.Failure()
+	b.Failure()
+
+	if b.State() != StateClosed {
+		t.Fatalf("State() = %v, want closed, a success resets the failures", b.State())
+	}
+
+	b.Failure()
+
+	if b.State() != StateOpen {
+		t.Fatalf("State() = %v, want open", b.State())
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("Allow() = %v, want ErrOpen", err)
+	}
+}
+
+func TestHalfOpen(t *testing.T) {
+	tests := []struct {
+		name      string
+		probe     func(*Breaker)
+		wantState State
+		wantAllow error
+	}{
+		{name: "probe succeeds", probe: (*Breaker).Success, wantState: StateClosed},
+		{name: "probe fails", probe: (*Breaker).Failure, wantState: StateOpen, wantAllow: ErrOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, now := newTestBreaker(1, time.Second)
+
+			b.Failure()
+
+			*now = now.Add(time.Second - time.Nanosecond)
+			if err := b.Allow(); !errors.Is(err, ErrOpen) {
+				t.Fatalf("Allow() before the open timeout = %v, want ErrOpen", err)
+			}
+
+			*now = now.Add(time.Nanosecond)
+			if err := b.Allow(); err != nil {
+				t.Fatalf("Allow() after the open timeout = %v, want the probe through", err)
+			}
+			if b.State() != StateHalfOpen {
+				t.Fatalf("State() = %v, want half-open", b.State())
+			}
+			if err := b.Allow(); !errors.Is(err, ErrOpen) {
+				t.Fatalf("Allow() during the probe = %v, want ErrOpen", err)
+			}
+
+			tt.probe(b)
+
+			if b.State() != tt.wantState {
+				t.Errorf("State() = %v, want %v", b.State(), tt.wantState)
+			}
+			if err := b.Allow(); !errors.Is(err, tt.wantAllow) {
+				t.Errorf("Allow() = %v, want %v", err, tt.wantAllow)
+			}
+		})
+	}
+}
+
+func TestStateString(t *testing.T) {
+	tests := map[State]string{
+		StateClosed:   "closed",
+		StateOpen:     "open",
+		StateHalfOpen: "half-open",
+		State(42):     "unknown",
+	}
+
+	for state, want := range tests {
+		if got := state.String(); got != want {
+			t.Errorf("State(%d).String() = %q, want %q", int(state), got, want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package zap
+
+import (
+	"cart/pkg/log"
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNewLoggerOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{name: "defaults", opts: Options{}},
+		{name: "console with sampling", opts: Options{Env: "development", Level: "debug", Sampling: Sampling{Initial: 1, Thereafter: 10}}},
+		{name: "unknown level", opts: Options{Level: "verbose"}, wantErr: true},
+		{name: "unknown encoding", opts: Options{Encoding: "xml"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLogger(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLogger() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := &Logger{L: zap.New(core), level: zap.NewAtomicLevel()}
+
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9},
+		SpanID:     trace.SpanID{0x00, 0xf0},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanCtx)
+	ctx = log.WithRequestID(ctx, "req-1")
+
+	logger.FromContext(ctx).Errorf("err in %s", "test")
+	logger.FromContext(context.Background()).Info("without ids")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("logged %d lines, want 2", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	want := map[string]interface{}{
+		"trace_id":   spanCtx.TraceID().String(),
+		"span_id":    spanCtx.SpanID().String(),
+		"request_id": "req-1",
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("%s = %v, want %v", key, fields[key], value)
+		}
+	}
+	if entries[0].Message != "err in test" {
+		t.Errorf("message = %q, want %q", entries[0].Message, "err in test")
+	}
+
+	if fields := entries[1].ContextMap(); len(fields) != 0 {
+		t.Errorf("line without ids has fields %v", fields)
+	}
+}
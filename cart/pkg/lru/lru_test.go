@@ -0,0 +1,96 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestCache(size int) (*Cache[string, int], *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c := New[string, int](size)
+	c.now = func() time.Time { return now }
+
+	return c, &now
+}
+
+func TestGetSet(t *testing.T) {
+	c, _ := newTestCache(2)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get() found a key that was never set")
+	}
+
+	c.Set("a", 1, time.Minute)
+	c.Set("a", 2, time.Minute)
+
+	if got, ok := c.Get("a"); !ok || got != 2 {
+		t.Errorf("Get() = %d, %v, want 2, true", got, ok)
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, want 1 after overwriting a key", c.Len())
+	}
+
+	c.Delete("a")
+
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get() found a deleted key")
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	c, now := newTestCache(2)
+
+	c.Set("a", 1, time.Minute)
+
+	*now = now.Add(time.Minute)
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("entry expired at its ttl, it should last until after")
+	}
+
+	*now = now.Add(time.Nanosecond)
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get() returned an expired entry")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len() = %d, want 0, expired entries are dropped on Get", c.Len())
+	}
+}
+
+func TestEvictsLeastRecentlyUsed(t *testing.T) {
+	tests := []struct {
+		name     string
+		touch    string
+		wantGone string
+		wantKept string
+	}{
+		{name: "oldest set", wantGone: "a", wantKept: "b"},
+		{name: "get refreshes", touch: "a", wantGone: "b", wantKept: "a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestCache(2)
+
+			c.Set("a", 1, time.Minute)
+			c.Set("b", 2, time.Minute)
+			if tt.touch != "" {
+				c.Get(tt.touch)
+			}
+			c.Set("c", 3, time.Minute)
+
+			if c.Len() != 2 {
+				t.Errorf("Len() = %d, want 2", c.Len())
+			}
+			if _, ok := c.Get(tt.wantGone); ok {
+				t.Errorf("%s was kept", tt.wantGone)
+			}
+			if _, ok := c.Get(tt.wantKept); !ok {
+				t.Errorf("%s was evicted", tt.wantKept)
+			}
+			if _, ok := c.Get("c"); !ok {
+				t.Error("new entry c was evicted")
+			}
+		})
+	}
+}
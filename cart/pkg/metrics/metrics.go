@@ -1,22 +1,48 @@
 package metrics
 
 import (
+	"net/http"
+
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type Metrics interface {
 	ObserveLatency(path, method, status string, duration float64)
 	IncError(path, method, status string)
+	ObserveGRPC(method, code string, duration float64)
+	IncCacheHit(cache string)
+	IncCacheMiss(cache string)
+	AddItemsAdded(count uint32)
+	IncInsufficientStock()
+	ObserveCartSize(items int)
+	IncRateLimited(method, keyType string)
+	IncCheckoutStep(step, result string)
+	Handler() http.Handler
 }
 
 var _ Metrics = &CartMetrics{}
 
 type CartMetrics struct {
-	ResponseLatency *prometheus.HistogramVec
-	ErrorsTotal     *prometheus.CounterVec
+	ResponseLatency   *prometheus.HistogramVec
+	ErrorsTotal       *prometheus.CounterVec
+	GRPCLatency       *prometheus.HistogramVec
+	GRPCHandled       *prometheus.CounterVec
+	CacheLookups      *prometheus.CounterVec
+	ItemsAdded        prometheus.Counter
+	InsufficientStock prometheus.Counter
+	CartSize          prometheus.Histogram
+	RateLimited       *prometheus.CounterVec
+	CheckoutSteps     *prometheus.CounterVec
+	registry          *prometheus.Registry
 }
 
+// RegisterMetrics registers the service metrics together with the Go runtime and
+// process collectors on a dedicated registry.
 func RegisterMetrics() (*CartMetrics, error) {
+	registry := prometheus.NewRegistry()
+
 	responseLatency := prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "http_response_time_seconds",
@@ -26,10 +52,6 @@ func RegisterMetrics() (*CartMetrics, error) {
 		[]string{"path", "method", "status"},
 	)
 
-	if err := prometheus.Register(responseLatency); err != nil {
-		return nil, err
-	}
-
 	errorCounter := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "http_failed_requests_total",
@@ -38,13 +60,100 @@ func RegisterMetrics() (*CartMetrics, error) {
 		[]string{"path", "method", "status"},
 	)
 
-	if err := prometheus.Register(errorCounter); err != nil {
-		return nil, err
+	grpcLatency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "gRPC call latencies in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method"},
+	)
+
+	grpcHandled := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "Total gRPC calls by status code",
+		},
+		[]string{"method", "code"},
+	)
+
+	cacheLookups := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_lookups_total",
+			Help: "Total cache lookups by result",
+		},
+		[]string{"cache", "result"},
+	)
+
+	itemsAdded := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "cart_items_added_total",
+			Help: "Total item units added to carts",
+		},
+	)
+
+	insufficientStock := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "cart_insufficient_stock_total",
+			Help: "Total add-to-cart requests rejected for insufficient stock",
+		},
+	)
+
+	cartSize := prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "cart_size_items",
+			Help:    "Number of lines in listed carts",
+			Buckets: []float64{0, 1, 2, 3, 5, 8, 13, 21, 34, 55},
+		},
+	)
+
+	rateLimited := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rate_limited_total",
+			Help: "Total requests rejected by the rate limiter",
+		},
+		[]string{"method", "key_type"},
+	)
+
+	checkoutSteps := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cart_checkout_steps_total",
+			Help: "Total checkout saga step attempts by result",
+		},
+		[]string{"step", "result"},
+	)
+
+	for _, c := range []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		responseLatency,
+		errorCounter,
+		grpcLatency,
+		grpcHandled,
+		cacheLookups,
+		itemsAdded,
+		insufficientStock,
+		cartSize,
+		rateLimited,
+		checkoutSteps,
+	} {
+		if err := registry.Register(c); err != nil {
+			return nil, err
+		}
 	}
 
 	return &CartMetrics{
-		ResponseLatency: responseLatency,
-		ErrorsTotal:     errorCounter,
+		ResponseLatency:   responseLatency,
+		ErrorsTotal:       errorCounter,
+		GRPCLatency:       grpcLatency,
+		GRPCHandled:       grpcHandled,
+		CacheLookups:      cacheLookups,
+		ItemsAdded:        itemsAdded,
+		InsufficientStock: insufficientStock,
+		CartSize:          cartSize,
+		RateLimited:       rateLimited,
+		CheckoutSteps:     checkoutSteps,
+		registry:          registry,
 	}, nil
 }
 
@@ -63,3 +172,59 @@ func (m *CartMetrics) IncError(path, method, status string) {
 		"status": status,
 	}).Inc()
 }
+
+func (m *CartMetrics) ObserveGRPC(method, code string, duration float64) {
+	m.GRPCLatency.With(prometheus.Labels{
+		"method": method,
+	}).Observe(duration)
+
+	m.GRPCHandled.With(prometheus.Labels{
+		"method": method,
+		"code":   code,
+	}).Inc()
+}
+
+func (m *CartMetrics) IncCacheHit(cache string) {
+	m.CacheLookups.With(prometheus.Labels{
+		"cache":  cache,
+		"result": "hit",
+	}).Inc()
+}
+
+func (m *CartMetrics) IncCacheMiss(cache string) {
+	m.CacheLookups.With(prometheus.Labels{
+		"cache":  cache,
+		"result": "miss",
+	}).Inc()
+}
+
+func (m *CartMetrics) AddItemsAdded(count uint32) {
+	m.ItemsAdded.Add(float64(count))
+}
+
+func (m *CartMetrics) IncInsufficientStock() {
+	m.InsufficientStock.Inc()
+}
+
+func (m *CartMetrics) ObserveCartSize(items int) {
+	m.CartSize.Observe(float64(items))
+}
+
+func (m *CartMetrics) IncRateLimited(method, keyType string) {
+	m.RateLimited.With(prometheus.Labels{
+		"method":   method,
+		"key_type": keyType,
+	}).Inc()
+}
+
+func (m *CartMetrics) IncCheckoutStep(step, result string) {
+	m.CheckoutSteps.With(prometheus.Labels{
+		"step":   step,
+		"result": result,
+	}).Inc()
+}
+
+// Handler serves the metrics of the dedicated registry.
+func (m *CartMetrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
@@ -23,6 +23,35 @@ func (c *PgClient) BeginTx(ctx context.Context, opts pgx.TxOptions) (pgx.Tx, err
 	return c.Pool.BeginTx(ctx, opts)
 }
 
+// Listen subscribes to channel on a dedicated connection, calls onListen once the
+// subscription is active and onNotify for every notification until ctx is done or
+// the connection fails.
+func (c *PgClient) Listen(ctx context.Context, channel string, onListen func(), onNotify func(payload string)) error {
+	conn, err := c.Pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The connection stays in LISTEN state, so it is taken out of the pool for good.
+	pgConn := conn.Hijack()
+	defer pgConn.Close(context.WithoutCancel(ctx))
+
+	if _, err := pgConn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+
+	onListen()
+
+	for {
+		notification, err := pgConn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		onNotify(notification.Payload)
+	}
+}
+
 func (c *PgClient) Close() {
 	c.Pool.Close()
 }
@@ -21,8 +21,23 @@ type Closer interface {
 	Close()
 }
 
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type TxStarter interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+type Listener interface {
+	Listen(ctx context.Context, channel string, onListen func(), onNotify func(payload string)) error
+}
+
 type Client interface {
 	DB
+	TxStarter
+	Listener
+	Pinger
 	Closer
 }
 
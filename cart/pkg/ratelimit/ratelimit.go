@@ -0,0 +1,195 @@
+package ratelimit
+
+import (
+	"container/list"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Rule is a token bucket refilled at RPS tokens per second and holding up to Burst
+// tokens. A rule with RPS <= 0 does not limit.
+type Rule struct {
+	RPS   float64
+	Burst int
+}
+
+type bucketKey struct {
+	method string
+	key    string
+}
+
+type bucket struct {
+	key      bucketKey
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// rules are the limits in effect, swapped as a whole by Update.
+type rules struct {
+	fallback Rule
+	methods  map[string]Rule
+}
+
+func (r *rules) rule(method string) Rule {
+	if rule, ok := r.methods[method]; ok {
+		return rule
+	}
+
+	return r.fallback
+}
+
+// Limiter keeps one token bucket per method and caller key. Buckets that were not
+// used for idleTTL are dropped, and past maxBuckets the least recently used bucket
+// is dropped. A new bucket starts full.
+type Limiter struct {
+	rules      atomic.Pointer[rules]
+	mu         sync.Mutex
+	buckets    map[bucketKey]*list.Element
+	recent     *list.List // of *bucket, most recently used first
+	idleTTL    time.Duration
+	maxBuckets int
+	now        func() time.Time
+}
+
+// New creates a limiter that applies methods[method] where set and fallback otherwise.
+// An idleTTL or maxBuckets <= 0 does not bound the buckets.
+func New(fallback Rule, methods map[string]Rule, idleTTL time.Duration, maxBuckets int) *Limiter {
+	l := &Limiter{
+		buckets:    make(map[bucketKey]*list.Element),
+		recent:     list.New(),
+		idleTTL:    idleTTL,
+		maxBuckets: maxBuckets,
+		now:        time.Now,
+	}
+	l.rules.Store(&rules{fallback: fallback, methods: methods})
+
+	return l
+}
+
+// Update replaces the limits of all methods. Existing buckets keep their tokens and
+// refill at the new rate from now on.
+func (l *Limiter) Update(fallback Rule, methods map[string]Rule) {
+	next := &rules{fallback: fallback, methods: methods}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rules.Store(next)
+
+	now := l.now()
+	for k, e := range l.buckets {
+		rule := next.rule(k.method)
+		b := e.Value.(*bucket)
+		b.limiter.SetLimitAt(now, rate.Limit(rule.RPS))
+		b.limiter.SetBurstAt(now, burst(rule))
+	}
+}
+
+// Allow takes a token from the bucket of method and key. When the bucket is empty it
+// reports false and how long the caller should wait before the next token.
+func (l *Limiter) Allow(method, key string) (bool, time.Duration) {
+	ok, _, retryAfter := l.AllowAll(method, key)
+	return ok, retryAfter
+}
+
+// AllowAll takes a token from the buckets of method and every key, from all of them or
+// none, so a bucket that is empty does not drain the others. When one is empty it
+// reports false, the index of the first empty bucket's key and how long the caller
+// should wait until every bucket has a token.
+func (l *Limiter) AllowAll(method string, keys ...string) (bool, int, time.Duration) {
+	if l.rules.Load().rule(method).RPS <= 0 {
+		return true, -1, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Update may have changed the rule meanwhile, it only does so holding l.mu.
+	rule := l.rules.Load().rule(method)
+	if rule.RPS <= 0 {
+		return true, -1, 0
+	}
+
+	now := l.now()
+	l.sweep(now)
+
+	var (
+		reservations = make([]*rate.Reservation, 0, len(keys))
+		denied       = -1
+		retryAfter   time.Duration
+	)
+
+	for i, key := range keys {
+		b := l.bucket(bucketKey{method: method, key: key}, rule)
+		b.lastSeen = now
+
+		r := b.limiter.ReserveN(now, 1)
+		reservations = append(reservations, r)
+
+		if delay := r.DelayFrom(now); delay > 0 {
+			if denied < 0 {
+				denied = i
+			}
+
+			retryAfter = max(retryAfter, delay)
+		}
+	}
+
+	if denied < 0 {
+		return true, -1, 0
+	}
+
+	for _, r := range reservations {
+		r.CancelAt(now)
+	}
+
+	return false, denied, retryAfter
+}
+
+// bucket returns the bucket of k, creating it and dropping the least recently used
+// one when the limiter is full. Caller must hold l.mu.
+func (l *Limiter) bucket(k bucketKey, rule Rule) *bucket {
+	if e, ok := l.buckets[k]; ok {
+		l.recent.MoveToFront(e)
+		return e.Value.(*bucket)
+	}
+
+	if l.maxBuckets > 0 && len(l.buckets) >= l.maxBuckets {
+		l.remove(l.recent.Back())
+	}
+
+	b := &bucket{key: k, limiter: rate.NewLimiter(rate.Limit(rule.RPS), burst(rule))}
+	l.buckets[k] = l.recent.PushFront(b)
+
+	return b
+}
+
+// sweep drops the buckets idle for idleTTL, which are at the back of l.recent.
+// Caller must hold l.mu.
+func (l *Limiter) sweep(now time.Time) {
+	if l.idleTTL <= 0 {
+		return
+	}
+
+	for e := l.recent.Back(); e != nil && now.Sub(e.Value.(*bucket).lastSeen) >= l.idleTTL; e = l.recent.Back() {
+		l.remove(e)
+	}
+}
+
+func (l *Limiter) remove(e *list.Element) {
+	delete(l.buckets, l.recent.Remove(e).(*bucket).key)
+}
+
+// burst defaults to one second worth of tokens, so a rule without burst still lets
+// requests through.
+func burst(rule Rule) int {
+	if rule.Burst > 0 {
+		return rule.Burst
+	}
+
+	return int(math.Max(1, math.Ceil(rule.RPS)))
+}
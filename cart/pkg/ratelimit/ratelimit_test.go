@@ -0,0 +1,217 @@
+package ratelimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestLimiter(fallback Rule, methods map[string]Rule, idleTTL time.Duration, maxBuckets int) (*Limiter, *clock) {
+	c := &clock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	l := New(fallback, methods, idleTTL, maxBuckets)
+	l.now = func() time.Time { return c.now }
+
+	return l, c
+}
+
+func TestAllow(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        Rule
+		requests    int
+		wantAllowed int
+		wantRetry   time.Duration
+	}{
+		{name: "burst", rule: Rule{RPS: 1, Burst: 3}, requests: 5, wantAllowed: 3, wantRetry: time.Second},
+		{name: "default burst is one second of tokens", rule: Rule{RPS: 2}, requests: 3, wantAllowed: 2, wantRetry: 500 * time.Millisecond},
+		{name: "fractional rps still lets one through", rule: Rule{RPS: 0.5}, requests: 2, wantAllowed: 1, wantRetry: 2 * time.Second},
+		{name: "zero rps does not limit", rule: Rule{}, requests: 100, wantAllowed: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, _ := newTestLimiter(tt.rule, nil, 0, 0)
+
+			allowed := 0
+			var retry time.Duration
+			for i := 0; i < tt.requests; i++ {
+				ok, retryAfter := l.Allow("/m", "k")
+				if ok {
+					allowed++
+				} else {
+					retry = retryAfter
+				}
+			}
+
+			if allowed != tt.wantAllowed {
+				t.Errorf("allowed = %d, want %d", allowed, tt.wantAllowed)
+			}
+			if retry != tt.wantRetry {
+				t.Errorf("retry after = %v, want %v", retry, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestAllowRefills(t *testing.T) {
+	l, c := newTestLimiter(Rule{RPS: 2, Burst: 1}, nil, 0, 0)
+
+	if ok, _ := l.Allow("/m", "k"); !ok {
+		t.Fatal("first request denied")
+	}
+	if ok, _ := l.Allow("/m", "k"); ok {
+		t.Fatal("second request allowed with an empty bucket")
+	}
+
+	c.advance(500 * time.Millisecond)
+
+	if ok, _ := l.Allow("/m", "k"); !ok {
+		t.Error("request denied after the bucket refilled")
+	}
+}
+
+func TestAllowSeparatesMethodsAndKeys(t *testing.T) {
+	l, _ := newTestLimiter(Rule{RPS: 1, Burst: 1}, map[string]Rule{"/free": {}}, 0, 0)
+
+	if ok, _ := l.Allow("/m", "a"); !ok {
+		t.Fatal("first request of a denied")
+	}
+	if ok, _ := l.Allow("/m", "b"); !ok {
+		t.Error("key b shares the bucket of a")
+	}
+	if ok, _ := l.Allow("/other", "a"); !ok {
+		t.Error("method /other shares the bucket of /m")
+	}
+	for i := 0; i < 10; i++ {
+		if ok, _ := l.Allow("/free", "a"); !ok {
+			t.Fatal("method without limit was limited")
+		}
+	}
+	if got := len(l.buckets); got != 3 {
+		t.Errorf("buckets = %d, want 3, unlimited methods need none", got)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	l, c := newTestLimiter(Rule{RPS: 1, Burst: 1}, nil, 0, 0)
+
+	if ok, _ := l.Allow("/m", "k"); !ok {
+		t.Fatal("first request denied")
+	}
+
+	l.Update(Rule{}, nil)
+
+	if ok, _ := l.Allow("/m", "k"); !ok {
+		t.Error("request denied after limits were removed")
+	}
+
+	l.Update(Rule{RPS: 10, Burst: 1}, nil)
+	c.advance(100 * time.Millisecond)
+
+	if ok, _ := l.Allow("/m", "k"); !ok {
+		t.Error("existing bucket did not refill at the new rate")
+	}
+	if ok, retryAfter := l.Allow("/m", "k"); ok || retryAfter != 100*time.Millisecond {
+		t.Errorf("Allow = %v, %v, want false, 100ms", ok, retryAfter)
+	}
+}
+
+func TestIdleBucketsAreDropped(t *testing.T) {
+	l, c := newTestLimiter(Rule{RPS: 1, Burst: 1}, nil, time.Minute, 0)
+
+	l.Allow("/m", "idle")
+	c.advance(30 * time.Second)
+	l.Allow("/m", "active")
+	c.advance(30 * time.Second)
+
+	if ok, _ := l.Allow("/m", "active"); !ok {
+		t.Fatal("active caller denied")
+	}
+
+	if _, ok := l.buckets[bucketKey{method: "/m", key: "idle"}]; ok {
+		t.Error("idle bucket was kept")
+	}
+	if _, ok := l.buckets[bucketKey{method: "/m", key: "active"}]; !ok {
+		t.Error("active bucket was dropped")
+	}
+
+	if ok, _ := l.Allow("/m", "idle"); !ok {
+		t.Error("caller returning after idle ttl did not get a full bucket")
+	}
+}
+
+func TestMaxBuckets(t *testing.T) {
+	l, _ := newTestLimiter(Rule{RPS: 1, Burst: 1}, nil, 0, 2)
+
+	l.Allow("/m", "a")
+	l.Allow("/m", "b")
+	// a is used again, so b is the least recently used one.
+	l.Allow("/m", "a")
+
+	for i := 0; i < 100; i++ {
+		l.Allow("/m", fmt.Sprintf("new-%d", i))
+	}
+
+	if got := len(l.buckets); got != 2 {
+		t.Fatalf("buckets = %d, want 2", got)
+	}
+	if l.recent.Len() != len(l.buckets) {
+		t.Errorf("recent list has %d buckets, map %d", l.recent.Len(), len(l.buckets))
+	}
+
+	l2, _ := newTestLimiter(Rule{RPS: 1, Burst: 1}, nil, 0, 2)
+	l2.Allow("/m", "a")
+	l2.Allow("/m", "b")
+	l2.Allow("/m", "a")
+	l2.Allow("/m", "c")
+
+	if _, ok := l2.buckets[bucketKey{method: "/m", key: "b"}]; ok {
+		t.Error("least recently used bucket b was kept")
+	}
+	if _, ok := l2.buckets[bucketKey{method: "/m", key: "a"}]; !ok {
+		t.Error("recently used bucket a was dropped")
+	}
+}
+
+func TestAllowAllSpendsAllOrNone(t *testing.T) {
+	l, c := newTestLimiter(Rule{RPS: 1, Burst: 2}, nil, 0, 0)
+
+	if ok, _ := l.Allow("/m", "user:1"); !ok {
+		t.Fatal("first request of user 1 denied")
+	}
+	if ok, _ := l.Allow("/m", "user:1"); !ok {
+		t.Fatal("second request of user 1 denied")
+	}
+
+	// The user bucket is empty, the address bucket must keep its tokens.
+	for i := 0; i < 3; i++ {
+		ok, denied, retryAfter := l.AllowAll("/m", "ip:a", "user:1")
+		if ok || denied != 1 || retryAfter != time.Second {
+			t.Fatalf("AllowAll() = %v, %d, %v, want false, 1, 1s", ok, denied, retryAfter)
+		}
+	}
+
+	for _, user := range []string{"user:2", "user:3"} {
+		if ok, denied, _ := l.AllowAll("/m", "ip:a", user); !ok {
+			t.Fatalf("AllowAll() for %s denied by key %d, a throttled user drained the address", user, denied)
+		}
+	}
+
+	if ok, denied, _ := l.AllowAll("/m", "ip:a", "user:4"); ok || denied != 0 {
+		t.Errorf("AllowAll() = %v, %d, want the address bucket empty after two requests", ok, denied)
+	}
+
+	c.advance(time.Second)
+	if ok, _, _ := l.AllowAll("/m", "ip:a", "user:1"); !ok {
+		t.Error("AllowAll() denied after both buckets refilled")
+	}
+}
@@ -6,13 +6,23 @@ import (
 	"metrics-consumer/internal/config"
 	"metrics-consumer/internal/handler"
 	kconstructor "metrics-consumer/internal/kafka"
+	"metrics-consumer/internal/tracing"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
+const tracerShutdownTimeout = 5 * time.Second
+
 func main() {
 	cfg := config.GetConfig()
+
+	shutdownTracer, err := tracing.InitTracer(context.Background(), cfg)
+	if err != nil {
+		log.Fatalf("Failed to init tracer: %s", err)
+	}
+
 	h := handler.NewHandler()
 
 	c, err := kconstructor.NewConsumer(h, cfg.Kafka.Brokers, cfg.Kafka.Topic, cfg.Kafka.GroupID)
@@ -43,4 +53,11 @@ func main() {
 	} else {
 		log.Println("✅ Consumer stopped gracefully")
 	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), tracerShutdownTimeout)
+	defer shutdownCancel()
+
+	if err := shutdownTracer(shutdownCtx); err != nil {
+		log.Printf("⚠️ Failed to shutdown tracer: %v", err)
+	}
 }
@@ -9,7 +9,8 @@ import (
 )
 
 type Configs struct {
-	Kafka Kafka `mapstructure:"kafka"`
+	Kafka   Kafka   `mapstructure:"kafka"`
+	Tracing Tracing `mapstructure:"tracing"`
 }
 
 type (
@@ -18,6 +19,16 @@ type (
 		Topic   string   `mapstructure:"topic"`
 		GroupID string   `mapstructure:"group_id"`
 	}
+
+	Tracing struct {
+		ServiceName string  `mapstructure:"service_name"`
+		Env         string  `mapstructure:"env"`
+		Exporter    string  `mapstructure:"exporter"`
+		Endpoint    string  `mapstructure:"endpoint"`
+		Insecure    bool    `mapstructure:"insecure"`
+		SampleRatio float64 `mapstructure:"sample_ratio"`
+		Version     string  `mapstructure:"version"`
+	}
 )
 
 var (
@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"context"
 	"log"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Handler struct {
@@ -13,7 +15,8 @@ func NewHandler() *Handler {
 	return &Handler{}
 }
 
-func (h *Handler) HandleMessage(message []byte, offset kafka.Offset) error {
-	log.Printf("Message from kafka with offset %d, %s", offset, message)
+func (h *Handler) HandleMessage(ctx context.Context, message []byte, offset kafka.Offset) error {
+	traceID := trace.SpanContextFromContext(ctx).TraceID()
+	log.Printf("Message from kafka with offset %d, trace %s, %s", offset, traceID, message)
 	return nil
 }
@@ -6,6 +6,10 @@ import (
 	"strings"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -16,7 +20,7 @@ const (
 )
 
 type Handler interface {
-	HandleMessage(message []byte, topic kafka.Offset) error
+	HandleMessage(ctx context.Context, message []byte, offset kafka.Offset) error
 }
 
 type Consumer struct {
@@ -66,7 +70,7 @@ func (c *Consumer) Start(ctx context.Context) error {
 
 			switch msg := ev.(type) {
 			case *kafka.Message:
-				if err := c.handler.HandleMessage(msg.Value, msg.TopicPartition.Offset); err != nil {
+				if err := c.handleMessage(ctx, msg); err != nil {
 					log.Printf("Error handling message: %v", err)
 					continue
 				}
@@ -88,6 +92,34 @@ func (c *Consumer) Start(ctx context.Context) error {
 	}
 }
 
+// handleMessage continues the producer's trace from the message headers.
+func (c *Consumer) handleMessage(ctx context.Context, msg *kafka.Message) error {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier{headers: &msg.Headers})
+
+	topic := ""
+	if msg.TopicPartition.Topic != nil {
+		topic = *msg.TopicPartition.Topic
+	}
+
+	ctx, span := otel.Tracer("metrics-consumer").Start(ctx, topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", topic),
+			attribute.Int64("messaging.kafka.offset", int64(msg.TopicPartition.Offset)),
+		),
+	)
+	defer span.End()
+
+	err := c.handler.HandleMessage(ctx, msg.Value, msg.TopicPartition.Offset)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
 func (c *Consumer) Stop() error {
 	log.Println("Committing and closing consumer...")
 	if _, err := c.consumer.Commit(); err != nil {
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"orders/internal/bootstrap"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	configPath := flag.String("config", "config.yml", "path of the config file")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	app, err := bootstrap.NewApp(ctx, *configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to initialize application: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger := app.Logger()
+
+	if err := app.Run(); err != nil {
+		logger.Fatalf("❌ Application stopped with error: %v", err)
+	}
+
+	logger.Info("✅ Application exited successfully.")
+}
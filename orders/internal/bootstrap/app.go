@@ -0,0 +1,273 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"orders/internal/config"
+	"orders/internal/constants"
+	"orders/internal/migrations"
+	"orders/internal/repository/interfaces"
+	kconstructor "orders/internal/repository/kafka"
+	"orders/internal/repository/postgres"
+	"orders/internal/service"
+	"orders/pkg/health"
+	"orders/pkg/log"
+	"orders/pkg/log/zap"
+	"orders/pkg/metrics"
+	"orders/pkg/postgresql"
+	"orders/pkg/tracing"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	grpcserver "orders/internal/delivery/grpc"
+	ordersapi "orders/pkg/api/orders"
+
+	tmsql "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	trm "github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"google.golang.org/grpc"
+)
+
+type App struct {
+	db             postgresql.Client
+	svc            service.OrderService
+	cfg            *config.Configs
+	grpcServer     *grpc.Server
+	gateway        grpcserver.Gateway
+	logger         log.Logger
+	shutdownTracer func(context.Context) error
+	metricsServer  metrics.MetricsServer
+	probes         *health.Health
+	checkouts      interfaces.KafkaConsumer
+}
+
+func NewApp(ctx context.Context, configPath string) (*App, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	logger, err := zap.NewLogger(loggerOptions(cfg))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing logger: %v\n", err)
+		return nil, err
+	}
+
+	shutdownTracer, err := tracing.InitTracer(ctx, cfg)
+	if err != nil {
+		logger.Errorf("failed to init tracer: %v", err)
+		return nil, err
+	}
+
+	orderMetrics, err := metrics.RegisterMetrics()
+	if err != nil {
+		logger.Errorf("Failed to register metrics: %v", err)
+		return nil, err
+	}
+
+	probes := health.New(cfg.Health.CheckTimeout, logger, ordersapi.OrderService_ServiceDesc.ServiceName)
+
+	metricsServer := metrics.NewServer(orderMetrics, probes, cfg.Metrics.Port, logger)
+	metricsServer.Handle("/log/level", grpcserver.AdminOnly(logger.LevelHandler(), cfg.Audit.AdminAPIKeys, logger))
+
+	db, err := postgresql.NewPostgres(ctx, cfg)
+	if err != nil {
+		logger.Errorf("❌ Failed to connect to DB: %v", err)
+		return nil, err
+	}
+
+	err = migrations.RunManualMigrations(ctx, db, cfg.Listen.MigrationsPath)
+	if err != nil {
+		logger.Errorf("failed to run migrations: %v", err)
+		return nil, err
+	}
+
+	driver := tmsql.NewDefaultFactory(db)
+	tm := trm.Must(driver)
+
+	kafkaProd, err := kconstructor.NewProducer(cfg.Kafka.Brokers, cfg.Kafka.Topic)
+	if err != nil {
+		logger.Errorf("failed to connect kafka: %v", err)
+		return nil, err
+	}
+
+	repo := postgres.NewRepository(db, tmsql.DefaultCtxGetter)
+	svc := service.NewService(repo, tm, kafkaProd, orderMetrics, logger)
+
+	checkouts, err := kconstructor.NewConsumer(svc, cfg.Kafka.Brokers, cfg.Checkout.Topic, cfg.Checkout.GroupID, logger)
+	if err != nil {
+		logger.Errorf("failed to create checkout consumer: %v", err)
+		return nil, err
+	}
+
+	probes.AddCheck("postgres", db.Ping)
+	probes.AddCheck("kafka", kafkaProd.Ping)
+
+	// gRPC Server Setup
+	grpcServer := grpcserver.NewGRPCServer(svc, orderMetrics, probes.GRPCServer(), cfg.Audit.AdminAPIKeys, logger)
+
+	// gRPC-Gateway Setup
+	gateway, err := grpcserver.NewGateway(ctx, cfg.Listen.GRPCPort, cfg.Listen.GatewayPort, logger, orderMetrics)
+	if err != nil {
+		logger.Errorf("failed to create gateway: %v", err)
+		return nil, err
+	}
+
+	return &App{
+		db:             db,
+		svc:            svc,
+		cfg:            cfg,
+		grpcServer:     grpcServer,
+		gateway:        gateway,
+		logger:         logger,
+		shutdownTracer: shutdownTracer,
+		metricsServer:  metricsServer,
+		probes:         probes,
+		checkouts:      checkouts,
+	}, nil
+}
+
+func (a *App) Run() error {
+	defer a.logger.Close()
+
+	serverErrors := make(chan error, 3)
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	// Start gRPC health status updates
+	go a.probes.Run(jobsCtx, a.cfg.Health.CheckInterval)
+
+	// Start metrics server
+	go func() {
+		if err := a.metricsServer.Run(); err != nil {
+			serverErrors <- fmt.Errorf("metrics server error: %w", err)
+		}
+	}()
+
+	// Place and update the orders of cart checkouts
+	go func() {
+		if err := a.checkouts.Start(jobsCtx); err != nil {
+			a.logger.Errorf("checkout consumer stopped: %v", err)
+		}
+	}()
+
+	// Start gRPC server
+	go func() {
+		lis, err := net.Listen("tcp", ":"+a.cfg.Listen.GRPCPort)
+		if err != nil {
+			serverErrors <- fmt.Errorf("failed to listen gRPC: %w", err)
+			return
+		}
+
+		a.logger.Infof("✅ gRPC server is running on port %s\n", a.cfg.Listen.GRPCPort)
+		if err := a.grpcServer.Serve(lis); err != nil {
+			serverErrors <- fmt.Errorf("gRPC server error: %w", err)
+		}
+	}()
+
+	// Start Gateway server
+	go func() {
+		a.logger.Infof("✅ gRPC-Gateway is running on port %s\n", a.cfg.Listen.GatewayPort)
+		if err := a.gateway.Run(); err != nil {
+			serverErrors <- fmt.Errorf("gateway error: %w", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+
+	select {
+	case sig := <-quit:
+		a.logger.Infof("⚠️ Received signal: %s. Shutting down...\n", sig)
+	case err := <-serverErrors:
+		return errors.New("server failed to start or stopped unexpectedly: " + err.Error())
+	}
+
+	// Fail readiness first so load balancers drain the instance before it stops serving
+	a.probes.Shutdown()
+	a.logger.Infof("⏳ Readiness set to not serving, draining for %s", a.cfg.Health.DrainDelay)
+	time.Sleep(a.cfg.Health.DrainDelay)
+
+	stopJobs()
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.ServerTimeout)
+	defer cancel()
+
+	defer func() {
+		if err := a.shutdownTracer(ctx); err != nil {
+			a.logger.Errorf("failed to shutdown tracer: %v", err)
+		}
+	}()
+
+	var shutdownErrors []error
+
+	// Shutdown gRPC server
+	stopped := make(chan struct{})
+	go func() {
+		a.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		a.grpcServer.Stop()
+	}
+	a.logger.Info("✅ gRPC server shutdown complete")
+
+	// Shutdown Gateway
+	if err := a.gateway.Shutdown(ctx); err != nil {
+		shutdownErrors = append(shutdownErrors, fmt.Errorf("gateway shutdown failed: %w", err))
+	} else {
+		a.logger.Info("✅ Gateway shutdown complete")
+	}
+
+	// Shutdown checkout consumer
+	if err := a.checkouts.Close(); err != nil {
+		shutdownErrors = append(shutdownErrors, fmt.Errorf("checkout consumer shutdown failed: %w", err))
+	} else {
+		a.logger.Info("✅ Checkout consumer closed")
+	}
+
+	// Shutdown metrics server
+	if err := a.metricsServer.Shutdown(ctx); err != nil {
+		shutdownErrors = append(shutdownErrors, err)
+	} else {
+		a.logger.Info("✅ Metrics server shutdown complete")
+	}
+
+	if a.db != nil {
+		a.db.Close()
+		a.logger.Info("✅ Database connection closed")
+	}
+
+	if len(shutdownErrors) > 0 {
+		return fmt.Errorf("shutdown completed with errors: %v", shutdownErrors)
+	}
+
+	a.logger.Info("✅ Graceful shutdown complete")
+
+	return nil
+}
+
+func (a *App) Logger() log.Logger {
+	return a.logger
+}
+
+func loggerOptions(cfg *config.Configs) zap.Options {
+	return zap.Options{
+		Service:  cfg.Listen.ServiceName,
+		Env:      cfg.Listen.Env,
+		Level:    cfg.Log.Level,
+		Encoding: cfg.Log.Encoding,
+		Sampling: zap.Sampling{
+			Initial:    cfg.Log.Sampling.Initial,
+			Thereafter: cfg.Log.Sampling.Thereafter,
+			Tick:       cfg.Log.Sampling.Tick,
+		},
+	}
+}
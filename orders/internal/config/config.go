@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type Configs struct {
+	Listen   Listen     `mapstructure:"listen"`
+	Log      Log        `mapstructure:"log"`
+	Postgres DbPostgres `mapstructure:"postgres"`
+	Kafka    Kafka      `mapstructure:"kafka"`
+	Tracing  Tracing    `mapstructure:"tracing"`
+	Metrics  Metrics    `mapstructure:"metrics"`
+	Health   Health     `mapstructure:"health"`
+	Audit    Audit      `mapstructure:"audit"`
+	Checkout Checkout   `mapstructure:"checkout"`
+}
+
+type (
+	Log struct {
+		// Level is debug, info, warn or error.
+		Level string `mapstructure:"level"`
+		// Encoding is json or console. Empty means console in development and json
+		// otherwise.
+		Encoding string      `mapstructure:"encoding"`
+		Sampling LogSampling `mapstructure:"sampling"`
+	}
+
+	// LogSampling thins out repeated lines: of the lines with the same level and
+	// message, the first Initial per Tick are written and then every Thereafter-th.
+	// Sampling is off while Initial is zero.
+	LogSampling struct {
+		Initial    int           `mapstructure:"initial"`
+		Thereafter int           `mapstructure:"thereafter"`
+		Tick       time.Duration `mapstructure:"tick"`
+	}
+
+	Listen struct {
+		GatewayPort    string `mapstructure:"gateway_port"`
+		GRPCPort       string `mapstructure:"grpc_port"`
+		MigrationsPath string `mapstructure:"migrations_path"`
+		ServiceName    string `mapstructure:"service_name"`
+		Env            string `mapstructure:"env"`
+	}
+
+	DbPostgres struct {
+		Host     string `mapstructure:"host"`
+		Port     string `mapstructure:"port"`
+		DbName   string `mapstructure:"db_name"`
+		UserName string `mapstructure:"username"`
+		Password string `mapstructure:"password" secret:"true"`
+		Sslmode  string `mapstructure:"ssl_mode"`
+	}
+
+	Kafka struct {
+		Brokers []string `mapstructure:"brokers"`
+		Topic   string   `mapstructure:"topic"`
+		GroupID string   `mapstructure:"group_id"`
+	}
+
+	Tracing struct {
+		Exporter    string  `mapstructure:"exporter"`
+		Endpoint    string  `mapstructure:"endpoint"`
+		Insecure    bool    `mapstructure:"insecure"`
+		SampleRatio float64 `mapstructure:"sample_ratio"`
+		Version     string  `mapstructure:"version"`
+	}
+
+	Metrics struct {
+		Port int64 `mapstructure:"port"`
+	}
+
+	Audit struct {
+		// AdminAPIKeys are the X-Api-Key values allowed to use the admin endpoints of the
+		// metrics server.
+		AdminAPIKeys []string `mapstructure:"admin_api_keys" secret:"true"`
+	}
+
+	// Checkout is the topic of the step events of cart checkouts, which place and
+	// update their orders.
+	Checkout struct {
+		Topic   string `mapstructure:"topic"`
+		GroupID string `mapstructure:"group_id"`
+	}
+
+	Health struct {
+		CheckInterval time.Duration `mapstructure:"check_interval"`
+		CheckTimeout  time.Duration `mapstructure:"check_timeout"`
+		DrainDelay    time.Duration `mapstructure:"drain_delay"`
+	}
+)
+
+// Load reads the config file at path. Every setting can be overridden by the
+// environment variable named after its key, like ORDERS_POSTGRES_PASSWORD for
+// postgres.password, or read from the file ORDERS_POSTGRES_PASSWORD_FILE names. Every
+// invalid setting is reported at once.
+func Load(path string) (*Configs, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(envKeyReplacer)
+	v.AutomaticEnv()
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	if err := bindEnv(v, reflect.TypeOf(Configs{}), ""); err != nil {
+		return nil, fmt.Errorf("failed to bind environment variables: %w", err)
+	}
+
+	if err := readSecretFiles(v); err != nil {
+		return nil, fmt.Errorf("failed to read secret files: %w", err)
+	}
+
+	cfg := &Configs{}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode config %s: %w", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s:\n%w", path, err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix starts the names of the environment variables that override settings.
+const EnvPrefix = "ORDERS"
+
+// fileSuffix marks the environment variables that name a file holding the setting,
+// for secrets mounted as files.
+const fileSuffix = "_FILE"
+
+var envKeyReplacer = strings.NewReplacer(".", "_")
+
+// bindEnv binds every setting of t to its environment variable, so settings missing
+// from the config file can be set from the environment too. Lists of structs can only
+// be set in the file.
+func bindEnv(v *viper.Viper, t reflect.Type, prefix string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := prefix + field.Tag.Get("mapstructure")
+
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			if err := bindEnv(v, field.Type, key+"."); err != nil {
+				return err
+			}
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct:
+			continue
+		default:
+			if err := v.BindEnv(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// readSecretFiles sets every setting whose _FILE variable is set to the contents of
+// that file, without trailing newlines. Setting a variable and its _FILE variant
+// both is an error.
+func readSecretFiles(v *viper.Viper) error {
+	var errs []error
+
+	for _, key := range v.AllKeys() {
+		name := envName(key)
+
+		path, ok := os.LookupEnv(name + fileSuffix)
+		if !ok {
+			continue
+		}
+
+		if _, ok := os.LookupEnv(name); ok {
+			errs = append(errs, fmt.Errorf("%s and %s%s are both set", name, name, fileSuffix))
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s%s: %w", name, fileSuffix, err))
+			continue
+		}
+
+		v.Set(key, strings.TrimRight(string(content), "\r\n"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// envName returns the environment variable of a setting, like ORDERS_POSTGRES_PASSWORD
+// for postgres.password.
+func envName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(envKeyReplacer.Replace(key))
+}
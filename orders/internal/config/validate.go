@@ -0,0 +1,121 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// problems collects what is wrong with a config, so every problem is reported at once.
+type problems []error
+
+func (p *problems) check(ok bool, key, format string, args ...any) {
+	if !ok {
+		*p = append(*p, fmt.Errorf("%s %s", key, fmt.Sprintf(format, args...)))
+	}
+}
+
+func (p *problems) required(key, value string) {
+	p.check(strings.TrimSpace(value) != "", key, "is required")
+}
+
+func (p *problems) port(key string, value int64) {
+	p.check(value > 0 && value <= 65535, key, "must be a port between 1 and 65535, got %d", value)
+}
+
+func (p *problems) portString(key, value string) {
+	port, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		p.check(false, key, "must be a port number, got %q", value)
+		return
+	}
+
+	p.port(key, port)
+}
+
+func (p *problems) oneOf(key, value string, allowed ...string) {
+	p.check(slices.Contains(allowed, value), key, "must be one of %q, got %q", allowed, value)
+}
+
+func nonNegative[T ~int | ~int64 | ~float64](p *problems, key string, value T) {
+	p.check(value >= 0, key, "must not be negative, got %v", value)
+}
+
+func (l Listen) validate(p *problems) {
+	p.required("listen.service_name", l.ServiceName)
+	p.portString("listen.gateway_port", l.GatewayPort)
+	p.portString("listen.grpc_port", l.GRPCPort)
+	p.required("listen.migrations_path", l.MigrationsPath)
+}
+
+func (l Log) validate(p *problems) {
+	p.oneOf("log.level", l.Level, "", "debug", "info", "warn", "error")
+	p.oneOf("log.encoding", l.Encoding, "", "json", "console")
+	nonNegative(p, "log.sampling.initial", l.Sampling.Initial)
+	nonNegative(p, "log.sampling.thereafter", l.Sampling.Thereafter)
+	nonNegative(p, "log.sampling.tick", l.Sampling.Tick)
+}
+
+func (d DbPostgres) validate(p *problems) {
+	p.required("postgres.host", d.Host)
+	p.portString("postgres.port", d.Port)
+	p.required("postgres.db_name", d.DbName)
+	p.required("postgres.username", d.UserName)
+	p.oneOf("postgres.ssl_mode", d.Sslmode, "", "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+}
+
+func (k Kafka) validate(p *problems) {
+	p.check(len(k.Brokers) > 0, "kafka.brokers", "is required")
+
+	for i, broker := range k.Brokers {
+		p.required(fmt.Sprintf("kafka.brokers[%d]", i), broker)
+	}
+
+	p.required("kafka.topic", k.Topic)
+}
+
+func (t Tracing) validate(p *problems) {
+	p.oneOf("tracing.exporter", t.Exporter, "", "otlp-grpc", "otlp-http", "stdout", "none")
+
+	if t.Exporter == "otlp-grpc" || t.Exporter == "otlp-http" {
+		p.required("tracing.endpoint", t.Endpoint)
+	}
+
+	p.check(t.SampleRatio >= 0 && t.SampleRatio <= 1, "tracing.sample_ratio", "must be between 0 and 1, got %v", t.SampleRatio)
+}
+
+func (a Audit) validate(p *problems) {
+	for i, key := range a.AdminAPIKeys {
+		p.required(fmt.Sprintf("audit.admin_api_keys[%d]", i), key)
+	}
+}
+
+func (c Checkout) validate(p *problems) {
+	p.required("checkout.topic", c.Topic)
+	p.required("checkout.group_id", c.GroupID)
+}
+
+func (h Health) validate(p *problems) {
+	nonNegative(p, "health.check_interval", h.CheckInterval)
+	nonNegative(p, "health.check_timeout", h.CheckTimeout)
+	nonNegative(p, "health.drain_delay", h.DrainDelay)
+}
+
+// Validate reports every invalid setting at once.
+func (c *Configs) Validate() error {
+	var p problems
+
+	c.Listen.validate(&p)
+	c.Log.validate(&p)
+	c.Postgres.validate(&p)
+	c.Kafka.validate(&p)
+	c.Tracing.validate(&p)
+	p.port("metrics.port", c.Metrics.Port)
+	c.Health.validate(&p)
+	c.Audit.validate(&p)
+	c.Checkout.validate(&p)
+
+	return errors.Join(p...)
+}
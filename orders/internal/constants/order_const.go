@@ -0,0 +1,24 @@
+package constants
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrNotRowAffected    = errors.New("not row affected")
+	ErrInvalidTransition = errors.New("invalid order status transition")
+	ErrInvalidPageToken  = errors.New("invalid page token")
+	ErrAdminRequired     = errors.New("admin api key required")
+	ErrCheckoutOrder     = errors.New("order is placed by a checkout")
+)
+
+const (
+	InternalServerErrMessage = "Something went wrong in server!"
+	ServerTimeout            = 5 * time.Second
+	ReadTimeout              = 3 * time.Second
+	RequestIDHeader          = "x-request-id"
+	RequestIDMaxLength       = 128
+	APIKeyHeader             = "x-api-key"
+)
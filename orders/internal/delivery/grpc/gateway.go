@@ -0,0 +1,77 @@
+package grpcserver
+
+import (
+	"context"
+	"net/http"
+	"net/textproto"
+	"orders/internal/constants"
+	"orders/pkg/log"
+	"orders/pkg/metrics"
+
+	ordersapi "orders/pkg/api/orders"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Server struct {
+	server  *http.Server
+	metrics metrics.Metrics
+}
+
+type Gateway interface {
+	Run() error
+	Shutdown(ctx context.Context) error
+}
+
+func NewGateway(ctx context.Context, grpcPort, gatewayPort string, logger log.Logger, m metrics.Metrics) (Gateway, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithErrorHandler(ErrorMiddleware(logger, m)),
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+	)
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
+
+	err := ordersapi.RegisterOrderServiceHandlerFromEndpoint(ctx, mux, "localhost:"+grpcPort, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	metricsWrapped := MetricsMiddleware(mux, m)
+	otelHandler := otelhttp.NewHandler(RequestIDMiddleware(metricsWrapped), "orders-grpc-gateway")
+
+	return &Server{
+		server: &http.Server{
+			Addr:    ":" + gatewayPort,
+			Handler: otelHandler,
+		},
+		metrics: m,
+	}, nil
+}
+
+// incomingHeaderMatcher forwards the X-Request-Id and X-Api-Key headers to the gRPC
+// server in addition to the headers accepted by runtime.DefaultHeaderMatcher.
+func incomingHeaderMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case textproto.CanonicalMIMEHeaderKey(constants.RequestIDHeader):
+		return constants.RequestIDHeader, true
+	case textproto.CanonicalMIMEHeaderKey(constants.APIKeyHeader):
+		return constants.APIKeyHeader, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func (g *Server) Run() error {
+	return g.server.ListenAndServe()
+}
+
+func (g *Server) Shutdown(ctx context.Context) error {
+	return g.server.Shutdown(ctx)
+}
@@ -0,0 +1,52 @@
+package grpcserver
+
+import (
+	"context"
+	"orders/pkg/log"
+	"orders/pkg/metrics"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func grpcLoggingInterceptor(logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+
+		if err != nil {
+			st, ok := status.FromError(err)
+			statusCode := codes.Unknown
+			errMsg := err.Error()
+
+			if ok {
+				statusCode = st.Code()
+				errMsg = st.Message()
+			}
+
+			// The server span is started by the otelgrpc stats handler from the incoming trace context.
+			logger.FromContext(ctx).Error("gRPC call failed",
+				log.String("method", info.FullMethod),
+				log.Any("status", statusCode),
+				log.String("error", errMsg),
+			)
+		}
+
+		return resp, err
+	}
+}
+
+// grpcMetricsInterceptor records latency and status code of every unary call, covering
+// direct gRPC callers as well as the gateway.
+func grpcMetricsInterceptor(m metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		m.ObserveGRPC(info.FullMethod, status.Code(err).String(), time.Since(start).Seconds())
+
+		return resp, err
+	}
+}
@@ -0,0 +1,109 @@
+package grpcserver
+
+import (
+	"orders/internal/constants"
+	"orders/internal/models"
+	ordersapi "orders/pkg/api/orders"
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var orderStatuses = map[models.OrderStatus]ordersapi.OrderStatus{
+	models.OrderCreated:         ordersapi.OrderStatus_ORDER_STATUS_CREATED,
+	models.OrderAwaitingPayment: ordersapi.OrderStatus_ORDER_STATUS_AWAITING_PAYMENT,
+	models.OrderPaid:            ordersapi.OrderStatus_ORDER_STATUS_PAID,
+	models.OrderFulfilled:       ordersapi.OrderStatus_ORDER_STATUS_FULFILLED,
+	models.OrderCancelled:       ordersapi.OrderStatus_ORDER_STATUS_CANCELLED,
+}
+
+func toStatusModel(status ordersapi.OrderStatus) models.OrderStatus {
+	for model, api := range orderStatuses {
+		if api == status {
+			return model
+		}
+	}
+
+	return ""
+}
+
+func ToOrderFilter(req *ordersapi.ListOrdersRequest) (models.OrderFilter, error) {
+	filter := models.OrderFilter{
+		UserID: req.UserId,
+		Status: toStatusModel(req.Status),
+		Limit:  int(req.PageSize),
+	}
+
+	if req.PageToken != "" {
+		beforeID, err := strconv.ParseInt(req.PageToken, 10, 64)
+		if err != nil || beforeID <= 0 {
+			return models.OrderFilter{}, constants.ErrInvalidPageToken
+		}
+
+		filter.BeforeID = beforeID
+	}
+
+	return filter, nil
+}
+
+func ToGetOrderModel(req *ordersapi.GetOrderRequest) models.GetOrder {
+	return models.GetOrder{
+		OrderID: req.OrderId,
+		UserID:  req.UserId,
+	}
+}
+
+func ToCancelOrderModel(req *ordersapi.CancelOrderRequest) models.TransitionOrder {
+	return models.TransitionOrder{
+		OrderID: req.OrderId,
+		UserID:  req.UserId,
+		Reason:  req.Reason,
+	}
+}
+
+func ToListOrdersResponse(page models.OrdersPage) *ordersapi.ListOrdersResponse {
+	orders := make([]*ordersapi.Order, 0, len(page.Orders))
+
+	for _, order := range page.Orders {
+		orders = append(orders, ToOrderResponse(order))
+	}
+
+	resp := &ordersapi.ListOrdersResponse{Orders: orders}
+	if page.NextCursor > 0 {
+		resp.NextPageToken = strconv.FormatInt(page.NextCursor, 10)
+	}
+
+	return resp
+}
+
+func ToOrderResponse(order models.Order) *ordersapi.Order {
+	resp := &ordersapi.Order{
+		Id:         order.ID,
+		UserId:     order.UserID,
+		CheckoutId: order.CheckoutID,
+		Status:     orderStatuses[order.Status],
+		TotalPrice: order.TotalPrice,
+		CreatedAt:  timestamppb.New(order.CreatedAt),
+		UpdatedAt:  timestamppb.New(order.UpdatedAt),
+	}
+
+	for _, item := range order.Items {
+		resp.Items = append(resp.Items, &ordersapi.OrderItem{
+			Sku:      item.SKU,
+			SellerId: item.SellerID,
+			Count:    item.Count,
+			Price:    item.Price,
+		})
+	}
+
+	for _, transition := range order.History {
+		resp.History = append(resp.History, &ordersapi.OrderTransition{
+			From:      orderStatuses[transition.From],
+			To:        orderStatuses[transition.To],
+			Reason:    transition.Reason,
+			CreatedAt: timestamppb.New(transition.CreatedAt),
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,119 @@
+package grpcserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"orders/internal/domainerr"
+	"orders/pkg/log"
+	"strings"
+	"unicode"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	// problemTypePrefix turns a reason into the problem type URI, e.g.
+	// urn:problem-type:orders:invalid-order-transition.
+	problemTypePrefix = "urn:problem-type:orders:"
+)
+
+// problem is an RFC 7807 problem details body, extended with the gRPC code, the
+// error reason and the google.rpc details of the status.
+type problem struct {
+	Type          string            `json:"type"`
+	Title         string            `json:"title"`
+	Status        int               `json:"status"`
+	Detail        string            `json:"detail,omitempty"`
+	Instance      string            `json:"instance,omitempty"`
+	Code          string            `json:"code"`
+	Reason        string            `json:"reason,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	InvalidParams []invalidParam    `json:"invalid_params,omitempty"`
+	Violations    []violation       `json:"violations,omitempty"`
+	TraceID       string            `json:"trace_id,omitempty"`
+}
+
+type invalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+type violation struct {
+	Type        string `json:"type"`
+	Subject     string `json:"subject"`
+	Description string `json:"description"`
+}
+
+func problemFromStatus(s *status.Status, instance, traceID string) problem {
+	httpStatus := runtime.HTTPStatusFromCode(s.Code())
+
+	p := problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(httpStatus),
+		Status:   httpStatus,
+		Detail:   s.Message(),
+		Instance: instance,
+		Code:     codeName(s),
+		TraceID:  traceID,
+	}
+
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			p.Type = problemType(d.GetReason())
+			p.Reason = d.GetReason()
+			p.Metadata = d.GetMetadata()
+		case *errdetails.BadRequest:
+			for _, fv := range d.GetFieldViolations() {
+				p.InvalidParams = append(p.InvalidParams, invalidParam{Name: fv.GetField(), Reason: fv.GetDescription()})
+			}
+		case *errdetails.PreconditionFailure:
+			for _, v := range d.GetViolations() {
+				p.Violations = append(p.Violations, violation{Type: v.GetType(), Subject: v.GetSubject(), Description: v.GetDescription()})
+			}
+		}
+	}
+
+	return p
+}
+
+func problemType(reason string) string {
+	if reason == "" {
+		return "about:blank"
+	}
+
+	return problemTypePrefix + strings.ReplaceAll(strings.ToLower(reason), "_", "-")
+}
+
+// codeName is the canonical upper-case name of the gRPC code, e.g. FAILED_PRECONDITION.
+func codeName(s *status.Status) string {
+	var b strings.Builder
+	var prev rune
+
+	for _, r := range s.Code().String() {
+		if unicode.IsUpper(r) && unicode.IsLower(prev) {
+			b.WriteByte('_')
+		}
+
+		b.WriteRune(r)
+		prev = r
+	}
+
+	return strings.ToUpper(b.String())
+}
+
+func writeProblem(w http.ResponseWriter, p problem, logger log.Logger) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(p.Status)
+
+	if err := json.NewEncoder(w).Encode(p); err != nil {
+		logger.Errorf("err in encode problem response: %v", err)
+	}
+}
+
+func domainProblem(domainErr *domainerr.Error, instance string) problem {
+	return problemFromStatus(domainStatus(domainErr), instance, "")
+}
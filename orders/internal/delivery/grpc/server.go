@@ -0,0 +1,100 @@
+package grpcserver
+
+import (
+	"context"
+	"orders/internal/service"
+	ordersapi "orders/pkg/api/orders"
+	"orders/pkg/log"
+	"orders/pkg/metrics"
+
+	"buf.build/go/protovalidate"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+type grpcServer struct {
+	ordersapi.UnimplementedOrderServiceServer
+	service service.OrderService
+	logger  log.Logger
+}
+
+func NewGRPCServer(svc service.OrderService, m metrics.Metrics, healthSrv healthgrpc.HealthServer, adminKeys []string, logger log.Logger) *grpc.Server {
+	grpcServer := &grpcServer{
+		service: svc,
+		logger:  logger,
+	}
+
+	srv := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			grpcRequestIDInterceptor(),
+			grpcMetricsInterceptor(m),
+			grpcLoggingInterceptor(logger),
+			grpcAdminInterceptor(adminKeys),
+			grpcValidationInterceptor(protovalidate.GlobalValidator, logger),
+		),
+	)
+
+	reflection.Register(srv)
+	healthgrpc.RegisterHealthServer(srv, healthSrv)
+
+	ordersapi.RegisterOrderServiceServer(srv, grpcServer)
+
+	return srv
+}
+
+func (s *grpcServer) ListOrders(ctx context.Context, req *ordersapi.ListOrdersRequest) (*ordersapi.ListOrdersResponse, error) {
+	ctx, span := otel.Tracer("orders-handler").Start(ctx, "grpcServer.ListOrders")
+	defer span.End()
+
+	filter, err := ToOrderFilter(req)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	page, err := s.service.ListOrders(ctx, filter)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return ToListOrdersResponse(page), nil
+}
+
+func (s *grpcServer) GetOrder(ctx context.Context, req *ordersapi.GetOrderRequest) (*ordersapi.GetOrderResponse, error) {
+	ctx, span := otel.Tracer("orders-handler").Start(ctx, "grpcServer.GetOrder")
+	defer span.End()
+
+	order, err := s.service.GetOrder(ctx, ToGetOrderModel(req))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &ordersapi.GetOrderResponse{Order: ToOrderResponse(order)}, nil
+}
+
+func (s *grpcServer) CancelOrder(ctx context.Context, req *ordersapi.CancelOrderRequest) (*ordersapi.CancelOrderResponse, error) {
+	ctx, span := otel.Tracer("orders-handler").Start(ctx, "grpcServer.CancelOrder")
+	defer span.End()
+
+	order, err := s.service.CancelOrder(ctx, ToCancelOrderModel(req))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &ordersapi.CancelOrderResponse{Order: ToOrderResponse(order)}, nil
+}
+
+func (s *grpcServer) FulfillOrder(ctx context.Context, req *ordersapi.FulfillOrderRequest) (*ordersapi.FulfillOrderResponse, error) {
+	ctx, span := otel.Tracer("orders-handler").Start(ctx, "grpcServer.FulfillOrder")
+	defer span.End()
+
+	order, err := s.service.FulfillOrder(ctx, req.OrderId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &ordersapi.FulfillOrderResponse{Order: ToOrderResponse(order)}, nil
+}
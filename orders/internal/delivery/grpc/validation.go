@@ -0,0 +1,66 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"orders/internal/domainerr"
+	"orders/pkg/log"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// Request rules are declared in orders.proto with buf.validate annotations.
+
+func grpcValidationInterceptor(validator protovalidate.Validator, logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validateRequest(validator, req, logger.FromContext(ctx)); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// validateRequest answers INVALID_ARGUMENT with an ErrorInfo detail and a BadRequest
+// detail that lists every violated field.
+func validateRequest(validator protovalidate.Validator, req interface{}, logger log.Logger) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	err := validator.Validate(msg)
+	if err == nil {
+		return nil
+	}
+
+	var valErr *protovalidate.ValidationError
+	if !errors.As(err, &valErr) {
+		logger.Errorf("err in validate request: %v", err)
+		return toStatusError(err)
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	messages := make([]string, 0, len(valErr.Violations))
+
+	for _, violation := range valErr.Violations {
+		field := protovalidate.FieldPathString(violation.Proto.GetField())
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: violation.Proto.GetMessage(),
+			Reason:      violation.Proto.GetRuleId(),
+		})
+		messages = append(messages, field+": "+violation.Proto.GetMessage())
+	}
+
+	st := domainStatus(domainerr.Wrap(domainerr.KindInvalidArgument, domainerr.ReasonInvalidRequest, errors.New("invalid request: "+strings.Join(messages, "; "))))
+	if withDetails, err := st.WithDetails(badRequest); err == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
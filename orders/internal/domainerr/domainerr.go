@@ -0,0 +1,145 @@
+package domainerr
+
+import (
+	"errors"
+	"fmt"
+	"orders/internal/constants"
+	"strconv"
+)
+
+// Kind is the class of a failure. The delivery layer maps it to a gRPC code.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindInvalidArgument
+	KindNotFound
+	KindAlreadyExists
+	KindFailedPrecondition
+	KindAborted
+	KindResourceExhausted
+	KindUnavailable
+	KindPermissionDenied
+)
+
+// Reason is a machine-readable cause, stable across releases.
+type Reason string
+
+const (
+	ReasonInternal          Reason = "INTERNAL"
+	ReasonInvalidRequest    Reason = "INVALID_REQUEST"
+	ReasonNotFound          Reason = "NOT_FOUND"
+	ReasonInvalidTransition Reason = "INVALID_ORDER_TRANSITION"
+	ReasonInvalidPageToken  Reason = "INVALID_PAGE_TOKEN"
+	ReasonAdminRequired     Reason = "ADMIN_REQUIRED"
+	ReasonCheckoutOrder     Reason = "CHECKOUT_ORDER"
+)
+
+// Violation is a precondition that did not hold.
+type Violation struct {
+	Type        string
+	Subject     string
+	Description string
+}
+
+// Error is a domain failure with its reason and details. It wraps the matching
+// sentinel from constants, so errors.Is keeps working on it.
+type Error struct {
+	Kind       Kind
+	Reason     Reason
+	Message    string
+	Metadata   map[string]string
+	Violations []Violation
+	err        error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Wrap creates an error of kind and reason with the message of err.
+func Wrap(kind Kind, reason Reason, err error) *Error {
+	return &Error{
+		Kind:    kind,
+		Reason:  reason,
+		Message: err.Error(),
+		err:     err,
+	}
+}
+
+// WithMetadata adds a key to the ErrorInfo metadata.
+func (e *Error) WithMetadata(key, value string) *Error {
+	if e.Metadata == nil {
+		e.Metadata = make(map[string]string)
+	}
+
+	e.Metadata[key] = value
+
+	return e
+}
+
+// WithViolation adds a failed precondition.
+func (e *Error) WithViolation(typ, subject, description string) *Error {
+	e.Violations = append(e.Violations, Violation{Type: typ, Subject: subject, Description: description})
+	return e
+}
+
+func OrderNotFound(orderID int64) *Error {
+	return Wrap(KindNotFound, ReasonNotFound, constants.ErrNotFound).
+		WithMetadata("order_id", strconv.FormatInt(orderID, 10))
+}
+
+// InvalidTransition reports that the order can not move from its current status to to.
+func InvalidTransition(orderID int64, from, to string) *Error {
+	orderStr := strconv.FormatInt(orderID, 10)
+
+	return Wrap(KindFailedPrecondition, ReasonInvalidTransition, constants.ErrInvalidTransition).
+		WithMetadata("order_id", orderStr).
+		WithMetadata("from", from).
+		WithMetadata("to", to).
+		WithViolation("STATUS", "order/"+orderStr, fmt.Sprintf("order is %s, can not become %s", from, to))
+}
+
+// CheckoutOrder reports that the order follows its checkout and can not be changed
+// by the user.
+func CheckoutOrder(orderID, checkoutID int64) *Error {
+	orderStr := strconv.FormatInt(orderID, 10)
+
+	return Wrap(KindFailedPrecondition, ReasonCheckoutOrder, constants.ErrCheckoutOrder).
+		WithMetadata("order_id", orderStr).
+		WithMetadata("checkout_id", strconv.FormatInt(checkoutID, 10)).
+		WithViolation("CHECKOUT", "order/"+orderStr, "order is placed by a checkout, the checkout cancels it")
+}
+
+var sentinels = []struct {
+	err    error
+	kind   Kind
+	reason Reason
+}{
+	{constants.ErrNotFound, KindNotFound, ReasonNotFound},
+	{constants.ErrInvalidTransition, KindFailedPrecondition, ReasonInvalidTransition},
+	{constants.ErrInvalidPageToken, KindInvalidArgument, ReasonInvalidPageToken},
+	{constants.ErrAdminRequired, KindPermissionDenied, ReasonAdminRequired},
+	{constants.ErrCheckoutOrder, KindFailedPrecondition, ReasonCheckoutOrder},
+}
+
+// From returns the domain error in err's chain, or one built from a known sentinel.
+// It returns nil for unexpected errors.
+func From(err error) *Error {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+
+	for _, s := range sentinels {
+		if errors.Is(err, s.err) {
+			return Wrap(s.kind, s.reason, s.err)
+		}
+	}
+
+	return nil
+}
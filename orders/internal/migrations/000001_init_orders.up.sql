@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS orders (
+	"id" BIGSERIAL PRIMARY KEY,
+	"user_id" BIGINT NOT NULL,
+	"status" TEXT NOT NULL DEFAULT 'created',
+	"total_price" BIGINT NOT NULL DEFAULT 0,
+	"created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	"updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT orders_status_check
+		CHECK ("status" IN ('created', 'awaiting_payment', 'paid', 'fulfilled', 'cancelled'))
+);
+
+ALTER TABLE "orders" OWNER TO "user_orders";
+
+CREATE INDEX IF NOT EXISTS orders_user_id_idx ON orders ("user_id", "id");
+
+CREATE TABLE IF NOT EXISTS order_items (
+	"id" BIGSERIAL PRIMARY KEY,
+	"order_id" BIGINT NOT NULL REFERENCES orders("id") ON DELETE CASCADE,
+	"sku" BIGINT NOT NULL,
+	"seller_id" BIGINT NOT NULL,
+	"count" INT NOT NULL,
+	"price" BIGINT NOT NULL DEFAULT 0
+);
+
+ALTER TABLE "order_items" OWNER TO "user_orders";
+
+CREATE INDEX IF NOT EXISTS order_items_order_id_idx ON order_items ("order_id");
+
+-- Every status change of an order, the creation included with an empty from_status.
+CREATE TABLE IF NOT EXISTS order_status_history (
+	"id" BIGSERIAL PRIMARY KEY,
+	"order_id" BIGINT NOT NULL REFERENCES orders("id") ON DELETE CASCADE,
+	"from_status" TEXT NOT NULL DEFAULT '',
+	"to_status" TEXT NOT NULL,
+	"reason" TEXT NOT NULL DEFAULT '',
+	"created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE "order_status_history" OWNER TO "user_orders";
+
+CREATE INDEX IF NOT EXISTS order_status_history_order_id_idx ON order_status_history ("order_id", "id");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS orders_checkout_id_idx;
+ALTER TABLE orders DROP COLUMN IF EXISTS "checkout_id";
@@ -0,0 +1,6 @@
+-- The cart checkout an order was placed by. Checkout events are delivered at least
+-- once, the unique index keeps them from placing an order twice.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS "checkout_id" BIGINT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS orders_checkout_id_idx ON orders ("checkout_id")
+	WHERE checkout_id IS NOT NULL;
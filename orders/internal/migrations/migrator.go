@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"orders/pkg/postgresql"
+)
+
+func RunManualMigrations(ctx context.Context, db postgresql.Client, migrationsDir string) error {
+	files := []string{}
+	err := filepath.WalkDir(migrationsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() && strings.HasSuffix(d.Name(), ".up.sql") {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to read migration files: %w", err)
+	}
+
+	sort.Strings(files)
+
+	for _, file := range files {
+		sqlBytes, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read migration file %s: %w", file, err)
+		}
+
+		_, err = db.Exec(ctx, string(sqlBytes))
+		if err != nil {
+			return fmt.Errorf("failed to execute migration %s: %w", file, err)
+		}
+
+		fmt.Printf("✅ Applied migration %s\n", filepath.Base(file))
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package models
+
+import "time"
+
+// OrderStatus is the state of an order, see the transitions in the service.
+type OrderStatus string
+
+const (
+	OrderCreated         OrderStatus = "created"
+	OrderAwaitingPayment OrderStatus = "awaiting_payment"
+	OrderPaid            OrderStatus = "paid"
+	OrderFulfilled       OrderStatus = "fulfilled"
+	OrderCancelled       OrderStatus = "cancelled"
+)
+
+type Order struct {
+	ID     int64
+	UserID int64
+	// CheckoutID is the cart checkout that placed the order, zero for other orders.
+	CheckoutID int64
+	Status     OrderStatus
+	TotalPrice uint64
+	Items      []OrderItem
+	History    []OrderTransition
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type OrderItem struct {
+	SKU      uint32
+	SellerID int64
+	Count    uint32
+	Price    uint32
+}
+
+// OrderTransition is one status change of an order. From is empty for the creation.
+type OrderTransition struct {
+	From      OrderStatus
+	To        OrderStatus
+	Reason    string
+	CreatedAt time.Time
+}
+
+// CreateOrder places an order of the items for the user. A non-zero CheckoutID places
+// at most one order per checkout.
+type CreateOrder struct {
+	UserID     int64
+	CheckoutID int64
+	Items      []OrderItem
+}
+
+// CheckoutUpdate is the progress of a cart checkout, announced whenever its saga moves
+// to another step. Items are the checked out lines.
+type CheckoutUpdate struct {
+	CheckoutID int64
+	UserID     int64
+	Status     string
+	Step       string
+	Items      []OrderItem
+	LastError  string
+}
+
+// TransitionOrder moves an order to status To. A non-zero UserID must own the order.
+type TransitionOrder struct {
+	OrderID int64
+	UserID  int64
+	To      OrderStatus
+	Reason  string
+}
+
+// OrderFilter selects the orders of a user, newest first. BeforeID continues a listing
+// below the last returned id.
+type OrderFilter struct {
+	UserID   int64
+	Status   OrderStatus
+	BeforeID int64
+	Limit    int
+}
+
+type OrdersPage struct {
+	Orders     []Order
+	NextCursor int64
+}
+
+type GetOrder struct {
+	OrderID int64
+	UserID  int64
+}
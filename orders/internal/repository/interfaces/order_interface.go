@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"orders/internal/models"
+)
+
+type OrderRepository interface {
+	CreateOrder(ctx context.Context, order models.CreateOrder, totalPrice uint64) (models.Order, error)
+	GetOrder(ctx context.Context, orderID int64, forUpdate bool) (models.Order, error)
+	GetOrderByCheckout(ctx context.Context, checkoutID int64) (models.Order, error)
+	ListOrders(ctx context.Context, filter models.OrderFilter) ([]models.Order, error)
+	ListItems(ctx context.Context, orderIDs []int64) (map[int64][]models.OrderItem, error)
+	ListHistory(ctx context.Context, orderID int64) ([]models.OrderTransition, error)
+	UpdateStatus(ctx context.Context, orderID int64, from, to models.OrderStatus) (models.Order, error)
+	AddHistory(ctx context.Context, orderID int64, transition models.OrderTransition) error
+}
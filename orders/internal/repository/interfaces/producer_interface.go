@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+type KafkaProd interface {
+	Produce(ctx context.Context, message []byte, key string, t time.Time) error
+	Ping(ctx context.Context) error
+	Close()
+}
@@ -0,0 +1,123 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"orders/internal/repository/interfaces"
+	"orders/pkg/log"
+	"strings"
+	"sync"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+const pollTimeoutMs = 100
+
+type Consumer struct {
+	consumer *kafka.Consumer
+	handler  interfaces.KafkaHandler
+	logger   log.Logger
+
+	// mu keeps Close from running in the middle of a Poll.
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewConsumer subscribes to topic, starting from the earliest offset for a new group.
+// The offset of a message is only stored once it was handled, so messages are
+// processed at least once and the handler has to be idempotent.
+func NewConsumer(handler interfaces.KafkaHandler, address []string, topic, groupID string, logger log.Logger) (interfaces.KafkaConsumer, error) {
+	if len(address) == 0 {
+		return nil, fmt.Errorf("kafka broker address list is empty")
+	}
+
+	conf := &kafka.ConfigMap{
+		"bootstrap.servers":        strings.Join(address, ","),
+		"group.id":                 groupID,
+		"enable.auto.commit":       true,
+		"enable.auto.offset.store": false,
+		"auto.offset.reset":        "earliest",
+	}
+
+	c, err := kafka.NewConsumer(conf)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kafka consumer: %w", err)
+	}
+
+	if err := c.Subscribe(topic, nil); err != nil {
+		return nil, fmt.Errorf("error subscribing to kafka topic: %w", err)
+	}
+
+	return &Consumer{
+		consumer: c,
+		handler:  handler,
+		logger:   logger,
+	}, nil
+}
+
+func (c *Consumer) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+			ev, ok := c.poll()
+			if !ok {
+				return nil
+			}
+
+			if ev == nil {
+				continue
+			}
+
+			switch msg := ev.(type) {
+			case *kafka.Message:
+				if err := c.handler.HandleMessage(msg.Value); err != nil {
+					c.logger.Errorf("err in handle kafka message: %v", err)
+				}
+
+				c.store(msg)
+			case kafka.Error:
+				c.logger.Errorf("kafka consumer error: %v", msg)
+			}
+		}
+	}
+}
+
+func (c *Consumer) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+
+	c.closed = true
+
+	return c.consumer.Close()
+}
+
+func (c *Consumer) poll() (kafka.Event, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, false
+	}
+
+	return c.consumer.Poll(pollTimeoutMs), true
+}
+
+// store marks msg as handled, its offset is committed with the next auto commit.
+func (c *Consumer) store(msg *kafka.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	if _, err := c.consumer.StoreMessage(msg); err != nil {
+		c.logger.Errorf("err in store kafka offset: %v", err)
+	}
+}
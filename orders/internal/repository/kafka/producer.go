@@ -0,0 +1,98 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"orders/internal/repository/interfaces"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	flushTimeout          = 5000
+	partition       int32 = 2
+	metadataTimeout       = 2 * time.Second
+)
+
+type Producer struct {
+	producer *kafka.Producer
+	topic    string
+}
+
+func NewProducer(address []string, topic string) (interfaces.KafkaProd, error) {
+	if len(address) == 0 {
+		return nil, fmt.Errorf("kafka broker address list is empty")
+	}
+
+	conf := &kafka.ConfigMap{
+		"bootstrap.servers": strings.Join(address, ","),
+	}
+
+	prod, err := kafka.NewProducer(conf)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kafka producer: %w", err)
+	}
+
+	go func() {
+		for e := range prod.Events() {
+			switch ev := e.(type) {
+			case *kafka.Message:
+				if ev.TopicPartition.Error != nil {
+					log.Printf("❌ Delivery failed: %v", ev.TopicPartition)
+				} else {
+					log.Printf("✅ Delivered to %v", ev.TopicPartition)
+				}
+			case kafka.Error:
+				// log.Printf("Kafka error: %v", ev)
+			}
+		}
+	}()
+
+	return &Producer{producer: prod, topic: topic}, nil
+}
+
+// Produce sends the message with the trace context of ctx in its headers.
+func (p *Producer) Produce(ctx context.Context, message []byte, key string, t time.Time) error {
+	var headers []kafka.Header
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier{headers: &headers})
+
+	kafkaMessage := &kafka.Message{
+		TopicPartition: kafka.TopicPartition{
+			Topic:     &p.topic,
+			Partition: partition,
+		},
+		Value:     message,
+		Key:       []byte(key),
+		Timestamp: t,
+		Headers:   headers,
+	}
+
+	if err := p.producer.Produce(kafkaMessage, nil); err != nil {
+		return fmt.Errorf("error sending message to kafka: %w", err)
+	}
+
+	return nil
+}
+
+// Ping checks that the brokers are reachable by fetching the topic metadata.
+func (p *Producer) Ping(ctx context.Context) error {
+	timeout := metadataTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	if _, err := p.producer.GetMetadata(&p.topic, false, int(timeout.Milliseconds())); err != nil {
+		return fmt.Errorf("error fetching kafka metadata: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Producer) Close() {
+	p.producer.Flush(flushTimeout)
+	p.producer.Close()
+}
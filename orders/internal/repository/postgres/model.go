@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"orders/internal/models"
+	"time"
+)
+
+type DbOrder struct {
+	ID         int64     `db:"id"`
+	UserID     int64     `db:"user_id"`
+	CheckoutID int64     `db:"checkout_id"`
+	Status     string    `db:"status"`
+	TotalPrice uint64    `db:"total_price"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+type DbOrderItem struct {
+	OrderID  int64  `db:"order_id"`
+	SKU      uint32 `db:"sku"`
+	SellerID int64  `db:"seller_id"`
+	Count    uint32 `db:"count"`
+	Price    uint32 `db:"price"`
+}
+
+type DbOrderTransition struct {
+	FromStatus string    `db:"from_status"`
+	ToStatus   string    `db:"to_status"`
+	Reason     string    `db:"reason"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func (d DbOrder) ToDomain() models.Order {
+	return models.Order{
+		ID:         d.ID,
+		UserID:     d.UserID,
+		CheckoutID: d.CheckoutID,
+		Status:     models.OrderStatus(d.Status),
+		TotalPrice: d.TotalPrice,
+		CreatedAt:  d.CreatedAt,
+		UpdatedAt:  d.UpdatedAt,
+	}
+}
+
+func (d DbOrderItem) ToDomain() models.OrderItem {
+	return models.OrderItem{
+		SKU:      d.SKU,
+		SellerID: d.SellerID,
+		Count:    d.Count,
+		Price:    d.Price,
+	}
+}
+
+func (d DbOrderTransition) ToDomain() models.OrderTransition {
+	return models.OrderTransition{
+		From:      models.OrderStatus(d.FromStatus),
+		To:        models.OrderStatus(d.ToStatus),
+		Reason:    d.Reason,
+		CreatedAt: d.CreatedAt,
+	}
+}
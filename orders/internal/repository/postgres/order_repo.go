@@ -0,0 +1,306 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"orders/internal/constants"
+	"orders/internal/models"
+	"orders/internal/repository/interfaces"
+	"orders/pkg/postgresql"
+
+	tmsql "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+type orderRepo struct {
+	db     postgresql.Client
+	getter *tmsql.CtxGetter
+}
+
+func NewRepository(db postgresql.Client, getter *tmsql.CtxGetter) interfaces.OrderRepository {
+	return &orderRepo{
+		db:     db,
+		getter: getter,
+	}
+}
+
+const orderColumns = `id, user_id, COALESCE(checkout_id, 0) AS checkout_id, status, total_price, created_at, updated_at`
+
+// CreateOrder inserts the order in the created status together with its items. It
+// returns ErrNotRowAffected when the checkout of the order placed one already.
+func (r *orderRepo) CreateOrder(ctx context.Context, order models.CreateOrder, totalPrice uint64) (models.Order, error) {
+	var dbOrder DbOrder
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `
+		INSERT INTO orders (user_id, status, total_price, checkout_id)
+		VALUES (@user_id, @status, @total_price, NULLIF(@checkout_id::BIGINT, 0))
+		ON CONFLICT (checkout_id) WHERE checkout_id IS NOT NULL DO NOTHING
+		RETURNING ` + orderColumns
+
+	args := pgx.NamedArgs{
+		"user_id":     order.UserID,
+		"status":      string(models.OrderCreated),
+		"total_price": totalPrice,
+		"checkout_id": order.CheckoutID,
+	}
+
+	err := txOrDb.QueryRow(ctx, query, args).Scan(
+		&dbOrder.ID, &dbOrder.UserID, &dbOrder.CheckoutID, &dbOrder.Status,
+		&dbOrder.TotalPrice, &dbOrder.CreatedAt, &dbOrder.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Order{}, constants.ErrNotRowAffected
+		}
+
+		return models.Order{}, err
+	}
+
+	query = `
+		INSERT INTO order_items (order_id, sku, seller_id, count, price)
+		VALUES (@order_id, @sku, @seller_id, @count, @price)
+	`
+
+	for _, item := range order.Items {
+		args := pgx.NamedArgs{
+			"order_id":  dbOrder.ID,
+			"sku":       item.SKU,
+			"seller_id": item.SellerID,
+			"count":     item.Count,
+			"price":     item.Price,
+		}
+
+		if _, err := txOrDb.Exec(ctx, query, args); err != nil {
+			return models.Order{}, err
+		}
+	}
+
+	result := dbOrder.ToDomain()
+	result.Items = order.Items
+
+	return result, nil
+}
+
+// GetOrder returns the order without its items. With forUpdate the row stays locked
+// until the transaction ends.
+func (r *orderRepo) GetOrder(ctx context.Context, orderID int64, forUpdate bool) (models.Order, error) {
+	var dbOrder DbOrder
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = @id`
+	if forUpdate {
+		query += ` FOR UPDATE`
+	}
+
+	args := pgx.NamedArgs{
+		"id": orderID,
+	}
+
+	err := txOrDb.QueryRow(ctx, query, args).Scan(
+		&dbOrder.ID, &dbOrder.UserID, &dbOrder.CheckoutID, &dbOrder.Status,
+		&dbOrder.TotalPrice, &dbOrder.CreatedAt, &dbOrder.UpdatedAt,
+	)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	return dbOrder.ToDomain(), nil
+}
+
+// GetOrderByCheckout returns the order placed by the checkout, without its items.
+func (r *orderRepo) GetOrderByCheckout(ctx context.Context, checkoutID int64) (models.Order, error) {
+	var dbOrder DbOrder
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE checkout_id = @checkout_id`
+
+	args := pgx.NamedArgs{
+		"checkout_id": checkoutID,
+	}
+
+	err := txOrDb.QueryRow(ctx, query, args).Scan(
+		&dbOrder.ID, &dbOrder.UserID, &dbOrder.CheckoutID, &dbOrder.Status,
+		&dbOrder.TotalPrice, &dbOrder.CreatedAt, &dbOrder.UpdatedAt,
+	)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	return dbOrder.ToDomain(), nil
+}
+
+// ListOrders returns the orders of the user matching filter, newest first, without
+// their items.
+func (r *orderRepo) ListOrders(ctx context.Context, filter models.OrderFilter) ([]models.Order, error) {
+	var result []models.Order
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE user_id = @user_id
+			AND (@status::TEXT = '' OR status = @status)
+			AND (@before_id::BIGINT = 0 OR id < @before_id)
+		ORDER BY id DESC
+		LIMIT @limit
+	`
+	args := pgx.NamedArgs{
+		"user_id":   filter.UserID,
+		"status":    string(filter.Status),
+		"before_id": filter.BeforeID,
+		"limit":     filter.Limit,
+	}
+
+	rows, err := txOrDb.Query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dbOrder DbOrder
+
+		err = rows.Scan(
+			&dbOrder.ID, &dbOrder.UserID, &dbOrder.CheckoutID, &dbOrder.Status,
+			&dbOrder.TotalPrice, &dbOrder.CreatedAt, &dbOrder.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, dbOrder.ToDomain())
+	}
+
+	return result, rows.Err()
+}
+
+// ListItems returns the items of the orders keyed by order id.
+func (r *orderRepo) ListItems(ctx context.Context, orderIDs []int64) (map[int64][]models.OrderItem, error) {
+	result := make(map[int64][]models.OrderItem, len(orderIDs))
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `
+		SELECT order_id, sku, seller_id, count, price
+		FROM order_items
+		WHERE order_id = ANY(@order_ids)
+		ORDER BY order_id, id
+	`
+	args := pgx.NamedArgs{
+		"order_ids": orderIDs,
+	}
+
+	rows, err := txOrDb.Query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item DbOrderItem
+
+		err = rows.Scan(&item.OrderID, &item.SKU, &item.SellerID, &item.Count, &item.Price)
+		if err != nil {
+			return nil, err
+		}
+
+		result[item.OrderID] = append(result[item.OrderID], item.ToDomain())
+	}
+
+	return result, rows.Err()
+}
+
+// ListHistory returns the status changes of the order, oldest first.
+func (r *orderRepo) ListHistory(ctx context.Context, orderID int64) ([]models.OrderTransition, error) {
+	var result []models.OrderTransition
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `
+		SELECT from_status, to_status, reason, created_at
+		FROM order_status_history
+		WHERE order_id = @order_id
+		ORDER BY id
+	`
+	args := pgx.NamedArgs{
+		"order_id": orderID,
+	}
+
+	rows, err := txOrDb.Query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transition DbOrderTransition
+
+		err = rows.Scan(&transition.FromStatus, &transition.ToStatus, &transition.Reason, &transition.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, transition.ToDomain())
+	}
+
+	return result, rows.Err()
+}
+
+// UpdateStatus moves the order from status from to status to. It returns
+// ErrNotRowAffected when the order is no longer in status from.
+func (r *orderRepo) UpdateStatus(ctx context.Context, orderID int64, from, to models.OrderStatus) (models.Order, error) {
+	var dbOrder DbOrder
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `
+		UPDATE orders SET
+			status = @to,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = @id AND status = @from
+		RETURNING ` + orderColumns
+
+	args := pgx.NamedArgs{
+		"id":   orderID,
+		"from": string(from),
+		"to":   string(to),
+	}
+
+	err := txOrDb.QueryRow(ctx, query, args).Scan(
+		&dbOrder.ID, &dbOrder.UserID, &dbOrder.CheckoutID, &dbOrder.Status,
+		&dbOrder.TotalPrice, &dbOrder.CreatedAt, &dbOrder.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Order{}, constants.ErrNotRowAffected
+		}
+
+		return models.Order{}, err
+	}
+
+	return dbOrder.ToDomain(), nil
+}
+
+func (r *orderRepo) AddHistory(ctx context.Context, orderID int64, transition models.OrderTransition) error {
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `
+		INSERT INTO order_status_history (order_id, from_status, to_status, reason)
+		VALUES (@order_id, @from_status, @to_status, @reason)
+	`
+	args := pgx.NamedArgs{
+		"order_id":    orderID,
+		"from_status": string(transition.From),
+		"to_status":   string(transition.To),
+		"reason":      transition.Reason,
+	}
+
+	_, err := txOrDb.Exec(ctx, query, args)
+
+	return err
+}
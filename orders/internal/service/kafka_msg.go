@@ -0,0 +1,119 @@
+package service
+
+import (
+	"encoding/json"
+	"orders/internal/models"
+	"time"
+)
+
+type Payload struct {
+	OrderID    int64  `json:"order_id"`
+	UserID     int64  `json:"user_id"`
+	From       string `json:"from,omitempty"`
+	To         string `json:"to"`
+	TotalPrice uint64 `json:"total_price"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+type KafkaEvent struct {
+	Type      string    `json:"type"`
+	Service   string    `json:"service"`
+	Timestamp time.Time `json:"timestamp"`
+	Payload   Payload   `json:"payload"`
+}
+
+func BuildKafkaEvent(order models.Order, transition models.OrderTransition) ([]byte, time.Time, error) {
+	timestamp := time.Now()
+	message := KafkaEvent{
+		Type:      orderEvent(transition.To),
+		Service:   "order",
+		Timestamp: timestamp,
+		Payload: Payload{
+			OrderID:    order.ID,
+			UserID:     order.UserID,
+			From:       string(transition.From),
+			To:         string(transition.To),
+			TotalPrice: order.TotalPrice,
+			Reason:     transition.Reason,
+		},
+	}
+
+	msg, err := json.Marshal(message)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return msg, timestamp, nil
+}
+
+// Checkout step events of the cart service, see CheckoutStepEvent in cart.
+const (
+	checkoutStepEventType = "checkout_step"
+
+	checkoutRunning      = "running"
+	checkoutCompensating = "compensating"
+	checkoutCompleted    = "completed"
+	checkoutFailed       = "failed"
+
+	checkoutStepReserveStock     = "reserve_stock"
+	checkoutStepAuthorizePayment = "authorize_payment"
+	checkoutStepCommitStock      = "commit_stock"
+	checkoutStepCapturePayment   = "capture_payment"
+	checkoutStepClearCart        = "clear_cart"
+)
+
+type CheckoutLine struct {
+	SKU      uint32 `json:"sku"`
+	SellerID int64  `json:"seller_id"`
+	Count    uint32 `json:"count"`
+	Price    uint32 `json:"price"`
+}
+
+type CheckoutStepPayload struct {
+	CheckoutID int64          `json:"checkout_id"`
+	UserID     int64          `json:"user_id"`
+	Status     string         `json:"status"`
+	Step       string         `json:"step"`
+	Lines      []CheckoutLine `json:"lines"`
+	LastError  string         `json:"last_error"`
+}
+
+type CheckoutStepEvent struct {
+	Type      string              `json:"type"`
+	Service   string              `json:"service"`
+	Timestamp time.Time           `json:"timestamp"`
+	Payload   CheckoutStepPayload `json:"payload"`
+}
+
+// ParseCheckoutStepEvent returns the update a cart checkout step event announces, false
+// for other events and events without lines.
+func ParseCheckoutStepEvent(message []byte) (models.CheckoutUpdate, bool, error) {
+	var event CheckoutStepEvent
+
+	if err := json.Unmarshal(message, &event); err != nil {
+		return models.CheckoutUpdate{}, false, err
+	}
+
+	if event.Type != checkoutStepEventType || event.Service != "cart" || len(event.Payload.Lines) == 0 {
+		return models.CheckoutUpdate{}, false, nil
+	}
+
+	items := make([]models.OrderItem, 0, len(event.Payload.Lines))
+	for _, line := range event.Payload.Lines {
+		items = append(items, models.OrderItem{
+			SKU:      line.SKU,
+			SellerID: line.SellerID,
+			Count:    line.Count,
+			Price:    line.Price,
+		})
+	}
+
+	return models.CheckoutUpdate{
+		CheckoutID: event.Payload.CheckoutID,
+		UserID:     event.Payload.UserID,
+		Status:     event.Payload.Status,
+		Step:       event.Payload.Step,
+		Items:      items,
+		LastError:  event.Payload.LastError,
+	}, true, nil
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"orders/internal/models"
+)
+
+type OrderService interface {
+	CreateOrder(ctx context.Context, order models.CreateOrder) (models.Order, error)
+	Transition(ctx context.Context, params models.TransitionOrder) (models.Order, error)
+	ApplyCheckout(ctx context.Context, update models.CheckoutUpdate) (models.Order, error)
+	CancelOrder(ctx context.Context, params models.TransitionOrder) (models.Order, error)
+	FulfillOrder(ctx context.Context, orderID int64) (models.Order, error)
+	GetOrder(ctx context.Context, params models.GetOrder) (models.Order, error)
+	ListOrders(ctx context.Context, filter models.OrderFilter) (models.OrdersPage, error)
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"orders/internal/constants"
+	"orders/internal/domainerr"
+	"orders/internal/models"
+	"orders/internal/repository/interfaces"
+	"orders/pkg/log"
+	"orders/pkg/metrics"
+
+	trm "github.com/avito-tech/go-transaction-manager/trm/v2"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	defaultOrdersPageSize = 50
+	maxOrdersPageSize     = 500
+)
+
+type Service struct {
+	repo      interfaces.OrderRepository
+	tm        trm.Manager
+	kafkaProd interfaces.KafkaProd
+	metrics   metrics.Metrics
+	logger    log.Logger
+}
+
+func NewService(repo interfaces.OrderRepository, tm trm.Manager, kafkaProd interfaces.KafkaProd, m metrics.Metrics, logger log.Logger) *Service {
+	return &Service{
+		repo:      repo,
+		tm:        tm,
+		kafkaProd: kafkaProd,
+		metrics:   m,
+		logger:    logger,
+	}
+}
+
+// CreateOrder places the order in the created status. The total price is the sum of
+// the item prices at the time of the order. Placing the order of a checkout again
+// returns the order placed first.
+func (s *Service) CreateOrder(ctx context.Context, params models.CreateOrder) (models.Order, error) {
+	ctx, span := otel.Tracer("order-service").Start(ctx, "OrderService.CreateOrder")
+	defer span.End()
+
+	var (
+		order      models.Order
+		totalPrice uint64
+		transition = models.OrderTransition{To: models.OrderCreated}
+	)
+
+	for _, item := range params.Items {
+		totalPrice += uint64(item.Price) * uint64(item.Count)
+	}
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		order, err = s.repo.CreateOrder(ctx, params, totalPrice)
+		if err != nil {
+			return err
+		}
+
+		return s.repo.AddHistory(ctx, order.ID, transition)
+	})
+	if errors.Is(err, constants.ErrNotRowAffected) {
+		return s.repo.GetOrderByCheckout(ctx, params.CheckoutID)
+	}
+
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in CreateOrder: %v", err)
+		return models.Order{}, err
+	}
+
+	s.metrics.IncOrderTransition(string(models.OrderCreated))
+	s.produceOrderEvent(ctx, order, transition)
+
+	return order, nil
+}
+
+// Transition moves the order to params.To if the state machine allows it from the
+// current status. The status is checked and changed under a row lock, so concurrent
+// transitions of one order are serialized.
+func (s *Service) Transition(ctx context.Context, params models.TransitionOrder) (models.Order, error) {
+	return s.transition(ctx, params, nil)
+}
+
+// transition is Transition that first checks the locked order with check, when given.
+func (s *Service) transition(ctx context.Context, params models.TransitionOrder, check func(models.Order) error) (models.Order, error) {
+	ctx, span := otel.Tracer("order-service").Start(ctx, "OrderService.Transition")
+	defer span.End()
+
+	var (
+		order      models.Order
+		transition = models.OrderTransition{To: params.To, Reason: params.Reason}
+	)
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		current, err := s.ownedOrder(ctx, params.OrderID, params.UserID, true)
+		if err != nil {
+			return err
+		}
+
+		transition.From = current.Status
+
+		if check != nil {
+			if err := check(current); err != nil {
+				return err
+			}
+		}
+
+		if !canTransition(current.Status, params.To) {
+			return domainerr.InvalidTransition(params.OrderID, string(current.Status), string(params.To))
+		}
+
+		order, err = s.repo.UpdateStatus(ctx, params.OrderID, current.Status, params.To)
+		if err != nil {
+			if errors.Is(err, constants.ErrNotRowAffected) {
+				return domainerr.InvalidTransition(params.OrderID, string(current.Status), string(params.To))
+			}
+
+			return err
+		}
+
+		return s.repo.AddHistory(ctx, order.ID, transition)
+	})
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in Transition: %v", err)
+		return models.Order{}, err
+	}
+
+	s.metrics.IncOrderTransition(string(params.To))
+	s.produceOrderEvent(ctx, order, transition)
+
+	return order, nil
+}
+
+// ApplyCheckout brings the order of a checkout up to date with its saga, placing it on
+// the first update. Updates may come late, twice or out of order: the order only moves
+// forward along the state machine, and statuses it cannot reach anymore are skipped.
+func (s *Service) ApplyCheckout(ctx context.Context, update models.CheckoutUpdate) (models.Order, error) {
+	ctx, span := otel.Tracer("order-service").Start(ctx, "OrderService.ApplyCheckout")
+	defer span.End()
+
+	order, err := s.repo.GetOrderByCheckout(ctx, update.CheckoutID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		order, err = s.CreateOrder(ctx, models.CreateOrder{
+			UserID:     update.UserID,
+			CheckoutID: update.CheckoutID,
+			Items:      update.Items,
+		})
+	}
+
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in get order of checkout %d: %v", update.CheckoutID, err)
+		return models.Order{}, err
+	}
+
+	target, ok := checkoutOrderStatus(update)
+	if !ok {
+		return order, nil
+	}
+
+	reason := ""
+	if target == models.OrderCancelled {
+		reason = update.LastError
+	}
+
+	for _, to := range transitionPath(order.Status, target) {
+		next, err := s.Transition(ctx, models.TransitionOrder{OrderID: order.ID, To: to, Reason: reason})
+		if err != nil {
+			// Another update moved the order meanwhile.
+			if errors.Is(err, constants.ErrInvalidTransition) {
+				return order, nil
+			}
+
+			return models.Order{}, err
+		}
+
+		order = next
+	}
+
+	return order, nil
+}
+
+// HandleMessage applies a checkout step event of the cart service to the order of the
+// checkout. Other events are ignored.
+func (s *Service) HandleMessage(message []byte) error {
+	update, ok, err := ParseCheckoutStepEvent(message)
+	if err != nil || !ok {
+		return err
+	}
+
+	_, err = s.ApplyCheckout(context.Background(), update)
+
+	return err
+}
+
+// CancelOrder cancels an order of the user that is not fulfilled or cancelled yet.
+// Orders placed by a checkout are refused: their checkout holds the stock and the
+// payment, and cancels the order itself when it compensates.
+func (s *Service) CancelOrder(ctx context.Context, params models.TransitionOrder) (models.Order, error) {
+	params.To = models.OrderCancelled
+
+	order, err := s.transition(ctx, params, func(current models.Order) error {
+		if current.CheckoutID != 0 {
+			return domainerr.CheckoutOrder(current.ID, current.CheckoutID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	return s.withDetails(ctx, order)
+}
+
+// FulfillOrder marks a paid order of any user as fulfilled.
+func (s *Service) FulfillOrder(ctx context.Context, orderID int64) (models.Order, error) {
+	order, err := s.Transition(ctx, models.TransitionOrder{OrderID: orderID, To: models.OrderFulfilled})
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	return s.withDetails(ctx, order)
+}
+
+// GetOrder returns an order of the user with its items and status history.
+func (s *Service) GetOrder(ctx context.Context, params models.GetOrder) (models.Order, error) {
+	ctx, span := otel.Tracer("order-service").Start(ctx, "OrderService.GetOrder")
+	defer span.End()
+
+	order, err := s.ownedOrder(ctx, params.OrderID, params.UserID, false)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	return s.withDetails(ctx, order)
+}
+
+// ListOrders returns one page of the user's orders with their items, newest first.
+// NextCursor is the id to pass as BeforeID for the next page, or zero on the last page.
+func (s *Service) ListOrders(ctx context.Context, filter models.OrderFilter) (models.OrdersPage, error) {
+	ctx, span := otel.Tracer("order-service").Start(ctx, "OrderService.ListOrders")
+	defer span.End()
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultOrdersPageSize
+	}
+
+	filter.Limit = min(filter.Limit, maxOrdersPageSize)
+
+	// One extra row tells whether another page follows.
+	pageSize := filter.Limit
+	filter.Limit++
+
+	orders, err := s.repo.ListOrders(ctx, filter)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in list orders: %v", err)
+		return models.OrdersPage{}, err
+	}
+
+	page := models.OrdersPage{Orders: orders}
+	if len(orders) > pageSize {
+		page.Orders = orders[:pageSize]
+		page.NextCursor = page.Orders[pageSize-1].ID
+	}
+
+	if len(page.Orders) == 0 {
+		return page, nil
+	}
+
+	orderIDs := make([]int64, 0, len(page.Orders))
+	for _, order := range page.Orders {
+		orderIDs = append(orderIDs, order.ID)
+	}
+
+	items, err := s.repo.ListItems(ctx, orderIDs)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in list order items: %v", err)
+		return models.OrdersPage{}, err
+	}
+
+	for i := range page.Orders {
+		page.Orders[i].Items = items[page.Orders[i].ID]
+	}
+
+	return page, nil
+}
+
+// ownedOrder returns the order, reporting it as not found when a non-zero userID does
+// not own it.
+func (s *Service) ownedOrder(ctx context.Context, orderID, userID int64, forUpdate bool) (models.Order, error) {
+	order, err := s.repo.GetOrder(ctx, orderID, forUpdate)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Order{}, domainerr.OrderNotFound(orderID)
+		}
+
+		return models.Order{}, err
+	}
+
+	if userID != 0 && order.UserID != userID {
+		return models.Order{}, domainerr.OrderNotFound(orderID)
+	}
+
+	return order, nil
+}
+
+func (s *Service) withDetails(ctx context.Context, order models.Order) (models.Order, error) {
+	items, err := s.repo.ListItems(ctx, []int64{order.ID})
+	if err != nil {
+		return models.Order{}, fmt.Errorf("failed to list items of order %d: %w", order.ID, err)
+	}
+
+	order.Items = items[order.ID]
+
+	order.History, err = s.repo.ListHistory(ctx, order.ID)
+	if err != nil {
+		return models.Order{}, fmt.Errorf("failed to list history of order %d: %w", order.ID, err)
+	}
+
+	return order, nil
+}
+
+func (s *Service) produceOrderEvent(ctx context.Context, order models.Order, transition models.OrderTransition) {
+	msg, timestamp, err := BuildKafkaEvent(order, transition)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in build kafka event: %v", err)
+	}
+
+	err = s.kafkaProd.Produce(ctx, msg, fmt.Sprint(order.ID), timestamp)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in produce kafka msg: %v", err)
+	}
+}
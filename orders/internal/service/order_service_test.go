@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"orders/internal/constants"
+	"orders/internal/domainerr"
+	"orders/internal/models"
+	"orders/internal/repository/interfaces"
+	"orders/pkg/log/zap"
+	"orders/pkg/metrics"
+	"slices"
+	"testing"
+	"time"
+
+	trm "github.com/avito-tech/go-transaction-manager/trm/v2"
+	"github.com/jackc/pgx/v5"
+	uzap "go.uber.org/zap"
+)
+
+type directTM struct {
+	trm.Manager
+}
+
+func (directTM) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeOrders keeps orders in memory.
+type fakeOrders struct {
+	interfaces.OrderRepository
+
+	orders    map[int64]models.Order
+	checkouts map[int64]int64
+	history   map[int64][]models.OrderTransition
+}
+
+func newFakeOrders() *fakeOrders {
+	return &fakeOrders{
+		orders:    make(map[int64]models.Order),
+		checkouts: make(map[int64]int64),
+		history:   make(map[int64][]models.OrderTransition),
+	}
+}
+
+func (f *fakeOrders) CreateOrder(_ context.Context, params models.CreateOrder, totalPrice uint64) (models.Order, error) {
+	if _, ok := f.checkouts[params.CheckoutID]; ok && params.CheckoutID != 0 {
+		return models.Order{}, constants.ErrNotRowAffected
+	}
+
+	order := models.Order{
+		ID:         int64(len(f.orders) + 1),
+		UserID:     params.UserID,
+		CheckoutID: params.CheckoutID,
+		Status:     models.OrderCreated,
+		TotalPrice: totalPrice,
+		Items:      params.Items,
+	}
+
+	f.orders[order.ID] = order
+	f.checkouts[params.CheckoutID] = order.ID
+
+	return order, nil
+}
+
+func (f *fakeOrders) GetOrder(_ context.Context, orderID int64, _ bool) (models.Order, error) {
+	order, ok := f.orders[orderID]
+	if !ok {
+		return models.Order{}, pgx.ErrNoRows
+	}
+
+	return order, nil
+}
+
+func (f *fakeOrders) GetOrderByCheckout(ctx context.Context, checkoutID int64) (models.Order, error) {
+	orderID, ok := f.checkouts[checkoutID]
+	if !ok {
+		return models.Order{}, pgx.ErrNoRows
+	}
+
+	return f.GetOrder(ctx, orderID, false)
+}
+
+func (f *fakeOrders) UpdateStatus(_ context.Context, orderID int64, from, to models.OrderStatus) (models.Order, error) {
+	order := f.orders[orderID]
+	if order.Status != from {
+		return models.Order{}, constants.ErrNotRowAffected
+	}
+
+	order.Status = to
+	f.orders[orderID] = order
+
+	return order, nil
+}
+
+func (f *fakeOrders) AddHistory(_ context.Context, orderID int64, transition models.OrderTransition) error {
+	f.history[orderID] = append(f.history[orderID], transition)
+	return nil
+}
+
+func (f *fakeOrders) ListItems(_ context.Context, orderIDs []int64) (map[int64][]models.OrderItem, error) {
+	items := make(map[int64][]models.OrderItem)
+	for _, orderID := range orderIDs {
+		items[orderID] = f.orders[orderID].Items
+	}
+
+	return items, nil
+}
+
+func (f *fakeOrders) ListHistory(_ context.Context, orderID int64) ([]models.OrderTransition, error) {
+	return f.history[orderID], nil
+}
+
+type fakeProducer struct {
+	interfaces.KafkaProd
+
+	types []string
+}
+
+func (p *fakeProducer) Produce(_ context.Context, message []byte, _ string, _ time.Time) error {
+	var event KafkaEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		return err
+	}
+
+	p.types = append(p.types, event.Type)
+
+	return nil
+}
+
+type orderMetrics struct {
+	metrics.Metrics
+}
+
+func (orderMetrics) IncOrderTransition(string) {}
+
+func newTestService() (*Service, *fakeOrders, *fakeProducer) {
+	repo := newFakeOrders()
+	producer := &fakeProducer{}
+
+	return NewService(repo, directTM{}, producer, orderMetrics{}, &zap.Logger{L: uzap.NewNop()}), repo, producer
+}
+
+func checkoutUpdate(status, step string) models.CheckoutUpdate {
+	return models.CheckoutUpdate{
+		CheckoutID: 42,
+		UserID:     7,
+		Status:     status,
+		Step:       step,
+		Items: []models.OrderItem{
+			{SKU: 1001, SellerID: 1, Count: 2, Price: 150},
+			{SKU: 2020, SellerID: 3, Count: 1, Price: 200},
+		},
+	}
+}
+
+func historyOf(repo *fakeOrders, orderID int64) []models.OrderStatus {
+	var statuses []models.OrderStatus
+	for _, transition := range repo.history[orderID] {
+		statuses = append(statuses, transition.To)
+	}
+
+	return statuses
+}
+
+func TestApplyCheckoutCompleted(t *testing.T) {
+	svc, repo, producer := newTestService()
+	ctx := context.Background()
+
+	steps := []struct {
+		update models.CheckoutUpdate
+		want   models.OrderStatus
+	}{
+		{checkoutUpdate(checkoutRunning, checkoutStepReserveStock), models.OrderCreated},
+		{checkoutUpdate(checkoutRunning, checkoutStepAuthorizePayment), models.OrderAwaitingPayment},
+		// Redelivered.
+		{checkoutUpdate(checkoutRunning, checkoutStepAuthorizePayment), models.OrderAwaitingPayment},
+		{checkoutUpdate(checkoutRunning, checkoutStepCommitStock), models.OrderAwaitingPayment},
+		{checkoutUpdate(checkoutCompleted, ""), models.OrderPaid},
+		// Late, after the checkout completed.
+		{checkoutUpdate(checkoutRunning, checkoutStepClearCart), models.OrderPaid},
+		{checkoutUpdate(checkoutRunning, checkoutStepReserveStock), models.OrderPaid},
+	}
+
+	for i, step := range steps {
+		order, err := svc.ApplyCheckout(ctx, step.update)
+		if err != nil {
+			t.Fatalf("ApplyCheckout() #%d error = %v", i+1, err)
+		}
+
+		if order.Status != step.want {
+			t.Errorf("ApplyCheckout() #%d of %s at %q = %s, want %s", i+1, step.update.Status, step.update.Step, order.Status, step.want)
+		}
+	}
+
+	if len(repo.orders) != 1 {
+		t.Fatalf("placed %d orders, want 1", len(repo.orders))
+	}
+
+	order := repo.orders[1]
+	if order.UserID != 7 || order.TotalPrice != 500 {
+		t.Errorf("order = %+v, want user 7 with total price 500", order)
+	}
+
+	wantHistory := []models.OrderStatus{models.OrderCreated, models.OrderAwaitingPayment, models.OrderPaid}
+	if got := historyOf(repo, 1); !slices.Equal(got, wantHistory) {
+		t.Errorf("history = %v, want %v", got, wantHistory)
+	}
+
+	wantEvents := []string{"order_created", "order_awaiting_payment", "order_paid"}
+	if !slices.Equal(producer.types, wantEvents) {
+		t.Errorf("events = %v, want %v", producer.types, wantEvents)
+	}
+}
+
+func TestApplyCheckoutFailed(t *testing.T) {
+	svc, repo, _ := newTestService()
+	ctx := context.Background()
+
+	// The first update of the checkout to arrive is its compensation.
+	update := checkoutUpdate(checkoutCompensating, "release_stock")
+	update.LastError = "insufficient stock"
+
+	order, err := svc.ApplyCheckout(ctx, update)
+	if err != nil {
+		t.Fatalf("ApplyCheckout() error = %v", err)
+	}
+
+	if order.Status != models.OrderCancelled {
+		t.Errorf("ApplyCheckout() = %s, want cancelled", order.Status)
+	}
+
+	order, err = svc.ApplyCheckout(ctx, checkoutUpdate(checkoutFailed, ""))
+	if err != nil || order.Status != models.OrderCancelled {
+		t.Errorf("ApplyCheckout() of the failed checkout = %s, %v, want cancelled", order.Status, err)
+	}
+
+	history := repo.history[order.ID]
+	if len(history) != 2 || history[1].To != models.OrderCancelled || history[1].Reason != "insufficient stock" {
+		t.Errorf("history = %+v, want created and cancelled for insufficient stock", history)
+	}
+}
+
+func TestHandleMessage(t *testing.T) {
+	svc, repo, _ := newTestService()
+
+	messages := []string{
+		`{"type": "order_paid", "service": "order", "payload": {"order_id": 1}}`,
+		`{"type": "checkout_step", "service": "cart", "payload": {"checkout_id": 5, "user_id": 9, "status": "running", "step": "authorize_payment",
+			"lines": [{"sku": 1001, "seller_id": 1, "count": 3, "price": 100}]}}`,
+	}
+
+	for _, message := range messages {
+		if err := svc.HandleMessage([]byte(message)); err != nil {
+			t.Fatalf("HandleMessage(%s) error = %v", message, err)
+		}
+	}
+
+	if len(repo.orders) != 1 {
+		t.Fatalf("placed %d orders, want 1", len(repo.orders))
+	}
+
+	order := repo.orders[1]
+	if order.UserID != 9 || order.Status != models.OrderAwaitingPayment || order.TotalPrice != 300 {
+		t.Errorf("order = %+v, want an order of user 9 awaiting payment of 300", order)
+	}
+
+	if err := svc.HandleMessage([]byte("not json")); err == nil {
+		t.Error("HandleMessage() of a malformed message succeeded")
+	}
+}
+
+func TestCancelOrderDuringCheckout(t *testing.T) {
+	svc, repo, producer := newTestService()
+	ctx := context.Background()
+
+	order, err := svc.ApplyCheckout(ctx, checkoutUpdate(checkoutRunning, checkoutStepAuthorizePayment))
+	if err != nil {
+		t.Fatalf("ApplyCheckout() error = %v", err)
+	}
+
+	_, err = svc.CancelOrder(ctx, models.TransitionOrder{OrderID: order.ID, UserID: 7})
+
+	var domainErr *domainerr.Error
+	if !errors.As(err, &domainErr) || domainErr.Reason != domainerr.ReasonCheckoutOrder {
+		t.Fatalf("CancelOrder() error = %v, want %s", err, domainerr.ReasonCheckoutOrder)
+	}
+
+	if status := repo.orders[order.ID].Status; status != models.OrderAwaitingPayment {
+		t.Errorf("order is %s after the refused cancellation, want awaiting_payment", status)
+	}
+
+	// The checkout still completes and the order follows it.
+	order, err = svc.ApplyCheckout(ctx, checkoutUpdate(checkoutCompleted, ""))
+	if err != nil || order.Status != models.OrderPaid {
+		t.Errorf("ApplyCheckout() of the completed checkout = %s, %v, want paid", order.Status, err)
+	}
+
+	if slices.Contains(producer.types, "order_cancelled") {
+		t.Errorf("events = %v, want no cancellation", producer.types)
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	svc, repo, _ := newTestService()
+	ctx := context.Background()
+
+	placed, err := svc.CreateOrder(ctx, models.CreateOrder{UserID: 7, Items: []models.OrderItem{{SKU: 1001, SellerID: 1, Count: 1, Price: 100}}})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	if _, err := svc.CancelOrder(ctx, models.TransitionOrder{OrderID: placed.ID, UserID: 8}); !errors.Is(err, constants.ErrNotFound) {
+		t.Errorf("CancelOrder() of another user's order error = %v, want not found", err)
+	}
+
+	order, err := svc.CancelOrder(ctx, models.TransitionOrder{OrderID: placed.ID, UserID: 7, Reason: "changed my mind"})
+	if err != nil {
+		t.Fatalf("CancelOrder() error = %v", err)
+	}
+
+	if order.Status != models.OrderCancelled {
+		t.Errorf("CancelOrder() = %s, want cancelled", order.Status)
+	}
+
+	if history := repo.history[placed.ID]; len(history) != 2 || history[1].Reason != "changed my mind" {
+		t.Errorf("history = %+v, want created and cancelled with the reason", history)
+	}
+}
+
+func TestFulfillOrder(t *testing.T) {
+	svc, _, producer := newTestService()
+	ctx := context.Background()
+
+	pending, err := svc.ApplyCheckout(ctx, checkoutUpdate(checkoutRunning, checkoutStepAuthorizePayment))
+	if err != nil {
+		t.Fatalf("ApplyCheckout() error = %v", err)
+	}
+
+	_, err = svc.FulfillOrder(ctx, pending.ID)
+	if !errors.Is(err, constants.ErrInvalidTransition) {
+		t.Errorf("FulfillOrder() of an unpaid order error = %v, want %v", err, constants.ErrInvalidTransition)
+	}
+
+	if _, err := svc.ApplyCheckout(ctx, checkoutUpdate(checkoutCompleted, "")); err != nil {
+		t.Fatalf("ApplyCheckout() error = %v", err)
+	}
+
+	order, err := svc.FulfillOrder(ctx, pending.ID)
+	if err != nil {
+		t.Fatalf("FulfillOrder() error = %v", err)
+	}
+
+	if order.Status != models.OrderFulfilled {
+		t.Errorf("FulfillOrder() = %s, want fulfilled", order.Status)
+	}
+
+	if last := producer.types[len(producer.types)-1]; last != "order_fulfilled" {
+		t.Errorf("last event = %s, want order_fulfilled", last)
+	}
+
+	if _, err := svc.FulfillOrder(ctx, 99); !errors.Is(err, constants.ErrNotFound) {
+		t.Errorf("FulfillOrder() of an unknown order error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"orders/internal/models"
+	"slices"
+)
+
+// transitions lists the statuses an order may move to from each status. Fulfilled and
+// cancelled orders are final.
+var transitions = map[models.OrderStatus][]models.OrderStatus{
+	models.OrderCreated:         {models.OrderAwaitingPayment, models.OrderCancelled},
+	models.OrderAwaitingPayment: {models.OrderPaid, models.OrderCancelled},
+	models.OrderPaid:            {models.OrderFulfilled, models.OrderCancelled},
+}
+
+// canTransition reports whether an order in status from may move to status to.
+func canTransition(from, to models.OrderStatus) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// forward is the order an order moves through while it is not cancelled.
+var forward = []models.OrderStatus{
+	models.OrderCreated,
+	models.OrderAwaitingPayment,
+	models.OrderPaid,
+	models.OrderFulfilled,
+}
+
+// transitionPath returns the transitions that take an order from status from to status
+// to, one step of the state machine each. It is empty when the order is at to already,
+// past it, or cannot reach it.
+func transitionPath(from, to models.OrderStatus) []models.OrderStatus {
+	if to == models.OrderCancelled {
+		if canTransition(from, to) {
+			return []models.OrderStatus{to}
+		}
+
+		return nil
+	}
+
+	i, j := slices.Index(forward, from), slices.Index(forward, to)
+	if i < 0 || j <= i {
+		return nil
+	}
+
+	return slices.Clone(forward[i+1 : j+1])
+}
+
+// checkoutOrderStatus is the status of the order of a checkout in the state the update
+// announces, false when the update does not change the order. The order waits for
+// payment from the authorization on and is paid once the payment is captured; a
+// compensating or failed checkout cancels it. A stuck checkout keeps the order as it
+// is for the operator finishing the checkout.
+func checkoutOrderStatus(update models.CheckoutUpdate) (models.OrderStatus, bool) {
+	switch update.Status {
+	case checkoutCompleted:
+		return models.OrderPaid, true
+	case checkoutCompensating, checkoutFailed:
+		return models.OrderCancelled, true
+	case checkoutRunning:
+		switch update.Step {
+		case checkoutStepReserveStock:
+			return models.OrderCreated, true
+		case checkoutStepAuthorizePayment, checkoutStepCommitStock, checkoutStepCapturePayment:
+			return models.OrderAwaitingPayment, true
+		case checkoutStepClearCart:
+			return models.OrderPaid, true
+		}
+	}
+
+	return "", false
+}
+
+// orderEvent is the Kafka event type of a transition to status, e.g. order_paid.
+func orderEvent(status models.OrderStatus) string {
+	return "order_" + string(status)
+}
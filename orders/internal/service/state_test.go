@@ -0,0 +1,97 @@
+package service
+
+import (
+	"orders/internal/models"
+	"slices"
+	"testing"
+)
+
+func TestCanTransition(t *testing.T) {
+	statuses := []models.OrderStatus{
+		models.OrderCreated,
+		models.OrderAwaitingPayment,
+		models.OrderPaid,
+		models.OrderFulfilled,
+		models.OrderCancelled,
+	}
+
+	allowed := map[[2]models.OrderStatus]bool{
+		{models.OrderCreated, models.OrderAwaitingPayment}:   true,
+		{models.OrderCreated, models.OrderCancelled}:         true,
+		{models.OrderAwaitingPayment, models.OrderPaid}:      true,
+		{models.OrderAwaitingPayment, models.OrderCancelled}: true,
+		{models.OrderPaid, models.OrderFulfilled}:            true,
+		{models.OrderPaid, models.OrderCancelled}:            true,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := allowed[[2]models.OrderStatus{from, to}]
+			if got := canTransition(from, to); got != want {
+				t.Errorf("canTransition(%s, %s) = %t, want %t", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestTransitionPath(t *testing.T) {
+	tests := []struct {
+		from, to models.OrderStatus
+		want     []models.OrderStatus
+	}{
+		{models.OrderCreated, models.OrderCreated, nil},
+		{models.OrderCreated, models.OrderAwaitingPayment, []models.OrderStatus{models.OrderAwaitingPayment}},
+		{models.OrderCreated, models.OrderPaid, []models.OrderStatus{models.OrderAwaitingPayment, models.OrderPaid}},
+		{models.OrderAwaitingPayment, models.OrderPaid, []models.OrderStatus{models.OrderPaid}},
+		{models.OrderPaid, models.OrderAwaitingPayment, nil},
+		{models.OrderFulfilled, models.OrderPaid, nil},
+		{models.OrderCreated, models.OrderCancelled, []models.OrderStatus{models.OrderCancelled}},
+		{models.OrderPaid, models.OrderCancelled, []models.OrderStatus{models.OrderCancelled}},
+		{models.OrderFulfilled, models.OrderCancelled, nil},
+		{models.OrderCancelled, models.OrderCancelled, nil},
+		{models.OrderCancelled, models.OrderPaid, nil},
+	}
+
+	for _, tt := range tests {
+		got := transitionPath(tt.from, tt.to)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("transitionPath(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+
+		// Every step of a path is allowed by the state machine.
+		from := tt.from
+		for _, to := range got {
+			if !canTransition(from, to) {
+				t.Errorf("transitionPath(%s, %s) moves from %s to %s", tt.from, tt.to, from, to)
+			}
+
+			from = to
+		}
+	}
+}
+
+func TestCheckoutOrderStatus(t *testing.T) {
+	tests := []struct {
+		status, step string
+		want         models.OrderStatus
+		wantOK       bool
+	}{
+		{checkoutRunning, checkoutStepReserveStock, models.OrderCreated, true},
+		{checkoutRunning, checkoutStepAuthorizePayment, models.OrderAwaitingPayment, true},
+		{checkoutRunning, checkoutStepCommitStock, models.OrderAwaitingPayment, true},
+		{checkoutRunning, checkoutStepCapturePayment, models.OrderAwaitingPayment, true},
+		{checkoutRunning, checkoutStepClearCart, models.OrderPaid, true},
+		{checkoutCompleted, "", models.OrderPaid, true},
+		{checkoutCompensating, "void_payment", models.OrderCancelled, true},
+		{checkoutFailed, "", models.OrderCancelled, true},
+		{"stuck", checkoutStepCapturePayment, "", false},
+		{checkoutRunning, "unknown", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := checkoutOrderStatus(models.CheckoutUpdate{Status: tt.status, Step: tt.step})
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("checkoutOrderStatus(%s at %q) = %q, %t, want %q, %t", tt.status, tt.step, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
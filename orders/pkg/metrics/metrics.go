@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type Metrics interface {
+	ObserveLatency(path, method, status string, duration float64)
+	IncError(path, method, status string)
+	ObserveGRPC(method, code string, duration float64)
+	IncOrderTransition(status string)
+	Handler() http.Handler
+}
+
+var _ Metrics = &OrderMetrics{}
+
+type OrderMetrics struct {
+	ResponseLatency  *prometheus.HistogramVec
+	ErrorsTotal      *prometheus.CounterVec
+	GRPCLatency      *prometheus.HistogramVec
+	GRPCHandled      *prometheus.CounterVec
+	OrderTransitions *prometheus.CounterVec
+	registry         *prometheus.Registry
+}
+
+// RegisterMetrics registers the service metrics together with the Go runtime and
+// process collectors on a dedicated registry.
+func RegisterMetrics() (*OrderMetrics, error) {
+	registry := prometheus.NewRegistry()
+
+	responseLatency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_response_time_seconds",
+			Help:    "HTTP response latencies in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"path", "method", "status"},
+	)
+
+	errorCounter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_failed_requests_total",
+			Help: "Total failed HTTP requests",
+		},
+		[]string{"path", "method", "status"},
+	)
+
+	grpcLatency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "gRPC call latencies in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method"},
+	)
+
+	grpcHandled := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "Total gRPC calls by status code",
+		},
+		[]string{"method", "code"},
+	)
+
+	orderTransitions := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "order_transitions_total",
+			Help: "Total order state transitions by target status",
+		},
+		[]string{"status"},
+	)
+
+	for _, c := range []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		responseLatency,
+		errorCounter,
+		grpcLatency,
+		grpcHandled,
+		orderTransitions,
+	} {
+		if err := registry.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return &OrderMetrics{
+		ResponseLatency:  responseLatency,
+		ErrorsTotal:      errorCounter,
+		GRPCLatency:      grpcLatency,
+		GRPCHandled:      grpcHandled,
+		OrderTransitions: orderTransitions,
+		registry:         registry,
+	}, nil
+}
+
+func (m *OrderMetrics) ObserveLatency(path, method, status string, duration float64) {
+	m.ResponseLatency.With(prometheus.Labels{
+		"path":   path,
+		"method": method,
+		"status": status,
+	}).Observe(duration)
+}
+
+func (m *OrderMetrics) IncError(path, method, status string) {
+	m.ErrorsTotal.With(prometheus.Labels{
+		"path":   path,
+		"method": method,
+		"status": status,
+	}).Inc()
+}
+
+func (m *OrderMetrics) ObserveGRPC(method, code string, duration float64) {
+	m.GRPCLatency.With(prometheus.Labels{
+		"method": method,
+	}).Observe(duration)
+
+	m.GRPCHandled.With(prometheus.Labels{
+		"method": method,
+		"code":   code,
+	}).Inc()
+}
+
+func (m *OrderMetrics) IncOrderTransition(status string) {
+	m.OrderTransitions.With(prometheus.Labels{
+		"status": status,
+	}).Inc()
+}
+
+// Handler serves the metrics of the dedicated registry.
+func (m *OrderMetrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
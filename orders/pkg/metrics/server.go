@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"orders/pkg/health"
+	"orders/pkg/log"
+	"strconv"
+)
+
+type Server struct {
+	metricsServer *http.Server
+	mux           *http.ServeMux
+}
+
+type MetricsServer interface {
+	// Handle serves an operations endpoint next to the metrics and probes. It has to
+	// be called before Run.
+	Handle(pattern string, handler http.Handler)
+	Run() error
+	Shutdown(ctx context.Context) error
+}
+
+func NewServer(m Metrics, probes *health.Health, metricsPort int64, logger log.Logger) MetricsServer {
+	mux := http.NewServeMux()
+
+	mux.Handle("/metrics", m.Handler())
+	mux.Handle("/livez", probes.LivezHandler())
+	mux.Handle("/readyz", probes.ReadyzHandler())
+
+	// Kept for existing probes; same as /livez.
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte("OK"))
+		if err != nil {
+			m.IncError(r.URL.Path, r.Method, strconv.Itoa(http.StatusInternalServerError))
+			logger.Errorf("Failed to write health check response: %v", err)
+		}
+	})
+
+	return &Server{
+		metricsServer: &http.Server{
+			Addr:    fmt.Sprintf(":%d", metricsPort),
+			Handler: mux,
+		},
+		mux: mux,
+	}
+}
+
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+func (s *Server) Run() error {
+	if err := s.metricsServer.ListenAndServe(); err != nil && errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("metrics server error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	if err := s.metricsServer.Shutdown(ctx); err != nil {
+		return fmt.Errorf("metrics server shutdown error: %w", err)
+	}
+
+	return nil
+}
@@ -10,17 +10,22 @@ import (
 	"stocks/internal/config"
 	"stocks/internal/constants"
 	"stocks/internal/migrations"
+	"stocks/internal/repository/interfaces"
 	kconstructor "stocks/internal/repository/kafka"
 	"stocks/internal/repository/postgres"
 	"stocks/internal/service"
+	"stocks/pkg/health"
 	"stocks/pkg/log"
 	"stocks/pkg/log/zap"
 	"stocks/pkg/metrics"
 	"stocks/pkg/postgresql"
+	"stocks/pkg/ratelimit"
 	"stocks/pkg/tracing"
 	"syscall"
+	"time"
 
 	grpcserver "stocks/internal/delivery/grpc"
+	stocksapi "stocks/pkg/api/stocks"
 
 	tmsql "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
 	trm "github.com/avito-tech/go-transaction-manager/trm/v2/manager"
@@ -28,25 +33,33 @@ import (
 )
 
 type App struct {
-	db             postgresql.Client
-	cfg            *config.Configs
-	grpcServer     *grpc.Server
-	gateway        grpcserver.Gateway
-	logger         log.Logger
-	shutdownTracer func(context.Context) error
-	metricsServer  metrics.MetricsServer
+	db              postgresql.Client
+	idempotencyRepo interfaces.IdempotencyRepository
+	svc             service.StockService
+	stockWatcher    interfaces.StockWatcher
+	cfg             *config.Configs
+	grpcServer      *grpc.Server
+	gateway         grpcserver.Gateway
+	logger          log.Logger
+	shutdownTracer  func(context.Context) error
+	metricsServer   metrics.MetricsServer
+	probes          *health.Health
+	reloader        *reloader
 }
 
-func NewApp(ctx context.Context) (*App, error) {
-	cfg := config.GetConfig()
+func NewApp(ctx context.Context, configPath string) (*App, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
 
-	logger, err := zap.NewLogger(cfg.Listen.ServiceName, cfg.Listen.Env)
+	logger, err := zap.NewLogger(loggerOptions(cfg))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing logger: %v\n", err)
 		return nil, err
 	}
 
-	shutdownTracer, err := tracing.InitTracer(cfg.Listen.ServiceName, cfg.Tracing.JaegerEndpoint)
+	shutdownTracer, err := tracing.InitTracer(ctx, cfg)
 	if err != nil {
 		logger.Errorf("failed to init tracer: %v", err)
 		return nil, err
@@ -58,7 +71,9 @@ func NewApp(ctx context.Context) (*App, error) {
 		return nil, err
 	}
 
-	metricsServer := metrics.NewServer(stockMetrics, cfg.Metrics.Port, logger)
+	probes := health.New(cfg.Health.CheckTimeout, logger, stocksapi.StockService_ServiceDesc.ServiceName)
+
+	metricsServer := metrics.NewServer(stockMetrics, probes, cfg.Metrics.Port, logger)
 
 	db, err := postgresql.NewPostgres(ctx, cfg)
 	if err != nil {
@@ -82,26 +97,63 @@ func NewApp(ctx context.Context) (*App, error) {
 	}
 
 	repo := postgres.NewRepository(db, tmsql.DefaultCtxGetter)
-	svc := service.NewService(repo, tm, kafkaProd, logger)
+	sellerRepo := postgres.NewSellerRepository(db, tmsql.DefaultCtxGetter)
+	idempotencyRepo := postgres.NewIdempotencyRepository(db)
+	stockWatcher := postgres.NewStockWatcher(db, logger)
+	reservationRepo := postgres.NewReservationRepository(db, tmsql.DefaultCtxGetter)
+	svc := service.NewService(repo, sellerRepo, reservationRepo, tm, stockWatcher, kafkaProd, stockMetrics, logger)
+
+	var auditProd interfaces.KafkaProd
+	if cfg.Audit.Topic != "" {
+		auditProd, err = kconstructor.NewProducer(cfg.Kafka.Brokers, cfg.Audit.Topic)
+		if err != nil {
+			logger.Errorf("failed to create audit producer: %v", err)
+			return nil, err
+		}
+	}
+
+	auditSvc := service.NewAuditService(postgres.NewAuditRepository(db), repo, sellerRepo, auditProd, logger)
+
+	probes.AddCheck("postgres", db.Ping)
+	probes.AddCheck("kafka", kafkaProd.Ping)
+
+	// The limiters exist even when rate limiting is disabled, so a reload can enable it.
+	grpcLimiter := newRateLimiter(cfg.RateLimit, cfg.RateLimit.GRPC)
+	gatewayLimiter := newRateLimiter(cfg.RateLimit, cfg.RateLimit.Gateway)
+
+	reloader := newReloader(configPath, cfg, logger, grpcLimiter, gatewayLimiter)
+	metricsServer.Handle("GET /config", grpcserver.AdminOnly(reloader, cfg.Audit.AdminAPIKeys, logger))
+	metricsServer.Handle("/log/level", grpcserver.AdminOnly(logger.LevelHandler(), cfg.Audit.AdminAPIKeys, logger))
+
+	callers, err := grpcserver.NewCallers(cfg.Audit.AdminAPIKeys, cfg.RateLimit.TrustedProxies)
+	if err != nil {
+		logger.Errorf("failed to create rate limit callers: %v", err)
+		return nil, err
+	}
 
 	// gRPC Server Setup
-	grpcServer := grpcserver.NewGRPCServer(svc, logger)
+	grpcServer := grpcserver.NewGRPCServer(svc, auditSvc, cfg.Audit.AdminAPIKeys, cfg.Audit.InternalAPIKeys, idempotencyRepo, cfg.Idempotency.TTL, stockMetrics, grpcLimiter, callers, probes.GRPCServer(), logger)
 
 	// gRPC-Gateway Setup
-	gateway, err := grpcserver.NewGateway(ctx, cfg.Listen.GRPCPort, cfg.Listen.GatewayPort, logger, stockMetrics)
+	gateway, err := grpcserver.NewGateway(ctx, cfg.Listen.GRPCPort, cfg.Listen.GatewayPort, gatewayLimiter, callers, logger, stockMetrics)
 	if err != nil {
 		logger.Errorf("failed to create gateway: %v", err)
 		return nil, err
 	}
 
 	return &App{
-		db:             db,
-		cfg:            cfg,
-		grpcServer:     grpcServer,
-		gateway:        gateway,
-		logger:         logger,
-		shutdownTracer: shutdownTracer,
-		metricsServer:  metricsServer,
+		db:              db,
+		idempotencyRepo: idempotencyRepo,
+		svc:             svc,
+		stockWatcher:    stockWatcher,
+		cfg:             cfg,
+		grpcServer:      grpcServer,
+		gateway:         gateway,
+		logger:          logger,
+		shutdownTracer:  shutdownTracer,
+		metricsServer:   metricsServer,
+		probes:          probes,
+		reloader:        reloader,
 	}, nil
 }
 
@@ -110,6 +162,31 @@ func (a *App) Run() error {
 
 	serverErrors := make(chan error, 3)
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	// Start expired idempotency keys cleanup
+	go a.cleanupIdempotencyKeys(jobsCtx)
+
+	// Start purge of deleted stock past retention
+	go a.purgeDeletedStock(jobsCtx)
+
+	// Start stock level gauges refresh
+	go a.reportStockLevels(jobsCtx)
+
+	// Start stock changes listener
+	go func() {
+		if err := a.stockWatcher.Run(jobsCtx); err != nil {
+			a.logger.Errorf("stock watcher stopped: %v", err)
+		}
+	}()
+
+	// Start applying config changes
+	go a.reloader.run(jobsCtx)
+
+	// Start gRPC health status updates
+	go a.probes.Run(jobsCtx, a.cfg.Health.CheckInterval)
+
 	// Start metrics server
 	go func() {
 		if err := a.metricsServer.Run(); err != nil {
@@ -149,6 +226,13 @@ func (a *App) Run() error {
 		return errors.New("server failed to start or stopped unexpectedly: " + err.Error())
 	}
 
+	// Fail readiness first so load balancers drain the instance before it stops serving
+	a.probes.Shutdown()
+	a.logger.Infof("⏳ Readiness set to not serving, draining for %s", a.cfg.Health.DrainDelay)
+	time.Sleep(a.cfg.Health.DrainDelay)
+
+	stopJobs()
+
 	ctx, cancel := context.WithTimeout(context.Background(), constants.ServerTimeout)
 	defer cancel()
 
@@ -160,8 +244,18 @@ func (a *App) Run() error {
 
 	var shutdownErrors []error
 
-	// Shutdown gRPC server
-	a.grpcServer.GracefulStop()
+	// Shutdown gRPC server; watch streams never finish on their own, so they are cut off at the deadline
+	stopped := make(chan struct{})
+	go func() {
+		a.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		a.grpcServer.Stop()
+	}
 	a.logger.Info("✅ gRPC server shutdown complete")
 
 	// Shutdown Gateway
@@ -192,6 +286,126 @@ func (a *App) Run() error {
 	return nil
 }
 
+func (a *App) cleanupIdempotencyKeys(ctx context.Context) {
+	if a.cfg.Idempotency.CleanupInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(a.cfg.Idempotency.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := a.idempotencyRepo.DeleteExpired(ctx)
+			if err != nil {
+				a.logger.Errorf("failed to delete expired idempotency keys: %v", err)
+				continue
+			}
+
+			if deleted > 0 {
+				a.logger.Infof("🧹 Deleted %d expired idempotency keys", deleted)
+			}
+		}
+	}
+}
+
+func (a *App) purgeDeletedStock(ctx context.Context) {
+	if a.cfg.SoftDelete.PurgeInterval <= 0 || a.cfg.SoftDelete.Retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(a.cfg.SoftDelete.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := a.svc.PurgeDeletedItems(ctx, a.cfg.SoftDelete.Retention)
+			if err != nil {
+				a.logger.Errorf("failed to purge deleted stock: %v", err)
+				continue
+			}
+
+			if purged > 0 {
+				a.logger.Infof("🧹 Purged %d deleted stock items", purged)
+			}
+		}
+	}
+}
+
+func (a *App) reportStockLevels(ctx context.Context) {
+	if a.cfg.Metrics.StockLevelsInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(a.cfg.Metrics.StockLevelsInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.svc.ReportStockLevels(ctx); err != nil && ctx.Err() == nil {
+			a.logger.Errorf("failed to report stock levels: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (a *App) Logger() log.Logger {
 	return a.logger
 }
+
+func newRateLimiter(cfg config.RateLimit, rules config.RateLimitRules) *ratelimit.Limiter {
+	fallback, methods := rateLimitRules(cfg, rules)
+
+	return ratelimit.New(fallback, methods, cfg.IdleTTL, rateLimitMaxBuckets(cfg))
+}
+
+func updateRateLimiter(limiter *ratelimit.Limiter, cfg config.RateLimit, rules config.RateLimitRules) {
+	limiter.Update(rateLimitRules(cfg, rules))
+}
+
+func rateLimitMaxBuckets(cfg config.RateLimit) int {
+	if cfg.MaxBuckets > 0 {
+		return cfg.MaxBuckets
+	}
+
+	return constants.RateLimitMaxBuckets
+}
+
+// rateLimitRules converts rules to limiter rules. With rate limiting disabled nothing
+// is limited.
+func rateLimitRules(cfg config.RateLimit, rules config.RateLimitRules) (ratelimit.Rule, map[string]ratelimit.Rule) {
+	if !cfg.Enabled {
+		return ratelimit.Rule{}, nil
+	}
+
+	methods := make(map[string]ratelimit.Rule, len(rules.Methods))
+	for _, method := range rules.Methods {
+		methods[method.Name] = ratelimit.Rule{RPS: method.RPS, Burst: method.Burst}
+	}
+
+	return ratelimit.Rule{RPS: rules.RPS, Burst: rules.Burst}, methods
+}
+
+func loggerOptions(cfg *config.Configs) zap.Options {
+	return zap.Options{
+		Service:  cfg.Listen.ServiceName,
+		Env:      cfg.Listen.Env,
+		Level:    cfg.Log.Level,
+		Encoding: cfg.Log.Encoding,
+		Sampling: zap.Sampling{
+			Initial:    cfg.Log.Sampling.Initial,
+			Thereafter: cfg.Log.Sampling.Thereafter,
+			Tick:       cfg.Log.Sampling.Tick,
+		},
+	}
+}
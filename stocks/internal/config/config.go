@@ -1,22 +1,46 @@
 package config
 
 import (
-	"log"
-	"os"
-	"sync"
+	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Configs struct {
-	Listen   Listen     `mapstructure:"listen"`
-	Postgres DbPostgres `mapstructure:"postgres"`
-	Kafka    Kafka      `mapstructure:"kafka"`
-	Tracing  Tracing    `mapstructure:"tracing"`
-	Metrics  Metrics    `mapstructure:"metrics"`
+	Listen      Listen      `mapstructure:"listen"`
+	Log         Log         `mapstructure:"log"`
+	Postgres    DbPostgres  `mapstructure:"postgres"`
+	Kafka       Kafka       `mapstructure:"kafka"`
+	Tracing     Tracing     `mapstructure:"tracing"`
+	Metrics     Metrics     `mapstructure:"metrics"`
+	Idempotency Idempotency `mapstructure:"idempotency"`
+	Health      Health      `mapstructure:"health"`
+	RateLimit   RateLimit   `mapstructure:"rate_limit"`
+	Audit       Audit       `mapstructure:"audit"`
+	SoftDelete  SoftDelete  `mapstructure:"soft_delete"`
 }
 
 type (
+	Log struct {
+		// Level is debug, info, warn or error.
+		Level string `mapstructure:"level" dynamic:"true"`
+		// Encoding is json or console. Empty means console in development and json
+		// otherwise.
+		Encoding string      `mapstructure:"encoding"`
+		Sampling LogSampling `mapstructure:"sampling"`
+	}
+
+	// LogSampling thins out repeated lines: of the lines with the same level and
+	// message, the first Initial per Tick are written and then every Thereafter-th.
+	// Sampling is off while Initial is zero.
+	LogSampling struct {
+		Initial    int           `mapstructure:"initial"`
+		Thereafter int           `mapstructure:"thereafter"`
+		Tick       time.Duration `mapstructure:"tick"`
+	}
+
 	Listen struct {
 		GatewayPort    string `mapstructure:"gateway_port"`
 		GRPCPort       string `mapstructure:"grpc_port"`
@@ -30,7 +54,7 @@ type (
 		Port     string `mapstructure:"port"`
 		DbName   string `mapstructure:"db_name"`
 		UserName string `mapstructure:"username"`
-		Password string `mapstructure:"password"`
+		Password string `mapstructure:"password" secret:"true"`
 		Sslmode  string `mapstructure:"ssl_mode"`
 	}
 
@@ -41,43 +65,98 @@ type (
 	}
 
 	Tracing struct {
-		JaegerEndpoint string `mapstructure:"jaeger_endpoint"`
+		Exporter    string  `mapstructure:"exporter"`
+		Endpoint    string  `mapstructure:"endpoint"`
+		Insecure    bool    `mapstructure:"insecure"`
+		SampleRatio float64 `mapstructure:"sample_ratio"`
+		Version     string  `mapstructure:"version"`
 	}
 
 	Metrics struct {
-		Port int64 `mapstructure:"port"`
+		Port                int64         `mapstructure:"port"`
+		StockLevelsInterval time.Duration `mapstructure:"stock_levels_interval"`
+	}
+
+	Health struct {
+		CheckInterval time.Duration `mapstructure:"check_interval"`
+		CheckTimeout  time.Duration `mapstructure:"check_timeout"`
+		DrainDelay    time.Duration `mapstructure:"drain_delay"`
+	}
+
+	Idempotency struct {
+		TTL             time.Duration `mapstructure:"ttl"`
+		CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+	}
+
+	RateLimit struct {
+		Enabled        bool           `mapstructure:"enabled" dynamic:"true"`
+		IdleTTL        time.Duration  `mapstructure:"idle_ttl"`
+		MaxBuckets     int            `mapstructure:"max_buckets"`
+		TrustedProxies []string       `mapstructure:"trusted_proxies"`
+		GRPC           RateLimitRules `mapstructure:"grpc" dynamic:"true"`
+		Gateway        RateLimitRules `mapstructure:"gateway" dynamic:"true"`
+	}
+
+	// RateLimitRules are the default limit and the per-method overrides. Methods are
+	// a list because viper would split gRPC method names used as map keys.
+	RateLimitRules struct {
+		RPS     float64       `mapstructure:"rps"`
+		Burst   int           `mapstructure:"burst"`
+		Methods []MethodLimit `mapstructure:"methods"`
+	}
+
+	MethodLimit struct {
+		Name  string  `mapstructure:"name"`
+		RPS   float64 `mapstructure:"rps"`
+		Burst int     `mapstructure:"burst"`
 	}
-)
 
-var (
-	once     sync.Once
-	instance *Configs
+	Audit struct {
+		// Topic also publishes audit events to Kafka when set.
+		Topic        string   `mapstructure:"topic"`
+		AdminAPIKeys []string `mapstructure:"admin_api_keys" secret:"true"`
+		// InternalAPIKeys are the keys of the services allowed to call internal RPCs.
+		InternalAPIKeys []string `mapstructure:"internal_api_keys" secret:"true"`
+	}
+
+	SoftDelete struct {
+		// Retention is how long deleted offers can be restored before they are purged.
+		Retention     time.Duration `mapstructure:"retention"`
+		PurgeInterval time.Duration `mapstructure:"purge_interval"`
+	}
 )
 
-func GetConfig() *Configs {
-	once.Do(func() {
-		pwd, err := os.Getwd()
-		if err != nil {
-			log.Println("err in config Getwd func: ", err)
-			os.Exit(1)
-		}
+// Load reads the config file at path. Every setting can be overridden by the
+// environment variable named after its key, like STOCKS_POSTGRES_PASSWORD for
+// postgres.password, or read from the file STOCKS_POSTGRES_PASSWORD_FILE names. Every
+// invalid setting is reported at once.
+func Load(path string) (*Configs, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(envKeyReplacer)
+	v.AutomaticEnv()
 
-		pathConfig := pwd + "/config.yml"
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	if err := bindEnv(v, reflect.TypeOf(Configs{}), ""); err != nil {
+		return nil, fmt.Errorf("failed to bind environment variables: %w", err)
+	}
 
-		viper.SetConfigFile(pathConfig)
+	if err := readSecretFiles(v); err != nil {
+		return nil, fmt.Errorf("failed to read secret files: %w", err)
+	}
 
-		err = viper.ReadInConfig()
-		if err != nil {
-			log.Println("err in load config:", err)
-			os.Exit(1)
-		}
+	cfg := &Configs{}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode config %s: %w", path, err)
+	}
 
-		instance = &Configs{}
-		if err = viper.Unmarshal(instance); err != nil {
-			log.Println("err in marshal load config:", err)
-			os.Exit(1)
-		}
-	})
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s:\n%w", path, err)
+	}
 
-	return instance
+	return cfg, nil
 }
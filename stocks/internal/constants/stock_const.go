@@ -2,19 +2,45 @@ package constants
 
 import (
 	"errors"
+	"math"
 	"time"
 )
 
 var (
-	ErrNotFound       = errors.New("not found")
-	ErrInvalidSKU     = errors.New("invalid sku")
-	ErrNotRowAffected = errors.New("not row affected")
-	ErrAlreadyAdded   = errors.New("already added")
-	ErrUnknownType    = errors.New("unknown event type")
+	ErrNotFound            = errors.New("not found")
+	ErrInvalidSKU          = errors.New("invalid sku")
+	ErrNotRowAffected      = errors.New("not row affected")
+	ErrNotSellerOwner      = errors.New("seller belongs to another user")
+	ErrSellerExists        = errors.New("user already has a seller")
+	ErrUnknownType         = errors.New("unknown event type")
+	ErrIdempotencyReused   = errors.New("idempotency key was already used with a different payload")
+	ErrIdempotencyPending  = errors.New("request with this idempotency key is still in progress")
+	ErrIdempotencyKeyLong  = errors.New("idempotency key is too long")
+	ErrRateLimited         = errors.New("rate limit exceeded")
+	ErrAdminRequired       = errors.New("admin api key required")
+	ErrInternalRequired    = errors.New("internal api key required")
+	ErrInvalidPageToken    = errors.New("invalid page token")
+	ErrInsufficientStock   = errors.New("insufficient stock")
+	ErrReservationReleased = errors.New("reservation released")
+	ErrCountOutOfRange     = errors.New("offer count out of range")
+	ErrSKUNameTaken        = errors.New("sku name is taken by another sku")
 )
 
 const (
 	InternalServerErrMessage = "Something went wrong in server!"
 	ServerTimeout            = 5 * time.Second
 	ReadTimeout              = 3 * time.Second
+	IdempotencyKeyHeader     = "idempotency-key"
+	IdempotencyReplayHeader  = "idempotent-replayed"
+	IdempotencyKeyMaxLength  = 255
+	StockChangesChannel      = "stock_changes"
+	ListenRetryInterval      = time.Second
+	APIKeyHeader             = "x-api-key"
+	ForwardedForHeader       = "x-forwarded-for"
+	RetryAfterHeader         = "Retry-After"
+	RateLimitMaxBuckets      = 100_000
+	ConfigReloadDelay        = 200 * time.Millisecond
+	RequestIDHeader          = "x-request-id"
+	RequestIDMaxLength       = 128
+	MaxOfferCount            = math.MaxInt32
 )
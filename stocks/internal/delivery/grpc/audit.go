@@ -0,0 +1,285 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"stocks/internal/constants"
+	"stocks/internal/domainerr"
+	"stocks/internal/models"
+	"stocks/internal/service"
+	stocksapi "stocks/pkg/api/stocks"
+	"stocks/pkg/log"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Entity types of audit events. The entity id is the SKU for stock and SKU events and
+// the seller id for seller events.
+const (
+	auditEntityStock  = "stock"
+	auditEntitySKU    = "sku"
+	auditEntitySeller = "seller"
+)
+
+// auditedMethods lists the mutating RPCs recorded in the audit log.
+var auditedMethods = map[string]struct{}{
+	stocksapi.StockService_AddStock_FullMethodName:       {},
+	stocksapi.StockService_DeleteStock_FullMethodName:    {},
+	stocksapi.StockService_RestoreStock_FullMethodName:   {},
+	stocksapi.StockService_CreateSeller_FullMethodName:   {},
+	stocksapi.StockService_TransferSeller_FullMethodName: {},
+	stocksapi.StockService_AdjustStock_FullMethodName:    {},
+	stocksapi.StockService_ImportSKUs_FullMethodName:     {},
+}
+
+// adminMethods lists the RPCs that require an admin API key.
+var adminMethods = map[string]struct{}{
+	stocksapi.StockService_ListAuditEvents_FullMethodName: {},
+	stocksapi.StockService_AdjustStock_FullMethodName:     {},
+	stocksapi.StockService_ImportSKUs_FullMethodName:      {},
+}
+
+// internalMethods lists the RPCs only other services may call, with one of the
+// internal API keys. They change stock for checkouts and are not on the gateway.
+var internalMethods = map[string]struct{}{
+	stocksapi.StockService_ReserveStock_FullMethodName:       {},
+	stocksapi.StockService_CommitReservation_FullMethodName:  {},
+	stocksapi.StockService_ReleaseReservation_FullMethodName: {},
+}
+
+// includeDeletedGetter is implemented by the read requests that can list deleted offers,
+// which is reserved to admins.
+type includeDeletedGetter interface {
+	GetIncludeDeleted() bool
+}
+
+type skuGetter interface {
+	GetSku() uint32
+}
+
+type sellerGetter interface {
+	GetSeller() *stocksapi.Seller
+}
+
+type dryRunGetter interface {
+	GetDryRun() bool
+}
+
+// auditTarget is the entity an audited call changes.
+type auditTarget struct {
+	entityType string
+	sku        uint32
+	sellerID   int64
+}
+
+func (t auditTarget) entityID() string {
+	if t.entityType == auditEntitySeller {
+		return strconv.FormatInt(t.sellerID, 10)
+	}
+
+	return strconv.FormatUint(uint64(t.sku), 10)
+}
+
+// auditTargets returns the entities an audited call changes: the SKU of a stock call, each
+// adjusted SKU of AdjustStock once, each imported SKU of ImportSKUs once, and the seller
+// of a seller call.
+func auditTargets(req interface{}) []auditTarget {
+	switch req := req.(type) {
+	case *stocksapi.ImportSKUsRequest:
+		targets := make([]auditTarget, 0, len(req.GetSkus()))
+		seen := make(map[uint32]struct{}, len(req.GetSkus()))
+
+		for _, sku := range req.GetSkus() {
+			if _, ok := seen[sku.GetSku()]; ok {
+				continue
+			}
+
+			seen[sku.GetSku()] = struct{}{}
+			targets = append(targets, auditTarget{entityType: auditEntitySKU, sku: sku.GetSku()})
+		}
+
+		return targets
+	case *stocksapi.AdjustStockRequest:
+		targets := make([]auditTarget, 0, len(req.GetAdjustments()))
+		seen := make(map[uint32]struct{}, len(req.GetAdjustments()))
+
+		for _, adjustment := range req.GetAdjustments() {
+			if _, ok := seen[adjustment.GetSku()]; ok {
+				continue
+			}
+
+			seen[adjustment.GetSku()] = struct{}{}
+			targets = append(targets, auditTarget{entityType: auditEntityStock, sku: adjustment.GetSku()})
+		}
+
+		return targets
+	case skuGetter:
+		return []auditTarget{{entityType: auditEntityStock, sku: req.GetSku()}}
+	case *stocksapi.TransferSellerRequest:
+		return []auditTarget{{entityType: auditEntitySeller, sellerID: req.GetSellerId()}}
+	default:
+		return []auditTarget{{entityType: auditEntitySeller}}
+	}
+}
+
+// snapshot returns the state of the entity, nil for a SKU or seller that is not created
+// yet.
+func (t auditTarget) snapshot(ctx context.Context, audit service.AuditService) ([]byte, error) {
+	switch t.entityType {
+	case auditEntityStock:
+		return audit.SnapshotStock(ctx, t.sku)
+	case auditEntitySKU:
+		return audit.SnapshotSKU(ctx, t.sku)
+	}
+
+	if t.sellerID == 0 {
+		return nil, nil
+	}
+
+	return audit.SnapshotSeller(ctx, t.sellerID)
+}
+
+// grpcAuditInterceptor records every audited call with the entity state before and after
+// it, one event per changed entity. It runs after the idempotency interceptor, so
+// replayed responses are not recorded twice. Dry runs change nothing and are not
+// recorded. Failing to record is logged and does not fail the call.
+func grpcAuditInterceptor(audit service.AuditService, logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, ok := auditedMethods[info.FullMethod]; !ok {
+			return handler(ctx, req)
+		}
+
+		r, ok := req.(userIDGetter)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		if dryRun, ok := req.(dryRunGetter); ok && dryRun.GetDryRun() {
+			return handler(ctx, req)
+		}
+
+		targets := auditTargets(req)
+
+		logger := logger.FromContext(ctx)
+
+		requestHash, err := hashRequest(req)
+		if err != nil {
+			logger.Errorf("err in hash audited request: %v", err)
+		}
+
+		before := make([][]byte, len(targets))
+		for i, target := range targets {
+			before[i], err = target.snapshot(ctx, audit)
+			if err != nil {
+				logger.Errorf("err in snapshot %s before audited call: %v", target.entityType, err)
+			}
+		}
+
+		resp, handlerErr := handler(ctx, req)
+
+		recordCtx := context.WithoutCancel(ctx)
+
+		for i, target := range targets {
+			// A created seller is only known from the response.
+			if created, ok := resp.(sellerGetter); ok && target.sellerID == 0 && created.GetSeller() != nil {
+				target.sellerID = created.GetSeller().GetId()
+			}
+
+			event := models.AuditEvent{
+				ActorID:     r.GetUserId(),
+				Method:      info.FullMethod,
+				EntityType:  target.entityType,
+				EntityID:    target.entityID(),
+				RequestHash: requestHash,
+				Before:      before[i],
+				Status:      codeName(status.Convert(handlerErr)),
+				TraceID:     traceIDFromContext(ctx),
+			}
+
+			if handlerErr == nil {
+				event.After, err = target.snapshot(recordCtx, audit)
+				if err != nil {
+					logger.Errorf("err in snapshot %s after audited call: %v", target.entityType, err)
+				}
+			}
+
+			if err := audit.Record(recordCtx, event); err != nil {
+				logger.Errorf("err in record audit event: %v", err)
+			}
+		}
+
+		return resp, handlerErr
+	}
+}
+
+// grpcAdminInterceptor rejects admin RPCs and requests with include_deleted unless
+// x-api-key is one of adminKeys, and internal RPCs unless it is one of internalKeys.
+// With no keys configured they are disabled.
+func grpcAdminInterceptor(adminKeys, internalKeys []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, internal := internalMethods[info.FullMethod]; internal {
+			if !isAdminKey(ctx, internalKeys) {
+				return nil, toStatusError(constants.ErrInternalRequired)
+			}
+
+			return handler(ctx, req)
+		}
+
+		_, admin := adminMethods[info.FullMethod]
+		if r, ok := req.(includeDeletedGetter); ok && r.GetIncludeDeleted() {
+			admin = true
+		}
+
+		if !admin {
+			return handler(ctx, req)
+		}
+
+		if !isAdminKey(ctx, adminKeys) {
+			return nil, toStatusError(constants.ErrAdminRequired)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func isAdminKey(ctx context.Context, adminKeys []string) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get(constants.APIKeyHeader)
+	if len(values) == 0 {
+		return false
+	}
+
+	return matchesAdminKey(values[0], adminKeys)
+}
+
+func matchesAdminKey(value string, adminKeys []string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, key := range adminKeys {
+		if subtle.ConstantTimeCompare([]byte(value), []byte(key)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AdminOnly guards an HTTP endpoint like admin RPCs: the X-Api-Key header has to be one
+// of adminKeys, and with no keys configured the endpoint is disabled.
+func AdminOnly(next http.Handler, adminKeys []string, logger log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !matchesAdminKey(r.Header.Get(constants.APIKeyHeader), adminKeys) {
+			writeProblem(w, domainProblem(domainerr.From(constants.ErrAdminRequired), r.URL.Path), logger)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
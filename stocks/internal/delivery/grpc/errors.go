@@ -0,0 +1,79 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"stocks/internal/constants"
+	"stocks/internal/domainerr"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the google.rpc.ErrorInfo domain of stocks errors.
+const errorDomain = "stocks.StockService"
+
+var kindCodes = map[domainerr.Kind]codes.Code{
+	domainerr.KindInternal:           codes.Internal,
+	domainerr.KindInvalidArgument:    codes.InvalidArgument,
+	domainerr.KindNotFound:           codes.NotFound,
+	domainerr.KindAlreadyExists:      codes.AlreadyExists,
+	domainerr.KindFailedPrecondition: codes.FailedPrecondition,
+	domainerr.KindAborted:            codes.Aborted,
+	domainerr.KindResourceExhausted:  codes.ResourceExhausted,
+	domainerr.KindUnavailable:        codes.Unavailable,
+	domainerr.KindPermissionDenied:   codes.PermissionDenied,
+}
+
+// toStatusError converts a service error into a gRPC status with an ErrorInfo detail,
+// plus a PreconditionFailure detail for failed preconditions. Unexpected errors become
+// INTERNAL without their message.
+func toStatusError(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	domainErr := domainerr.From(err)
+	if domainErr == nil {
+		domainErr = &domainerr.Error{
+			Kind:    domainerr.KindInternal,
+			Reason:  domainerr.ReasonInternal,
+			Message: constants.InternalServerErrMessage,
+		}
+	}
+
+	return domainStatus(domainErr).Err()
+}
+
+func domainStatus(domainErr *domainerr.Error) *status.Status {
+	st := status.New(kindCodes[domainErr.Kind], domainErr.Message)
+
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{
+			Reason:   string(domainErr.Reason),
+			Domain:   errorDomain,
+			Metadata: domainErr.Metadata,
+		},
+	}
+
+	if len(domainErr.Violations) > 0 {
+		failure := &errdetails.PreconditionFailure{}
+		for _, v := range domainErr.Violations {
+			failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+				Type:        v.Type,
+				Subject:     v.Subject,
+				Description: v.Description,
+			})
+		}
+
+		details = append(details, failure)
+	}
+
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+
+	return st
+}
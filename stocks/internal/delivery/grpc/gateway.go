@@ -3,12 +3,16 @@ package grpcserver
 import (
 	"context"
 	"net/http"
+	"net/textproto"
+	"stocks/internal/constants"
 	"stocks/pkg/log"
 	"stocks/pkg/metrics"
+	"stocks/pkg/ratelimit"
 
 	stocksapi "stocks/pkg/api/stocks"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -24,13 +28,15 @@ type Gateway interface {
 	Shutdown(ctx context.Context) error
 }
 
-func NewGateway(ctx context.Context, grpcPort, gatewayPort string, logger log.Logger, m metrics.Metrics) (Gateway, error) {
+func NewGateway(ctx context.Context, grpcPort, gatewayPort string, limiter *ratelimit.Limiter, callers *Callers, logger log.Logger, m metrics.Metrics) (Gateway, error) {
 	mux := runtime.NewServeMux(
 		runtime.WithErrorHandler(ErrorMiddleware(logger, m)),
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
 	)
 
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	}
 
 	err := stocksapi.RegisterStockServiceHandlerFromEndpoint(ctx, mux, "localhost:"+grpcPort, opts)
@@ -38,8 +44,13 @@ func NewGateway(ctx context.Context, grpcPort, gatewayPort string, logger log.Lo
 		return nil, err
 	}
 
-	metricsWrapped := MetricsMiddleware(mux, m)
-	otelHandler := otelhttp.NewHandler(metricsWrapped, "stocks-grpc-gateway")
+	var handler http.Handler = mux
+	if limiter != nil {
+		handler = RateLimitMiddleware(handler, limiter, callers, m, logger)
+	}
+
+	metricsWrapped := MetricsMiddleware(handler, m)
+	otelHandler := otelhttp.NewHandler(RequestIDMiddleware(metricsWrapped), "stocks-grpc-gateway")
 
 	return &Server{
 		server: &http.Server{
@@ -50,6 +61,21 @@ func NewGateway(ctx context.Context, grpcPort, gatewayPort string, logger log.Lo
 	}, nil
 }
 
+// incomingHeaderMatcher forwards the Idempotency-Key, X-Api-Key and X-Request-Id headers to the gRPC
+// server in addition to the headers accepted by runtime.DefaultHeaderMatcher.
+func incomingHeaderMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case textproto.CanonicalMIMEHeaderKey(constants.IdempotencyKeyHeader):
+		return constants.IdempotencyKeyHeader, true
+	case textproto.CanonicalMIMEHeaderKey(constants.APIKeyHeader):
+		return constants.APIKeyHeader, true
+	case textproto.CanonicalMIMEHeaderKey(constants.RequestIDHeader):
+		return constants.RequestIDHeader, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
 func (g *Server) Run() error {
 	return g.server.ListenAndServe()
 }
@@ -3,8 +3,10 @@ package grpcserver
 import (
 	"context"
 	"stocks/pkg/log"
+	"stocks/pkg/metrics"
+	"time"
 
-	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -12,16 +14,33 @@ import (
 
 func grpcLoggingInterceptor(logger log.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		ctx, span := otel.Tracer("stocks-grpc-server").Start(ctx, info.FullMethod)
-		defer span.End()
-
 		resp, err := handler(ctx, req)
 
-		var traceID string
-		if span.SpanContext().IsValid() {
-			traceID = span.SpanContext().TraceID().String()
+		if err != nil {
+			st, ok := status.FromError(err)
+			statusCode := codes.Unknown
+			errMsg := err.Error()
+
+			if ok {
+				statusCode = st.Code()
+				errMsg = st.Message()
+			}
+
+			// The server span is started by the otelgrpc stats handler from the incoming trace context.
+			logger.FromContext(ctx).Error("gRPC call failed",
+				log.String("method", info.FullMethod),
+				log.Any("status", statusCode),
+				log.String("error", errMsg),
+			)
 		}
 
+		return resp, err
+	}
+}
+
+func grpcStreamLoggingInterceptor(logger log.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
 		if err != nil {
 			st, ok := status.FromError(err)
 			statusCode := codes.Unknown
@@ -32,14 +51,52 @@ func grpcLoggingInterceptor(logger log.Logger) grpc.UnaryServerInterceptor {
 				errMsg = st.Message()
 			}
 
-			logger.Error("gRPC call failed",
+			if statusCode == codes.Canceled {
+				return err
+			}
+
+			logger.FromContext(ss.Context()).Error("gRPC stream failed",
 				log.String("method", info.FullMethod),
 				log.Any("status", statusCode),
-				log.String("trace_id", traceID),
 				log.String("error", errMsg),
 			)
 		}
 
+		return err
+	}
+}
+
+func traceIDFromContext(ctx context.Context) string {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return ""
+	}
+
+	return spanCtx.TraceID().String()
+}
+
+// grpcMetricsInterceptor records latency and status code of every unary call, covering
+// direct gRPC callers as well as the gateway.
+func grpcMetricsInterceptor(m metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		m.ObserveGRPC(info.FullMethod, status.Code(err).String(), time.Since(start).Seconds())
+
 		return resp, err
 	}
 }
+
+func grpcStreamMetricsInterceptor(m metrics.Metrics) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		m.ObserveGRPC(info.FullMethod, status.Code(err).String(), time.Since(start).Seconds())
+
+		return err
+	}
+}
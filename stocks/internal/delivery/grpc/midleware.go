@@ -6,11 +6,13 @@ import (
 	"strconv"
 	"time"
 
+	"stocks/internal/constants"
 	"stocks/pkg/log"
 	"stocks/pkg/metrics"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -56,8 +58,9 @@ func MetricsMiddleware(next http.Handler, m metrics.Metrics) http.Handler {
 	})
 }
 
+// ErrorMiddleware answers failed gateway calls with an RFC 7807 problem+json body.
 func ErrorMiddleware(logger log.Logger, m metrics.Metrics) runtime.ErrorHandlerFunc {
-	return func(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	return func(ctx context.Context, mux *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
 		s, ok := status.FromError(err)
 		if !ok {
 			s = status.New(codes.Unknown, err.Error())
@@ -71,28 +74,25 @@ func ErrorMiddleware(logger log.Logger, m metrics.Metrics) runtime.ErrorHandlerF
 			traceID = span.SpanContext().TraceID().String()
 		}
 
-		logger.Error("gRPC-Gateway error",
+		logger.FromContext(ctx).Error("gRPC-Gateway error",
 			log.String("method", r.Method),
 			log.String("path", r.URL.Path),
 			log.Int("status", httpStatus),
-			log.String("trace_id", traceID),
 			log.String("error", s.Message()),
 		)
 
-		w.Header().Set("Content-Type", marshaler.ContentType("application/json"))
-		w.WriteHeader(runtime.HTTPStatusFromCode(s.Code()))
-
-		response := map[string]interface{}{
-			"error": s.Message(),
-			"code":  s.Code(),
-		}
-
-		if details := s.Details(); len(details) > 0 {
-			response["details"] = details
-		}
+		setRetryAfter(w, s)
+		writeProblem(w, problemFromStatus(s, r.URL.Path, traceID), logger)
+	}
+}
 
-		if err := marshaler.NewEncoder(w).Encode(response); err != nil {
-			logger.Errorf("Failed to encode error response: %v", err)
+// setRetryAfter turns a RetryInfo detail, sent with rate limited calls, into the
+// Retry-After header.
+func setRetryAfter(w http.ResponseWriter, s *status.Status) {
+	for _, detail := range s.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			w.Header().Set(constants.RetryAfterHeader, retryAfterSeconds(info.GetRetryDelay().AsDuration()))
+			return
 		}
 	}
 }
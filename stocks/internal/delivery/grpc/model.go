@@ -1,8 +1,14 @@
 package grpcserver
 
 import (
+	"stocks/internal/constants"
 	"stocks/internal/models"
 	stocksapi "stocks/pkg/api/stocks"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func ToAddStockModel(req *stocksapi.AddStockRequest) models.StockItem {
@@ -12,36 +18,243 @@ func ToAddStockModel(req *stocksapi.AddStockRequest) models.StockItem {
 		Count:    req.Count,
 		Price:    req.Price,
 		Location: req.Location,
+		SellerID: req.SellerId,
+	}
+}
+
+func ToDeleteStockModel(req *stocksapi.DeleteStockRequest) models.StockOfferParams {
+	return models.StockOfferParams{
+		UserID:   req.UserId,
+		SellerID: req.SellerId,
+		SKU:      req.Sku,
+	}
+}
+
+func ToRestoreStockModel(req *stocksapi.RestoreStockRequest) models.StockOfferParams {
+	return models.StockOfferParams{
+		UserID:   req.UserId,
+		SellerID: req.SellerId,
+		SKU:      req.Sku,
+	}
+}
+
+func ToGetStockModel(req *stocksapi.GetStockRequest) models.GetStockParams {
+	return models.GetStockParams{
+		SKU:            req.Sku,
+		SellerID:       req.SellerId,
+		IncludeDeleted: req.IncludeDeleted,
 	}
 }
 
 func ToListStocksModel(req *stocksapi.ListStocksByLocationRequest) models.ListStockParams {
 	return models.ListStockParams{
-		UserID:      req.UserId,
-		Location:    req.Location,
-		PageSize:    req.PageSize,
-		CurrentPage: req.CurrentPage,
+		UserID:         req.UserId,
+		Location:       req.Location,
+		PageSize:       req.PageSize,
+		CurrentPage:    req.CurrentPage,
+		IncludeDeleted: req.IncludeDeleted,
 	}
 }
 
-func ToListStocksResponse(domain models.ListStock) *stocksapi.ListStocksByLocationResponse {
-	items := make([]*stocksapi.StockItem, 0, len(domain.Items))
+func ToStockItemResponse(item models.StockItem) *stocksapi.StockItem {
+	resp := &stocksapi.StockItem{
+		Sku:      item.SKU,
+		Name:     item.Name,
+		Type:     item.Type,
+		Count:    item.Count,
+		Price:    item.Price,
+		Location: item.Location,
+		SellerId: item.SellerID,
+	}
 
-	for _, item := range domain.Items {
-		items = append(items, &stocksapi.StockItem{
-			Sku:      item.SKU,
-			Name:     item.Name,
-			Type:     item.Type,
-			Count:    item.Count,
-			Price:    item.Price,
-			Location: item.Location,
-		})
+	if item.DeletedAt != nil {
+		resp.DeletedAt = timestamppb.New(*item.DeletedAt)
+	}
+
+	return resp
+}
+
+func ToStockItemsResponse(domain []models.StockItem) []*stocksapi.StockItem {
+	items := make([]*stocksapi.StockItem, 0, len(domain))
+
+	for _, item := range domain {
+		items = append(items, ToStockItemResponse(item))
 	}
 
+	return items
+}
+
+func ToListStocksResponse(domain models.ListStock) *stocksapi.ListStocksByLocationResponse {
 	return &stocksapi.ListStocksByLocationResponse{
-		Items:      items,
+		Items:      ToStockItemsResponse(domain.Items),
 		TotalCount: domain.TotalCount,
 		PageNumber: domain.PageNumber,
 		TotalPages: domain.TotalPages,
 	}
 }
+
+func ToStockChangeResponse(change models.StockChange) *stocksapi.StockChange {
+	return &stocksapi.StockChange{
+		Sku:      change.SKU,
+		SellerId: change.SellerID,
+		Count:    change.Count,
+		Price:    change.Price,
+		Deleted:  change.Deleted,
+	}
+}
+
+func ToSellerModel(req *stocksapi.CreateSellerRequest) models.Seller {
+	return models.Seller{
+		UserID: req.UserId,
+		Name:   req.Name,
+	}
+}
+
+func ToTransferSellerModel(req *stocksapi.TransferSellerRequest) models.TransferSeller {
+	return models.TransferSeller{
+		UserID:    req.UserId,
+		SellerID:  req.SellerId,
+		NewUserID: req.NewUserId,
+	}
+}
+
+func ToSellerResponse(seller models.Seller) *stocksapi.Seller {
+	return &stocksapi.Seller{
+		Id:     seller.ID,
+		UserId: seller.UserID,
+		Name:   seller.Name,
+	}
+}
+
+func ToReservationModel(req *stocksapi.ReserveStockRequest) models.Reservation {
+	reservation := models.Reservation{
+		CheckoutID: req.CheckoutId,
+		Items:      make([]models.ReservationItem, 0, len(req.Items)),
+	}
+
+	for _, item := range req.Items {
+		reservation.Items = append(reservation.Items, models.ReservationItem{
+			SKU:      item.Sku,
+			SellerID: item.SellerId,
+			Count:    item.Count,
+		})
+	}
+
+	return reservation
+}
+
+func ToAdjustStockModel(req *stocksapi.AdjustStockRequest) models.AdjustStock {
+	params := models.AdjustStock{
+		UserID:      req.UserId,
+		Adjustments: make([]models.StockAdjustment, 0, len(req.Adjustments)),
+		DryRun:      req.DryRun,
+	}
+
+	for _, adjustment := range req.Adjustments {
+		params.Adjustments = append(params.Adjustments, models.StockAdjustment{
+			SKU:      adjustment.Sku,
+			SellerID: adjustment.SellerId,
+			Delta:    adjustment.Delta,
+		})
+	}
+
+	return params
+}
+
+func ToImportSKUsModel(req *stocksapi.ImportSKUsRequest) models.ImportSKUs {
+	params := models.ImportSKUs{
+		UserID: req.UserId,
+		SKUs:   make([]models.SKU, 0, len(req.Skus)),
+		DryRun: req.DryRun,
+	}
+
+	for _, sku := range req.Skus {
+		params.SKUs = append(params.SKUs, models.SKU{SKUID: sku.Sku, Name: sku.Name, Type: sku.Type})
+	}
+
+	return params
+}
+
+func ToImportSKUsResponse(imported []models.ImportedSKU) *stocksapi.ImportSKUsResponse {
+	resp := &stocksapi.ImportSKUsResponse{Skus: make([]*stocksapi.ImportedSKU, 0, len(imported))}
+
+	for _, sku := range imported {
+		resp.Skus = append(resp.Skus, &stocksapi.ImportedSKU{
+			Sku:     &stocksapi.CatalogSKU{Sku: sku.SKUID, Name: sku.Name, Type: sku.Type},
+			Created: sku.Created,
+		})
+	}
+
+	return resp
+}
+
+func ToAuditFilter(req *stocksapi.ListAuditEventsRequest) (models.AuditFilter, error) {
+	filter := models.AuditFilter{
+		ActorID:    req.ActorId,
+		EntityType: req.EntityType,
+		EntityID:   req.EntityId,
+		Limit:      int(req.PageSize),
+	}
+
+	if req.From != nil {
+		from := req.From.AsTime()
+		filter.From = &from
+	}
+
+	if req.To != nil {
+		to := req.To.AsTime()
+		filter.To = &to
+	}
+
+	if req.PageToken != "" {
+		beforeID, err := strconv.ParseInt(req.PageToken, 10, 64)
+		if err != nil || beforeID <= 0 {
+			return models.AuditFilter{}, constants.ErrInvalidPageToken
+		}
+
+		filter.BeforeID = beforeID
+	}
+
+	return filter, nil
+}
+
+func ToListAuditEventsResponse(page models.AuditEventsPage) *stocksapi.ListAuditEventsResponse {
+	events := make([]*stocksapi.AuditEvent, 0, len(page.Events))
+
+	for _, event := range page.Events {
+		events = append(events, &stocksapi.AuditEvent{
+			Id:          event.ID,
+			ActorId:     event.ActorID,
+			Method:      event.Method,
+			EntityType:  event.EntityType,
+			EntityId:    event.EntityID,
+			RequestHash: event.RequestHash,
+			Before:      toStruct(event.Before),
+			After:       toStruct(event.After),
+			Status:      event.Status,
+			TraceId:     event.TraceID,
+			CreatedAt:   timestamppb.New(event.CreatedAt),
+		})
+	}
+
+	resp := &stocksapi.ListAuditEventsResponse{Events: events}
+	if page.NextCursor > 0 {
+		resp.NextPageToken = strconv.FormatInt(page.NextCursor, 10)
+	}
+
+	return resp
+}
+
+// toStruct decodes a JSON snapshot, returning nil when there is none.
+func toStruct(data []byte) *structpb.Struct {
+	if len(data) == 0 {
+		return nil
+	}
+
+	s := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, s); err != nil {
+		return nil
+	}
+
+	return s
+}
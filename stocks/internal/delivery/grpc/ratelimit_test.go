@@ -0,0 +1,64 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"reflect"
+	stocksapi "stocks/pkg/api/stocks"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// TestGRPCCallers covers the callers of stocks requests. Address and admin key handling
+// is shared with cart and tested there.
+func TestGRPCCallers(t *testing.T) {
+	callers, err := NewCallers([]string{"admin-key"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &net.TCPAddr{IP: net.IPv4(203, 0, 113, 7), Port: 40000}
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		req  interface{}
+		want []caller
+	}{
+		{
+			name: "seller calls are limited per address and user",
+			req:  &stocksapi.AddStockRequest{UserId: 1},
+			want: []caller{{keyType: keyTypeIP, key: "203.0.113.7"}, {keyType: keyTypeUser, key: "1"}},
+		},
+		{
+			name: "admin adjustments use the admin key bucket",
+			md:   metadata.Pairs("x-api-key", "admin-key"),
+			req:  &stocksapi.AdjustStockRequest{UserId: 1},
+			want: []caller{{keyType: keyTypeAPIKey, key: "admin-key"}},
+		},
+		{
+			name: "reservations are limited per calling service address",
+			md:   metadata.Pairs("x-api-key", "internal-key"),
+			req:  &stocksapi.ReserveStockRequest{CheckoutId: "7"},
+			want: []caller{{keyType: keyTypeIP, key: "203.0.113.7"}},
+		},
+		{
+			name: "reads without a user",
+			req:  &stocksapi.ListOffersRequest{Sku: 1001},
+			want: []caller{{keyType: keyTypeIP, key: "203.0.113.7"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			ctx = peer.NewContext(ctx, &peer.Peer{Addr: client})
+
+			if got := callers.grpcCallers(ctx, tt.req); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("grpcCallers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
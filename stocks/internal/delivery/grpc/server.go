@@ -2,15 +2,20 @@ package grpcserver
 
 import (
 	"context"
-	"errors"
-	"stocks/internal/constants"
+	"stocks/internal/models"
+	"stocks/internal/repository/interfaces"
 	"stocks/internal/service"
 	stocksapi "stocks/pkg/api/stocks"
 	"stocks/pkg/log"
+	"stocks/pkg/metrics"
+	"stocks/pkg/ratelimit"
+	"time"
 
+	"buf.build/go/protovalidate"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
@@ -18,20 +23,43 @@ import (
 type grpcServer struct {
 	stocksapi.UnimplementedStockServiceServer
 	service service.StockService
+	audit   service.AuditService
 	logger  log.Logger
 }
 
-func NewGRPCServer(svc service.StockService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(svc service.StockService, audit service.AuditService, adminKeys, internalKeys []string, idempotencyRepo interfaces.IdempotencyRepository, idempotencyTTL time.Duration, m metrics.Metrics, limiter *ratelimit.Limiter, callers *Callers, healthSrv healthgrpc.HealthServer, logger log.Logger) *grpc.Server {
 	grpcServer := &grpcServer{
 		service: svc,
+		audit:   audit,
 		logger:  logger,
 	}
 
+	unary := []grpc.UnaryServerInterceptor{
+		grpcRequestIDInterceptor(),
+		grpcMetricsInterceptor(m),
+		grpcLoggingInterceptor(logger),
+		grpcRateLimitInterceptor(limiter, callers, m),
+		grpcAdminInterceptor(adminKeys, internalKeys),
+		grpcValidationInterceptor(protovalidate.GlobalValidator, logger),
+		grpcIdempotencyInterceptor(idempotencyRepo, idempotencyTTL, logger),
+		grpcAuditInterceptor(audit, logger),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		grpcStreamRequestIDInterceptor(),
+		grpcStreamMetricsInterceptor(m),
+		grpcStreamLoggingInterceptor(logger),
+		grpcStreamRateLimitInterceptor(limiter, callers, m),
+		grpcStreamValidationInterceptor(protovalidate.GlobalValidator, logger),
+	}
+
 	srv := grpc.NewServer(
-		grpc.UnaryInterceptor(grpcLoggingInterceptor(logger)),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
 	)
 
 	reflection.Register(srv)
+	healthgrpc.RegisterHealthServer(srv, healthSrv)
 
 	stocksapi.RegisterStockServiceServer(srv, grpcServer)
 
@@ -42,19 +70,9 @@ func (s *grpcServer) AddStock(ctx context.Context, req *stocksapi.AddStockReques
 	ctx, span := otel.Tracer("stocks-handler").Start(ctx, "grpcServer.AddStock")
 	defer span.End()
 
-	if err := ValidateAddStock(req); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
-	}
-
 	err := s.service.AddItem(ctx, ToAddStockModel(req))
 	if err != nil {
-		if errors.Is(err, constants.ErrAlreadyAdded) {
-			return nil, status.Error(codes.AlreadyExists, err.Error())
-		} else if errors.Is(err, constants.ErrInvalidSKU) {
-			return nil, status.Error(codes.InvalidArgument, err.Error())
-		}
-
-		return nil, status.Error(codes.Internal, constants.InternalServerErrMessage)
+		return nil, toStatusError(err)
 	}
 
 	return &stocksapi.AddStockResponse{Message: "Stock item succesfully created"}, nil
@@ -64,33 +82,33 @@ func (s *grpcServer) DeleteStock(ctx context.Context, req *stocksapi.DeleteStock
 	ctx, span := otel.Tracer("stocks-handler").Start(ctx, "grpcServer.DeleteStock")
 	defer span.End()
 
-	if err := ValidateDeleteStock(req); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+	err := s.service.DeleteItem(ctx, ToDeleteStockModel(req))
+	if err != nil {
+		return nil, toStatusError(err)
 	}
 
-	err := s.service.DeleteItem(ctx, req.Sku)
-	if err != nil {
-		if errors.Is(err, constants.ErrNotFound) {
-			return nil, status.Error(codes.NotFound, err.Error())
-		}
+	return &stocksapi.DeleteStockResponse{Message: "Stock deleted successfully"}, nil
+}
 
-		return nil, status.Error(codes.Internal, constants.InternalServerErrMessage)
+func (s *grpcServer) RestoreStock(ctx context.Context, req *stocksapi.RestoreStockRequest) (*stocksapi.RestoreStockResponse, error) {
+	ctx, span := otel.Tracer("stocks-handler").Start(ctx, "grpcServer.RestoreStock")
+	defer span.End()
+
+	err := s.service.RestoreItem(ctx, ToRestoreStockModel(req))
+	if err != nil {
+		return nil, toStatusError(err)
 	}
 
-	return &stocksapi.DeleteStockResponse{Message: "Stock deleted successfully"}, nil
+	return &stocksapi.RestoreStockResponse{Message: "Stock restored successfully"}, nil
 }
 
 func (s *grpcServer) ListStocksByLocation(ctx context.Context, req *stocksapi.ListStocksByLocationRequest) (*stocksapi.ListStocksByLocationResponse, error) {
 	ctx, span := otel.Tracer("stocks-handler").Start(ctx, "grpcServer.ListStocksByLocation")
 	defer span.End()
 
-	if err := ValidateListStocks(req); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
-	}
-
 	result, err := s.service.ListByLocation(ctx, ToListStocksModel(req))
 	if err != nil {
-		return nil, status.Error(codes.Internal, constants.InternalServerErrMessage)
+		return nil, toStatusError(err)
 	}
 
 	return ToListStocksResponse(result), nil
@@ -100,27 +118,141 @@ func (s *grpcServer) GetStock(ctx context.Context, req *stocksapi.GetStockReques
 	ctx, span := otel.Tracer("stocks-handler").Start(ctx, "grpcServer.GetStock")
 	defer span.End()
 
-	if err := ValidateGetStock(req); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+	stock, err := s.service.GetItemBySKU(ctx, ToGetStockModel(req))
+	if err != nil {
+		return nil, toStatusError(err)
 	}
 
-	stock, err := s.service.GetItemBySKU(ctx, req.Sku)
+	return &stocksapi.GetStockResponse{Stock: ToStockItemResponse(stock)}, nil
+}
+
+func (s *grpcServer) ListOffers(ctx context.Context, req *stocksapi.ListOffersRequest) (*stocksapi.ListOffersResponse, error) {
+	ctx, span := otel.Tracer("stocks-handler").Start(ctx, "grpcServer.ListOffers")
+	defer span.End()
+
+	offers, err := s.service.ListOffers(ctx, req.Sku, req.IncludeDeleted)
 	if err != nil {
-		if errors.Is(err, constants.ErrNotFound) {
-			return nil, status.Error(codes.NotFound, err.Error())
+		return nil, toStatusError(err)
+	}
+
+	return &stocksapi.ListOffersResponse{Offers: ToStockItemsResponse(offers)}, nil
+}
+
+func (s *grpcServer) CreateSeller(ctx context.Context, req *stocksapi.CreateSellerRequest) (*stocksapi.CreateSellerResponse, error) {
+	ctx, span := otel.Tracer("stocks-handler").Start(ctx, "grpcServer.CreateSeller")
+	defer span.End()
+
+	seller, err := s.service.CreateSeller(ctx, ToSellerModel(req))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &stocksapi.CreateSellerResponse{Seller: ToSellerResponse(seller)}, nil
+}
+
+func (s *grpcServer) TransferSeller(ctx context.Context, req *stocksapi.TransferSellerRequest) (*stocksapi.TransferSellerResponse, error) {
+	ctx, span := otel.Tracer("stocks-handler").Start(ctx, "grpcServer.TransferSeller")
+	defer span.End()
+
+	seller, err := s.service.TransferSeller(ctx, ToTransferSellerModel(req))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &stocksapi.TransferSellerResponse{Seller: ToSellerResponse(seller)}, nil
+}
+
+func (s *grpcServer) WatchStock(req *stocksapi.WatchStockRequest, stream grpc.ServerStreamingServer[stocksapi.StockChange]) error {
+	ctx, span := otel.Tracer("stocks-handler").Start(stream.Context(), "grpcServer.WatchStock")
+	defer span.End()
+
+	err := s.service.WatchStock(ctx, req.Skus, func(change models.StockChange) error {
+		return stream.Send(ToStockChangeResponse(change))
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
 		}
 
-		return nil, status.Error(codes.Internal, constants.InternalServerErrMessage)
+		return toStatusError(err)
+	}
+
+	return nil
+}
+
+func (s *grpcServer) ReserveStock(ctx context.Context, req *stocksapi.ReserveStockRequest) (*stocksapi.ReserveStockResponse, error) {
+	ctx, span := otel.Tracer("stocks-handler").Start(ctx, "grpcServer.ReserveStock")
+	defer span.End()
+
+	err := s.service.ReserveStock(ctx, ToReservationModel(req))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &stocksapi.ReserveStockResponse{}, nil
+}
+
+func (s *grpcServer) CommitReservation(ctx context.Context, req *stocksapi.CommitReservationRequest) (*stocksapi.CommitReservationResponse, error) {
+	ctx, span := otel.Tracer("stocks-handler").Start(ctx, "grpcServer.CommitReservation")
+	defer span.End()
+
+	err := s.service.CommitReservation(ctx, req.CheckoutId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &stocksapi.CommitReservationResponse{}, nil
+}
+
+func (s *grpcServer) ReleaseReservation(ctx context.Context, req *stocksapi.ReleaseReservationRequest) (*stocksapi.ReleaseReservationResponse, error) {
+	ctx, span := otel.Tracer("stocks-handler").Start(ctx, "grpcServer.ReleaseReservation")
+	defer span.End()
+
+	err := s.service.ReleaseReservation(ctx, req.CheckoutId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &stocksapi.ReleaseReservationResponse{}, nil
+}
+
+func (s *grpcServer) AdjustStock(ctx context.Context, req *stocksapi.AdjustStockRequest) (*stocksapi.AdjustStockResponse, error) {
+	ctx, span := otel.Tracer("stocks-handler").Start(ctx, "grpcServer.AdjustStock")
+	defer span.End()
+
+	offers, err := s.service.AdjustStock(ctx, ToAdjustStockModel(req))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &stocksapi.AdjustStockResponse{Offers: ToStockItemsResponse(offers)}, nil
+}
+
+func (s *grpcServer) ImportSKUs(ctx context.Context, req *stocksapi.ImportSKUsRequest) (*stocksapi.ImportSKUsResponse, error) {
+	ctx, span := otel.Tracer("stocks-handler").Start(ctx, "grpcServer.ImportSKUs")
+	defer span.End()
+
+	imported, err := s.service.ImportSKUs(ctx, ToImportSKUsModel(req))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return ToImportSKUsResponse(imported), nil
+}
+
+func (s *grpcServer) ListAuditEvents(ctx context.Context, req *stocksapi.ListAuditEventsRequest) (*stocksapi.ListAuditEventsResponse, error) {
+	ctx, span := otel.Tracer("stocks-handler").Start(ctx, "grpcServer.ListAuditEvents")
+	defer span.End()
+
+	filter, err := ToAuditFilter(req)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	page, err := s.audit.ListAuditEvents(ctx, filter)
+	if err != nil {
+		return nil, toStatusError(err)
 	}
 
-	return &stocksapi.GetStockResponse{
-		Stock: &stocksapi.StockItem{
-			Sku:      stock.SKU,
-			Name:     stock.Name,
-			Type:     stock.Type,
-			Count:    stock.Count,
-			Price:    stock.Price,
-			Location: stock.Location,
-		},
-	}, nil
+	return ToListAuditEventsResponse(page), nil
 }
@@ -1,66 +1,87 @@
 package grpcserver
 
 import (
+	"context"
 	"errors"
-	stocksapi "stocks/pkg/api/stocks"
+	"stocks/internal/domainerr"
+	"stocks/pkg/log"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
-func ValidateAddStock(req *stocksapi.AddStockRequest) error {
-	if req.UserId == 0 {
-		return errors.New("user_id is required")
-	}
+// Request rules are declared in stocks.proto with buf.validate annotations.
 
-	if req.Sku == 0 {
-		return errors.New("sku is required")
-	}
+func grpcValidationInterceptor(validator protovalidate.Validator, logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validateRequest(validator, req, logger.FromContext(ctx)); err != nil {
+			return nil, err
+		}
 
-	if req.Count == 0 {
-		return errors.New("count must be greater than 0")
+		return handler(ctx, req)
 	}
+}
 
-	if req.Location == "" {
-		return errors.New("location is required")
+func grpcStreamValidationInterceptor(validator protovalidate.Validator, logger log.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss, validator: validator, logger: logger})
 	}
-
-	return nil
 }
 
-func ValidateDeleteStock(req *stocksapi.DeleteStockRequest) error {
-	if req.UserId == 0 {
-		return errors.New("userId is required")
-	}
+// validatingStream validates every message the client sends on a stream.
+type validatingStream struct {
+	grpc.ServerStream
+	validator protovalidate.Validator
+	logger    log.Logger
+}
 
-	if req.Sku == 0 {
-		return errors.New("sku is required")
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
 	}
 
-	return nil
+	return validateRequest(s.validator, m, s.logger.FromContext(s.Context()))
 }
 
-func ValidateListStocks(req *stocksapi.ListStocksByLocationRequest) error {
-	if req.UserId == 0 {
-		return errors.New("userId is required")
+// validateRequest answers INVALID_ARGUMENT with an ErrorInfo detail and a BadRequest
+// detail that lists every violated field.
+func validateRequest(validator protovalidate.Validator, req interface{}, logger log.Logger) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
 	}
 
-	if req.Location == "" {
-		return errors.New("location is required")
+	err := validator.Validate(msg)
+	if err == nil {
+		return nil
 	}
 
-	if req.PageSize <= 0 {
-		return errors.New("pageSize must be greater than 0")
+	var valErr *protovalidate.ValidationError
+	if !errors.As(err, &valErr) {
+		logger.Errorf("err in validate request: %v", err)
+		return toStatusError(err)
 	}
 
-	if req.CurrentPage <= 0 {
-		return errors.New("currentPage must be greater than 0")
+	badRequest := &errdetails.BadRequest{}
+	messages := make([]string, 0, len(valErr.Violations))
+
+	for _, violation := range valErr.Violations {
+		field := protovalidate.FieldPathString(violation.Proto.GetField())
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: violation.Proto.GetMessage(),
+			Reason:      violation.Proto.GetRuleId(),
+		})
+		messages = append(messages, field+": "+violation.Proto.GetMessage())
 	}
 
-	return nil
-}
-
-func ValidateGetStock(req *stocksapi.GetStockRequest) error {
-	if req.Sku == 0 {
-		return errors.New("SKU must be greater than 0")
+	st := domainStatus(domainerr.Wrap(domainerr.KindInvalidArgument, domainerr.ReasonInvalidRequest, errors.New("invalid request: "+strings.Join(messages, "; "))))
+	if withDetails, err := st.WithDetails(badRequest); err == nil {
+		st = withDetails
 	}
 
-	return nil
+	return st.Err()
 }
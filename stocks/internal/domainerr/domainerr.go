@@ -0,0 +1,205 @@
+package domainerr
+
+import (
+	"errors"
+	"fmt"
+	"stocks/internal/constants"
+	"strconv"
+)
+
+// Kind is the class of a failure. The delivery layer maps it to a gRPC code.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindInvalidArgument
+	KindNotFound
+	KindAlreadyExists
+	KindFailedPrecondition
+	KindAborted
+	KindResourceExhausted
+	KindUnavailable
+	KindPermissionDenied
+)
+
+// Reason is a machine-readable cause, stable across releases.
+type Reason string
+
+const (
+	ReasonInternal            Reason = "INTERNAL"
+	ReasonInvalidRequest      Reason = "INVALID_REQUEST"
+	ReasonNotFound            Reason = "NOT_FOUND"
+	ReasonInvalidSKU          Reason = "INVALID_SKU"
+	ReasonNotSellerOwner      Reason = "NOT_SELLER_OWNER"
+	ReasonSellerExists        Reason = "SELLER_ALREADY_EXISTS"
+	ReasonIdempotencyReused   Reason = "IDEMPOTENCY_KEY_REUSED"
+	ReasonIdempotencyPending  Reason = "IDEMPOTENCY_KEY_IN_PROGRESS"
+	ReasonIdempotencyKeyLong  Reason = "IDEMPOTENCY_KEY_TOO_LONG"
+	ReasonRateLimited         Reason = "RATE_LIMITED"
+	ReasonAdminRequired       Reason = "ADMIN_REQUIRED"
+	ReasonInternalRequired    Reason = "INTERNAL_REQUIRED"
+	ReasonInvalidPageToken    Reason = "INVALID_PAGE_TOKEN"
+	ReasonInsufficientStock   Reason = "INSUFFICIENT_STOCK"
+	ReasonReservationReleased Reason = "RESERVATION_RELEASED"
+	ReasonCountOutOfRange     Reason = "COUNT_OUT_OF_RANGE"
+	ReasonSKUNameTaken        Reason = "SKU_NAME_TAKEN"
+)
+
+// Violation is a precondition that did not hold.
+type Violation struct {
+	Type        string
+	Subject     string
+	Description string
+}
+
+// Error is a domain failure with its reason and details. It wraps the matching
+// sentinel from constants, so errors.Is keeps working on it.
+type Error struct {
+	Kind       Kind
+	Reason     Reason
+	Message    string
+	Metadata   map[string]string
+	Violations []Violation
+	err        error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Wrap creates an error of kind and reason with the message of err.
+func Wrap(kind Kind, reason Reason, err error) *Error {
+	return &Error{
+		Kind:    kind,
+		Reason:  reason,
+		Message: err.Error(),
+		err:     err,
+	}
+}
+
+// WithMetadata adds a key to the ErrorInfo metadata.
+func (e *Error) WithMetadata(key, value string) *Error {
+	if e.Metadata == nil {
+		e.Metadata = make(map[string]string)
+	}
+
+	e.Metadata[key] = value
+
+	return e
+}
+
+// WithViolation adds a failed precondition.
+func (e *Error) WithViolation(typ, subject, description string) *Error {
+	e.Violations = append(e.Violations, Violation{Type: typ, Subject: subject, Description: description})
+	return e
+}
+
+func InvalidSKU(sku uint32) *Error {
+	return Wrap(KindInvalidArgument, ReasonInvalidSKU, constants.ErrInvalidSKU).
+		WithMetadata("sku", strconv.FormatUint(uint64(sku), 10))
+}
+
+func StockNotFound(sku uint32) *Error {
+	return Wrap(KindNotFound, ReasonNotFound, constants.ErrNotFound).
+		WithMetadata("sku", strconv.FormatUint(uint64(sku), 10))
+}
+
+// OfferNotFound reports that the seller has no stock of sku.
+func OfferNotFound(sku uint32, sellerID int64) *Error {
+	return StockNotFound(sku).WithMetadata("seller_id", strconv.FormatInt(sellerID, 10))
+}
+
+func SellerNotFound(sellerID int64) *Error {
+	return Wrap(KindNotFound, ReasonNotFound, constants.ErrNotFound).
+		WithMetadata("seller_id", strconv.FormatInt(sellerID, 10))
+}
+
+// NotSellerOwner reports that the caller tried to act for a seller owned by another user.
+func NotSellerOwner(sellerID int64) *Error {
+	return Wrap(KindPermissionDenied, ReasonNotSellerOwner, constants.ErrNotSellerOwner).
+		WithMetadata("seller_id", strconv.FormatInt(sellerID, 10))
+}
+
+// InsufficientStock reports that the offer has fewer units than requested.
+func InsufficientStock(sku uint32, sellerID int64, requested, available uint32) *Error {
+	skuStr := strconv.FormatUint(uint64(sku), 10)
+
+	return Wrap(KindFailedPrecondition, ReasonInsufficientStock, constants.ErrInsufficientStock).
+		WithMetadata("sku", skuStr).
+		WithMetadata("seller_id", strconv.FormatInt(sellerID, 10)).
+		WithMetadata("requested", strconv.FormatUint(uint64(requested), 10)).
+		WithMetadata("available", strconv.FormatUint(uint64(available), 10)).
+		WithViolation("STOCK", "sku/"+skuStr, fmt.Sprintf("requested %d, available %d", requested, available))
+}
+
+// CountOutOfRange reports that adding delta to the count of the offer would take it
+// above the largest count an offer can hold.
+func CountOutOfRange(sku uint32, sellerID int64, delta int32, count uint32) *Error {
+	return Wrap(KindInvalidArgument, ReasonCountOutOfRange, constants.ErrCountOutOfRange).
+		WithMetadata("sku", strconv.FormatUint(uint64(sku), 10)).
+		WithMetadata("seller_id", strconv.FormatInt(sellerID, 10)).
+		WithMetadata("delta", strconv.FormatInt(int64(delta), 10)).
+		WithMetadata("count", strconv.FormatUint(uint64(count), 10))
+}
+
+// SKUNameTaken reports that another SKU of the catalog has the name.
+func SKUNameTaken(sku uint32, name string) *Error {
+	return Wrap(KindAlreadyExists, ReasonSKUNameTaken, constants.ErrSKUNameTaken).
+		WithMetadata("sku", strconv.FormatUint(uint64(sku), 10)).
+		WithMetadata("name", name)
+}
+
+// ReservationNotFound reports that the checkout has no reservation left to commit.
+func ReservationNotFound(checkoutID string) *Error {
+	return Wrap(KindNotFound, ReasonNotFound, constants.ErrNotFound).
+		WithMetadata("checkout_id", checkoutID)
+}
+
+// ReservationReleased reports that the checkout was released already and cannot be
+// reserved any more.
+func ReservationReleased(checkoutID string) *Error {
+	return Wrap(KindFailedPrecondition, ReasonReservationReleased, constants.ErrReservationReleased).
+		WithMetadata("checkout_id", checkoutID)
+}
+
+var sentinels = []struct {
+	err    error
+	kind   Kind
+	reason Reason
+}{
+	{constants.ErrNotFound, KindNotFound, ReasonNotFound},
+	{constants.ErrInvalidSKU, KindInvalidArgument, ReasonInvalidSKU},
+	{constants.ErrNotSellerOwner, KindPermissionDenied, ReasonNotSellerOwner},
+	{constants.ErrSellerExists, KindAlreadyExists, ReasonSellerExists},
+	{constants.ErrIdempotencyReused, KindInvalidArgument, ReasonIdempotencyReused},
+	{constants.ErrIdempotencyPending, KindAborted, ReasonIdempotencyPending},
+	{constants.ErrIdempotencyKeyLong, KindInvalidArgument, ReasonIdempotencyKeyLong},
+	{constants.ErrRateLimited, KindResourceExhausted, ReasonRateLimited},
+	{constants.ErrAdminRequired, KindPermissionDenied, ReasonAdminRequired},
+	{constants.ErrInternalRequired, KindPermissionDenied, ReasonInternalRequired},
+	{constants.ErrInvalidPageToken, KindInvalidArgument, ReasonInvalidPageToken},
+	{constants.ErrInsufficientStock, KindFailedPrecondition, ReasonInsufficientStock},
+	{constants.ErrCountOutOfRange, KindInvalidArgument, ReasonCountOutOfRange},
+	{constants.ErrSKUNameTaken, KindAlreadyExists, ReasonSKUNameTaken},
+}
+
+// From returns the domain error in err's chain, or one built from a known sentinel.
+// It returns nil for unexpected errors.
+func From(err error) *Error {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+
+	for _, s := range sentinels {
+		if errors.Is(err, s.err) {
+			return Wrap(s.kind, s.reason, s.err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+	"key" TEXT NOT NULL,
+	"method" TEXT NOT NULL,
+	"request_hash" TEXT NOT NULL,
+	"response" BYTEA,
+	"created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	"expires_at" TIMESTAMP NOT NULL,
+	PRIMARY KEY ("key", "method")
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys ("expires_at");
+
+ALTER TABLE "idempotency_keys" OWNER TO "user_stocks";
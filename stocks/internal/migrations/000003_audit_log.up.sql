@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+	"id" BIGSERIAL PRIMARY KEY,
+	"actor_id" BIGINT NOT NULL,
+	"method" TEXT NOT NULL,
+	"entity_type" TEXT NOT NULL,
+	"entity_id" TEXT NOT NULL,
+	"request_hash" TEXT NOT NULL,
+	"before" JSONB,
+	"after" JSONB,
+	"status" TEXT NOT NULL,
+	"trace_id" TEXT,
+	"created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_actor_idx ON audit_log ("actor_id", "created_at");
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log ("entity_type", "entity_id", "created_at");
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON audit_log ("created_at");
+
+ALTER TABLE "audit_log" OWNER TO "user_stocks";
@@ -0,0 +1,19 @@
+DROP INDEX IF EXISTS items_seller_location_idx;
+DROP INDEX IF EXISTS items_sku_seller_idx;
+
+ALTER TABLE items ADD COLUMN IF NOT EXISTS "user_id" INT;
+
+UPDATE items i SET user_id = s.user_id
+FROM sellers s
+WHERE s.id = i.seller_id;
+
+-- Only one offer per SKU survives, the one with the most units.
+DELETE FROM items i
+USING items o
+WHERE i.sku = o.sku AND (i.count < o.count OR (i.count = o.count AND i.id > o.id));
+
+ALTER TABLE items ALTER COLUMN "user_id" SET NOT NULL;
+ALTER TABLE items ADD CONSTRAINT items_sku_key UNIQUE ("sku");
+ALTER TABLE items DROP COLUMN IF EXISTS "seller_id";
+
+DROP TABLE IF EXISTS "sellers";
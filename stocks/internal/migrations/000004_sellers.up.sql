@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS sellers (
+	"id" BIGSERIAL PRIMARY KEY,
+	"user_id" BIGINT NOT NULL UNIQUE,
+	"name" TEXT NOT NULL,
+	"created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	"updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE "sellers" OWNER TO "user_stocks";
+
+ALTER TABLE items ADD COLUMN IF NOT EXISTS "seller_id" BIGINT REFERENCES sellers("id");
+
+-- Items were owned by the user who added them. Every such user becomes a seller and
+-- their items become the offers of that seller.
+DO $$
+BEGIN
+	IF EXISTS (
+		SELECT 1 FROM information_schema.columns
+		WHERE table_name = 'items' AND column_name = 'user_id'
+	) THEN
+		INSERT INTO sellers (user_id, name)
+		SELECT DISTINCT user_id, 'seller-' || user_id FROM items
+		ON CONFLICT (user_id) DO NOTHING;
+
+		UPDATE items i SET seller_id = s.id
+		FROM sellers s
+		WHERE i.seller_id IS NULL AND s.user_id = i.user_id;
+
+		ALTER TABLE items DROP COLUMN user_id;
+	END IF;
+END $$;
+
+ALTER TABLE items ALTER COLUMN "seller_id" SET NOT NULL;
+
+-- Several sellers can offer the same SKU, each once.
+ALTER TABLE items DROP CONSTRAINT IF EXISTS items_sku_key;
+CREATE UNIQUE INDEX IF NOT EXISTS items_sku_seller_idx ON items ("sku", "seller_id");
+CREATE INDEX IF NOT EXISTS items_seller_location_idx ON items ("seller_id", "location");
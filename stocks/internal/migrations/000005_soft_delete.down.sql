@@ -0,0 +1,5 @@
+DELETE FROM items WHERE "deleted_at" IS NOT NULL;
+
+DROP INDEX IF EXISTS items_deleted_at_idx;
+
+ALTER TABLE items DROP COLUMN IF EXISTS "deleted_at";
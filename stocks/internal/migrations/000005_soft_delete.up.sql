@@ -0,0 +1,3 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS "deleted_at" TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS items_deleted_at_idx ON items ("deleted_at") WHERE "deleted_at" IS NOT NULL;
@@ -0,0 +1,16 @@
+-- Units held for checkouts. Reserving takes them out of the offer's count right away,
+-- releasing puts them back and committing keeps them taken.
+CREATE TABLE IF NOT EXISTS reservations (
+	"id" BIGSERIAL PRIMARY KEY,
+	"checkout_id" TEXT NOT NULL,
+	"sku" BIGINT NOT NULL,
+	"seller_id" BIGINT NOT NULL,
+	"count" INT NOT NULL,
+	"status" TEXT NOT NULL DEFAULT 'reserved',
+	"created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	"updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE "reservations" OWNER TO "user_stocks";
+
+CREATE UNIQUE INDEX IF NOT EXISTS reservations_checkout_offer_idx ON reservations ("checkout_id", "sku", "seller_id");
@@ -0,0 +1,9 @@
+-- Keys of different users may collide once the user is dropped. They only guard
+-- retries, so the stored ones are discarded.
+DELETE FROM idempotency_keys;
+
+DROP INDEX IF EXISTS idempotency_keys_user_key_method_idx;
+
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS "user_id";
+
+ALTER TABLE idempotency_keys ADD PRIMARY KEY ("key", "method");
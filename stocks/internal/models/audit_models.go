@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// AuditEvent records one mutating call: who made it, on which entity, and the entity
+// state before and after it as JSON.
+type AuditEvent struct {
+	ID          int64
+	ActorID     int64
+	Method      string
+	EntityType  string
+	EntityID    string
+	RequestHash string
+	Before      []byte
+	After       []byte
+	Status      string
+	TraceID     string
+	CreatedAt   time.Time
+}
+
+// AuditFilter selects audit events, newest first. Zero fields do not filter.
+// BeforeID continues a listing below the last returned id.
+type AuditFilter struct {
+	ActorID    *int64
+	EntityType string
+	EntityID   string
+	From       *time.Time
+	To         *time.Time
+	BeforeID   int64
+	Limit      int
+}
+
+type AuditEventsPage struct {
+	Events     []AuditEvent
+	NextCursor int64
+}
@@ -0,0 +1,23 @@
+package models
+
+// ReservationStatus is the state of the units held for a checkout.
+type ReservationStatus string
+
+const (
+	ReservationNone      ReservationStatus = ""
+	ReservationReserved  ReservationStatus = "reserved"
+	ReservationCommitted ReservationStatus = "committed"
+	ReservationReleased  ReservationStatus = "released"
+)
+
+type ReservationItem struct {
+	SKU      uint32
+	SellerID int64
+	Count    uint32
+}
+
+// Reservation holds the units of the items for CheckoutID.
+type Reservation struct {
+	CheckoutID string
+	Items      []ReservationItem
+}
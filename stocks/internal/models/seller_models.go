@@ -0,0 +1,15 @@
+package models
+
+// Seller is a merchant offering stock. It is owned by one user, who alone may change
+// its offers.
+type Seller struct {
+	ID     int64
+	UserID int64
+	Name   string
+}
+
+type TransferSeller struct {
+	UserID    int64
+	SellerID  int64
+	NewUserID int64
+}
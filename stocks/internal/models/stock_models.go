@@ -1,28 +1,79 @@
 package models
 
+import "time"
+
+// StockItem is the offer of one seller for a SKU. UserID is the user who owns the seller.
+// DeletedAt is set while the offer is soft deleted.
 type StockItem struct {
-	ID       int64
+	ID        int64
+	UserID    int64
+	SellerID  int64
+	SKU       uint32
+	Name      string
+	Type      string
+	Count     uint32
+	Price     uint32
+	Location  string
+	DeletedAt *time.Time
+}
+
+type SKU struct {
+	SKUID uint32
+	Name  string
+	Type  string
+}
+
+// StockOfferParams selects the offer of SellerID for SKU. A zero SellerID means the
+// seller of UserID.
+type StockOfferParams struct {
 	UserID   int64
+	SellerID int64
 	SKU      uint32
-	Name     string
-	Type     string
-	Count    uint32
-	Price    uint32
-	Location string
 }
 
-type SKU struct {
-	SKUID  uint32
-	Name   string
-	Type   string
-	UserID *int64
+// StockAdjustment adds Delta units, possibly negative, to the offer of SellerID for SKU.
+type StockAdjustment struct {
+	SKU      uint32
+	SellerID int64
+	Delta    int32
 }
 
-type ListStockParams struct {
+// AdjustStock are the adjustments an admin applies as UserID. With DryRun the resulting
+// offers are computed and nothing is changed.
+type AdjustStock struct {
 	UserID      int64
-	Location    string
-	PageSize    int64
-	CurrentPage int64
+	Adjustments []StockAdjustment
+	DryRun      bool
+}
+
+// ImportSKUs are the SKUs of the catalog an admin creates or updates as UserID. With
+// DryRun they are checked and nothing is changed.
+type ImportSKUs struct {
+	UserID int64
+	SKUs   []SKU
+	DryRun bool
+}
+
+// ImportedSKU tells whether an imported SKU was created or updated.
+type ImportedSKU struct {
+	SKU
+	Created bool
+}
+
+type ListStockParams struct {
+	UserID         int64
+	Location       string
+	PageSize       int64
+	CurrentPage    int64
+	IncludeDeleted bool
+}
+
+// GetStockParams selects the offer of SellerID for SKU, or the default offer when
+// SellerID is zero.
+type GetStockParams struct {
+	SKU            uint32
+	SellerID       int64
+	IncludeDeleted bool
 }
 
 type ListStock struct {
@@ -31,3 +82,12 @@ type ListStock struct {
 	PageNumber int64
 	TotalPages int64
 }
+
+// StockChange is the state of the default offer of a SKU, Deleted when it has no offers left.
+type StockChange struct {
+	SKU      uint32
+	SellerID int64
+	Count    uint32
+	Price    uint32
+	Deleted  bool
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"stocks/internal/models"
+)
+
+type AuditRepository interface {
+	Insert(ctx context.Context, event models.AuditEvent) (models.AuditEvent, error)
+	List(ctx context.Context, filter models.AuditFilter) ([]models.AuditEvent, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"stocks/internal/models"
+	"time"
+)
+
+type IdempotencyRepository interface {
+	Acquire(ctx context.Context, userID int64, key, method, requestHash string, ttl time.Duration) (models.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, userID int64, key, method string, response []byte) error
+	Release(ctx context.Context, userID int64, key, method string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -3,13 +3,21 @@ package interfaces
 import (
 	"context"
 	"stocks/internal/models"
+	"time"
 )
 
 type StockRepository interface {
-	AddItem(ctx context.Context, item models.StockItem) (string, error)
-	DeleteItem(ctx context.Context, sku uint32) error
-	GetItemsByLocation(ctx context.Context, location string, userID, limit, offset int64) ([]models.StockItem, error)
-	CountItemsByLocation(ctx context.Context, location string, userID int64) (int64, error)
-	GetItemBySKU(ctx context.Context, sku uint32) (models.StockItem, error)
+	AddItem(ctx context.Context, item models.StockItem) (string, uint32, error)
+	DeleteItem(ctx context.Context, sku uint32, sellerID int64) (models.StockItem, error)
+	RestoreItem(ctx context.Context, sku uint32, sellerID int64) (models.StockItem, error)
+	AdjustItem(ctx context.Context, adjustment models.StockAdjustment) (models.StockItem, error)
+	PurgeDeletedItems(ctx context.Context, before time.Time) (int64, error)
+	GetItemsByLocation(ctx context.Context, params models.ListStockParams, limit, offset int64) ([]models.StockItem, error)
+	CountItemsByLocation(ctx context.Context, params models.ListStockParams) (int64, error)
+	GetItemBySKU(ctx context.Context, sku uint32, sellerID int64, includeDeleted bool) (models.StockItem, error)
+	ListOffers(ctx context.Context, sku uint32, includeDeleted bool) ([]models.StockItem, error)
 	GetSKUByID(ctx context.Context, skuID uint32) (models.SKU, error)
+	UpsertSKU(ctx context.Context, sku models.SKU) (bool, error)
+	NotifyStockChange(ctx context.Context, change models.StockChange) error
+	StockLevels(ctx context.Context) (map[string]uint64, error)
 }
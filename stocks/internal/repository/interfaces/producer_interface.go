@@ -1,8 +1,12 @@
 package interfaces
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type KafkaProd interface {
-	Produce(message []byte, key string, t time.Time) error
+	Produce(ctx context.Context, message []byte, key string, t time.Time) error
+	Ping(ctx context.Context) error
 	Close()
 }
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"stocks/internal/models"
+)
+
+type ReservationRepository interface {
+	// LockReservation holds off other transactions on the checkout's reservation until
+	// the current one ends.
+	LockReservation(ctx context.Context, checkoutID string) error
+	ReservationStatus(ctx context.Context, checkoutID string) (models.ReservationStatus, error)
+	TakeStock(ctx context.Context, item models.ReservationItem) (models.StockItem, error)
+	ReturnStock(ctx context.Context, item models.ReservationItem) (models.StockItem, error)
+	AddReservation(ctx context.Context, checkoutID string, item models.ReservationItem) error
+	// AddReleasedMarker records a checkout that was released before it was reserved.
+	AddReleasedMarker(ctx context.Context, checkoutID string) error
+	SetReservationStatus(ctx context.Context, checkoutID string, from, to models.ReservationStatus) ([]models.ReservationItem, error)
+}
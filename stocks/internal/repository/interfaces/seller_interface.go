@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"stocks/internal/models"
+)
+
+type SellerRepository interface {
+	CreateSeller(ctx context.Context, seller models.Seller) (models.Seller, error)
+	EnsureSeller(ctx context.Context, userID int64) (models.Seller, error)
+	GetSeller(ctx context.Context, sellerID int64) (models.Seller, error)
+	GetSellerByUser(ctx context.Context, userID int64) (models.Seller, error)
+	TransferSeller(ctx context.Context, sellerID, newUserID int64) (models.Seller, error)
+}
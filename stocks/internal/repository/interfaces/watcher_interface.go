@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"stocks/internal/models"
+)
+
+type StockSubscription interface {
+	Ready() <-chan struct{}
+	Drain() ([]models.StockChange, bool)
+	Close()
+}
+
+type StockWatcher interface {
+	Subscribe(skus []uint32) StockSubscription
+	Run(ctx context.Context) error
+}
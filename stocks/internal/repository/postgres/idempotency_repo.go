@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"stocks/internal/models"
+	"stocks/internal/repository/interfaces"
+	"stocks/pkg/postgresql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type idempotencyRepo struct {
+	db postgresql.Client
+}
+
+func NewIdempotencyRepository(db postgresql.Client) interfaces.IdempotencyRepository {
+	return &idempotencyRepo{db: db}
+}
+
+// Acquire reserves the key of the user for the given method. It returns true when the
+// caller owns the key and must execute the request, otherwise the stored record is
+// returned.
+func (r *idempotencyRepo) Acquire(ctx context.Context, userID int64, key, method, requestHash string, ttl time.Duration) (models.IdempotencyRecord, bool, error) {
+	var record DbIdempotencyRecord
+
+	query := `
+		INSERT INTO idempotency_keys (user_id, key, method, request_hash, expires_at)
+		VALUES (@user_id, @key, @method, @request_hash, NOW() + make_interval(secs => @ttl))
+		ON CONFLICT (user_id, key, method) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			response = NULL,
+			created_at = CURRENT_TIMESTAMP,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < NOW()
+		RETURNING key
+	`
+	args := pgx.NamedArgs{
+		"user_id":      userID,
+		"key":          key,
+		"method":       method,
+		"request_hash": requestHash,
+		"ttl":          ttl.Seconds(),
+	}
+
+	err := r.db.QueryRow(ctx, query, args).Scan(&record.Key)
+	if err == nil {
+		return models.IdempotencyRecord{}, true, nil
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return models.IdempotencyRecord{}, false, err
+	}
+
+	query = `
+		SELECT
+			user_id, key, method, request_hash, response, expires_at
+		FROM idempotency_keys
+		WHERE user_id = @user_id AND key = @key AND method = @method
+	`
+
+	err = r.db.QueryRow(ctx, query, args).Scan(
+		&record.UserID, &record.Key, &record.Method, &record.RequestHash,
+		&record.Response, &record.ExpiresAt,
+	)
+	if err != nil {
+		return models.IdempotencyRecord{}, false, err
+	}
+
+	return record.ToDomain(), false, nil
+}
+
+func (r *idempotencyRepo) Complete(ctx context.Context, userID int64, key, method string, response []byte) error {
+	query := `UPDATE idempotency_keys SET response = @response WHERE user_id = @user_id AND key = @key AND method = @method`
+
+	args := pgx.NamedArgs{
+		"user_id":  userID,
+		"key":      key,
+		"method":   method,
+		"response": response,
+	}
+
+	_, err := r.db.Exec(ctx, query, args)
+
+	return err
+}
+
+func (r *idempotencyRepo) Release(ctx context.Context, userID int64, key, method string) error {
+	query := `DELETE FROM idempotency_keys WHERE user_id = @user_id AND key = @key AND method = @method AND response IS NULL`
+
+	args := pgx.NamedArgs{
+		"user_id": userID,
+		"key":     key,
+		"method":  method,
+	}
+
+	_, err := r.db.Exec(ctx, query, args)
+
+	return err
+}
+
+func (r *idempotencyRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at < NOW()`
+
+	cmdTag, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return cmdTag.RowsAffected(), nil
+}
@@ -2,44 +2,134 @@ package postgres
 
 import (
 	"stocks/internal/models"
+	"time"
 )
 
 type DbStockItem struct {
-	ID       int64  `db:"id"`
-	UserID   int64  `db:"user_id"`
-	SKU      uint32 `db:"sku"`
-	Name     string `db:"name"`
-	Type     string `db:"type"`
-	Count    uint32 `db:"count"`
-	Price    uint32 `db:"price"`
-	Location string `db:"location"`
+	ID        int64      `db:"id"`
+	UserID    int64      `db:"user_id"`
+	SellerID  int64      `db:"seller_id"`
+	SKU       uint32     `db:"sku"`
+	Name      string     `db:"name"`
+	Type      string     `db:"type"`
+	Count     uint32     `db:"count"`
+	Price     uint32     `db:"price"`
+	Location  string     `db:"location"`
+	DeletedAt *time.Time `db:"deleted_at"`
 }
 
 type DbSKU struct {
-	SKUID  uint32 `db:"sku_id"`
+	SKUID uint32 `db:"sku_id"`
+	Name  string `db:"name"`
+	Type  string `db:"type"`
+}
+
+type DbSeller struct {
+	ID     int64  `db:"id"`
+	UserID int64  `db:"user_id"`
 	Name   string `db:"name"`
-	Type   string `db:"type"`
-	UserID *int64 `db:"user_id"`
 }
 
 func (d DbStockItem) ToDomain() models.StockItem {
 	return models.StockItem{
-		ID:       d.ID,
-		UserID:   d.UserID,
-		SKU:      d.SKU,
-		Name:     d.Name,
-		Type:     d.Type,
-		Count:    d.Count,
-		Price:    d.Price,
-		Location: d.Location,
+		ID:        d.ID,
+		UserID:    d.UserID,
+		SellerID:  d.SellerID,
+		SKU:       d.SKU,
+		Name:      d.Name,
+		Type:      d.Type,
+		Count:     d.Count,
+		Price:     d.Price,
+		Location:  d.Location,
+		DeletedAt: d.DeletedAt,
 	}
 }
 
 func (d DbSKU) ToDomain() models.SKU {
 	return models.SKU{
-		SKUID:  d.SKUID,
-		Name:   d.Name,
-		Type:   d.Type,
+		SKUID: d.SKUID,
+		Name:  d.Name,
+		Type:  d.Type,
+	}
+}
+
+func (d DbSeller) ToDomain() models.Seller {
+	return models.Seller{
+		ID:     d.ID,
 		UserID: d.UserID,
+		Name:   d.Name,
+	}
+}
+
+type DbIdempotencyRecord struct {
+	UserID      int64     `db:"user_id"`
+	Key         string    `db:"key"`
+	Method      string    `db:"method"`
+	RequestHash string    `db:"request_hash"`
+	Response    []byte    `db:"response"`
+	ExpiresAt   time.Time `db:"expires_at"`
+}
+
+func (d DbIdempotencyRecord) ToDomain() models.IdempotencyRecord {
+	return models.IdempotencyRecord{
+		UserID:      d.UserID,
+		Key:         d.Key,
+		Method:      d.Method,
+		RequestHash: d.RequestHash,
+		Response:    d.Response,
+		ExpiresAt:   d.ExpiresAt,
 	}
 }
+
+type DbStockChange struct {
+	SKU      uint32 `json:"sku"`
+	SellerID int64  `json:"seller_id"`
+	Count    uint32 `json:"count"`
+	Price    uint32 `json:"price"`
+	Deleted  bool   `json:"deleted"`
+}
+
+func (d DbStockChange) ToDomain() models.StockChange {
+	return models.StockChange{
+		SKU:      d.SKU,
+		SellerID: d.SellerID,
+		Count:    d.Count,
+		Price:    d.Price,
+		Deleted:  d.Deleted,
+	}
+}
+
+type DbAuditEvent struct {
+	ID          int64     `db:"id"`
+	ActorID     int64     `db:"actor_id"`
+	Method      string    `db:"method"`
+	EntityType  string    `db:"entity_type"`
+	EntityID    string    `db:"entity_id"`
+	RequestHash string    `db:"request_hash"`
+	Before      []byte    `db:"before"`
+	After       []byte    `db:"after"`
+	Status      string    `db:"status"`
+	TraceID     *string   `db:"trace_id"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (d DbAuditEvent) ToDomain() models.AuditEvent {
+	event := models.AuditEvent{
+		ID:          d.ID,
+		ActorID:     d.ActorID,
+		Method:      d.Method,
+		EntityType:  d.EntityType,
+		EntityID:    d.EntityID,
+		RequestHash: d.RequestHash,
+		Before:      d.Before,
+		After:       d.After,
+		Status:      d.Status,
+		CreatedAt:   d.CreatedAt,
+	}
+
+	if d.TraceID != nil {
+		event.TraceID = *d.TraceID
+	}
+
+	return event
+}
@@ -0,0 +1,199 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"stocks/internal/constants"
+	"stocks/internal/models"
+	"stocks/internal/repository/interfaces"
+	"stocks/pkg/postgresql"
+
+	tmsql "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+type reservationRepo struct {
+	db     postgresql.Client
+	getter *tmsql.CtxGetter
+}
+
+func NewReservationRepository(db postgresql.Client, getter *tmsql.CtxGetter) interfaces.ReservationRepository {
+	return &reservationRepo{
+		db:     db,
+		getter: getter,
+	}
+}
+
+// LockReservation takes a transaction-level advisory lock on the checkout, so checking
+// and changing its reservation does not race with other calls for the same checkout.
+func (r *reservationRepo) LockReservation(ctx context.Context, checkoutID string) error {
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `SELECT pg_advisory_xact_lock(hashtext('reservations'), hashtext(@checkout_id))`
+
+	args := pgx.NamedArgs{
+		"checkout_id": checkoutID,
+	}
+
+	_, err := txOrDb.Exec(ctx, query, args)
+
+	return err
+}
+
+// ReservationStatus returns the status of the checkout's reservation, ReservationNone
+// when there is none.
+func (r *reservationRepo) ReservationStatus(ctx context.Context, checkoutID string) (models.ReservationStatus, error) {
+	var status string
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `SELECT status FROM reservations WHERE checkout_id = @checkout_id LIMIT 1`
+
+	args := pgx.NamedArgs{
+		"checkout_id": checkoutID,
+	}
+
+	err := txOrDb.QueryRow(ctx, query, args).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ReservationNone, nil
+		}
+
+		return models.ReservationNone, err
+	}
+
+	return models.ReservationStatus(status), nil
+}
+
+// TakeStock removes the units from a live offer and returns it. It returns
+// ErrNotRowAffected when the offer does not exist or has fewer units.
+func (r *reservationRepo) TakeStock(ctx context.Context, item models.ReservationItem) (models.StockItem, error) {
+	query := `
+		UPDATE items SET
+			count = count - @count,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE sku = @sku AND seller_id = @seller_id AND deleted_at IS NULL AND count >= @count
+		RETURNING id, seller_id, sku, count, price, location, deleted_at
+	`
+
+	return r.changeStock(ctx, query, item)
+}
+
+// ReturnStock adds the units back to the offer, deleted or not, and returns it. It
+// returns ErrNotRowAffected when the offer was purged.
+func (r *reservationRepo) ReturnStock(ctx context.Context, item models.ReservationItem) (models.StockItem, error) {
+	query := `
+		UPDATE items SET
+			count = count + @count,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE sku = @sku AND seller_id = @seller_id
+		RETURNING id, seller_id, sku, count, price, location, deleted_at
+	`
+
+	return r.changeStock(ctx, query, item)
+}
+
+func (r *reservationRepo) changeStock(ctx context.Context, query string, item models.ReservationItem) (models.StockItem, error) {
+	var offer DbStockItem
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	args := pgx.NamedArgs{
+		"sku":       item.SKU,
+		"seller_id": item.SellerID,
+		"count":     item.Count,
+	}
+
+	err := txOrDb.QueryRow(ctx, query, args).Scan(
+		&offer.ID, &offer.SellerID, &offer.SKU, &offer.Count,
+		&offer.Price, &offer.Location, &offer.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.StockItem{}, constants.ErrNotRowAffected
+		}
+
+		return models.StockItem{}, err
+	}
+
+	return offer.ToDomain(), nil
+}
+
+func (r *reservationRepo) AddReservation(ctx context.Context, checkoutID string, item models.ReservationItem) error {
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `
+		INSERT INTO reservations (checkout_id, sku, seller_id, count, status)
+		VALUES (@checkout_id, @sku, @seller_id, @count, @status)
+	`
+	args := pgx.NamedArgs{
+		"checkout_id": checkoutID,
+		"sku":         item.SKU,
+		"seller_id":   item.SellerID,
+		"count":       item.Count,
+		"status":      string(models.ReservationReserved),
+	}
+
+	_, err := txOrDb.Exec(ctx, query, args)
+
+	return err
+}
+
+// AddReleasedMarker stores a released row without units for the checkout. It keeps a
+// reserve call arriving after the release from taking units that nobody gives back.
+func (r *reservationRepo) AddReleasedMarker(ctx context.Context, checkoutID string) error {
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `
+		INSERT INTO reservations (checkout_id, sku, seller_id, count, status)
+		VALUES (@checkout_id, 0, 0, 0, @status)
+		ON CONFLICT (checkout_id, sku, seller_id) DO NOTHING
+	`
+	args := pgx.NamedArgs{
+		"checkout_id": checkoutID,
+		"status":      string(models.ReservationReleased),
+	}
+
+	_, err := txOrDb.Exec(ctx, query, args)
+
+	return err
+}
+
+// SetReservationStatus moves the checkout's reservation from status from to status to
+// and returns its items, none when it was not in status from.
+func (r *reservationRepo) SetReservationStatus(ctx context.Context, checkoutID string, from, to models.ReservationStatus) ([]models.ReservationItem, error) {
+	var result []models.ReservationItem
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `
+		UPDATE reservations SET
+			status = @to,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE checkout_id = @checkout_id AND status = @from
+		RETURNING sku, seller_id, count
+	`
+	args := pgx.NamedArgs{
+		"checkout_id": checkoutID,
+		"from":        string(from),
+		"to":          string(to),
+	}
+
+	rows, err := txOrDb.Query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.ReservationItem
+
+		if err := rows.Scan(&item.SKU, &item.SellerID, &item.Count); err != nil {
+			return nil, err
+		}
+
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"stocks/internal/constants"
+	"stocks/internal/models"
+	"stocks/internal/repository/interfaces"
+	"stocks/pkg/postgresql"
+
+	tmsql "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const uniqueViolation = "23505"
+
+type sellerRepo struct {
+	db     postgresql.Client
+	getter *tmsql.CtxGetter
+}
+
+func NewSellerRepository(db postgresql.Client, getter *tmsql.CtxGetter) interfaces.SellerRepository {
+	return &sellerRepo{
+		db:     db,
+		getter: getter,
+	}
+}
+
+func (r *sellerRepo) CreateSeller(ctx context.Context, seller models.Seller) (models.Seller, error) {
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `
+		INSERT INTO sellers (user_id, name)
+		VALUES (@user_id, @name)
+		RETURNING id
+	`
+	args := pgx.NamedArgs{
+		"user_id": seller.UserID,
+		"name":    seller.Name,
+	}
+
+	err := txOrDb.QueryRow(ctx, query, args).Scan(&seller.ID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return models.Seller{}, constants.ErrSellerExists
+		}
+
+		return models.Seller{}, err
+	}
+
+	return seller, nil
+}
+
+// EnsureSeller returns the seller of the user, creating a default one on first use.
+func (r *sellerRepo) EnsureSeller(ctx context.Context, userID int64) (models.Seller, error) {
+	var seller DbSeller
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	// The no-op update makes RETURNING yield the existing row as well.
+	query := `
+		INSERT INTO sellers (user_id, name)
+		VALUES (@user_id, @name)
+		ON CONFLICT (user_id) DO UPDATE SET user_id = EXCLUDED.user_id
+		RETURNING id, user_id, name
+	`
+	args := pgx.NamedArgs{
+		"user_id": userID,
+		"name":    fmt.Sprintf("seller-%d", userID),
+	}
+
+	err := txOrDb.QueryRow(ctx, query, args).Scan(&seller.ID, &seller.UserID, &seller.Name)
+	if err != nil {
+		return models.Seller{}, err
+	}
+
+	return seller.ToDomain(), nil
+}
+
+func (r *sellerRepo) GetSeller(ctx context.Context, sellerID int64) (models.Seller, error) {
+	return r.getSeller(ctx, "id = @id", pgx.NamedArgs{"id": sellerID})
+}
+
+func (r *sellerRepo) GetSellerByUser(ctx context.Context, userID int64) (models.Seller, error) {
+	return r.getSeller(ctx, "user_id = @user_id", pgx.NamedArgs{"user_id": userID})
+}
+
+// TransferSeller hands the seller and all its offers over to another user.
+func (r *sellerRepo) TransferSeller(ctx context.Context, sellerID, newUserID int64) (models.Seller, error) {
+	var seller DbSeller
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `
+		UPDATE sellers
+		SET user_id = @new_user_id, updated_at = CURRENT_TIMESTAMP
+		WHERE id = @id
+		RETURNING id, user_id, name
+	`
+	args := pgx.NamedArgs{
+		"id":          sellerID,
+		"new_user_id": newUserID,
+	}
+
+	err := txOrDb.QueryRow(ctx, query, args).Scan(&seller.ID, &seller.UserID, &seller.Name)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return models.Seller{}, constants.ErrSellerExists
+		}
+
+		return models.Seller{}, err
+	}
+
+	return seller.ToDomain(), nil
+}
+
+func (r *sellerRepo) getSeller(ctx context.Context, where string, args pgx.NamedArgs) (models.Seller, error) {
+	var seller DbSeller
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `SELECT id, user_id, name FROM sellers WHERE ` + where
+
+	err := txOrDb.QueryRow(ctx, query, args).Scan(&seller.ID, &seller.UserID, &seller.Name)
+	if err != nil {
+		return models.Seller{}, err
+	}
+
+	return seller.ToDomain(), nil
+}
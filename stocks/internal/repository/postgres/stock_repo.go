@@ -2,13 +2,17 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"stocks/internal/constants"
 	"stocks/internal/models"
 	"stocks/internal/repository/interfaces"
 	"stocks/pkg/postgresql"
+	"time"
 
 	tmsql "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type stockRepo struct {
@@ -23,9 +27,12 @@ func NewRepository(db postgresql.Client, getter *tmsql.CtxGetter) interfaces.Sto
 	}
 }
 
-func (r *stockRepo) AddItem(ctx context.Context, item models.StockItem) (string, error) {
+// AddItem upserts the offer of the seller and returns the event type along with the
+// resulting count. A deleted offer is restored with the added count only.
+func (r *stockRepo) AddItem(ctx context.Context, item models.StockItem) (string, uint32, error) {
 	var (
 		xmax   uint32
+		count  uint32
 		result = "sku_created"
 	)
 
@@ -33,77 +40,170 @@ func (r *stockRepo) AddItem(ctx context.Context, item models.StockItem) (string,
 
 	query := `
 		INSERT INTO items (
-			user_id, sku, count, price, location
+			seller_id, sku, count, price, location
 		) VALUES (
-			@user_id, @sku, @count, @price, @location
+			@seller_id, @sku, @count, @price, @location
 		) 
-		ON CONFLICT (sku) DO UPDATE SET
-			count = items.count + EXCLUDED.count,
+		ON CONFLICT (sku, seller_id) DO UPDATE SET
+			count = CASE WHEN items.deleted_at IS NULL THEN items.count + EXCLUDED.count ELSE EXCLUDED.count END,
 			price = EXCLUDED.price,
-			user_id = EXCLUDED.user_id,
 			location = EXCLUDED.location,
+			deleted_at = NULL,
 			updated_at = CURRENT_TIMESTAMP
-		RETURNING xmax
+		RETURNING xmax, count
 	`
 	args := pgx.NamedArgs{
-		"user_id":  item.UserID,
-		"sku":      item.SKU,
-		"count":    item.Count,
-		"price":    item.Price,
-		"location": item.Location,
+		"seller_id": item.SellerID,
+		"sku":       item.SKU,
+		"count":     item.Count,
+		"price":     item.Price,
+		"location":  item.Location,
 	}
 
-	err := txOrDb.QueryRow(ctx, query, args).Scan(&xmax)
+	err := txOrDb.QueryRow(ctx, query, args).Scan(&xmax, &count)
 	if err != nil {
-		return result, err
+		return result, 0, err
 	}
 
 	if xmax != 0 {
 		result = "sku_changed"
 	}
 
-	return result, nil
+	return result, count, nil
+}
+
+// DeleteItem marks the offer of the seller deleted and returns it. Deleted offers are
+// kept until PurgeDeletedItems removes them.
+func (r *stockRepo) DeleteItem(ctx context.Context, sku uint32, sellerID int64) (models.StockItem, error) {
+	query := `
+		UPDATE items SET
+			deleted_at = CURRENT_TIMESTAMP,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE sku = @sku AND seller_id = @seller_id AND deleted_at IS NULL
+		RETURNING id, seller_id, sku, count, price, location, deleted_at
+	`
+
+	return r.setDeleted(ctx, query, sku, sellerID)
+}
+
+// RestoreItem clears the deletion of the seller's offer and returns it.
+func (r *stockRepo) RestoreItem(ctx context.Context, sku uint32, sellerID int64) (models.StockItem, error) {
+	query := `
+		UPDATE items SET
+			deleted_at = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE sku = @sku AND seller_id = @seller_id AND deleted_at IS NOT NULL
+		RETURNING id, seller_id, sku, count, price, location, deleted_at
+	`
+
+	return r.setDeleted(ctx, query, sku, sellerID)
 }
 
-func (r *stockRepo) DeleteItem(ctx context.Context, sku uint32) error {
-	query := "DELETE FROM items WHERE sku = @sku"
+func (r *stockRepo) setDeleted(ctx context.Context, query string, sku uint32, sellerID int64) (models.StockItem, error) {
+	var item DbStockItem
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
 
 	args := pgx.NamedArgs{
-		"sku": sku,
+		"sku":       sku,
+		"seller_id": sellerID,
 	}
 
-	cmdTag, err := r.db.Exec(ctx, query, args)
+	err := txOrDb.QueryRow(ctx, query, args).Scan(
+		&item.ID, &item.SellerID, &item.SKU, &item.Count,
+		&item.Price, &item.Location, &item.DeletedAt,
+	)
 	if err != nil {
-		return err
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.StockItem{}, constants.ErrNotRowAffected
+		}
+
+		return models.StockItem{}, err
+	}
+
+	return item.ToDomain(), nil
+}
+
+// AdjustItem adds the delta to the count of a live offer and returns it. It returns
+// ErrNotRowAffected when the offer does not exist, is deleted, or the count would leave
+// the range from zero to MaxOfferCount.
+func (r *stockRepo) AdjustItem(ctx context.Context, adjustment models.StockAdjustment) (models.StockItem, error) {
+	query := `
+		UPDATE items SET
+			count = count + @delta,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE sku = @sku AND seller_id = @seller_id AND deleted_at IS NULL
+			AND count::BIGINT + @delta BETWEEN 0 AND @max_count
+		RETURNING id, seller_id, sku, count, price, location, deleted_at
+	`
+
+	var item DbStockItem
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	args := pgx.NamedArgs{
+		"sku":       adjustment.SKU,
+		"seller_id": adjustment.SellerID,
+		"delta":     adjustment.Delta,
+		"max_count": constants.MaxOfferCount,
+	}
+
+	err := txOrDb.QueryRow(ctx, query, args).Scan(
+		&item.ID, &item.SellerID, &item.SKU, &item.Count,
+		&item.Price, &item.Location, &item.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.StockItem{}, constants.ErrNotRowAffected
+		}
+
+		return models.StockItem{}, err
+	}
+
+	return item.ToDomain(), nil
+}
+
+// PurgeDeletedItems removes the offers deleted before the given time.
+func (r *stockRepo) PurgeDeletedItems(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM items WHERE deleted_at < @before`
+
+	args := pgx.NamedArgs{
+		"before": before,
 	}
 
-	if cmdTag.RowsAffected() == 0 {
-		return constants.ErrNotRowAffected
+	cmdTag, err := r.db.Exec(ctx, query, args)
+	if err != nil {
+		return 0, err
 	}
 
-	return nil
+	return cmdTag.RowsAffected(), nil
 }
 
-func (r *stockRepo) GetItemsByLocation(ctx context.Context, location string, userID, limit, offset int64) ([]models.StockItem, error) {
+func (r *stockRepo) GetItemsByLocation(ctx context.Context, params models.ListStockParams, limit, offset int64) ([]models.StockItem, error) {
 	var result []models.StockItem
 
 	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
 
 	query := `
 		SELECT 
-			i.sku, i.count, s.name, 
-			s.type, i.price, i.location 
+			i.sku, i.seller_id, i.count, s.name, 
+			s.type, i.price, i.location, i.deleted_at
 		FROM items i
+		JOIN sellers se
+			ON i.seller_id = se.id
 		LEFT JOIN sku s
 			ON i.sku = s.sku_id
-		WHERE i.location = @location AND i.user_id = @user_id
+		WHERE i.location = @location AND se.user_id = @user_id
+			AND (@include_deleted::BOOLEAN OR i.deleted_at IS NULL)
+		ORDER BY i.sku, i.seller_id
 		LIMIT @limit OFFSET @offset
 	`
 	args := pgx.NamedArgs{
-		"location": location,
-		"user_id":  userID,
-		"limit":    limit,
-		"offset":   offset,
+		"location":        params.Location,
+		"user_id":         params.UserID,
+		"include_deleted": params.IncludeDeleted,
+		"limit":           limit,
+		"offset":          offset,
 	}
 
 	rows, err := txOrDb.Query(ctx, query, args)
@@ -114,10 +214,11 @@ func (r *stockRepo) GetItemsByLocation(ctx context.Context, location string, use
 
 	for rows.Next() {
 		var item DbStockItem
+		item.UserID = params.UserID
 
 		err = rows.Scan(
-			&item.SKU, &item.Count, &item.Name,
-			&item.Type, &item.Price, &item.Location,
+			&item.SKU, &item.SellerID, &item.Count, &item.Name,
+			&item.Type, &item.Price, &item.Location, &item.DeletedAt,
 		)
 
 		if err != nil {
@@ -130,16 +231,24 @@ func (r *stockRepo) GetItemsByLocation(ctx context.Context, location string, use
 	return result, nil
 }
 
-func (r *stockRepo) CountItemsByLocation(ctx context.Context, location string, userID int64) (int64, error) {
+func (r *stockRepo) CountItemsByLocation(ctx context.Context, params models.ListStockParams) (int64, error) {
 	var count int64
 
 	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
 
-	query := `SELECT COUNT(*) FROM items WHERE location = @location AND user_id = @user_id`
+	query := `
+		SELECT COUNT(*)
+		FROM items i
+		JOIN sellers se
+			ON i.seller_id = se.id
+		WHERE i.location = @location AND se.user_id = @user_id
+			AND (@include_deleted::BOOLEAN OR i.deleted_at IS NULL)
+	`
 
 	args := pgx.NamedArgs{
-		"location": location,
-		"user_id":  userID,
+		"location":        params.Location,
+		"user_id":         params.UserID,
+		"include_deleted": params.IncludeDeleted,
 	}
 
 	err := txOrDb.QueryRow(ctx, query, args).Scan(&count)
@@ -150,25 +259,47 @@ func (r *stockRepo) CountItemsByLocation(ctx context.Context, location string, u
 	return count, nil
 }
 
-func (r *stockRepo) GetItemBySKU(ctx context.Context, sku uint32) (models.StockItem, error) {
+// offersQuery selects offers with the SKU info and the user owning the seller. Deleted
+// offers are skipped unless include_deleted is set.
+const offersQuery = `
+	SELECT 
+		i.id, se.user_id, i.seller_id, i.sku, i.count, s.name, 
+		s.type, i.price, i.location, i.deleted_at
+	FROM items i
+	JOIN sellers se
+		ON i.seller_id = se.id
+	LEFT JOIN sku s
+		ON i.sku = s.sku_id
+	WHERE i.sku = @sku AND (@include_deleted::BOOLEAN OR i.deleted_at IS NULL)
+`
+
+// offersOrder puts the default offer first: live before deleted, then in stock, then
+// the cheapest one.
+const offersOrder = `
+	ORDER BY i.deleted_at IS NULL DESC, i.count > 0 DESC, i.price, i.seller_id
+`
+
+// GetItemBySKU returns the offer of the seller for sku. With a zero sellerID it returns
+// the default offer: the cheapest one in stock, else the cheapest one.
+func (r *stockRepo) GetItemBySKU(ctx context.Context, sku uint32, sellerID int64, includeDeleted bool) (models.StockItem, error) {
 	var item DbStockItem
 
-	query := `
-		SELECT 
-			i.id, i.user_id, i.sku, i.count, s.name, 
-			s.type, i.price, i.location
-		FROM items i
-		LEFT JOIN sku s
-			ON i.sku = s.sku_id
-		WHERE i.sku = @sku
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := offersQuery + `
+		AND (@seller_id::BIGINT = 0 OR i.seller_id = @seller_id)
+	` + offersOrder + `
+		LIMIT 1
 	`
 	args := pgx.NamedArgs{
-		"sku": sku,
+		"sku":             sku,
+		"seller_id":       sellerID,
+		"include_deleted": includeDeleted,
 	}
 
-	err := r.db.QueryRow(ctx, query, args).Scan(
-		&item.ID, &item.UserID, &item.SKU, &item.Count,
-		&item.Name, &item.Type, &item.Price, &item.Location,
+	err := txOrDb.QueryRow(ctx, query, args).Scan(
+		&item.ID, &item.UserID, &item.SellerID, &item.SKU, &item.Count,
+		&item.Name, &item.Type, &item.Price, &item.Location, &item.DeletedAt,
 	)
 
 	if err != nil {
@@ -178,26 +309,145 @@ func (r *stockRepo) GetItemBySKU(ctx context.Context, sku uint32) (models.StockI
 	return item.ToDomain(), nil
 }
 
+// ListOffers returns every seller's offer for sku in default offer order.
+func (r *stockRepo) ListOffers(ctx context.Context, sku uint32, includeDeleted bool) ([]models.StockItem, error) {
+	var result []models.StockItem
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := offersQuery + offersOrder
+	args := pgx.NamedArgs{
+		"sku":             sku,
+		"include_deleted": includeDeleted,
+	}
+
+	rows, err := txOrDb.Query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item DbStockItem
+
+		err = rows.Scan(
+			&item.ID, &item.UserID, &item.SellerID, &item.SKU, &item.Count,
+			&item.Name, &item.Type, &item.Price, &item.Location, &item.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, item.ToDomain())
+	}
+
+	return result, rows.Err()
+}
+
 func (r *stockRepo) GetSKUByID(ctx context.Context, skuID uint32) (models.SKU, error) {
 	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
 	var sku DbSKU
 
-	query := `
-		SELECT 
-			s.sku_id, s.name, s.type, i.user_id
-		FROM sku s
-		LEFT JOIN items i
-			ON s.sku_id = i.sku
-		WHERE s.sku_id = @sku_id
-	`
+	query := `SELECT sku_id, name, type FROM sku WHERE sku_id = @sku_id`
+
 	args := pgx.NamedArgs{
 		"sku_id": skuID,
 	}
 
-	err := txOrDb.QueryRow(ctx, query, args).Scan(&sku.SKUID, &sku.Name, &sku.Type, &sku.UserID)
+	err := txOrDb.QueryRow(ctx, query, args).Scan(&sku.SKUID, &sku.Name, &sku.Type)
 	if err != nil {
 		return models.SKU{}, err
 	}
 
 	return sku.ToDomain(), nil
 }
+
+// UpsertSKU creates the SKU or renames and retypes it, and tells whether it was created.
+// It returns ErrSKUNameTaken when another SKU has the name.
+func (r *stockRepo) UpsertSKU(ctx context.Context, sku models.SKU) (bool, error) {
+	var created bool
+
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	query := `
+		INSERT INTO sku (sku_id, name, type)
+		VALUES (@sku_id, @name, @type)
+		ON CONFLICT (sku_id) DO UPDATE SET
+			name = EXCLUDED.name,
+			type = EXCLUDED.type
+		RETURNING xmax = 0
+	`
+	args := pgx.NamedArgs{
+		"sku_id": sku.SKUID,
+		"name":   sku.Name,
+		"type":   sku.Type,
+	}
+
+	err := txOrDb.QueryRow(ctx, query, args).Scan(&created)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return false, constants.ErrSKUNameTaken
+		}
+
+		return false, err
+	}
+
+	return created, nil
+}
+
+// NotifyStockChange publishes the change to every stocks replica. Inside a transaction
+// the notification is only delivered once it commits.
+func (r *stockRepo) NotifyStockChange(ctx context.Context, change models.StockChange) error {
+	txOrDb := r.getter.DefaultTrOrDB(ctx, r.db.(*postgresql.PgClient))
+
+	payload, err := json.Marshal(DbStockChange{
+		SKU:      change.SKU,
+		SellerID: change.SellerID,
+		Count:    change.Count,
+		Price:    change.Price,
+		Deleted:  change.Deleted,
+	})
+	if err != nil {
+		return err
+	}
+
+	query := `SELECT pg_notify(@channel, @payload)`
+
+	args := pgx.NamedArgs{
+		"channel": constants.StockChangesChannel,
+		"payload": string(payload),
+	}
+
+	_, err = txOrDb.Exec(ctx, query, args)
+
+	return err
+}
+
+// StockLevels returns the total item units in stock per location.
+func (r *stockRepo) StockLevels(ctx context.Context) (map[string]uint64, error) {
+	query := `SELECT location, COALESCE(SUM(count), 0)::BIGINT FROM items WHERE deleted_at IS NULL GROUP BY location`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	levels := make(map[string]uint64)
+
+	for rows.Next() {
+		var (
+			location string
+			count    int64
+		)
+
+		if err = rows.Scan(&location, &count); err != nil {
+			return nil, err
+		}
+
+		levels[location] = uint64(count)
+	}
+
+	return levels, rows.Err()
+}
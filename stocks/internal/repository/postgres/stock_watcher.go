@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"stocks/internal/constants"
+	"stocks/internal/models"
+	"stocks/internal/repository/interfaces"
+	"stocks/pkg/log"
+	"stocks/pkg/postgresql"
+	"sync"
+	"time"
+)
+
+// stockWatcher is an in-process broker fanning out stock changes received through
+// Postgres LISTEN/NOTIFY, so changes made on any replica reach every subscriber.
+type stockWatcher struct {
+	db     postgresql.Listener
+	logger log.Logger
+
+	mu   sync.Mutex
+	subs map[uint32]map[*stockSubscription]struct{}
+}
+
+// stockSubscription keeps only the latest pending change per SKU, so a slow reader
+// never blocks the broker and never falls behind by more than one change per SKU.
+type stockSubscription struct {
+	watcher *stockWatcher
+	skus    []uint32
+	ready   chan struct{}
+
+	mu      sync.Mutex
+	pending map[uint32]models.StockChange
+	resync  bool
+}
+
+func NewStockWatcher(db postgresql.Listener, logger log.Logger) interfaces.StockWatcher {
+	return &stockWatcher{
+		db:     db,
+		logger: logger,
+		subs:   make(map[uint32]map[*stockSubscription]struct{}),
+	}
+}
+
+func (w *stockWatcher) Subscribe(skus []uint32) interfaces.StockSubscription {
+	sub := &stockSubscription{
+		watcher: w,
+		skus:    skus,
+		ready:   make(chan struct{}, 1),
+		pending: make(map[uint32]models.StockChange),
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, sku := range skus {
+		if w.subs[sku] == nil {
+			w.subs[sku] = make(map[*stockSubscription]struct{})
+		}
+		w.subs[sku][sub] = struct{}{}
+	}
+
+	return sub
+}
+
+// Run listens for stock changes until ctx is done, reconnecting on failures.
+func (w *stockWatcher) Run(ctx context.Context) error {
+	reconnected := false
+
+	for {
+		err := w.db.Listen(ctx, constants.StockChangesChannel, func() {
+			// Changes made while the listener was down are unknown, so everyone resyncs.
+			if reconnected {
+				w.broadcastResync()
+			}
+		}, w.dispatch)
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		w.logger.Errorf("stock changes listener stopped: %v", err)
+		reconnected = true
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(constants.ListenRetryInterval):
+		}
+	}
+}
+
+func (w *stockWatcher) dispatch(payload string) {
+	var change DbStockChange
+
+	if err := json.Unmarshal([]byte(payload), &change); err != nil {
+		w.logger.Errorf("err in unmarshal stock change: %v", err)
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for sub := range w.subs[change.SKU] {
+		sub.push(change.ToDomain())
+	}
+}
+
+func (w *stockWatcher) broadcastResync() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, subs := range w.subs {
+		for sub := range subs {
+			sub.markResync()
+		}
+	}
+}
+
+func (w *stockWatcher) unsubscribe(sub *stockSubscription) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, sku := range sub.skus {
+		delete(w.subs[sku], sub)
+		if len(w.subs[sku]) == 0 {
+			delete(w.subs, sku)
+		}
+	}
+}
+
+// Ready is signalled whenever Drain has something to return.
+func (s *stockSubscription) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Drain returns the pending changes and whether the subscriber has to reload the
+// state of all its SKUs because changes may have been missed.
+func (s *stockSubscription) Drain() ([]models.StockChange, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changes := make([]models.StockChange, 0, len(s.pending))
+	for _, sku := range s.skus {
+		if change, ok := s.pending[sku]; ok {
+			changes = append(changes, change)
+		}
+	}
+
+	resync := s.resync
+
+	s.pending = make(map[uint32]models.StockChange)
+	s.resync = false
+
+	return changes, resync
+}
+
+func (s *stockSubscription) Close() {
+	s.watcher.unsubscribe(s)
+}
+
+func (s *stockSubscription) push(change models.StockChange) {
+	s.mu.Lock()
+	s.pending[change.SKU] = change
+	s.mu.Unlock()
+
+	s.signal()
+}
+
+func (s *stockSubscription) markResync() {
+	s.mu.Lock()
+	s.resync = true
+	s.mu.Unlock()
+
+	s.signal()
+}
+
+func (s *stockSubscription) signal() {
+	select {
+	case s.ready <- struct{}{}:
+	default:
+		// A drain is already pending and will pick up this change.
+	}
+}
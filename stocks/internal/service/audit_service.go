@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"stocks/internal/models"
+	"stocks/internal/repository/interfaces"
+	"stocks/pkg/log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 500
+)
+
+type Auditor struct {
+	audit     interfaces.AuditRepository
+	repo      interfaces.StockRepository
+	sellers   interfaces.SellerRepository
+	kafkaProd interfaces.KafkaProd
+	logger    log.Logger
+}
+
+// NewAuditService records audit events in the audit_log table. When kafkaProd is not
+// nil every recorded event is also published to its topic.
+func NewAuditService(audit interfaces.AuditRepository, repo interfaces.StockRepository, sellers interfaces.SellerRepository, kafkaProd interfaces.KafkaProd, logger log.Logger) *Auditor {
+	return &Auditor{
+		audit:     audit,
+		repo:      repo,
+		sellers:   sellers,
+		kafkaProd: kafkaProd,
+		logger:    logger,
+	}
+}
+
+type offerSnapshot struct {
+	SellerID  int64      `json:"seller_id"`
+	UserID    int64      `json:"user_id"`
+	Count     uint32     `json:"count"`
+	Price     uint32     `json:"price"`
+	Location  string     `json:"location"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type stockSnapshot struct {
+	SKU    uint32          `json:"sku"`
+	Offers []offerSnapshot `json:"offers"`
+}
+
+type skuSnapshot struct {
+	SKU  uint32 `json:"sku"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type sellerSnapshot struct {
+	ID     int64  `json:"id"`
+	UserID int64  `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+// SnapshotStock returns every offer of the SKU as JSON, deleted ones included.
+func (s *Auditor) SnapshotStock(ctx context.Context, sku uint32) ([]byte, error) {
+	offers, err := s.repo.ListOffers(ctx, sku, true)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := stockSnapshot{
+		SKU:    sku,
+		Offers: make([]offerSnapshot, 0, len(offers)),
+	}
+
+	for _, offer := range offers {
+		snapshot.Offers = append(snapshot.Offers, offerSnapshot{
+			SellerID:  offer.SellerID,
+			UserID:    offer.UserID,
+			Count:     offer.Count,
+			Price:     offer.Price,
+			Location:  offer.Location,
+			DeletedAt: offer.DeletedAt,
+		})
+	}
+
+	return json.Marshal(snapshot)
+}
+
+// SnapshotSeller returns the seller as JSON, or nil when there is none.
+func (s *Auditor) SnapshotSeller(ctx context.Context, sellerID int64) ([]byte, error) {
+	seller, err := s.sellers.GetSeller(ctx, sellerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return json.Marshal(sellerSnapshot{ID: seller.ID, UserID: seller.UserID, Name: seller.Name})
+}
+
+// SnapshotSKU returns the catalog entry of the SKU as JSON, or nil when there is none.
+func (s *Auditor) SnapshotSKU(ctx context.Context, sku uint32) ([]byte, error) {
+	entry, err := s.repo.GetSKUByID(ctx, sku)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return json.Marshal(skuSnapshot{SKU: entry.SKUID, Name: entry.Name, Type: entry.Type})
+}
+
+type AuditKafkaEvent struct {
+	Type      string            `json:"type"`
+	Service   string            `json:"service"`
+	Timestamp time.Time         `json:"timestamp"`
+	Payload   AuditEventPayload `json:"payload"`
+}
+
+type AuditEventPayload struct {
+	ID          int64           `json:"id"`
+	ActorID     int64           `json:"actor_id"`
+	Method      string          `json:"method"`
+	EntityType  string          `json:"entity_type"`
+	EntityID    string          `json:"entity_id"`
+	RequestHash string          `json:"request_hash"`
+	Before      json.RawMessage `json:"before,omitempty"`
+	After       json.RawMessage `json:"after,omitempty"`
+	Status      string          `json:"status"`
+	TraceID     string          `json:"trace_id,omitempty"`
+}
+
+func (s *Auditor) Record(ctx context.Context, event models.AuditEvent) error {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "AuditService.Record")
+	defer span.End()
+
+	event, err := s.audit.Insert(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	if s.kafkaProd == nil {
+		return nil
+	}
+
+	msg, err := json.Marshal(AuditKafkaEvent{
+		Type:      "audit_event",
+		Service:   "stock",
+		Timestamp: event.CreatedAt,
+		Payload: AuditEventPayload{
+			ID:          event.ID,
+			ActorID:     event.ActorID,
+			Method:      event.Method,
+			EntityType:  event.EntityType,
+			EntityID:    event.EntityID,
+			RequestHash: event.RequestHash,
+			Before:      event.Before,
+			After:       event.After,
+			Status:      event.Status,
+			TraceID:     event.TraceID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.kafkaProd.Produce(ctx, msg, event.EntityID, event.CreatedAt); err != nil {
+		s.logger.FromContext(ctx).Errorf("err in produce audit event: %v", err)
+	}
+
+	return nil
+}
+
+// ListAuditEvents returns one page of events, newest first. NextCursor is the id to pass
+// as BeforeID for the next page, or zero on the last page.
+func (s *Auditor) ListAuditEvents(ctx context.Context, filter models.AuditFilter) (models.AuditEventsPage, error) {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "AuditService.ListAuditEvents")
+	defer span.End()
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditPageSize
+	}
+
+	filter.Limit = min(filter.Limit, maxAuditPageSize)
+
+	// One extra row tells whether another page follows.
+	pageSize := filter.Limit
+	filter.Limit++
+
+	events, err := s.audit.List(ctx, filter)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in list audit events: %v", err)
+		return models.AuditEventsPage{}, err
+	}
+
+	page := models.AuditEventsPage{Events: events}
+	if len(events) > pageSize {
+		page.Events = events[:pageSize]
+		page.NextCursor = page.Events[pageSize-1].ID
+	}
+
+	return page, nil
+}
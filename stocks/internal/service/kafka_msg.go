@@ -7,9 +7,10 @@ import (
 )
 
 type Payload struct {
-	SKU   uint32 `json:"sku"`
-	Count uint32 `json:"count"`
-	Price uint32 `json:"price"`
+	SKU      uint32 `json:"sku"`
+	SellerID int64  `json:"seller_id"`
+	Count    uint32 `json:"count"`
+	Price    uint32 `json:"price"`
 }
 
 type KafkaEvent struct {
@@ -26,9 +27,10 @@ func BuildKafkaEvent(addedType string, item models.StockItem) ([]byte, time.Time
 		Service:   "stock",
 		Timestamp: timestamp,
 		Payload: Payload{
-			SKU:   item.SKU,
-			Count: item.Count,
-			Price: item.Price,
+			SKU:      item.SKU,
+			SellerID: item.SellerID,
+			Count:    item.Count,
+			Price:    item.Price,
 		},
 	}
 
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"stocks/internal/constants"
+	"stocks/internal/domainerr"
+	"stocks/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+// ReserveStock takes the units of every item out of its offer in one transaction, so
+// either all items are reserved or none. A checkout that already has a reservation is
+// not reserved again, and a released checkout cannot be reserved any more: a reserve
+// call arriving after the release would hold units that are never given back.
+func (s *Service) ReserveStock(ctx context.Context, reservation models.Reservation) error {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.ReserveStock")
+	defer span.End()
+
+	var changed []models.StockItem
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		changed = nil
+
+		if err := s.reservations.LockReservation(ctx, reservation.CheckoutID); err != nil {
+			return err
+		}
+
+		status, err := s.reservations.ReservationStatus(ctx, reservation.CheckoutID)
+		if err != nil {
+			return err
+		}
+
+		switch status {
+		case models.ReservationNone:
+		case models.ReservationReleased:
+			return domainerr.ReservationReleased(reservation.CheckoutID)
+		default:
+			return nil
+		}
+
+		for _, item := range reservation.Items {
+			offer, err := s.reservations.TakeStock(ctx, item)
+			if err != nil {
+				if errors.Is(err, constants.ErrNotRowAffected) {
+					return s.insufficientStock(ctx, item)
+				}
+
+				return err
+			}
+
+			if err := s.reservations.AddReservation(ctx, reservation.CheckoutID, item); err != nil {
+				return err
+			}
+
+			if err := s.notifyDefaultOffer(ctx, item.SKU); err != nil {
+				return err
+			}
+
+			changed = append(changed, offer)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in ReserveStock: %v", err)
+		return err
+	}
+
+	for _, offer := range changed {
+		s.produceStockEvent(ctx, "stock_reserved", offer)
+	}
+
+	return nil
+}
+
+// CommitReservation makes the units reserved for the checkout final. Committing twice
+// is fine, a released or unknown reservation is not found.
+func (s *Service) CommitReservation(ctx context.Context, checkoutID string) error {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.CommitReservation")
+	defer span.End()
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		if err := s.reservations.LockReservation(ctx, checkoutID); err != nil {
+			return err
+		}
+
+		status, err := s.reservations.ReservationStatus(ctx, checkoutID)
+		if err != nil {
+			return err
+		}
+
+		switch status {
+		case models.ReservationCommitted:
+			return nil
+		case models.ReservationReserved:
+			_, err = s.reservations.SetReservationStatus(ctx, checkoutID, models.ReservationReserved, models.ReservationCommitted)
+			return err
+		default:
+			return domainerr.ReservationNotFound(checkoutID)
+		}
+	})
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in CommitReservation: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// ReleaseReservation gives the units reserved for the checkout back to their offers.
+// Committed and released reservations are left as they are. An unknown checkout is
+// marked released, so a reserve call still on its way is refused.
+func (s *Service) ReleaseReservation(ctx context.Context, checkoutID string) error {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.ReleaseReservation")
+	defer span.End()
+
+	var changed []models.StockItem
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		changed = nil
+
+		if err := s.reservations.LockReservation(ctx, checkoutID); err != nil {
+			return err
+		}
+
+		status, err := s.reservations.ReservationStatus(ctx, checkoutID)
+		if err != nil {
+			return err
+		}
+
+		switch status {
+		case models.ReservationNone:
+			return s.reservations.AddReleasedMarker(ctx, checkoutID)
+		case models.ReservationReserved:
+		default:
+			return nil
+		}
+
+		items, err := s.reservations.SetReservationStatus(ctx, checkoutID, models.ReservationReserved, models.ReservationReleased)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			offer, err := s.reservations.ReturnStock(ctx, item)
+			if err != nil {
+				// The offer was purged meanwhile, there is nothing to give the units back to.
+				if errors.Is(err, constants.ErrNotRowAffected) {
+					continue
+				}
+
+				return err
+			}
+
+			if err := s.notifyDefaultOffer(ctx, item.SKU); err != nil {
+				return err
+			}
+
+			changed = append(changed, offer)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in ReleaseReservation: %v", err)
+		return err
+	}
+
+	for _, offer := range changed {
+		s.produceStockEvent(ctx, "stock_released", offer)
+	}
+
+	return nil
+}
+
+// insufficientStock reports why the units of item could not be taken from its offer.
+func (s *Service) insufficientStock(ctx context.Context, item models.ReservationItem) error {
+	offer, err := s.repo.GetItemBySKU(ctx, item.SKU, item.SellerID, false)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domainerr.OfferNotFound(item.SKU, item.SellerID)
+		}
+
+		return err
+	}
+
+	return domainerr.InsufficientStock(item.SKU, item.SellerID, item.Count, offer.Count)
+}
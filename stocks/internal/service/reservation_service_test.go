@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"stocks/internal/constants"
+	"stocks/internal/domainerr"
+	"stocks/internal/models"
+	"stocks/internal/repository/interfaces"
+	"stocks/pkg/log/zap"
+	"stocks/pkg/metrics"
+	"testing"
+	"time"
+
+	trm "github.com/avito-tech/go-transaction-manager/trm/v2"
+	"github.com/jackc/pgx/v5"
+	uzap "go.uber.org/zap"
+)
+
+type directTM struct {
+	trm.Manager
+}
+
+func (directTM) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type reservationRow struct {
+	item   models.ReservationItem
+	status models.ReservationStatus
+}
+
+// fakeReservations keeps reservations and offer counts in memory.
+type fakeReservations struct {
+	rows   map[string][]reservationRow
+	counts map[models.ReservationItem]uint32 // keyed by offer, Count zero
+	locks  int
+}
+
+func newFakeReservations() *fakeReservations {
+	return &fakeReservations{
+		rows:   make(map[string][]reservationRow),
+		counts: make(map[models.ReservationItem]uint32),
+	}
+}
+
+func offerOf(item models.ReservationItem) models.ReservationItem {
+	return models.ReservationItem{SKU: item.SKU, SellerID: item.SellerID}
+}
+
+func (f *fakeReservations) LockReservation(context.Context, string) error {
+	f.locks++
+	return nil
+}
+
+func (f *fakeReservations) ReservationStatus(_ context.Context, checkoutID string) (models.ReservationStatus, error) {
+	rows := f.rows[checkoutID]
+	if len(rows) == 0 {
+		return models.ReservationNone, nil
+	}
+
+	return rows[0].status, nil
+}
+
+func (f *fakeReservations) TakeStock(_ context.Context, item models.ReservationItem) (models.StockItem, error) {
+	count, ok := f.counts[offerOf(item)]
+	if !ok || count < item.Count {
+		return models.StockItem{}, constants.ErrNotRowAffected
+	}
+
+	f.counts[offerOf(item)] = count - item.Count
+
+	return models.StockItem{SKU: item.SKU, SellerID: item.SellerID, Count: count - item.Count}, nil
+}
+
+func (f *fakeReservations) ReturnStock(_ context.Context, item models.ReservationItem) (models.StockItem, error) {
+	f.counts[offerOf(item)] += item.Count
+
+	return models.StockItem{SKU: item.SKU, SellerID: item.SellerID, Count: f.counts[offerOf(item)]}, nil
+}
+
+func (f *fakeReservations) AddReservation(_ context.Context, checkoutID string, item models.ReservationItem) error {
+	f.rows[checkoutID] = append(f.rows[checkoutID], reservationRow{item: item, status: models.ReservationReserved})
+	return nil
+}
+
+func (f *fakeReservations) AddReleasedMarker(_ context.Context, checkoutID string) error {
+	f.rows[checkoutID] = append(f.rows[checkoutID], reservationRow{status: models.ReservationReleased})
+	return nil
+}
+
+func (f *fakeReservations) SetReservationStatus(_ context.Context, checkoutID string, from, to models.ReservationStatus) ([]models.ReservationItem, error) {
+	var items []models.ReservationItem
+
+	for i, row := range f.rows[checkoutID] {
+		if row.status == from {
+			f.rows[checkoutID][i].status = to
+			items = append(items, row.item)
+		}
+	}
+
+	return items, nil
+}
+
+type fakeStockRepo struct {
+	interfaces.StockRepository
+}
+
+func (fakeStockRepo) GetItemBySKU(context.Context, uint32, int64, bool) (models.StockItem, error) {
+	return models.StockItem{}, pgx.ErrNoRows
+}
+
+func (fakeStockRepo) NotifyStockChange(context.Context, models.StockChange) error {
+	return nil
+}
+
+type fakeProducer struct {
+	interfaces.KafkaProd
+}
+
+func (fakeProducer) Produce(context.Context, []byte, string, time.Time) error {
+	return nil
+}
+
+type stockEventMetrics struct {
+	metrics.Metrics
+}
+
+func (stockEventMetrics) IncStockEvent(string) {}
+
+func newReservationService(t *testing.T) (*Service, *fakeReservations) {
+	t.Helper()
+
+	reservations := newFakeReservations()
+	logger := &zap.Logger{L: uzap.NewNop()}
+
+	return NewService(fakeStockRepo{}, nil, reservations, directTM{}, nil, fakeProducer{}, stockEventMetrics{}, logger), reservations
+}
+
+var (
+	offerA = models.ReservationItem{SKU: 1001, SellerID: 1}
+	itemA  = models.ReservationItem{SKU: 1001, SellerID: 1, Count: 2}
+)
+
+func TestReleaseBeforeReserve(t *testing.T) {
+	svc, reservations := newReservationService(t)
+	reservations.counts[offerA] = 5
+
+	if err := svc.ReleaseReservation(context.Background(), "42"); err != nil {
+		t.Fatalf("ReleaseReservation() error = %v", err)
+	}
+
+	err := svc.ReserveStock(context.Background(), models.Reservation{CheckoutID: "42", Items: []models.ReservationItem{itemA}})
+
+	var domainErr *domainerr.Error
+	if !errors.As(err, &domainErr) || domainErr.Reason != domainerr.ReasonReservationReleased {
+		t.Fatalf("ReserveStock() after release error = %v, want RESERVATION_RELEASED", err)
+	}
+	if got := reservations.counts[offerA]; got != 5 {
+		t.Errorf("offer count = %d, want 5, a released checkout must not take units", got)
+	}
+
+	// Releasing again keeps the marker and does not fail.
+	if err := svc.ReleaseReservation(context.Background(), "42"); err != nil {
+		t.Errorf("second ReleaseReservation() error = %v", err)
+	}
+	if got := len(reservations.rows["42"]); got != 1 {
+		t.Errorf("reservation rows = %d, want the one marker", got)
+	}
+}
+
+func TestReserveAndRelease(t *testing.T) {
+	svc, reservations := newReservationService(t)
+	reservations.counts[offerA] = 5
+
+	reservation := models.Reservation{CheckoutID: "7", Items: []models.ReservationItem{itemA}}
+
+	for i := 0; i < 2; i++ {
+		if err := svc.ReserveStock(context.Background(), reservation); err != nil {
+			t.Fatalf("ReserveStock() error = %v", err)
+		}
+	}
+	if got := reservations.counts[offerA]; got != 3 {
+		t.Fatalf("offer count = %d, want 3, repeated reserve calls reserve once", got)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := svc.ReleaseReservation(context.Background(), "7"); err != nil {
+			t.Fatalf("ReleaseReservation() error = %v", err)
+		}
+	}
+	if got := reservations.counts[offerA]; got != 5 {
+		t.Errorf("offer count = %d, want 5, repeated release calls give back once", got)
+	}
+
+	// A reserve retried after the release does not take the units again.
+	if err := svc.ReserveStock(context.Background(), reservation); err == nil {
+		t.Error("ReserveStock() after release error = nil")
+	}
+	if got := reservations.counts[offerA]; got != 5 {
+		t.Errorf("offer count = %d, want 5", got)
+	}
+	if reservations.locks == 0 {
+		t.Error("reservation calls did not lock the checkout")
+	}
+}
+
+func TestReleaseCommitted(t *testing.T) {
+	svc, reservations := newReservationService(t)
+	reservations.counts[offerA] = 5
+
+	if err := svc.ReserveStock(context.Background(), models.Reservation{CheckoutID: "9", Items: []models.ReservationItem{itemA}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.CommitReservation(context.Background(), "9"); err != nil {
+		t.Fatalf("CommitReservation() error = %v", err)
+	}
+	if err := svc.ReleaseReservation(context.Background(), "9"); err != nil {
+		t.Fatalf("ReleaseReservation() error = %v", err)
+	}
+
+	if got := reservations.counts[offerA]; got != 3 {
+		t.Errorf("offer count = %d, want 3, committed units are not given back", got)
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"stocks/internal/domainerr"
+	"stocks/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+func (s *Service) CreateSeller(ctx context.Context, seller models.Seller) (models.Seller, error) {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.CreateSeller")
+	defer span.End()
+
+	created, err := s.sellers.CreateSeller(ctx, seller)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in create seller: %v", err)
+		return models.Seller{}, err
+	}
+
+	return created, nil
+}
+
+// TransferSeller hands the seller over to another user. Only its current owner may do so.
+func (s *Service) TransferSeller(ctx context.Context, params models.TransferSeller) (models.Seller, error) {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.TransferSeller")
+	defer span.End()
+
+	var seller models.Seller
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.ownedSeller(ctx, params.UserID, params.SellerID, false); err != nil {
+			return err
+		}
+
+		var err error
+
+		seller, err = s.sellers.TransferSeller(ctx, params.SellerID, params.NewUserID)
+
+		return err
+	})
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in transfer seller: %v", err)
+		return models.Seller{}, err
+	}
+
+	return seller, nil
+}
+
+// ownedSeller returns the seller the user acts for. A zero sellerID means the user's own
+// seller, created when create is set. Sellers of other users are rejected.
+func (s *Service) ownedSeller(ctx context.Context, userID, sellerID int64, create bool) (models.Seller, error) {
+	var (
+		seller models.Seller
+		err    error
+	)
+
+	switch {
+	case sellerID == 0 && create:
+		return s.sellers.EnsureSeller(ctx, userID)
+	case sellerID == 0:
+		seller, err = s.sellers.GetSellerByUser(ctx, userID)
+	default:
+		seller, err = s.sellers.GetSeller(ctx, sellerID)
+	}
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Seller{}, domainerr.SellerNotFound(sellerID)
+		}
+
+		return models.Seller{}, err
+	}
+
+	if seller.UserID != userID {
+		return models.Seller{}, domainerr.NotSellerOwner(seller.ID)
+	}
+
+	return seller, nil
+}
@@ -3,11 +3,32 @@ package service
 import (
 	"context"
 	"stocks/internal/models"
+	"time"
 )
 
 type StockService interface {
 	AddItem(ctx context.Context, item models.StockItem) error
-	DeleteItem(ctx context.Context, sku uint32) error
+	DeleteItem(ctx context.Context, params models.StockOfferParams) error
+	RestoreItem(ctx context.Context, params models.StockOfferParams) error
+	AdjustStock(ctx context.Context, params models.AdjustStock) ([]models.StockItem, error)
+	ImportSKUs(ctx context.Context, params models.ImportSKUs) ([]models.ImportedSKU, error)
+	PurgeDeletedItems(ctx context.Context, retention time.Duration) (int64, error)
 	ListByLocation(ctx context.Context, params models.ListStockParams) (models.ListStock, error)
-	GetItemBySKU(ctx context.Context, sku uint32) (models.StockItem, error)
+	GetItemBySKU(ctx context.Context, params models.GetStockParams) (models.StockItem, error)
+	ListOffers(ctx context.Context, sku uint32, includeDeleted bool) ([]models.StockItem, error)
+	CreateSeller(ctx context.Context, seller models.Seller) (models.Seller, error)
+	TransferSeller(ctx context.Context, params models.TransferSeller) (models.Seller, error)
+	ReportStockLevels(ctx context.Context) error
+	WatchStock(ctx context.Context, skus []uint32, send func(models.StockChange) error) error
+	ReserveStock(ctx context.Context, reservation models.Reservation) error
+	CommitReservation(ctx context.Context, checkoutID string) error
+	ReleaseReservation(ctx context.Context, checkoutID string) error
+}
+
+type AuditService interface {
+	SnapshotStock(ctx context.Context, sku uint32) ([]byte, error)
+	SnapshotSeller(ctx context.Context, sellerID int64) ([]byte, error)
+	SnapshotSKU(ctx context.Context, sku uint32) ([]byte, error)
+	Record(ctx context.Context, event models.AuditEvent) error
+	ListAuditEvents(ctx context.Context, filter models.AuditFilter) (models.AuditEventsPage, error)
 }
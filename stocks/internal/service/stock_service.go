@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"stocks/internal/constants"
+	"stocks/internal/domainerr"
 	"stocks/internal/models"
 	"stocks/internal/repository/interfaces"
 	"stocks/pkg/log"
+	"stocks/pkg/metrics"
+	"time"
 
 	trm "github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/jackc/pgx/v5"
@@ -15,21 +18,31 @@ import (
 )
 
 type Service struct {
-	repo      interfaces.StockRepository
-	tm        trm.Manager
-	kafkaProd interfaces.KafkaProd
-	logger    log.Logger
+	repo         interfaces.StockRepository
+	sellers      interfaces.SellerRepository
+	reservations interfaces.ReservationRepository
+	tm           trm.Manager
+	watcher      interfaces.StockWatcher
+	kafkaProd    interfaces.KafkaProd
+	metrics      metrics.Metrics
+	logger       log.Logger
 }
 
-func NewService(repo interfaces.StockRepository, tm trm.Manager, kafkaProd interfaces.KafkaProd, logger log.Logger) *Service {
+func NewService(repo interfaces.StockRepository, sellers interfaces.SellerRepository, reservations interfaces.ReservationRepository, tm trm.Manager, watcher interfaces.StockWatcher, kafkaProd interfaces.KafkaProd, m metrics.Metrics, logger log.Logger) *Service {
 	return &Service{
-		repo:      repo,
-		tm:        tm,
-		kafkaProd: kafkaProd,
-		logger:    logger,
+		repo:         repo,
+		sellers:      sellers,
+		reservations: reservations,
+		tm:           tm,
+		watcher:      watcher,
+		kafkaProd:    kafkaProd,
+		metrics:      m,
+		logger:       logger,
 	}
 }
 
+// AddItem adds to the offer of the item's seller, which the user must own. Without a
+// seller the user's own seller is used and created on first use.
 func (s *Service) AddItem(ctx context.Context, item models.StockItem) error {
 	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.AddItem")
 	defer span.End()
@@ -37,24 +50,33 @@ func (s *Service) AddItem(ctx context.Context, item models.StockItem) error {
 	var addedType string
 
 	err := s.tm.Do(ctx, func(ctx context.Context) error {
-		sku, err := s.repo.GetSKUByID(ctx, item.SKU)
+		_, err := s.repo.GetSKUByID(ctx, item.SKU)
 		if err != nil {
-			s.logger.Errorf("err in get sku in AddItem: %v", err)
+			s.logger.FromContext(ctx).Errorf("err in get sku in AddItem: %v", err)
 
 			if errors.Is(err, pgx.ErrNoRows) {
-				return constants.ErrInvalidSKU
+				return domainerr.InvalidSKU(item.SKU)
 			}
 
 			return err
 		}
 
-		if sku.UserID != nil && *sku.UserID != item.UserID {
-			return constants.ErrAlreadyAdded
+		seller, err := s.ownedSeller(ctx, item.UserID, item.SellerID, true)
+		if err != nil {
+			return err
 		}
 
-		addedType, err = s.repo.AddItem(ctx, item)
+		item.SellerID = seller.ID
+
+		addedType, _, err = s.repo.AddItem(ctx, item)
 		if err != nil {
-			s.logger.Errorf("err in add item: %v", err)
+			s.logger.FromContext(ctx).Errorf("err in add item: %v", err)
+			return err
+		}
+
+		err = s.notifyDefaultOffer(ctx, item.SKU)
+		if err != nil {
+			s.logger.FromContext(ctx).Errorf("err in notify stock change: %v", err)
 			return err
 		}
 
@@ -62,33 +84,241 @@ func (s *Service) AddItem(ctx context.Context, item models.StockItem) error {
 	})
 
 	if err != nil {
-		s.logger.Errorf("err transaction manager AddItem: %v", err)
+		s.logger.FromContext(ctx).Errorf("err transaction manager AddItem: %v", err)
 		return err
 	}
 
-	msg, timestamp, err := BuildKafkaEvent(addedType, item)
+	s.produceStockEvent(ctx, addedType, item)
+
+	return nil
+}
+
+// DeleteItem soft deletes the offer of the seller, which the user must own. Carts learn
+// about it from the stock_deleted event.
+func (s *Service) DeleteItem(ctx context.Context, params models.StockOfferParams) error {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.DeleteItem")
+	defer span.End()
+
+	item, err := s.setDeleted(ctx, params, s.repo.DeleteItem)
 	if err != nil {
-		s.logger.Errorf("err in build kafka event: %v", err)
+		return err
 	}
 
-	err = s.kafkaProd.Produce(msg, fmt.Sprint(item.SKU), timestamp)
+	s.produceStockEvent(ctx, "stock_deleted", item)
+
+	return nil
+}
+
+// RestoreItem undoes the deletion of the seller's offer, which the user must own. Offers
+// already purged can not be restored.
+func (s *Service) RestoreItem(ctx context.Context, params models.StockOfferParams) error {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.RestoreItem")
+	defer span.End()
+
+	item, err := s.setDeleted(ctx, params, s.repo.RestoreItem)
 	if err != nil {
-		s.logger.Errorf("err in produce kafka msg: %v", err)
+		return err
 	}
 
+	s.produceStockEvent(ctx, "stock_restored", item)
+
 	return nil
 }
 
-func (s *Service) DeleteItem(ctx context.Context, sku uint32) error {
-	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.DeleteItem")
+// errDryRun rolls back the transaction of a dry run.
+var errDryRun = errors.New("dry run")
+
+// AdjustStock adds the deltas to the counts of live offers in one transaction, so either
+// all adjustments apply or none. It is for admins and does not check seller ownership.
+// A dry run returns the offers it would leave and rolls back.
+func (s *Service) AdjustStock(ctx context.Context, params models.AdjustStock) ([]models.StockItem, error) {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.AdjustStock")
 	defer span.End()
 
-	err := s.repo.DeleteItem(ctx, sku)
-	if err != nil && errors.Is(err, constants.ErrNotRowAffected) {
-		return constants.ErrNotFound
+	var changed []models.StockItem
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		changed = make([]models.StockItem, 0, len(params.Adjustments))
+
+		for _, adjustment := range params.Adjustments {
+			offer, err := s.repo.AdjustItem(ctx, adjustment)
+			if err != nil {
+				if errors.Is(err, constants.ErrNotRowAffected) {
+					return s.invalidAdjustment(ctx, adjustment)
+				}
+
+				return err
+			}
+
+			if err := s.notifyDefaultOffer(ctx, adjustment.SKU); err != nil {
+				return err
+			}
+
+			changed = append(changed, offer)
+		}
+
+		if params.DryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		s.logger.FromContext(ctx).Errorf("err in AdjustStock: %v", err)
+		return nil, err
+	}
+
+	if params.DryRun {
+		return changed, nil
 	}
 
-	return err
+	for _, offer := range changed {
+		s.produceStockEvent(ctx, "sku_changed", offer)
+	}
+
+	return changed, nil
+}
+
+// ImportSKUs creates or updates the SKUs of the catalog in one transaction, so either
+// all of them are imported or none. The imports are returned in the order of the SKUs,
+// and each is published as sku_created or sku_changed, which clears the SKU from the
+// cart stock caches.
+func (s *Service) ImportSKUs(ctx context.Context, params models.ImportSKUs) ([]models.ImportedSKU, error) {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.ImportSKUs")
+	defer span.End()
+
+	var imported []models.ImportedSKU
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		imported = make([]models.ImportedSKU, 0, len(params.SKUs))
+
+		for _, sku := range params.SKUs {
+			created, err := s.repo.UpsertSKU(ctx, sku)
+			if err != nil {
+				if errors.Is(err, constants.ErrSKUNameTaken) {
+					return domainerr.SKUNameTaken(sku.SKUID, sku.Name)
+				}
+
+				return err
+			}
+
+			imported = append(imported, models.ImportedSKU{SKU: sku, Created: created})
+		}
+
+		if params.DryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		s.logger.FromContext(ctx).Errorf("err in ImportSKUs: %v", err)
+		return nil, err
+	}
+
+	if params.DryRun {
+		return imported, nil
+	}
+
+	for _, sku := range imported {
+		eventType := "sku_changed"
+		if sku.Created {
+			eventType = "sku_created"
+		}
+
+		s.produceStockEvent(ctx, eventType, models.StockItem{SKU: sku.SKUID})
+	}
+
+	return imported, nil
+}
+
+// invalidAdjustment reports why the adjustment could not be applied to its offer.
+func (s *Service) invalidAdjustment(ctx context.Context, adjustment models.StockAdjustment) error {
+	offer, err := s.repo.GetItemBySKU(ctx, adjustment.SKU, adjustment.SellerID, false)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domainerr.OfferNotFound(adjustment.SKU, adjustment.SellerID)
+		}
+
+		return err
+	}
+
+	if adjustment.Delta < 0 {
+		return domainerr.InsufficientStock(adjustment.SKU, adjustment.SellerID, uint32(-int64(adjustment.Delta)), offer.Count)
+	}
+
+	return domainerr.CountOutOfRange(adjustment.SKU, adjustment.SellerID, adjustment.Delta, offer.Count)
+}
+
+// setDeleted runs the repository change on the offer of an owned seller and notifies the
+// watchers of the SKU's default offer.
+func (s *Service) setDeleted(ctx context.Context, params models.StockOfferParams, change func(ctx context.Context, sku uint32, sellerID int64) (models.StockItem, error)) (models.StockItem, error) {
+	var item models.StockItem
+
+	err := s.tm.Do(ctx, func(ctx context.Context) error {
+		seller, err := s.ownedSeller(ctx, params.UserID, params.SellerID, false)
+		if err != nil {
+			return err
+		}
+
+		item, err = change(ctx, params.SKU, seller.ID)
+		if err != nil {
+			if errors.Is(err, constants.ErrNotRowAffected) {
+				return domainerr.OfferNotFound(params.SKU, seller.ID)
+			}
+
+			return err
+		}
+
+		item.UserID = seller.UserID
+
+		return s.notifyDefaultOffer(ctx, params.SKU)
+	})
+
+	return item, err
+}
+
+// PurgeDeletedItems removes the offers deleted longer than retention ago.
+func (s *Service) PurgeDeletedItems(ctx context.Context, retention time.Duration) (int64, error) {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.PurgeDeletedItems")
+	defer span.End()
+
+	return s.repo.PurgeDeletedItems(ctx, time.Now().Add(-retention))
+}
+
+// produceStockEvent publishes the event of an offer change and counts it in the
+// stock_events_total metric under the same type.
+func (s *Service) produceStockEvent(ctx context.Context, eventType string, item models.StockItem) {
+	s.metrics.IncStockEvent(eventType)
+
+	msg, timestamp, err := BuildKafkaEvent(eventType, item)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in build kafka event: %v", err)
+	}
+
+	err = s.kafkaProd.Produce(ctx, msg, fmt.Sprint(item.SKU), timestamp)
+	if err != nil {
+		s.logger.FromContext(ctx).Errorf("err in produce kafka msg: %v", err)
+	}
+}
+
+// notifyDefaultOffer publishes the current default offer of sku to the watchers.
+func (s *Service) notifyDefaultOffer(ctx context.Context, sku uint32) error {
+	change := models.StockChange{SKU: sku}
+
+	item, err := s.repo.GetItemBySKU(ctx, sku, 0, false)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		change.Deleted = true
+	case err != nil:
+		return err
+	default:
+		change.SellerID = item.SellerID
+		change.Count = item.Count
+		change.Price = item.Price
+	}
+
+	return s.repo.NotifyStockChange(ctx, change)
 }
 
 func (s *Service) ListByLocation(ctx context.Context, params models.ListStockParams) (models.ListStock, error) {
@@ -100,12 +330,12 @@ func (s *Service) ListByLocation(ctx context.Context, params models.ListStockPar
 	offset := (params.CurrentPage - 1) * params.PageSize
 
 	err := s.tm.Do(ctx, func(ctx context.Context) error {
-		items, err := s.repo.GetItemsByLocation(ctx, params.Location, params.UserID, limit, offset)
+		items, err := s.repo.GetItemsByLocation(ctx, params, limit, offset)
 		if err != nil {
 			return err
 		}
 
-		count, err := s.repo.CountItemsByLocation(ctx, params.Location, params.UserID)
+		count, err := s.repo.CountItemsByLocation(ctx, params)
 		if err != nil {
 			return err
 		}
@@ -126,14 +356,20 @@ func (s *Service) ListByLocation(ctx context.Context, params models.ListStockPar
 	return result, nil
 }
 
-func (s *Service) GetItemBySKU(ctx context.Context, sku uint32) (models.StockItem, error) {
+// GetItemBySKU returns the offer of the seller for sku, or the default offer when
+// SellerID is zero. Deleted offers are only returned with IncludeDeleted.
+func (s *Service) GetItemBySKU(ctx context.Context, params models.GetStockParams) (models.StockItem, error) {
 	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.GetItemBySKU")
 	defer span.End()
 
-	item, err := s.repo.GetItemBySKU(ctx, sku)
+	item, err := s.repo.GetItemBySKU(ctx, params.SKU, params.SellerID, params.IncludeDeleted)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return models.StockItem{}, constants.ErrNotFound
+			if params.SellerID != 0 {
+				return models.StockItem{}, domainerr.OfferNotFound(params.SKU, params.SellerID)
+			}
+
+			return models.StockItem{}, domainerr.StockNotFound(params.SKU)
 		}
 
 		return models.StockItem{}, err
@@ -141,3 +377,25 @@ func (s *Service) GetItemBySKU(ctx context.Context, sku uint32) (models.StockIte
 
 	return item, nil
 }
+
+func (s *Service) ListOffers(ctx context.Context, sku uint32, includeDeleted bool) ([]models.StockItem, error) {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.ListOffers")
+	defer span.End()
+
+	return s.repo.ListOffers(ctx, sku, includeDeleted)
+}
+
+// ReportStockLevels refreshes the per-location stock level gauges.
+func (s *Service) ReportStockLevels(ctx context.Context) error {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.ReportStockLevels")
+	defer span.End()
+
+	levels, err := s.repo.StockLevels(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.metrics.SetStockLevels(levels)
+
+	return nil
+}
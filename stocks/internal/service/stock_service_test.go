@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"stocks/internal/constants"
+	"stocks/internal/domainerr"
+	"stocks/internal/models"
+	"stocks/internal/repository/interfaces"
+	"stocks/pkg/log/zap"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	uzap "go.uber.org/zap"
+)
+
+// adjustableStockRepo keeps the counts of live offers in memory and records notifications.
+type adjustableStockRepo struct {
+	fakeStockRepo
+	counts   map[models.StockAdjustment]uint32 // keyed by offer, Delta zero
+	notified []uint32
+}
+
+func (r *adjustableStockRepo) AdjustItem(_ context.Context, adjustment models.StockAdjustment) (models.StockItem, error) {
+	offer := models.StockAdjustment{SKU: adjustment.SKU, SellerID: adjustment.SellerID}
+
+	count, ok := r.counts[offer]
+	adjusted := int64(count) + int64(adjustment.Delta)
+	if !ok || adjusted < 0 || adjusted > constants.MaxOfferCount {
+		return models.StockItem{}, constants.ErrNotRowAffected
+	}
+
+	r.counts[offer] = uint32(adjusted)
+
+	return models.StockItem{SKU: adjustment.SKU, SellerID: adjustment.SellerID, Count: r.counts[offer]}, nil
+}
+
+func (r *adjustableStockRepo) GetItemBySKU(_ context.Context, sku uint32, sellerID int64, _ bool) (models.StockItem, error) {
+	count, ok := r.counts[models.StockAdjustment{SKU: sku, SellerID: sellerID}]
+	if !ok {
+		return models.StockItem{}, pgx.ErrNoRows
+	}
+
+	return models.StockItem{SKU: sku, SellerID: sellerID, Count: count}, nil
+}
+
+func (r *adjustableStockRepo) NotifyStockChange(_ context.Context, change models.StockChange) error {
+	r.notified = append(r.notified, change.SKU)
+	return nil
+}
+
+type countingProducer struct {
+	interfaces.KafkaProd
+	produced int
+}
+
+func (p *countingProducer) Produce(context.Context, []byte, string, time.Time) error {
+	p.produced++
+	return nil
+}
+
+func TestAdjustStock(t *testing.T) {
+	offerA := models.StockAdjustment{SKU: 1001, SellerID: 1}
+	offerB := models.StockAdjustment{SKU: 2002, SellerID: 2}
+	offerFull := models.StockAdjustment{SKU: 3003, SellerID: 3}
+
+	tests := []struct {
+		name        string
+		adjustments []models.StockAdjustment
+		dryRun      bool
+		wantReason  domainerr.Reason
+		wantCounts  []uint32
+		wantEvents  int
+	}{
+		{
+			name:        "applies every adjustment",
+			adjustments: []models.StockAdjustment{{SKU: 1001, SellerID: 1, Delta: -3}, {SKU: 2002, SellerID: 2, Delta: 4}},
+			wantCounts:  []uint32{2, 4},
+			wantEvents:  2,
+		},
+		{
+			name:        "dry run publishes no events",
+			adjustments: []models.StockAdjustment{{SKU: 1001, SellerID: 1, Delta: 1}},
+			dryRun:      true,
+			wantCounts:  []uint32{6},
+		},
+		{
+			name:        "count below zero",
+			adjustments: []models.StockAdjustment{{SKU: 2002, SellerID: 2, Delta: 1}, {SKU: 1001, SellerID: 1, Delta: -6}},
+			wantReason:  domainerr.ReasonInsufficientStock,
+		},
+		{
+			name:        "count above the maximum",
+			adjustments: []models.StockAdjustment{{SKU: 3003, SellerID: 3, Delta: 2}},
+			wantReason:  domainerr.ReasonCountOutOfRange,
+		},
+		{
+			name:        "unknown offer",
+			adjustments: []models.StockAdjustment{{SKU: 1001, SellerID: 2, Delta: 1}},
+			wantReason:  domainerr.ReasonNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &adjustableStockRepo{counts: map[models.StockAdjustment]uint32{offerA: 5, offerB: 0, offerFull: constants.MaxOfferCount - 1}}
+			producer := &countingProducer{}
+			logger := &zap.Logger{L: uzap.NewNop()}
+			svc := NewService(repo, nil, nil, directTM{}, nil, producer, stockEventMetrics{}, logger)
+
+			offers, err := svc.AdjustStock(context.Background(), models.AdjustStock{UserID: 1, Adjustments: tt.adjustments, DryRun: tt.dryRun})
+
+			if tt.wantReason != "" {
+				var domainErr *domainerr.Error
+				if !errors.As(err, &domainErr) || domainErr.Reason != tt.wantReason {
+					t.Fatalf("AdjustStock() error = %v, want %s", err, tt.wantReason)
+				}
+				if producer.produced != 0 {
+					t.Errorf("produced %d events for a rejected batch", producer.produced)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("AdjustStock() error = %v", err)
+			}
+
+			if len(offers) != len(tt.wantCounts) {
+				t.Fatalf("AdjustStock() returned %d offers, want %d", len(offers), len(tt.wantCounts))
+			}
+			for i, offer := range offers {
+				if offer.Count != tt.wantCounts[i] {
+					t.Errorf("offer %d count = %d, want %d", offer.SKU, offer.Count, tt.wantCounts[i])
+				}
+			}
+			if len(repo.notified) != len(tt.adjustments) {
+				t.Errorf("notified %d changes, want %d", len(repo.notified), len(tt.adjustments))
+			}
+			if producer.produced != tt.wantEvents {
+				t.Errorf("produced %d events, want %d", producer.produced, tt.wantEvents)
+			}
+		})
+	}
+}
+
+// catalogRepo keeps the SKU catalog in memory.
+type catalogRepo struct {
+	fakeStockRepo
+	names map[uint32]string
+}
+
+func (r *catalogRepo) UpsertSKU(_ context.Context, sku models.SKU) (bool, error) {
+	for id, name := range r.names {
+		if name == sku.Name && id != sku.SKUID {
+			return false, constants.ErrSKUNameTaken
+		}
+	}
+
+	_, exists := r.names[sku.SKUID]
+	r.names[sku.SKUID] = sku.Name
+
+	return !exists, nil
+}
+
+type typedProducer struct {
+	interfaces.KafkaProd
+	types []string
+}
+
+func (p *typedProducer) Produce(_ context.Context, message []byte, _ string, _ time.Time) error {
+	var event KafkaEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		return err
+	}
+
+	p.types = append(p.types, event.Type)
+
+	return nil
+}
+
+func TestImportSKUs(t *testing.T) {
+	tests := []struct {
+		name       string
+		skus       []models.SKU
+		dryRun     bool
+		wantReason domainerr.Reason
+		wantEvents []string
+	}{
+		{
+			name:       "creates and renames",
+			skus:       []models.SKU{{SKUID: 1001, Name: "t-shirt v2"}, {SKUID: 5005, Name: "mug"}},
+			wantEvents: []string{"sku_changed", "sku_created"},
+		},
+		{
+			name:   "dry run publishes no events",
+			skus:   []models.SKU{{SKUID: 5005, Name: "mug"}},
+			dryRun: true,
+		},
+		{
+			name:       "name of another sku",
+			skus:       []models.SKU{{SKUID: 5005, Name: "mug"}, {SKUID: 1001, Name: "cup"}},
+			wantReason: domainerr.ReasonSKUNameTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &catalogRepo{names: map[uint32]string{1001: "t-shirt", 2020: "cup"}}
+			producer := &typedProducer{}
+			svc := NewService(repo, nil, nil, directTM{}, nil, producer, stockEventMetrics{}, &zap.Logger{L: uzap.NewNop()})
+
+			imported, err := svc.ImportSKUs(context.Background(), models.ImportSKUs{UserID: 1, SKUs: tt.skus, DryRun: tt.dryRun})
+
+			if tt.wantReason != "" {
+				var domainErr *domainerr.Error
+				if !errors.As(err, &domainErr) || domainErr.Reason != tt.wantReason {
+					t.Fatalf("ImportSKUs() error = %v, want %s", err, tt.wantReason)
+				}
+				if len(producer.types) != 0 {
+					t.Errorf("produced %v for a rejected import", producer.types)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImportSKUs() error = %v", err)
+			}
+
+			if len(imported) != len(tt.skus) {
+				t.Fatalf("ImportSKUs() returned %d skus, want %d", len(imported), len(tt.skus))
+			}
+			if !slices.Equal(producer.types, tt.wantEvents) {
+				t.Errorf("events = %v, want %v", producer.types, tt.wantEvents)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"stocks/internal/constants"
+	"stocks/internal/models"
+
+	"go.opentelemetry.io/otel"
+)
+
+// WatchStock sends the current default offer of every requested SKU and then each change
+// of it until ctx is done or send fails. A slow reader only gets the latest change per SKU.
+func (s *Service) WatchStock(ctx context.Context, skus []uint32, send func(models.StockChange) error) error {
+	ctx, span := otel.Tracer("stock-service").Start(ctx, "StockService.WatchStock")
+	defer span.End()
+
+	skus = uniqueSKUs(skus)
+
+	sub := s.watcher.Subscribe(skus)
+	defer sub.Close()
+
+	if err := s.sendSnapshot(ctx, skus, send); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.Ready():
+			changes, resync := sub.Drain()
+			if resync {
+				if err := s.sendSnapshot(ctx, skus, send); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			for _, change := range changes {
+				if err := send(change); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func (s *Service) sendSnapshot(ctx context.Context, skus []uint32, send func(models.StockChange) error) error {
+	for _, sku := range skus {
+		change := models.StockChange{SKU: sku}
+
+		item, err := s.GetItemBySKU(ctx, models.GetStockParams{SKU: sku})
+		switch {
+		case errors.Is(err, constants.ErrNotFound):
+			change.Deleted = true
+		case err != nil:
+			s.logger.FromContext(ctx).Errorf("err in get stock snapshot: %v", err)
+			return err
+		default:
+			change.SellerID = item.SellerID
+			change.Count = item.Count
+			change.Price = item.Price
+		}
+
+		if err := send(change); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func uniqueSKUs(skus []uint32) []uint32 {
+	seen := make(map[uint32]struct{}, len(skus))
+	result := make([]uint32, 0, len(skus))
+
+	for _, sku := range skus {
+		if _, ok := seen[sku]; ok {
+			continue
+		}
+
+		seen[sku] = struct{}{}
+		result = append(result, sku)
+	}
+
+	return result
+}
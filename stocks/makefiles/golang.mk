@@ -13,7 +13,7 @@ build: ## 🔨 Build the stocks app
 
 run: build ## 🚀 Run the stocks app locally
 	@echo "🚀 Running $(APP_NAME) on port $(PORT)..."
-	@./$(BIN_DIR)/$(APP_NAME) -port $(PORT)
+	@./$(BIN_DIR)/$(APP_NAME) -config config.yml
 
 test: ## 🧪 Run unit tests
 	@echo "🧪 Testing $(APP_NAME)..."
@@ -30,13 +30,19 @@ install-deps: ## 📥 Install protoc-gen-* plugins locally
 	GOBIN=$(LOCAL_BIN) go install -mod=mod google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest
 	GOBIN=$(LOCAL_BIN) go install google.golang.org/protobuf/cmd/protoc-gen-go@latest
 
-vendor-proto: ## 📦 Vendor google api protos if not already present
+vendor-proto: ## 📦 Vendor google api and protovalidate protos if not already present
 	@if [ ! -d $(VENDOR_PROTO_DIR)/google/api ]; then \
 		git clone https://github.com/googleapis/googleapis.git $(VENDOR_PROTO_DIR)/googleapis && \
 		mkdir -p $(VENDOR_PROTO_DIR)/google && \
 		mv $(VENDOR_PROTO_DIR)/googleapis/google/api $(VENDOR_PROTO_DIR)/google/ && \
 		rm -rf $(VENDOR_PROTO_DIR)/googleapis; \
 	fi
+	@if [ ! -d $(VENDOR_PROTO_DIR)/buf/validate ]; then \
+		git clone --depth 1 --branch v0.14.0 https://github.com/bufbuild/protovalidate.git $(VENDOR_PROTO_DIR)/protovalidate && \
+		mkdir -p $(VENDOR_PROTO_DIR)/buf && \
+		mv $(VENDOR_PROTO_DIR)/protovalidate/proto/protovalidate/buf/validate $(VENDOR_PROTO_DIR)/buf/ && \
+		rm -rf $(VENDOR_PROTO_DIR)/protovalidate; \
+	fi
 
 generate_protoc: install-deps vendor-proto ## 🛠 Generate protobuf, grpc and grpc-gateway code
 	protoc -I ../proto -I $(VENDOR_PROTO_DIR) \
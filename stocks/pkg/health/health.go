@@ -0,0 +1,180 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"stocks/pkg/log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+)
+
+// Check reports whether a dependency needed to serve traffic is usable.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Health backs the liveness and readiness probes and the grpc.health.v1 service.
+type Health struct {
+	mu       sync.RWMutex
+	checks   []namedCheck
+	services []string
+	timeout  time.Duration
+	draining atomic.Bool
+	grpc     *health.Server
+	logger   log.Logger
+}
+
+type readyResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// New returns a Health reporting the overall ("") status and the given gRPC services.
+func New(timeout time.Duration, logger log.Logger, services ...string) *Health {
+	return &Health{
+		services: append([]string{""}, services...),
+		timeout:  timeout,
+		grpc:     health.NewServer(),
+		logger:   logger,
+	}
+}
+
+func (h *Health) AddCheck(name string, check Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks = append(h.checks, namedCheck{name: name, check: check})
+}
+
+func (h *Health) GRPCServer() healthgrpc.HealthServer {
+	return h.grpc
+}
+
+// Run keeps the grpc.health.v1 status in line with the readiness checks until ctx is done.
+func (h *Health) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, ready := h.check(ctx)
+		h.setServing(ready)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown marks the service as not ready for good, so load balancers stop sending
+// traffic before the servers stop.
+func (h *Health) Shutdown() {
+	h.draining.Store(true)
+	h.grpc.Shutdown()
+}
+
+// LivezHandler reports that the process is up; it does not look at dependencies.
+func (h *Health) LivezHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.writeJSON(w, http.StatusOK, readyResponse{Status: statusOK})
+	})
+}
+
+// ReadyzHandler reports whether the service can serve traffic, with the result of each check.
+func (h *Health) ReadyzHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.draining.Load() {
+			h.writeJSON(w, http.StatusServiceUnavailable, readyResponse{Status: "draining"})
+			return
+		}
+
+		results, ready := h.check(r.Context())
+
+		resp := readyResponse{Status: statusOK, Checks: results}
+		code := http.StatusOK
+
+		if !ready {
+			resp.Status = statusFail
+			code = http.StatusServiceUnavailable
+		}
+
+		h.writeJSON(w, code, resp)
+	})
+}
+
+func (h *Health) check(ctx context.Context) (map[string]string, bool) {
+	h.mu.RLock()
+	checks := h.checks
+	h.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		ready   = true
+		results = make(map[string]string, len(checks))
+	)
+
+	for _, c := range checks {
+		wg.Add(1)
+
+		go func(c namedCheck) {
+			defer wg.Done()
+
+			err := c.check(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				h.logger.Errorf("health check %s failed: %v", c.name, err)
+				results[c.name] = err.Error()
+				ready = false
+
+				return
+			}
+
+			results[c.name] = statusOK
+		}(c)
+	}
+
+	wg.Wait()
+
+	return results, ready
+}
+
+func (h *Health) setServing(ready bool) {
+	status := healthgrpc.HealthCheckResponse_SERVING
+	if !ready {
+		status = healthgrpc.HealthCheckResponse_NOT_SERVING
+	}
+
+	for _, service := range h.services {
+		h.grpc.SetServingStatus(service, status)
+	}
+}
+
+func (h *Health) writeJSON(w http.ResponseWriter, code int, resp readyResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Errorf("failed to write health response: %v", err)
+	}
+}
@@ -1,9 +1,14 @@
 package log
 
+import "context"
+
 // Logger is the universal logger that can do everything.
 type Logger interface {
 	loggerStructured
 	loggerFmt
+	// FromContext returns a logger that adds the trace id, span id and request id of
+	// ctx to every line.
+	FromContext(ctx context.Context) Logger
 	Close() error
 }
 
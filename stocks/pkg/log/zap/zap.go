@@ -1,42 +1,156 @@
 package zap
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"stocks/pkg/log"
+	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+const (
+	encodingJSON    = "json"
+	encodingConsole = "console"
+	developmentEnv  = "development"
+)
+
 var _ log.Logger = &Logger{}
 
 type Logger struct {
-	L *zap.Logger
+	L     *zap.Logger
+	level zap.AtomicLevel
+}
+
+// Options configure a logger. Every line carries Service and Env.
+type Options struct {
+	Service string
+	Env     string
+	// Level is debug, info, warn or error, info when empty.
+	Level string
+	// Encoding is json or console. Empty means console when Env is development and
+	// json otherwise.
+	Encoding string
+	Sampling Sampling
 }
 
-func NewLogger(service, env string) (*Logger, error) {
+// Sampling thins out repeated lines: of the lines with the same level and message,
+// the first Initial per Tick are written and then every Thereafter-th. Sampling is
+// off while Initial is zero, Tick defaults to a second.
+type Sampling struct {
+	Initial    int
+	Thereafter int
+	Tick       time.Duration
+}
+
+// NewLogger writes to stdout as opts say.
+func NewLogger(opts Options) (*Logger, error) {
+	atomicLevel := zap.NewAtomicLevel()
+	if err := setLevel(atomicLevel, opts.Level); err != nil {
+		return nil, err
+	}
+
 	encoderCfg := zap.NewProductionEncoderConfig()
 	encoderCfg.TimeKey = "timestamp"
 	encoderCfg.LevelKey = "level"
 	encoderCfg.MessageKey = "msg"
 
-	core := zapcore.NewCore(
-		zapcore.NewJSONEncoder(encoderCfg),
-		zapcore.AddSync(os.Stdout),
-		zapcore.InfoLevel,
-	)
+	encoding := opts.Encoding
+	if encoding == "" && opts.Env == developmentEnv {
+		encoding = encodingConsole
+	}
+
+	var encoder zapcore.Encoder
+
+	switch encoding {
+	case "", encodingJSON:
+		encoder = zapcore.NewJSONEncoder(encoderCfg)
+	case encodingConsole:
+		encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoderCfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderCfg)
+	default:
+		return nil, fmt.Errorf("unknown log encoding %q", encoding)
+	}
+
+	core := zapcore.NewCore(encoder, zapcore.AddSync(os.Stdout), atomicLevel)
+
+	if opts.Sampling.Initial > 0 {
+		tick := opts.Sampling.Tick
+		if tick <= 0 {
+			tick = time.Second
+		}
+
+		core = zapcore.NewSamplerWithOptions(core, tick, opts.Sampling.Initial, opts.Sampling.Thereafter)
+	}
 
 	logger := zap.New(core).With(
-		zap.String("service", service),
-		zap.String("env", env),
+		zap.String("service", opts.Service),
+		zap.String("env", opts.Env),
 	)
 
 	return &Logger{
-		L: logger,
+		L:     logger,
+		level: atomicLevel,
 	}, nil
 }
 
+// SetLevel changes the level of the logger and every logger derived from it.
+func (l *Logger) SetLevel(level string) error {
+	return setLevel(l.level, level)
+}
+
+// LevelHandler shows the level as {"level":"info"} on GET and changes it on PUT with a
+// body of the same form.
+func (l *Logger) LevelHandler() http.Handler {
+	return l.level
+}
+
+// FromContext returns a logger that adds the trace and span id of the span in ctx and
+// the request id of ctx to every line.
+func (l *Logger) FromContext(ctx context.Context) log.Logger {
+	fields := make([]zap.Field, 0, 3)
+
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", spanCtx.TraceID().String()),
+			zap.String("span_id", spanCtx.SpanID().String()),
+		)
+	}
+
+	if requestID := log.RequestIDFromContext(ctx); requestID != "" {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+
+	if len(fields) == 0 {
+		return l
+	}
+
+	return &Logger{
+		L:     l.L.With(fields...),
+		level: l.level,
+	}
+}
+
+func setLevel(atomicLevel zap.AtomicLevel, level string) error {
+	if level == "" {
+		level = zapcore.InfoLevel.String()
+	}
+
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	atomicLevel.SetLevel(parsed)
+
+	return nil
+}
+
 func (l *Logger) Close() error {
 	if err := l.L.Sync(); err != nil && err.Error() != "sync /dev/stdout: inappropriate ioctl for device" {
 		return err
@@ -53,10 +167,7 @@ func (l *Logger) Trace(msg string, fields ...log.Field) {
 
 // Tracef logs at Trace log level using fmt formatter
 func (l *Logger) Tracef(msg string, args ...interface{}) {
-	if ce := l.L.Check(zap.DebugLevel, ""); ce != nil {
-		ce.Message = fmt.Sprintf(msg, args...)
-		ce.Write()
-	}
+	l.logf(zap.DebugLevel, msg, args)
 }
 
 // Debug logs at Debug log level using fields
@@ -68,10 +179,7 @@ func (l *Logger) Debug(msg string, fields ...log.Field) {
 
 // Debugf logs at Debug log level using fmt formatter
 func (l *Logger) Debugf(msg string, args ...interface{}) {
-	if ce := l.L.Check(zap.DebugLevel, ""); ce != nil {
-		ce.Message = fmt.Sprintf(msg, args...)
-		ce.Write()
-	}
+	l.logf(zap.DebugLevel, msg, args)
 }
 
 // Info logs at Info log level using fields
@@ -83,10 +191,7 @@ func (l *Logger) Info(msg string, fields ...log.Field) {
 
 // Infof logs at Info log level using fmt formatter
 func (l *Logger) Infof(msg string, args ...interface{}) {
-	if ce := l.L.Check(zap.InfoLevel, ""); ce != nil {
-		ce.Message = fmt.Sprintf(msg, args...)
-		ce.Write()
-	}
+	l.logf(zap.InfoLevel, msg, args)
 }
 
 // Warn logs at Warn log level using fields
@@ -98,10 +203,7 @@ func (l *Logger) Warn(msg string, fields ...log.Field) {
 
 // Warnf logs at Warn log level using fmt formatter
 func (l *Logger) Warnf(msg string, args ...interface{}) {
-	if ce := l.L.Check(zap.WarnLevel, ""); ce != nil {
-		ce.Message = fmt.Sprintf(msg, args...)
-		ce.Write()
-	}
+	l.logf(zap.WarnLevel, msg, args)
 }
 
 // Error logs at Error log level using fields
@@ -113,10 +215,7 @@ func (l *Logger) Error(msg string, fields ...log.Field) {
 
 // Errorf logs at Error log level using fmt formatter
 func (l *Logger) Errorf(msg string, args ...interface{}) {
-	if ce := l.L.Check(zap.ErrorLevel, ""); ce != nil {
-		ce.Message = fmt.Sprintf(msg, args...)
-		ce.Write()
-	}
+	l.logf(zap.ErrorLevel, msg, args)
 }
 
 // Fatal logs at Fatal log level using fields
@@ -128,8 +227,17 @@ func (l *Logger) Fatal(msg string, fields ...log.Field) {
 
 // Fatalf logs at Fatal log level using fmt formatter
 func (l *Logger) Fatalf(msg string, args ...interface{}) {
-	if ce := l.L.Check(zap.FatalLevel, ""); ce != nil {
-		ce.Message = fmt.Sprintf(msg, args...)
+	l.logf(zap.FatalLevel, msg, args)
+}
+
+// logf formats the message before the check, so sampling tells formatted messages
+// apart. Nothing is formatted below the level.
+func (l *Logger) logf(level zapcore.Level, msg string, args []interface{}) {
+	if !l.L.Core().Enabled(level) {
+		return
+	}
+
+	if ce := l.L.Check(level, fmt.Sprintf(msg, args...)); ce != nil {
 		ce.Write()
 	}
 }
@@ -1,12 +1,21 @@
 package metrics
 
 import (
+	"net/http"
+
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type Metrics interface {
 	ObserveLatency(path, method, status string, duration float64)
 	IncError(path, method, status string)
+	ObserveGRPC(method, code string, duration float64)
+	IncStockEvent(eventType string)
+	SetStockLevels(levels map[string]uint64)
+	IncRateLimited(method, keyType string)
+	Handler() http.Handler
 }
 
 var _ Metrics = &StockMetrics{}
@@ -14,9 +23,19 @@ var _ Metrics = &StockMetrics{}
 type StockMetrics struct {
 	ResponseLatency *prometheus.HistogramVec
 	ErrorsTotal     *prometheus.CounterVec
+	GRPCLatency     *prometheus.HistogramVec
+	GRPCHandled     *prometheus.CounterVec
+	StockEvents     *prometheus.CounterVec
+	StockLevels     *prometheus.GaugeVec
+	RateLimited     *prometheus.CounterVec
+	registry        *prometheus.Registry
 }
 
+// RegisterMetrics registers the service metrics together with the Go runtime and
+// process collectors on a dedicated registry.
 func RegisterMetrics() (*StockMetrics, error) {
+	registry := prometheus.NewRegistry()
+
 	responseLatency := prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "http_response_time_seconds",
@@ -26,10 +45,6 @@ func RegisterMetrics() (*StockMetrics, error) {
 		[]string{"path", "method", "status"},
 	)
 
-	if err := prometheus.Register(responseLatency); err != nil {
-		return nil, err
-	}
-
 	errorCounter := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "http_failed_requests_total",
@@ -38,13 +53,72 @@ func RegisterMetrics() (*StockMetrics, error) {
 		[]string{"path", "method", "status"},
 	)
 
-	if err := prometheus.Register(errorCounter); err != nil {
-		return nil, err
+	grpcLatency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "gRPC call latencies in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method"},
+	)
+
+	grpcHandled := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "Total gRPC calls by status code",
+		},
+		[]string{"method", "code"},
+	)
+
+	stockEvents := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "stock_events_total",
+			Help: "Total stock changes by event type",
+		},
+		[]string{"type"},
+	)
+
+	stockLevels := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "stock_level_items",
+			Help: "Item units in stock per location",
+		},
+		[]string{"location"},
+	)
+
+	rateLimited := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rate_limited_total",
+			Help: "Total requests rejected by the rate limiter",
+		},
+		[]string{"method", "key_type"},
+	)
+
+	for _, c := range []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		responseLatency,
+		errorCounter,
+		grpcLatency,
+		grpcHandled,
+		stockEvents,
+		stockLevels,
+		rateLimited,
+	} {
+		if err := registry.Register(c); err != nil {
+			return nil, err
+		}
 	}
 
 	return &StockMetrics{
 		ResponseLatency: responseLatency,
 		ErrorsTotal:     errorCounter,
+		GRPCLatency:     grpcLatency,
+		GRPCHandled:     grpcHandled,
+		StockEvents:     stockEvents,
+		StockLevels:     stockLevels,
+		RateLimited:     rateLimited,
+		registry:        registry,
 	}, nil
 }
 
@@ -63,3 +137,43 @@ func (m *StockMetrics) IncError(path, method, status string) {
 		"status": status,
 	}).Inc()
 }
+
+func (m *StockMetrics) ObserveGRPC(method, code string, duration float64) {
+	m.GRPCLatency.With(prometheus.Labels{
+		"method": method,
+	}).Observe(duration)
+
+	m.GRPCHandled.With(prometheus.Labels{
+		"method": method,
+		"code":   code,
+	}).Inc()
+}
+
+func (m *StockMetrics) IncStockEvent(eventType string) {
+	m.StockEvents.With(prometheus.Labels{
+		"type": eventType,
+	}).Inc()
+}
+
+// SetStockLevels replaces the per-location gauges, so emptied locations disappear.
+func (m *StockMetrics) SetStockLevels(levels map[string]uint64) {
+	m.StockLevels.Reset()
+
+	for location, count := range levels {
+		m.StockLevels.With(prometheus.Labels{
+			"location": location,
+		}).Set(float64(count))
+	}
+}
+
+func (m *StockMetrics) IncRateLimited(method, keyType string) {
+	m.RateLimited.With(prometheus.Labels{
+		"method":   method,
+		"key_type": keyType,
+	}).Inc()
+}
+
+// Handler serves the metrics of the dedicated registry.
+func (m *StockMetrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
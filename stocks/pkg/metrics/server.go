@@ -5,26 +5,32 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"stocks/pkg/health"
 	"stocks/pkg/log"
 	"strconv"
-
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type Server struct {
 	metricsServer *http.Server
+	mux           *http.ServeMux
 }
 
 type MetricsServer interface {
+	// Handle serves an operations endpoint next to the metrics and probes. It has to
+	// be called before Run.
+	Handle(pattern string, handler http.Handler)
 	Run() error
 	Shutdown(ctx context.Context) error
 }
 
-func NewServer(m Metrics, metricsPort int64, logger log.Logger) MetricsServer {
+func NewServer(m Metrics, probes *health.Health, metricsPort int64, logger log.Logger) MetricsServer {
 	mux := http.NewServeMux()
 
-	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/metrics", m.Handler())
+	mux.Handle("/livez", probes.LivezHandler())
+	mux.Handle("/readyz", probes.ReadyzHandler())
 
+	// Kept for existing probes; same as /livez.
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, err := w.Write([]byte("OK"))
@@ -39,9 +45,14 @@ func NewServer(m Metrics, metricsPort int64, logger log.Logger) MetricsServer {
 			Addr:    fmt.Sprintf(":%d", metricsPort),
 			Handler: mux,
 		},
+		mux: mux,
 	}
 }
 
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
 func (s *Server) Run() error {
 	if err := s.metricsServer.ListenAndServe(); err != nil && errors.Is(err, http.ErrServerClosed) {
 		return fmt.Errorf("metrics server error: %w", err)